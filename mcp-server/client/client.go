@@ -0,0 +1,427 @@
+// Package client provides a typed Go client for the Mockery MCP server.
+//
+// A Client wraps a Transport (stdio or WebSocket), assigns request IDs,
+// marshals calls into JSON-RPC envelopes and decodes results and errors.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Error is a JSON-RPC error returned by the server
+type Error struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if len(e.Data) == 0 || string(e.Data) == "null" {
+		return fmt.Sprintf("mcp error %d: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("mcp error %d: %s: %s", e.Code, e.Message, string(e.Data))
+}
+
+// Kind returns the machine-readable failure kind from the error data, if any
+func (e *Error) Kind() string {
+	var data struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(e.Data, &data); err != nil {
+		return ""
+	}
+	return data.Kind
+}
+
+// Content is a single content block of a tool result
+type Content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// ToolResult is the result of a tools/call request
+type ToolResult struct {
+	Content []Content `json:"content"`
+}
+
+// Text returns the concatenated text of all text content blocks
+func (r *ToolResult) Text() string {
+	var texts []string
+	for _, content := range r.Content {
+		if content.Type == "text" {
+			texts = append(texts, content.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// Tool describes a tool advertised by the server
+type Tool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	InputSchema json.RawMessage `json:"inputSchema"`
+}
+
+// DiscoverInterfacesParams are the arguments of the discover_interfaces tool
+type DiscoverInterfacesParams struct {
+	ProjectPath     string   `json:"project_path"`
+	IncludePatterns []string `json:"include_patterns,omitempty"`
+	ExcludePatterns []string `json:"exclude_patterns,omitempty"`
+	IfNoneMatch     string   `json:"if_none_match,omitempty"`
+	Fields          []string `json:"fields,omitempty"`
+	MaxDepth        int      `json:"max_depth,omitempty"`
+	Index           bool     `json:"index,omitempty"`
+}
+
+// GenerateMockParams are the arguments of the generate_mock tool
+type GenerateMockParams struct {
+	InterfaceName   string   `json:"interface_name,omitempty"`
+	PackagePath     string   `json:"package_path"`
+	OutputDir       string   `json:"output_dir,omitempty"`
+	WithExpecter    *bool    `json:"with_expecter,omitempty"`
+	FilenameFormat  string   `json:"filename_format,omitempty"`
+	Recursive       bool     `json:"recursive,omitempty"`
+	BoilerplateFile string   `json:"boilerplate_file,omitempty"`
+	ProjectPath     string   `json:"project_path,omitempty"`
+	InPackage       bool     `json:"in_package,omitempty"`
+	TestOnly        bool     `json:"test_only,omitempty"`
+	MockName        string   `json:"mock_name,omitempty"`
+	ReturnContent   bool     `json:"return_content,omitempty"`
+	ExtraArgs       []string `json:"extra_args,omitempty"`
+	SkipPrecheck    bool     `json:"skip_precheck,omitempty"`
+	MocksModule     string   `json:"mocks_module,omitempty"`
+	ProjectID       string   `json:"project_id,omitempty"`
+	BuildTag        string   `json:"build_tag,omitempty"`
+}
+
+// UpdateConfigParams are the arguments of the update_mockery_config tool
+type UpdateConfigParams struct {
+	ProjectPath  string                 `json:"project_path,omitempty"`
+	ConfigPath   string                 `json:"config_path,omitempty"`
+	Interfaces   map[string]interface{} `json:"interfaces,omitempty"`
+	GlobalConfig map[string]interface{} `json:"global_config,omitempty"`
+}
+
+// NotificationHandler receives server-initiated notifications
+type NotificationHandler func(method string, params json.RawMessage)
+
+// Client is a typed MCP client. Calls are serialized, so a Client is safe for
+// concurrent use but only has one request in flight at a time.
+type Client struct {
+	transport      Transport
+	onNotification NotificationHandler
+
+	mu     sync.Mutex
+	nextID int64
+	// initParams are the parameters of a successful Initialize, replayed
+	// to set up a connection the transport re-established
+	initParams interface{}
+}
+
+// idempotentMethods are the methods a failed call is retried with after a
+// reconnect. The server may have acted on any other request before the
+// connection dropped, so those fail instead of running twice.
+var idempotentMethods = map[string]bool{
+	"initialize":               true,
+	"ping":                     true,
+	"tools/list":               true,
+	"prompts/list":             true,
+	"prompts/get":              true,
+	"resources/list":           true,
+	"resources/read":           true,
+	"resources/templates/list": true,
+}
+
+// notification is a server-initiated message received during an exchange
+type notification struct {
+	method string
+	params json.RawMessage
+}
+
+// request is an outgoing JSON-RPC envelope
+type request struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      *int64      `json:"id,omitempty"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+// response is an incoming JSON-RPC envelope
+type response struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error"`
+}
+
+// New creates a client over the given transport
+func New(transport Transport) *Client {
+	return &Client{transport: transport}
+}
+
+// NewStdio creates a client that reads responses from r and writes requests to w
+func NewStdio(r io.Reader, w io.Writer) *Client {
+	return New(NewStdioTransport(r, w))
+}
+
+// DialWebSocket creates a client connected to a WebSocket MCP endpoint
+func DialWebSocket(ctx context.Context, url string, header http.Header) (*Client, error) {
+	transport, err := DialWebSocketTransport(ctx, url, header)
+	if err != nil {
+		return nil, err
+	}
+	return New(transport), nil
+}
+
+// OnNotification registers a handler for notifications received while waiting for responses
+func (c *Client) OnNotification(handler NotificationHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onNotification = handler
+}
+
+// Close closes the underlying transport
+func (c *Client) Close() error {
+	return c.transport.Close()
+}
+
+// Call sends a request and decodes its result into result (which may be nil).
+// If the transport supports reconnecting, a failed exchange reconnects and,
+// once Initialize has succeeded, repeats the handshake on the new
+// connection. Only idempotent methods are then retried; other calls return
+// the error, since the server may already have acted on them.
+func (c *Client) Call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	raw, err := c.roundTrip(ctx, method, params)
+	if err != nil {
+		return err
+	}
+
+	if raw.Error != nil {
+		return raw.Error
+	}
+
+	if result == nil || len(raw.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw.Result, result); err != nil {
+		return fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+	return nil
+}
+
+// roundTrip performs a call while holding the client's lock, then hands the
+// notifications received meanwhile to the handler once the lock is
+// released, so a handler may call the client itself
+func (c *Client) roundTrip(ctx context.Context, method string, params interface{}) (*response, error) {
+	var notifications []notification
+
+	c.mu.Lock()
+	handler := c.onNotification
+	raw, err := c.call(ctx, method, params, &notifications)
+	c.mu.Unlock()
+
+	if handler != nil {
+		for _, n := range notifications {
+			handler(n.method, n.params)
+		}
+	}
+	return raw, err
+}
+
+// call sends a request and waits for its response, reconnecting once if the
+// exchange fails. The caller must hold c.mu.
+func (c *Client) call(ctx context.Context, method string, params interface{}, notifications *[]notification) (*response, error) {
+	c.nextID++
+	id := c.nextID
+
+	message, err := json.Marshal(request{JSONRPC: "2.0", ID: &id, Method: method, Params: params})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	raw, err := c.exchange(ctx, id, message, notifications)
+	if err == nil {
+		return raw, nil
+	}
+
+	reconnector, ok := c.transport.(Reconnector)
+	if !ok || ctx.Err() != nil {
+		return nil, err
+	}
+	if reconnectErr := reconnector.Reconnect(ctx); reconnectErr != nil {
+		return nil, fmt.Errorf("%w (reconnect failed: %v)", err, reconnectErr)
+	}
+	if method != "initialize" {
+		if initErr := c.reinitialize(ctx, notifications); initErr != nil {
+			return nil, fmt.Errorf("%w (initialize after reconnect failed: %v)", err, initErr)
+		}
+	}
+	if !idempotentMethods[method] {
+		return nil, fmt.Errorf("%w (reconnected, but %s is not retried since the server may have received it)", err, method)
+	}
+	return c.exchange(ctx, id, message, notifications)
+}
+
+// reinitialize repeats a successful Initialize on a new connection, which
+// the server treats as a new session. The caller must hold c.mu.
+func (c *Client) reinitialize(ctx context.Context, notifications *[]notification) error {
+	if c.initParams == nil {
+		return nil
+	}
+
+	c.nextID++
+	id := c.nextID
+	message, err := json.Marshal(request{JSONRPC: "2.0", ID: &id, Method: "initialize", Params: c.initParams})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+	raw, err := c.exchange(ctx, id, message, notifications)
+	if err != nil {
+		return err
+	}
+	if raw.Error != nil {
+		return raw.Error
+	}
+	return c.sendNotification(ctx, "notifications/initialized", nil)
+}
+
+// Notify sends a notification, which has no ID and receives no response
+func (c *Client) Notify(ctx context.Context, method string, params interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sendNotification(ctx, method, params)
+}
+
+// sendNotification sends a notification. The caller must hold c.mu.
+func (c *Client) sendNotification(ctx context.Context, method string, params interface{}) error {
+	message, err := json.Marshal(request{JSONRPC: "2.0", Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+	return c.transport.Send(ctx, message)
+}
+
+// exchange sends a request and waits for the response carrying the same ID,
+// collecting any notifications received in the meantime
+func (c *Client) exchange(ctx context.Context, id int64, message []byte, notifications *[]notification) (*response, error) {
+	if err := c.transport.Send(ctx, message); err != nil {
+		return nil, err
+	}
+
+	expectedID := strconv.FormatInt(id, 10)
+	for {
+		data, err := c.transport.Receive(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 || string(data) == "null" {
+			continue
+		}
+
+		var raw response
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		if len(raw.ID) == 0 || string(raw.ID) == "null" {
+			if raw.Method != "" {
+				*notifications = append(*notifications, notification{method: raw.Method, params: raw.Params})
+			}
+			continue
+		}
+
+		if normalizeID(raw.ID) != expectedID {
+			continue
+		}
+		return &raw, nil
+	}
+}
+
+// normalizeID renders a JSON ID so numeric forms like 1 and 1.0 compare equal
+func normalizeID(id json.RawMessage) string {
+	var number float64
+	if err := json.Unmarshal(id, &number); err == nil {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return string(id)
+}
+
+// Ping checks that the server is responsive
+func (c *Client) Ping(ctx context.Context) error {
+	return c.Call(ctx, "ping", nil, nil)
+}
+
+// Initialize performs the MCP initialize handshake and sends the initialized notification
+func (c *Client) Initialize(ctx context.Context) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	params := map[string]interface{}{
+		"protocolVersion": "2024-11-05",
+		"capabilities":    map[string]interface{}{},
+		"clientInfo": map[string]interface{}{
+			"name":    "mockery-mcp-client",
+			"version": "1.0.0",
+		},
+	}
+	if err := c.Call(ctx, "initialize", params, &result); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.initParams = params
+	c.mu.Unlock()
+
+	if err := c.Notify(ctx, "notifications/initialized", nil); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListTools returns the tools advertised by the server
+func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
+	var result struct {
+		Tools []Tool `json:"tools"`
+	}
+	if err := c.Call(ctx, "tools/list", map[string]interface{}{}, &result); err != nil {
+		return nil, err
+	}
+	return result.Tools, nil
+}
+
+// CallTool invokes a tool by name with the given arguments
+func (c *Client) CallTool(ctx context.Context, name string, arguments interface{}) (*ToolResult, error) {
+	var result ToolResult
+	params := map[string]interface{}{
+		"name":      name,
+		"arguments": arguments,
+	}
+	if err := c.Call(ctx, "tools/call", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DiscoverInterfaces invokes the discover_interfaces tool
+func (c *Client) DiscoverInterfaces(ctx context.Context, params DiscoverInterfacesParams) (*ToolResult, error) {
+	return c.CallTool(ctx, "discover_interfaces", params)
+}
+
+// GenerateMock invokes the generate_mock tool
+func (c *Client) GenerateMock(ctx context.Context, params GenerateMockParams) (*ToolResult, error) {
+	return c.CallTool(ctx, "generate_mock", params)
+}
+
+// UpdateConfig invokes the update_mockery_config tool
+func (c *Client) UpdateConfig(ctx context.Context, params UpdateConfigParams) (*ToolResult, error) {
+	return c.CallTool(ctx, "update_mockery_config", params)
+}
@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTransport replays canned responses and records sent messages
+type fakeTransport struct {
+	sent       [][]byte
+	responses  [][]byte
+	failSends  int
+	reconnects int
+}
+
+func (f *fakeTransport) Send(ctx context.Context, message []byte) error {
+	if f.failSends > 0 {
+		f.failSends--
+		return errors.New("connection reset")
+	}
+	f.sent = append(f.sent, message)
+	return nil
+}
+
+func (f *fakeTransport) Receive(ctx context.Context) ([]byte, error) {
+	if len(f.responses) == 0 {
+		return nil, errors.New("no more responses")
+	}
+	response := f.responses[0]
+	f.responses = f.responses[1:]
+	return response, nil
+}
+
+func (f *fakeTransport) Close() error { return nil }
+
+func (f *fakeTransport) Reconnect(ctx context.Context) error {
+	f.reconnects++
+	return nil
+}
+
+func TestClient_CallTool(t *testing.T) {
+	transport := &fakeTransport{
+		responses: [][]byte{
+			[]byte(`null`),
+			[]byte(`{"jsonrpc":"2.0","method":"notifications/message","params":{"level":"info"}}`),
+			[]byte(`{"jsonrpc":"2.0","id":99,"result":{}}`),
+			[]byte(`{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"text","text":"Found 1 interfaces"}]}}`),
+		},
+	}
+	c := New(transport)
+
+	var notifications []string
+	c.OnNotification(func(method string, params json.RawMessage) {
+		notifications = append(notifications, method)
+	})
+
+	result, err := c.DiscoverInterfaces(context.Background(), DiscoverInterfacesParams{ProjectPath: "/tmp/project"})
+	require.NoError(t, err)
+	assert.Equal(t, "Found 1 interfaces", result.Text())
+	assert.Equal(t, []string{"notifications/message"}, notifications)
+
+	require.Len(t, transport.sent, 1)
+	var sent map[string]interface{}
+	require.NoError(t, json.Unmarshal(transport.sent[0], &sent))
+	assert.Equal(t, "2.0", sent["jsonrpc"])
+	assert.Equal(t, float64(1), sent["id"])
+	assert.Equal(t, "tools/call", sent["method"])
+	params := sent["params"].(map[string]interface{})
+	assert.Equal(t, "discover_interfaces", params["name"])
+	assert.Equal(t, "/tmp/project", params["arguments"].(map[string]interface{})["project_path"])
+}
+
+func TestClient_RequestIDsIncrease(t *testing.T) {
+	transport := &fakeTransport{
+		responses: [][]byte{
+			[]byte(`{"jsonrpc":"2.0","id":1,"result":{"status":"pong"}}`),
+			[]byte(`{"jsonrpc":"2.0","id":2,"result":{"status":"pong"}}`),
+		},
+	}
+	c := New(transport)
+
+	require.NoError(t, c.Ping(context.Background()))
+	require.NoError(t, c.Ping(context.Background()))
+
+	require.Len(t, transport.sent, 2)
+	assert.Contains(t, string(transport.sent[0]), `"id":1`)
+	assert.Contains(t, string(transport.sent[1]), `"id":2`)
+}
+
+func TestClient_ErrorResponse(t *testing.T) {
+	transport := &fakeTransport{
+		responses: [][]byte{
+			[]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"Missing or invalid interface_name"}}`),
+		},
+	}
+	c := New(transport)
+
+	_, err := c.GenerateMock(context.Background(), GenerateMockParams{PackagePath: "./internal"})
+	require.Error(t, err)
+
+	var mcpErr *Error
+	require.True(t, errors.As(err, &mcpErr))
+	assert.Equal(t, -32602, mcpErr.Code)
+	assert.Equal(t, "Missing or invalid interface_name", mcpErr.Message)
+	assert.Empty(t, mcpErr.Kind())
+}
+
+func TestClient_ErrorKind(t *testing.T) {
+	transport := &fakeTransport{
+		responses: [][]byte{
+			[]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32001,"message":"Project path does not exist","data":{"kind":"path_not_found","detail":"path not found: /missing"}}}`),
+		},
+	}
+	c := New(transport)
+
+	_, err := c.DiscoverInterfaces(context.Background(), DiscoverInterfacesParams{ProjectPath: "/missing"})
+
+	var mcpErr *Error
+	require.ErrorAs(t, err, &mcpErr)
+	assert.Equal(t, -32001, mcpErr.Code)
+	assert.Equal(t, "path_not_found", mcpErr.Kind())
+}
+
+func TestClient_ReconnectsOnceAfterFailure(t *testing.T) {
+	transport := &fakeTransport{
+		failSends: 1,
+		responses: [][]byte{
+			[]byte(`{"jsonrpc":"2.0","id":1,"result":{"status":"pong"}}`),
+		},
+	}
+	c := New(transport)
+
+	require.NoError(t, c.Ping(context.Background()))
+	assert.Equal(t, 1, transport.reconnects)
+	assert.Len(t, transport.sent, 1)
+}
+
+func TestClient_DoesNotRetryNonIdempotentCalls(t *testing.T) {
+	transport := &fakeTransport{failSends: 1}
+	c := New(transport)
+
+	_, err := c.GenerateMock(context.Background(), GenerateMockParams{InterfaceName: "Repo", PackagePath: "./internal"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tools/call is not retried")
+	assert.Equal(t, 1, transport.reconnects)
+	assert.Empty(t, transport.sent)
+}
+
+func TestClient_ReinitializesAfterReconnect(t *testing.T) {
+	transport := &fakeTransport{
+		responses: [][]byte{
+			[]byte(`{"jsonrpc":"2.0","id":1,"result":{"protocolVersion":"2024-11-05"}}`),
+			[]byte(`{"jsonrpc":"2.0","id":3,"result":{"protocolVersion":"2024-11-05"}}`),
+			[]byte(`{"jsonrpc":"2.0","id":2,"result":{"status":"pong"}}`),
+		},
+	}
+	c := New(transport)
+
+	_, err := c.Initialize(context.Background())
+	require.NoError(t, err)
+
+	transport.failSends = 1
+	require.NoError(t, c.Ping(context.Background()))
+	assert.Equal(t, 1, transport.reconnects)
+
+	// The handshake is repeated on the new connection before the retry
+	var methods []string
+	for _, message := range transport.sent {
+		var sent map[string]interface{}
+		require.NoError(t, json.Unmarshal(message, &sent))
+		methods = append(methods, sent["method"].(string))
+	}
+	assert.Equal(t, []string{"initialize", "notifications/initialized", "initialize", "notifications/initialized", "ping"}, methods)
+}
+
+func TestClient_NotificationHandlerMayCallClient(t *testing.T) {
+	transport := &fakeTransport{
+		responses: [][]byte{
+			[]byte(`{"jsonrpc":"2.0","method":"notifications/tools/list_changed"}`),
+			[]byte(`{"jsonrpc":"2.0","id":1,"result":{"status":"pong"}}`),
+			[]byte(`{"jsonrpc":"2.0","id":2,"result":{"tools":[{"name":"discover_interfaces"}]}}`),
+		},
+	}
+	c := New(transport)
+
+	var tools []Tool
+	c.OnNotification(func(method string, params json.RawMessage) {
+		var err error
+		tools, err = c.ListTools(context.Background())
+		require.NoError(t, err)
+	})
+
+	require.NoError(t, c.Ping(context.Background()))
+	require.Len(t, tools, 1)
+	assert.Equal(t, "discover_interfaces", tools[0].Name)
+}
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// noDeadline clears a connection deadline
+var noDeadline time.Time
+
+// compressionDialer is the default dialer offering permessage-deflate.
+// Servers that don't enable compression decline the offer.
+var compressionDialer = &websocket.Dialer{
+	Proxy:             http.ProxyFromEnvironment,
+	HandshakeTimeout:  45 * time.Second,
+	EnableCompression: true,
+}
+
+// Transport carries JSON-RPC messages between a client and an MCP server
+type Transport interface {
+	Send(ctx context.Context, message []byte) error
+	Receive(ctx context.Context) ([]byte, error)
+	Close() error
+}
+
+// Reconnector is implemented by transports that can re-establish a dropped connection
+type Reconnector interface {
+	Reconnect(ctx context.Context) error
+}
+
+// StdioTransport exchanges newline-delimited messages over a reader and writer,
+// typically the stdout and stdin of a server started with -addr stdio
+type StdioTransport struct {
+	reader *bufio.Reader
+	writer io.Writer
+}
+
+// NewStdioTransport creates a transport reading responses from r and writing requests to w
+func NewStdioTransport(r io.Reader, w io.Writer) *StdioTransport {
+	return &StdioTransport{
+		reader: bufio.NewReader(r),
+		writer: w,
+	}
+}
+
+// Send writes a single message followed by a newline
+func (t *StdioTransport) Send(ctx context.Context, message []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if _, err := t.writer.Write(append(message, '\n')); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	return nil
+}
+
+// Receive reads the next non-empty line. Reads cannot be interrupted, so ctx
+// is only checked before blocking.
+func (t *StdioTransport) Receive(ctx context.Context) ([]byte, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		line, err := t.reader.ReadBytes('\n')
+		if len(line) > 1 || (len(line) == 1 && line[0] != '\n') {
+			if line[len(line)-1] == '\n' {
+				line = line[:len(line)-1]
+			}
+			return line, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read message: %w", err)
+		}
+	}
+}
+
+// Close closes the writer if it supports closing
+func (t *StdioTransport) Close() error {
+	if closer, ok := t.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// WebSocketTransport exchanges messages over a WebSocket connection and
+// redials the server when asked to reconnect
+type WebSocketTransport struct {
+	url    string
+	header http.Header
+	dialer *websocket.Dialer
+
+	mu   sync.Mutex
+	conn *websocket.Conn
+}
+
+// DialWebSocketTransport connects to the MCP endpoint at url (e.g. ws://localhost:8080/mcp)
+func DialWebSocketTransport(ctx context.Context, url string, header http.Header) (*WebSocketTransport, error) {
+	t := &WebSocketTransport{
+		url:    url,
+		header: header,
+		dialer: compressionDialer,
+	}
+
+	if err := t.Reconnect(ctx); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Send writes a single text message
+func (t *WebSocketTransport) Send(ctx context.Context, message []byte) error {
+	conn, err := t.connection()
+	if err != nil {
+		return err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetWriteDeadline(deadline)
+		defer conn.SetWriteDeadline(noDeadline)
+	}
+
+	if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	return nil
+}
+
+// Receive reads the next message, honouring the context deadline
+func (t *WebSocketTransport) Receive(ctx context.Context) ([]byte, error) {
+	conn, err := t.connection()
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetReadDeadline(deadline)
+		defer conn.SetReadDeadline(noDeadline)
+	}
+
+	_, message, err := conn.ReadMessage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read message: %w", err)
+	}
+	return message, nil
+}
+
+// Reconnect closes the current connection, if any, and dials a new one
+func (t *WebSocketTransport) Reconnect(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.conn != nil {
+		t.conn.Close()
+		t.conn = nil
+	}
+
+	conn, _, err := t.dialer.DialContext(ctx, t.url, t.header)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", t.url, err)
+	}
+
+	t.conn = conn
+	return nil
+}
+
+// Close closes the underlying connection
+func (t *WebSocketTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.conn == nil {
+		return nil
+	}
+
+	err := t.conn.Close()
+	t.conn = nil
+	return err
+}
+
+// connection returns the current connection
+func (t *WebSocketTransport) connection() (*websocket.Conn, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.conn == nil {
+		return nil, fmt.Errorf("not connected to %s", t.url)
+	}
+	return t.conn, nil
+}
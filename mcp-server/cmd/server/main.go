@@ -1,31 +1,75 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
+	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
+	"runtime"
+	"strings"
 	"syscall"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
 	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/server"
 )
 
 func main() {
 	// Parse command line flags
 	var (
-		addr     = flag.String("addr", ":8080", "HTTP server address")
-		logLevel = flag.String("log-level", "info", "Log level (debug, info, warn, error)")
+		addr           = flag.String("addr", ":8080", "HTTP server address")
+		logLevel       = flag.String("log-level", "info", "Log level (debug, info, warn, error)")
+		logFormat      = flag.String("log-format", "", "Log encoding (json, console); defaults to console for debug and json otherwise")
+		logFile        = flag.String("log-file", "", "Append logs to this file instead of stderr")
+		outputTemplate = flag.String("output-template", "", "Template for the default mock output directory (e.g. ./mocks/{{.PackageName}})")
+		readTimeout    = flag.Duration("ws-read-timeout", 60*time.Second, "Close WebSocket connections idle for longer than this (0 disables)")
+		writeTimeout   = flag.Duration("ws-write-timeout", 10*time.Second, "Deadline for writing a WebSocket message (0 disables)")
+		retries        = flag.Int("mockery-retries", 0, "Times to retry mockery after a transient failure")
+		retryBackoff   = flag.Duration("mockery-retry-backoff", 500*time.Millisecond, "Initial backoff between mockery retries, doubled after each attempt")
+		mockeryPath    = flag.String("mockery-path", "mockery", "Mockery executable to run")
+		mockeryVersion = flag.String("mockery-version", "auto", "Mockery command line style: v2 flags, v3 config file, or auto to detect it from mockery --version")
+		allowedOrigins = flag.String("allowed-origins", "", "Comma-separated WebSocket origins to accept (empty allows all)")
+		configFile     = flag.String("config", "", "YAML file with hot-reloadable settings, re-read by the reload_config tool")
+		enableReload   = flag.Bool("enable-reload-config", false, "Offer the reload_config tool, which lets any client re-read the -config file")
+		excludeIfaces  = flag.String("exclude-interfaces", "", "Comma-separated interface names or globs to always omit from discovery")
+		requireModule  = flag.Bool("require-module", false, "Reject discovery of paths that are not inside a Go module")
+		withExpecter   = flag.Bool("default-with-expecter", true, "Generate mocks with expecter methods when a request doesn't set with_expecter")
+		filenameCase   = flag.String("filename-case", "snake", "Casing of the interface name in default mock filenames: snake, lower or original")
+		wsCompression  = flag.Bool("ws-compression", false, "Offer permessage-deflate compression to WebSocket clients")
+		fileMode       = flag.String("file-mode", "", "Octal mode for generated mock files, e.g. 0664 (empty keeps mockery's default)")
+		dirMode        = flag.String("dir-mode", "", "Octal mode for mock output directories the server creates, e.g. 0775 (default 0755 less the umask)")
+		toolTimeout    = flag.Duration("tool-timeout", 0, "Fail tool calls that run longer than this, stopping their scans and mockery runs (0 disables)")
+		once           = flag.Bool("once", false, "With -addr stdio, handle a single request, write its response and exit")
+		shutdownWait   = flag.Duration("shutdown-timeout", 30*time.Second, "How long to let in-flight async jobs finish on shutdown")
+		maxConns       = flag.Int("max-connections", 0, "Maximum simultaneous WebSocket connections (0 means unlimited)")
+		maxGenerations = flag.Int("max-generations", runtime.NumCPU(), "Maximum mockery runs executing at once across all connections")
+		expandEnv      = flag.Bool("expand-env", true, "Expand $VAR references in dir, filename and outpkg values of mockery configs")
+		maxFileSize    = flag.Int64("max-file-size", scanner.DefaultMaxFileSize, "Largest Go file in bytes to parse when scanning (0 means unlimited)")
+		strictEnv      = flag.Bool("strict-env", false, "Fail to read a mockery config that references an unset environment variable")
+		readOnly       = flag.Bool("read-only", false, "Disable tools that write files, run project code or change server settings")
+		rateLimit      = flag.Float64("rate-limit", 0, "Tool calls per second allowed on each connection (0 means unlimited)")
+		rateBurst      = flag.Int("rate-burst", server.DefaultToolCallBurst, "Tool calls a connection may make at once before -rate-limit applies")
 	)
 	flag.Parse()
 
 	// Initialize logger
-	logger, err := initLogger(*logLevel)
+	logger, closeLog, err := initLogger(loggerOptions{
+		Level:  *logLevel,
+		Format: *logFormat,
+		File:   *logFile,
+		Stdio:  *addr == "stdio",
+	})
 	if err != nil {
 		log.Fatalf("Failed to initialize logger: %v", err)
 	}
+	defer closeLog()
 	defer logger.Sync()
 
 	logger.Info("Starting Mockery MCP Server",
@@ -33,56 +77,183 @@ func main() {
 		zap.String("log_level", *logLevel),
 	)
 
+	if *once && *addr != "stdio" {
+		logger.Fatal("Invalid configuration", zap.Error(errors.New("-once requires -addr stdio")))
+	}
+
 	// Create MCP server
 	mcpServer := server.NewMockeryMCPServer(logger)
+	mcpServer.SetMaxConnections(*maxConns)
+	if err := mcpServer.SetMaxConcurrentGenerations(*maxGenerations); err != nil {
+		logger.Fatal("Invalid configuration", zap.Error(err))
+	}
+	mcpServer.SetConfigEnvExpansion(*expandEnv, *strictEnv)
+	mcpServer.SetMaxScanFileSize(*maxFileSize)
+	mcpServer.SetReadOnly(*readOnly)
+	mcpServer.SetReloadConfigEnabled(*enableReload)
+	if err := mcpServer.SetToolCallRateLimit(*rateLimit, *rateBurst); err != nil {
+		logger.Fatal("Invalid configuration", zap.Error(err))
+	}
+
+	serverConfig := server.DefaultServerConfig()
+	serverConfig.MockeryCommand = *mockeryPath
+	serverConfig.MockeryVersion = *mockeryVersion
+	serverConfig.ToolTimeout = *toolTimeout
+	serverConfig.OutputTemplate = *outputTemplate
+	serverConfig.ReadTimeout = *readTimeout
+	serverConfig.WriteTimeout = *writeTimeout
+	serverConfig.MockeryRetries = *retries
+	serverConfig.RetryBackoff = *retryBackoff
+	if *allowedOrigins != "" {
+		serverConfig.AllowedOrigins = strings.Split(*allowedOrigins, ",")
+	}
+	if *excludeIfaces != "" {
+		serverConfig.ExcludeInterfaces = strings.Split(*excludeIfaces, ",")
+	}
+	serverConfig.RequireModule = *requireModule
+	serverConfig.DefaultWithExpecter = *withExpecter
+	serverConfig.FilenameCase = *filenameCase
+	serverConfig.WSCompression = *wsCompression
+	serverConfig.FileMode = *fileMode
+	serverConfig.DirMode = *dirMode
+	if err := mcpServer.ApplyConfig(serverConfig); err != nil {
+		logger.Fatal("Invalid configuration", zap.Error(err))
+	}
+
+	// Settings in the config file take precedence over flags
+	if *configFile != "" {
+		if err := mcpServer.SetConfigFile(*configFile); err != nil {
+			logger.Fatal("Failed to load config file", zap.Error(err))
+		}
+	}
 
 	// Handle stdio-based MCP communication for clients like Roo
 	if *addr == "stdio" {
 		logger.Info("Starting MCP server in stdio mode")
-		if err := mcpServer.HandleStdio(); err != nil {
+		serve := mcpServer.HandleStdio
+		if *once {
+			serve = mcpServer.HandleStdioOnce
+		}
+		if err := serve(); err != nil {
 			logger.Fatal("Failed to handle stdio", zap.Error(err))
 		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), *shutdownWait)
+		defer cancel()
+		if err := mcpServer.Drain(ctx); err != nil {
+			logger.Warn("Async jobs cancelled at shutdown", zap.Error(err))
+		}
 		return
 	}
 
 	// Set up graceful shutdown for WebSocket mode
+	shutdownDone := make(chan struct{})
 	go func() {
+		defer close(shutdownDone)
+
 		sigChan := make(chan os.Signal, 1)
 		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 		<-sigChan
 
 		logger.Info("Shutting down server...")
-		os.Exit(0)
+		ctx, cancel := context.WithTimeout(context.Background(), *shutdownWait)
+		defer cancel()
+		if err := mcpServer.Shutdown(ctx); err != nil {
+			logger.Warn("Shutdown did not complete cleanly", zap.Error(err))
+		}
 	}()
 
 	// Start WebSocket server
 	logger.Info("Server starting", zap.String("address", *addr))
-	if err := mcpServer.Start(*addr); err != nil {
+	if err := mcpServer.Start(*addr); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		logger.Fatal("Server failed to start", zap.Error(err))
 	}
+	<-shutdownDone
+}
+
+// loggerOptions controls where and how the application logs
+type loggerOptions struct {
+	Level  string
+	Format string
+	File   string
+	Stdio  bool
 }
 
-// initLogger initializes the application logger
-func initLogger(level string) (*zap.Logger, error) {
+// initLogger initializes the application logger. The returned function
+// closes the log file, if one was opened.
+func initLogger(opts loggerOptions) (*zap.Logger, func(), error) {
 	var config zap.Config
 
-	switch level {
+	switch opts.Level {
 	case "debug":
 		config = zap.NewDevelopmentConfig()
 	case "info", "warn", "error":
 		config = zap.NewProductionConfig()
-		config.Level = zap.NewAtomicLevelAt(parseLogLevel(level))
+		config.Level = zap.NewAtomicLevelAt(parseLogLevel(opts.Level))
 	default:
 		config = zap.NewProductionConfig()
 	}
 
-	// Ensure logs go to stderr, not stdout (which is used for MCP communication)
-	config.OutputPaths = []string{"stderr"}
-	config.ErrorOutputPaths = []string{"stderr"}
-	config.DisableCaller = true
-	config.DisableStacktrace = true
+	switch opts.Format {
+	case "":
+	case "json", "console":
+		config.Encoding = opts.Format
+	default:
+		return nil, nil, fmt.Errorf("unknown log format %q (want json or console)", opts.Format)
+	}
+
+	var encoder zapcore.Encoder
+	if config.Encoding == "console" {
+		config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoder = zapcore.NewConsoleEncoder(config.EncoderConfig)
+	} else {
+		encoder = zapcore.NewJSONEncoder(config.EncoderConfig)
+	}
+
+	// Logs default to stderr; stdout is never used because it carries the
+	// MCP protocol in stdio mode
+	var sink zapcore.WriteSyncer = os.Stderr
+	closeLog := func() {}
+	if opts.File != "" {
+		if opts.File == "-" || isStdout(opts.File) {
+			return nil, nil, fmt.Errorf("refusing to log to stdout: %s", opts.File)
+		}
+
+		// O_APPEND keeps writes at the end of the file, so external rotation
+		// that truncates or copies the file doesn't leave holes
+		file, err := os.OpenFile(opts.File, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		sink = file
+		closeLog = func() { file.Close() }
+	}
+
+	zapOpts := []zap.Option{zap.ErrorOutput(zapcore.Lock(sink))}
 
-	return config.Build()
+	// Caller and stacktrace information is kept out of stdio mode, where
+	// logs share a terminal with the client
+	if !opts.Stdio {
+		zapOpts = append(zapOpts, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel))
+	}
+
+	core := zapcore.NewCore(encoder, zapcore.Lock(sink), config.Level)
+	return zap.New(core, zapOpts...), closeLog, nil
+}
+
+// isStdout reports whether path names the file stdout writes to, however
+// it is spelled: /dev/stdout, /dev/fd/1, /proc/self/fd/1 or the file or
+// terminal stdout is redirected to
+func isStdout(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	stdout, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return os.SameFile(info, stdout)
 }
 
 // parseLogLevel converts string to zap log level
@@ -99,4 +270,4 @@ func parseLogLevel(level string) zapcore.Level {
 	default:
 		return zapcore.InfoLevel
 	}
-}
\ No newline at end of file
+}
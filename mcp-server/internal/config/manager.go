@@ -1,10 +1,13 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"text/template"
 
 	"gopkg.in/yaml.v3"
 
@@ -14,6 +17,24 @@ import (
 // MockeryConfigManager manages .mockery.yaml configuration files
 type MockeryConfigManager struct {
 	defaultConfig types.MockeryConfig
+	noExpandEnv   bool
+	strictEnv     bool
+}
+
+// ConfigFilenames are the names mockery config files are looked up by in a
+// project directory, in order of preference
+var ConfigFilenames = []string{".mockery.yaml", ".mockery.yml", "mockery.yaml"}
+
+// FindConfigFile returns the path of the first file in ConfigFilenames that
+// exists in dir
+func FindConfigFile(dir string) (string, bool) {
+	for _, name := range ConfigFilenames {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
 }
 
 // NewMockeryConfigManager creates a new configuration manager
@@ -28,6 +49,19 @@ func NewMockeryConfigManager() *MockeryConfigManager {
 	}
 }
 
+// SetExpandEnv controls whether ${VAR} and $VAR references in dir,
+// filename and outpkg values are expanded when reading a config file.
+// Expansion is enabled by default.
+func (m *MockeryConfigManager) SetExpandEnv(enabled bool) {
+	m.noExpandEnv = !enabled
+}
+
+// SetStrictEnv makes reading a config file fail when it references an unset
+// environment variable, instead of leaving the reference as written
+func (m *MockeryConfigManager) SetStrictEnv(strict bool) {
+	m.strictEnv = strict
+}
+
 // GenerateConfig creates a .mockery.yaml configuration file
 func (m *MockeryConfigManager) GenerateConfig(request *types.MockGenerationRequest) (*types.MockeryConfig, error) {
 	config := m.defaultConfig
@@ -61,6 +95,9 @@ func (m *MockeryConfigManager) GenerateConfig(request *types.MockGenerationReque
 	if request.WithExpector {
 		config.WithExpector = true
 	}
+	if request.BoilerplateFile != "" {
+		config.BoilerplateFile = request.BoilerplateFile
+	}
 
 	return &config, nil
 }
@@ -93,39 +130,155 @@ func (m *MockeryConfigManager) UpdateInterfaceConfig(
 	return nil
 }
 
-// ValidateConfigSyntax validates a mockery configuration
-func (m *MockeryConfigManager) ValidateConfigSyntax(config *types.MockeryConfig) error {
+// Severity grades a configuration issue
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// ConfigIssue is a single problem found while validating a configuration
+type ConfigIssue struct {
+	Severity Severity `json:"severity"`
+	Path     string   `json:"path"`
+	Message  string   `json:"message"`
+}
+
+// ValidateConfig checks a mockery configuration and returns every issue
+// found, errors first, rather than stopping at the first one
+func (m *MockeryConfigManager) ValidateConfig(config *types.MockeryConfig) []ConfigIssue {
+	var issues []ConfigIssue
+	add := func(severity Severity, path, format string, args ...interface{}) {
+		issues = append(issues, ConfigIssue{Severity: severity, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
 	// Check required fields
 	if config.Filename == "" {
-		return fmt.Errorf("filename is required")
+		add(SeverityError, "filename", "filename is required")
+	} else {
+		checkFilenameTemplate(config.Filename, "filename", add)
 	}
 
 	if config.OutPkg == "" {
-		return fmt.Errorf("outpkg is required")
+		add(SeverityError, "outpkg", "outpkg is required")
 	}
 
 	// Validate package configurations
 	for packagePath, packageConfig := range config.Packages {
+		location := "packages." + packagePath
 		if packagePath == "" {
-			return fmt.Errorf("package path cannot be empty")
+			add(SeverityError, "packages", "package path cannot be empty")
 		}
 
 		if len(packageConfig.Interfaces) == 0 {
-			return fmt.Errorf("package %s has no interfaces configured", packagePath)
+			add(SeverityError, location, "package %s has no interfaces configured", packagePath)
 		}
 
 		// Validate interface configurations
 		for interfaceName, interfaceConfig := range packageConfig.Interfaces {
+			interfaceLocation := location + ".interfaces." + interfaceName
 			if interfaceName == "" {
-				return fmt.Errorf("interface name cannot be empty in package %s", packagePath)
+				add(SeverityError, location+".interfaces", "interface name cannot be empty in package %s", packagePath)
 			}
 
 			if interfaceConfig.Config.Dir == "" {
-				return fmt.Errorf("directory is required for interface %s in package %s", interfaceName, packagePath)
+				add(SeverityError, interfaceLocation+".config.dir", "directory is required for interface %s in package %s", interfaceName, packagePath)
+			}
+
+			if interfaceConfig.Config.Filename != "" {
+				checkFilenameTemplate(interfaceConfig.Config.Filename, interfaceLocation+".config.filename", add)
 			}
 		}
 	}
 
+	// Map iteration order is random, so sort for stable reports
+	SortIssues(issues)
+	return issues
+}
+
+// SortIssues orders issues errors first, then by path
+func SortIssues(issues []ConfigIssue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Severity != issues[j].Severity {
+			return issues[i].Severity == SeverityError
+		}
+		return issues[i].Path < issues[j].Path
+	})
+}
+
+// v2OnlyKeys are mockery v2 settings that mockery v3 no longer reads, with
+// what replaces each
+var v2OnlyKeys = map[string]string{
+	"outpkg":        "use pkgname",
+	"mockname":      "use structname",
+	"inpackage":     "set dir to the interface's directory and pkgname to its package",
+	"with-expecter": "v3 always generates expecters",
+	"keeptree":      "use a dir template such as {{.InterfaceDir}}",
+}
+
+// CheckV2OnlyKeys reports a warning for every v2-only key in a mockery
+// config, at the top level and in package and interface config sections.
+// Mockery v3 ignores these keys, so mocks silently lose the settings.
+func CheckV2OnlyKeys(content []byte) ([]ConfigIssue, error) {
+	var raw struct {
+		Settings map[string]interface{} `yaml:",inline"`
+		Packages map[string]struct {
+			Config     map[string]interface{} `yaml:"config"`
+			Interfaces map[string]struct {
+				Config map[string]interface{} `yaml:"config"`
+			} `yaml:"interfaces"`
+		} `yaml:"packages"`
+	}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration: %w", err)
+	}
+
+	var issues []ConfigIssue
+	check := func(settings map[string]interface{}, location string) {
+		for key := range settings {
+			if hint, ok := v2OnlyKeys[key]; ok {
+				issues = append(issues, ConfigIssue{
+					Severity: SeverityWarning,
+					Path:     location + key,
+					Message:  fmt.Sprintf("%s is a mockery v2 setting that mockery v3 ignores; %s", key, hint),
+				})
+			}
+		}
+	}
+	check(raw.Settings, "")
+	for packagePath, packageConfig := range raw.Packages {
+		location := "packages." + packagePath
+		check(packageConfig.Config, location+".config.")
+		for interfaceName, interfaceConfig := range packageConfig.Interfaces {
+			check(interfaceConfig.Config, location+".interfaces."+interfaceName+".config.")
+		}
+	}
+
+	SortIssues(issues)
+	return issues, nil
+}
+
+// checkFilenameTemplate reports a filename template that mockery would fail
+// to parse, or one that won't produce a Go file
+func checkFilenameTemplate(filename, path string, add func(Severity, string, string, ...interface{})) {
+	if _, err := template.New(path).Parse(filename); err != nil {
+		add(SeverityError, path, "invalid filename template: %v", err)
+		return
+	}
+	if !strings.HasSuffix(filename, ".go") {
+		add(SeverityWarning, path, "filename %q does not end in .go", filename)
+	}
+}
+
+// ValidateConfigSyntax validates a mockery configuration, returning the
+// first error found
+func (m *MockeryConfigManager) ValidateConfigSyntax(config *types.MockeryConfig) error {
+	for _, issue := range m.ValidateConfig(config) {
+		if issue.Severity == SeverityError {
+			return errors.New(issue.Message)
+		}
+	}
 	return nil
 }
 
@@ -158,6 +311,34 @@ func (m *MockeryConfigManager) WriteConfigFile(config *types.MockeryConfig, file
 
 // ReadConfigFile reads a configuration from a .mockery.yaml file
 func (m *MockeryConfigManager) ReadConfigFile(filePath string) (*types.MockeryConfig, error) {
+	config, err := m.LoadConfigFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate configuration
+	if err := m.ValidateConfigSyntax(config); err != nil {
+		return nil, fmt.Errorf("invalid configuration in %s: %w", filePath, err)
+	}
+
+	return config, nil
+}
+
+// LoadConfigFile reads a configuration from a .mockery.yaml file without
+// validating it
+func (m *MockeryConfigManager) LoadConfigFile(filePath string) (*types.MockeryConfig, error) {
+	return m.loadConfigFile(filePath, !m.noExpandEnv)
+}
+
+// LoadLiteralConfigFile is LoadConfigFile without environment variable
+// expansion, for showing a config without the server's environment
+func (m *MockeryConfigManager) LoadLiteralConfigFile(filePath string) (*types.MockeryConfig, error) {
+	return m.loadConfigFile(filePath, false)
+}
+
+// loadConfigFile reads a configuration file, expanding environment
+// variables in it when expand is set
+func (m *MockeryConfigManager) loadConfigFile(filePath string, expand bool) (*types.MockeryConfig, error) {
 	// Check if file exists
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		return nil, fmt.Errorf("configuration file %s does not exist", filePath)
@@ -175,14 +356,52 @@ func (m *MockeryConfigManager) ReadConfigFile(filePath string) (*types.MockeryCo
 		return nil, fmt.Errorf("failed to unmarshal configuration from %s: %w", filePath, err)
 	}
 
-	// Validate configuration
-	if err := m.ValidateConfigSyntax(&config); err != nil {
-		return nil, fmt.Errorf("invalid configuration in %s: %w", filePath, err)
+	if expand {
+		if err := m.expandEnv(&config); err != nil {
+			return nil, fmt.Errorf("failed to expand configuration from %s: %w", filePath, err)
+		}
 	}
 
 	return &config, nil
 }
 
+// expandEnv expands environment variable references in the values mockery
+// resolves as paths or names. Unset variables are left as written unless
+// strict mode is on.
+func (m *MockeryConfigManager) expandEnv(config *types.MockeryConfig) error {
+	missing := make(map[string]bool)
+	expand := func(value string) string {
+		return os.Expand(value, func(name string) string {
+			if value, ok := os.LookupEnv(name); ok {
+				return value
+			}
+			missing[name] = true
+			return "${" + name + "}"
+		})
+	}
+
+	config.Filename = expand(config.Filename)
+	config.OutPkg = expand(config.OutPkg)
+	for packagePath, packageConfig := range config.Packages {
+		for interfaceName, interfaceConfig := range packageConfig.Interfaces {
+			interfaceConfig.Config.Dir = expand(interfaceConfig.Config.Dir)
+			interfaceConfig.Config.Filename = expand(interfaceConfig.Config.Filename)
+			packageConfig.Interfaces[interfaceName] = interfaceConfig
+		}
+		config.Packages[packagePath] = packageConfig
+	}
+
+	if m.strictEnv && len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("undefined environment variables: %s", strings.Join(names, ", "))
+	}
+	return nil
+}
+
 // MergeConfigurations merges multiple configurations
 func (m *MockeryConfigManager) MergeConfigurations(base *types.MockeryConfig, override *types.MockeryConfig) *types.MockeryConfig {
 	result := *base
@@ -197,6 +416,9 @@ func (m *MockeryConfigManager) MergeConfigurations(base *types.MockeryConfig, ov
 	if override.OutPkg != "" {
 		result.OutPkg = override.OutPkg
 	}
+	if override.BoilerplateFile != "" {
+		result.BoilerplateFile = override.BoilerplateFile
+	}
 
 	// Merge packages
 	if result.Packages == nil {
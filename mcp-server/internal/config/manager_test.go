@@ -15,17 +15,19 @@ func TestMockeryConfigManager_GenerateConfig(t *testing.T) {
 	manager := NewMockeryConfigManager()
 
 	request := &types.MockGenerationRequest{
-		InterfaceName:  "UserRepository",
-		PackagePath:    "github.com/example/project/internal/domain",
-		OutputDir:      "./mocks",
-		WithExpector:   true,
-		FilenameFormat: "mock_{{.InterfaceName}}.go",
+		InterfaceName:   "UserRepository",
+		PackagePath:     "github.com/example/project/internal/domain",
+		OutputDir:       "./mocks",
+		WithExpector:    true,
+		FilenameFormat:  "mock_{{.InterfaceName}}.go",
+		BoilerplateFile: "./hack/boilerplate.go.txt",
 	}
 
 	config, err := manager.GenerateConfig(request)
 
 	require.NoError(t, err)
 	assert.True(t, config.WithExpector)
+	assert.Equal(t, "./hack/boilerplate.go.txt", config.BoilerplateFile)
 	assert.Equal(t, "mock_{{.InterfaceName}}.go", config.Filename)
 	assert.Equal(t, "mocks", config.OutPkg)
 
@@ -85,6 +87,71 @@ func TestMockeryConfigManager_ValidateConfigSyntax(t *testing.T) {
 	})
 }
 
+func TestMockeryConfigManager_ValidateConfig(t *testing.T) {
+	manager := NewMockeryConfigManager()
+
+	config := &types.MockeryConfig{
+		Filename: "mock_{{.InterfaceName}.go",
+		Packages: map[string]types.Package{
+			"github.com/example/project": {
+				Interfaces: map[string]types.InterfaceConfig{
+					"UserRepository": {
+						Config: types.InterfaceSettings{
+							Filename: "user_repository_mock.txt",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	issues := manager.ValidateConfig(config)
+	require.Len(t, issues, 4)
+
+	// Every problem is reported, errors before warnings
+	assert.Equal(t, SeverityError, issues[0].Severity)
+	assert.Equal(t, "filename", issues[0].Path)
+	assert.Contains(t, issues[0].Message, "invalid filename template")
+	assert.Equal(t, "outpkg", issues[1].Path)
+	assert.Equal(t, "packages.github.com/example/project.interfaces.UserRepository.config.dir", issues[2].Path)
+	assert.Equal(t, SeverityWarning, issues[3].Severity)
+	assert.Contains(t, issues[3].Message, "does not end in .go")
+
+	// ValidateConfigSyntax still fails on the first error
+	assert.EqualError(t, manager.ValidateConfigSyntax(config), issues[0].Message)
+}
+
+func TestCheckV2OnlyKeys(t *testing.T) {
+	issues, err := CheckV2OnlyKeys([]byte(`with-expecter: true
+filename: mock_{{.InterfaceName}}.go
+pkgname: mocks
+packages:
+  example.com/app:
+    config:
+      keeptree: true
+    interfaces:
+      Repo:
+        config:
+          dir: ./mocks
+          inpackage: true
+`))
+	require.NoError(t, err)
+
+	paths := make([]string, len(issues))
+	for i, issue := range issues {
+		assert.Equal(t, SeverityWarning, issue.Severity)
+		paths[i] = issue.Path
+	}
+	assert.Equal(t, []string{
+		"packages.example.com/app.config.keeptree",
+		"packages.example.com/app.interfaces.Repo.config.inpackage",
+		"with-expecter",
+	}, paths)
+
+	_, err = CheckV2OnlyKeys([]byte("packages: [unclosed\n"))
+	assert.Error(t, err)
+}
+
 func TestMockeryConfigManager_WriteAndReadConfigFile(t *testing.T) {
 	manager := NewMockeryConfigManager()
 	tempDir := t.TempDir()
@@ -128,6 +195,65 @@ func TestMockeryConfigManager_WriteAndReadConfigFile(t *testing.T) {
 	assert.Equal(t, len(originalConfig.Packages), len(readConfig.Packages))
 }
 
+func TestMockeryConfigManager_ReadConfigFileExpandsEnv(t *testing.T) {
+	t.Setenv("MOCKS_ROOT", "/tmp/project")
+	t.Setenv("MOCKS_PKG", "fakes")
+
+	configFile := filepath.Join(t.TempDir(), ".mockery.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`with-expecter: true
+filename: "mock_{{.InterfaceName}}.go"
+outpkg: $MOCKS_PKG
+packages:
+  github.com/example/project:
+    interfaces:
+      UserRepository:
+        config:
+          dir: ${MOCKS_ROOT}/internal/mocks
+          filename: ${MOCKS_UNSET_PREFIX}_user.go
+`), 0644))
+
+	t.Run("expands set variables", func(t *testing.T) {
+		config, err := NewMockeryConfigManager().ReadConfigFile(configFile)
+		require.NoError(t, err)
+
+		settings := config.Packages["github.com/example/project"].Interfaces["UserRepository"].Config
+		assert.Equal(t, "fakes", config.OutPkg)
+		assert.Equal(t, "mock_{{.InterfaceName}}.go", config.Filename)
+		assert.Equal(t, "/tmp/project/internal/mocks", settings.Dir)
+		assert.Equal(t, "${MOCKS_UNSET_PREFIX}_user.go", settings.Filename, "unset variables are left as written")
+	})
+
+	t.Run("strict mode rejects unset variables", func(t *testing.T) {
+		manager := NewMockeryConfigManager()
+		manager.SetStrictEnv(true)
+
+		_, err := manager.ReadConfigFile(configFile)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "MOCKS_UNSET_PREFIX")
+	})
+
+	t.Run("expansion can be disabled", func(t *testing.T) {
+		manager := NewMockeryConfigManager()
+		manager.SetExpandEnv(false)
+		manager.SetStrictEnv(true)
+
+		config, err := manager.ReadConfigFile(configFile)
+		require.NoError(t, err)
+		assert.Equal(t, "$MOCKS_PKG", config.OutPkg)
+		assert.Equal(t, "${MOCKS_ROOT}/internal/mocks",
+			config.Packages["github.com/example/project"].Interfaces["UserRepository"].Config.Dir)
+	})
+
+	t.Run("literal loading ignores expansion", func(t *testing.T) {
+		manager := NewMockeryConfigManager()
+		manager.SetStrictEnv(true)
+
+		config, err := manager.LoadLiteralConfigFile(configFile)
+		require.NoError(t, err)
+		assert.Equal(t, "$MOCKS_PKG", config.OutPkg)
+	})
+}
+
 func TestMockeryConfigManager_MergeConfigurations(t *testing.T) {
 	manager := NewMockeryConfigManager()
 
@@ -173,4 +299,36 @@ func TestMockeryConfigManager_MergeConfigurations(t *testing.T) {
 	_, hasOverride := result.Packages["github.com/example/override"]
 	assert.True(t, hasBase)
 	assert.True(t, hasOverride)
-}
\ No newline at end of file
+}
+
+func TestFindConfigFile(t *testing.T) {
+	for _, name := range ConfigFilenames {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("outpkg: mocks\n"), 0644))
+
+			path, ok := FindConfigFile(dir)
+			require.True(t, ok)
+			assert.Equal(t, filepath.Join(dir, name), path)
+		})
+	}
+
+	t.Run("prefers .mockery.yaml", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, name := range []string{"mockery.yaml", ".mockery.yml", ".mockery.yaml"} {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("outpkg: mocks\n"), 0644))
+		}
+
+		path, ok := FindConfigFile(dir)
+		require.True(t, ok)
+		assert.Equal(t, filepath.Join(dir, ".mockery.yaml"), path)
+	})
+
+	t.Run("none", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(dir, ".mockery.yaml"), 0755))
+
+		_, ok := FindConfigFile(dir)
+		assert.False(t, ok)
+	})
+}
@@ -1,32 +1,42 @@
 package models
 
 import (
+	"math/rand/v2"
+	"sort"
+	"sync"
 	"time"
 
-	"github.com/example/mockery-mcp-server/internal/types"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
 )
 
 // MockeryProject represents a project with mockery configuration
 type MockeryProject struct {
-	ID          string                 `json:"id"`
-	Name        string                 `json:"name"`
-	Path        string                 `json:"path"`
-	Config      types.MockeryConfig    `json:"config"`
-	CreatedAt   time.Time              `json:"created_at"`
-	UpdatedAt   time.Time              `json:"updated_at"`
-	Interfaces  []types.InterfaceDefinition `json:"interfaces"`
+	ID         string                      `json:"id"`
+	Name       string                      `json:"name"`
+	Path       string                      `json:"path"`
+	Config     types.MockeryConfig         `json:"config"`
+	CreatedAt  time.Time                   `json:"created_at"`
+	UpdatedAt  time.Time                   `json:"updated_at"`
+	Interfaces []types.InterfaceDefinition `json:"interfaces"`
 }
 
 // GeneratedMock represents a generated mock file
 type GeneratedMock struct {
-	ID            string    `json:"id"`
-	ProjectID     string    `json:"project_id"`
-	InterfaceName string    `json:"interface_name"`
-	PackagePath   string    `json:"package_path"`
-	FilePath      string    `json:"file_path"`
-	GeneratedAt   time.Time `json:"generated_at"`
-	MockeryVersion string   `json:"mockery_version"`
-	Hash          string    `json:"hash"` // Hash of the generated content for change detection
+	ID             string    `json:"id"`
+	ProjectID      string    `json:"project_id"`
+	InterfaceName  string    `json:"interface_name"`
+	PackagePath    string    `json:"package_path"`
+	FilePath       string    `json:"file_path"`
+	GeneratedAt    time.Time `json:"generated_at"`
+	MockeryVersion string    `json:"mockery_version"`
+	Hash           string    `json:"hash"` // Hash of the generated content for change detection
+	// SourceHash is the interface's signature hash when the mock was
+	// generated, empty when the interface couldn't be scanned
+	SourceHash string `json:"source_hash,omitempty"`
+
+	// Request is the generation request that produced the mock, kept so it
+	// can be regenerated with the same options
+	Request types.MockGenerationRequest `json:"request"`
 }
 
 // InterfaceRegistry manages discovered interfaces for a project
@@ -43,33 +53,37 @@ type ScanResults struct {
 	InterfacesFound int           `json:"interfaces_found"`
 	ScanDuration    time.Duration `json:"scan_duration"`
 	Errors          []string      `json:"errors,omitempty"`
+
+	// PackageDocs maps package directories to their package comment
+	PackageDocs map[string]string `json:"package_docs,omitempty"`
 }
 
 // MockGenerationJob represents a mock generation job
 type MockGenerationJob struct {
-	ID          string                        `json:"id"`
-	ProjectID   string                        `json:"project_id"`
-	Request     types.MockGenerationRequest   `json:"request"`
-	Result      *types.MockGenerationResult   `json:"result,omitempty"`
-	Status      JobStatus                     `json:"status"`
-	CreatedAt   time.Time                     `json:"created_at"`
-	StartedAt   *time.Time                    `json:"started_at,omitempty"`
-	CompletedAt *time.Time                    `json:"completed_at,omitempty"`
+	ID          string                      `json:"id"`
+	ProjectID   string                      `json:"project_id"`
+	Request     types.MockGenerationRequest `json:"request"`
+	Result      *types.MockGenerationResult `json:"result,omitempty"`
+	Status      JobStatus                   `json:"status"`
+	CreatedAt   time.Time                   `json:"created_at"`
+	StartedAt   *time.Time                  `json:"started_at,omitempty"`
+	CompletedAt *time.Time                  `json:"completed_at,omitempty"`
 }
 
 // JobStatus represents the status of a generation job
 type JobStatus string
 
 const (
-	JobStatusPending    JobStatus = "pending"
-	JobStatusRunning    JobStatus = "running"
-	JobStatusCompleted  JobStatus = "completed"
-	JobStatusFailed     JobStatus = "failed"
-	JobStatusCancelled  JobStatus = "cancelled"
+	JobStatusPending   JobStatus = "pending"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusCompleted JobStatus = "completed"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusCancelled JobStatus = "cancelled"
 )
 
 // ProjectManager manages mockery projects
 type ProjectManager struct {
+	mu       sync.RWMutex
 	projects map[string]*MockeryProject
 	mocks    map[string]*GeneratedMock
 	jobs     map[string]*MockGenerationJob
@@ -86,6 +100,9 @@ func NewProjectManager() *ProjectManager {
 
 // GetProject retrieves a project by ID
 func (pm *ProjectManager) GetProject(id string) (*MockeryProject, bool) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
 	project, exists := pm.projects[id]
 	return project, exists
 }
@@ -99,17 +116,57 @@ func (pm *ProjectManager) CreateProject(name, path string) *MockeryProject {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
 	pm.projects[project.ID] = project
 	return project
 }
 
-// AddGeneratedMock records a generated mock
+// ListProjects returns all projects, oldest first
+func (pm *ProjectManager) ListProjects() []*MockeryProject {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	projects := make([]*MockeryProject, 0, len(pm.projects))
+	for _, project := range pm.projects {
+		projects = append(projects, project)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		if !projects[i].CreatedAt.Equal(projects[j].CreatedAt) {
+			return projects[i].CreatedAt.Before(projects[j].CreatedAt)
+		}
+		return projects[i].ID < projects[j].ID
+	})
+	return projects
+}
+
+// AddGeneratedMock records a generated mock, assigning an ID if it has none
 func (pm *ProjectManager) AddGeneratedMock(mock *GeneratedMock) {
+	if mock.ID == "" {
+		mock.ID = generateID()
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
 	pm.mocks[mock.ID] = mock
 }
 
+// RemoveGeneratedMock forgets the mock with the given ID
+func (pm *ProjectManager) RemoveGeneratedMock(id string) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	delete(pm.mocks, id)
+}
+
 // GetGeneratedMocks returns all mocks for a project
 func (pm *ProjectManager) GetGeneratedMocks(projectID string) []*GeneratedMock {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
 	var mocks []*GeneratedMock
 	for _, mock := range pm.mocks {
 		if mock.ProjectID == projectID {
@@ -119,6 +176,19 @@ func (pm *ProjectManager) GetGeneratedMocks(projectID string) []*GeneratedMock {
 	return mocks
 }
 
+// FindGeneratedMock returns the mock recorded for a project at the given file path
+func (pm *ProjectManager) FindGeneratedMock(projectID, filePath string) (*GeneratedMock, bool) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	for _, mock := range pm.mocks {
+		if mock.ProjectID == projectID && mock.FilePath == filePath {
+			return mock, true
+		}
+	}
+	return nil, false
+}
+
 // CreateJob creates a new mock generation job
 func (pm *ProjectManager) CreateJob(projectID string, request types.MockGenerationRequest) *MockGenerationJob {
 	job := &MockGenerationJob{
@@ -128,22 +198,37 @@ func (pm *ProjectManager) CreateJob(projectID string, request types.MockGenerati
 		Status:    JobStatusPending,
 		CreatedAt: time.Now(),
 	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
 	pm.jobs[job.ID] = job
 	return job
 }
 
-// GetJob retrieves a job by ID
+// GetJob retrieves a snapshot of a job by ID. Jobs are updated as they run,
+// so a copy is returned rather than the live record.
 func (pm *ProjectManager) GetJob(id string) (*MockGenerationJob, bool) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
 	job, exists := pm.jobs[id]
-	return job, exists
+	if !exists {
+		return nil, false
+	}
+	snapshot := *job
+	return &snapshot, true
 }
 
 // UpdateJobStatus updates the status of a job
 func (pm *ProjectManager) UpdateJobStatus(jobID string, status JobStatus) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
 	if job, exists := pm.jobs[jobID]; exists {
 		job.Status = status
 		now := time.Now()
-		
+
 		switch status {
 		case JobStatusRunning:
 			job.StartedAt = &now
@@ -153,6 +238,17 @@ func (pm *ProjectManager) UpdateJobStatus(jobID string, status JobStatus) {
 	}
 }
 
+// SetJobResult records the result of a finished job along with its final status
+func (pm *ProjectManager) SetJobResult(jobID string, status JobStatus, result *types.MockGenerationResult) {
+	pm.mu.Lock()
+	if job, exists := pm.jobs[jobID]; exists {
+		job.Result = result
+	}
+	pm.mu.Unlock()
+
+	pm.UpdateJobStatus(jobID, status)
+}
+
 // Helper function to generate unique IDs
 func generateID() string {
 	return time.Now().Format("20060102150405") + "-" + randomString(8)
@@ -162,7 +258,7 @@ func randomString(length int) string {
 	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 	result := make([]byte, length)
 	for i := range result {
-		result[i] = charset[time.Now().UnixNano()%int64(len(charset))]
+		result[i] = charset[rand.IntN(len(charset))]
 	}
 	return string(result)
-}
\ No newline at end of file
+}
@@ -0,0 +1,140 @@
+package scanner
+
+import (
+	"go/ast"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// DirectiveKeys are the mock settings recognized in interface comment
+// directives
+var DirectiveKeys = []string{"dir", "filename", "outpkg", "mockname", "with-expecter", "inpackage", "testonly"}
+
+// booleanDirectives are the directive keys that take no value
+var booleanDirectives = map[string]bool{"with-expecter": true, "inpackage": true, "testonly": true}
+
+// mockeryFlagKeys maps mockery command line flags to directive keys
+var mockeryFlagKeys = map[string]string{
+	"output":        "dir",
+	"filename":      "filename",
+	"outpkg":        "outpkg",
+	"mockname":      "mockname",
+	"with-expecter": "with-expecter",
+	"inpackage":     "inpackage",
+	"testonly":      "testonly",
+}
+
+// parseDirectives collects the mock settings given by directives in an
+// interface's doc comment. Two forms are recognized:
+//
+//	//mockery:dir ./mocks/storage
+//	//go:generate mockery --name=Store --output=./mocks/storage --with-expecter
+//
+// A mockery directive's key may also be followed by "=value"; a boolean key
+// alone means true. A go:generate line naming another interface with --name
+// is ignored. Later directives override earlier ones, and keys other than
+// DirectiveKeys are dropped.
+func parseDirectives(group *ast.CommentGroup, interfaceName string) map[string]string {
+	if group == nil {
+		return nil
+	}
+
+	directives := make(map[string]string)
+	for _, comment := range group.List {
+		if rest, ok := strings.CutPrefix(comment.Text, "//mockery:"); ok {
+			key, value, found := strings.Cut(strings.TrimSpace(rest), "=")
+			if !found {
+				key, value, _ = strings.Cut(key, " ")
+			}
+			key, value = strings.TrimSpace(key), unquoteDirective(strings.TrimSpace(value))
+			if booleanDirectives[key] && value == "" {
+				value = "true"
+			}
+			if isDirectiveKey(key) && value != "" {
+				directives[key] = value
+			}
+			continue
+		}
+
+		if rest, ok := strings.CutPrefix(comment.Text, "//go:generate "); ok {
+			for key, value := range mockeryGenerateFlags(rest, interfaceName) {
+				directives[key] = value
+			}
+		}
+	}
+
+	if len(directives) == 0 {
+		return nil
+	}
+	return directives
+}
+
+// mockeryGenerateFlags returns the directive settings of a go:generate
+// command that runs mockery for interfaceName, whether mockery is invoked
+// directly or with go run
+func mockeryGenerateFlags(command, interfaceName string) map[string]string {
+	fields := strings.Fields(command)
+	start := -1
+	for i, field := range fields {
+		// Matches mockery, /path/to/mockery and github.com/vektra/mockery/v2@v2.53.0
+		name, _, _ := strings.Cut(field, "@")
+		if base := path.Base(name); base == "mockery" || (isMajorVersion(base) && path.Base(path.Dir(name)) == "mockery") {
+			start = i + 1
+			break
+		}
+	}
+	if start < 0 {
+		return nil
+	}
+
+	flags := make(map[string]string)
+	var name string
+	args := fields[start:]
+	for i := 0; i < len(args); i++ {
+		flag := strings.TrimLeft(args[i], "-")
+		if flag == args[i] {
+			continue
+		}
+		key, value, found := strings.Cut(flag, "=")
+		if !found && !booleanDirectives[key] && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			i++
+			value = args[i]
+		}
+		value = unquoteDirective(value)
+
+		if key == "name" {
+			name = value
+			continue
+		}
+		if directiveKey, ok := mockeryFlagKeys[key]; ok {
+			if booleanDirectives[directiveKey] && value == "" {
+				value = "true"
+			}
+			flags[directiveKey] = value
+		}
+	}
+
+	if name != "" && name != interfaceName {
+		return nil
+	}
+	return flags
+}
+
+// isDirectiveKey reports whether key is one of DirectiveKeys
+func isDirectiveKey(key string) bool {
+	for _, directiveKey := range DirectiveKeys {
+		if key == directiveKey {
+			return true
+		}
+	}
+	return false
+}
+
+// unquoteDirective removes the quotes around a quoted directive value
+func unquoteDirective(value string) string {
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	return value
+}
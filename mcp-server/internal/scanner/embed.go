@@ -0,0 +1,77 @@
+package scanner
+
+import (
+	"path/filepath"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// packageKey identifies a package by its directory and name
+type packageKey struct {
+	dir  string
+	name string
+}
+
+// resolveEmbeddedInterfaces expands interfaces that embed other interfaces
+// of the same package, which may be declared in a different file, into their
+// full method sets. Methods declared directly take precedence over promoted
+// ones of the same name, and embedding cycles are ignored. Promoted methods
+// record the interface declaring them as their Origin, which for nested
+// embeds is the innermost one. Embeds from other packages are left as
+// references since their sources aren't scanned.
+func resolveEmbeddedInterfaces(interfaces []types.InterfaceDefinition) []types.InterfaceDefinition {
+	packages := make(map[packageKey]map[string]int)
+	for i, iface := range interfaces {
+		key := packageKey{dir: filepath.Dir(iface.FilePath), name: iface.Package}
+		if packages[key] == nil {
+			packages[key] = make(map[string]int)
+		}
+		packages[key][iface.Name] = i
+	}
+
+	resolved := make(map[int][]types.MethodSignature)
+	var methodSet func(index int, visiting map[int]bool) []types.MethodSignature
+	methodSet = func(index int, visiting map[int]bool) []types.MethodSignature {
+		if methods, ok := resolved[index]; ok {
+			return methods
+		}
+		iface := interfaces[index]
+		if len(iface.Embeds) == 0 {
+			return iface.Methods
+		}
+
+		visiting[index] = true
+		defer delete(visiting, index)
+
+		local := packages[packageKey{dir: filepath.Dir(iface.FilePath), name: iface.Package}]
+		methods := append([]types.MethodSignature(nil), iface.Methods...)
+		seen := make(map[string]bool, len(methods))
+		for _, method := range methods {
+			seen[method.Name] = true
+		}
+
+		for _, embed := range iface.Embeds {
+			embedded, ok := local[embed]
+			if !ok || visiting[embedded] {
+				continue
+			}
+			for _, method := range methodSet(embedded, visiting) {
+				if !seen[method.Name] {
+					seen[method.Name] = true
+					if method.Origin == "" {
+						method.Origin = embed
+					}
+					methods = append(methods, method)
+				}
+			}
+		}
+
+		resolved[index] = methods
+		return methods
+	}
+
+	for i := range interfaces {
+		interfaces[i].Methods = methodSet(i, make(map[int]bool))
+	}
+	return interfaces
+}
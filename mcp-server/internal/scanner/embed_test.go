@@ -0,0 +1,133 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+func TestGoInterfaceScanner_ResolvesEmbeddedInterfacesAcrossFiles(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"reader.go": `package store
+
+type Reader interface {
+	Get(id string) (string, error)
+}
+`,
+		"writer.go": `package store
+
+type Writer interface {
+	Put(id, value string) error
+	Close() error
+}
+`,
+		"store.go": `package store
+
+import "io"
+
+type ReadWriter interface {
+	Reader
+	Writer
+	io.Closer
+	// Close is redeclared directly and wins over the promoted method
+	Close() error
+}
+
+type Store interface {
+	ReadWriter
+	Flush()
+}
+`,
+		"other/reader.go": `package other
+
+// Reader in another package must not satisfy store's embeds
+type Reader interface {
+	Scan() bool
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	interfaces, err := NewGoInterfaceScanner().ScanProject(root)
+	require.NoError(t, err)
+
+	byName := make(map[string]types.InterfaceDefinition)
+	for _, iface := range interfaces {
+		if iface.Package == "store" {
+			byName[iface.Name] = iface
+		}
+	}
+
+	methodNames := func(iface types.InterfaceDefinition) []string {
+		var names []string
+		for _, method := range iface.Methods {
+			names = append(names, method.Name)
+		}
+		return names
+	}
+
+	readWriter := byName["ReadWriter"]
+	assert.Equal(t, []string{"Reader", "Writer", "io.Closer"}, readWriter.Embeds)
+	assert.Equal(t, []string{"Close", "Get", "Put"}, methodNames(readWriter))
+
+	// Embedding resolves transitively
+	store := byName["Store"]
+	assert.Equal(t, []string{"ReadWriter"}, store.Embeds)
+	assert.Equal(t, []string{"Flush", "Close", "Get", "Put"}, methodNames(store))
+
+	// Promoted methods keep their full signatures
+	assert.Equal(t, "string", store.Methods[2].Parameters[0].Type)
+
+	// Promoted methods record the interface declaring them
+	origins := func(iface types.InterfaceDefinition) []string {
+		var names []string
+		for _, method := range iface.Methods {
+			names = append(names, method.Origin)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"", "Reader", "Writer"}, origins(readWriter))
+	assert.Equal(t, []string{"", "ReadWriter", "Reader", "Writer"}, origins(store))
+
+	assert.Empty(t, byName["Reader"].Embeds)
+	assert.Equal(t, []string{"Get"}, methodNames(byName["Reader"]))
+}
+
+func TestGoInterfaceScanner_ExtractInterfaceMetadataResolvesEmbeds(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "store.go"), []byte(`package store
+
+type Reader interface {
+	Get(id string) (string, error)
+}
+
+type ReadWriter interface {
+	Reader
+	Closer
+	Write() error
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "closer.go"), []byte("package store\n\ntype Closer interface{ Close() error }\n"), 0644))
+	// A sibling that doesn't parse is skipped
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.go"), []byte("package store\n\ntype {"), 0644))
+
+	iface, err := NewGoInterfaceScanner().ExtractInterfaceMetadata(filepath.Join(dir, "store.go"), "ReadWriter")
+	require.NoError(t, err)
+
+	var names []string
+	for _, method := range iface.Methods {
+		names = append(names, method.Name)
+	}
+	assert.Equal(t, []string{"Write", "Get", "Close"}, names)
+	assert.Equal(t, "Closer", iface.Methods[2].Origin)
+}
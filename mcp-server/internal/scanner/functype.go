@@ -0,0 +1,83 @@
+package scanner
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// ScanFunctionTypes scans the given Go files for named function types, such
+// as type HandlerFunc func(w Writer, r *Request). Like ScanFiles it fails on
+// the first file that cannot be parsed.
+func (s *GoInterfaceScanner) ScanFunctionTypes(paths []string) ([]types.FunctionTypeDefinition, error) {
+	var functions []types.FunctionTypeDefinition
+	for _, path := range paths {
+		fileFunctions, err := s.scanFileFunctionTypes(path)
+		if err != nil {
+			return nil, err
+		}
+		functions = append(functions, fileFunctions...)
+	}
+	return functions, nil
+}
+
+// scanFileFunctionTypes scans a single Go file for top-level named function
+// types. Aliases (type F = func()) are skipped, since mockery only mocks
+// defined types.
+func (s *GoInterfaceScanner) scanFileFunctionTypes(filePath string) ([]types.FunctionTypeDefinition, error) {
+	if err := s.checkFileSize(filePath); err != nil {
+		return nil, err
+	}
+
+	fileSet := token.NewFileSet()
+	src, err := parser.ParseFile(fileSet, filePath, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file %s: %w", filePath, err)
+	}
+
+	var functions []types.FunctionTypeDefinition
+	imports := fileImports(src)
+	for _, decl := range src.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok || typeSpec.Assign.IsValid() {
+				continue
+			}
+			funcType, ok := typeSpec.Type.(*ast.FuncType)
+			if !ok {
+				continue
+			}
+
+			// Grouped declarations document each spec individually
+			docGroup := typeSpec.Doc
+			if docGroup == nil && len(genDecl.Specs) == 1 {
+				docGroup = genDecl.Doc
+			}
+
+			// A function type has the shape of a single method, so its
+			// parameters are extracted the same way
+			signature := s.extractMethodSignature(typeSpec.Name.Name, funcType, nil,
+				newMatcherQualifier(src.Name.Name, imports, typeSpec.TypeParams))
+			functions = append(functions, types.FunctionTypeDefinition{
+				Name:       typeSpec.Name.Name,
+				Package:    src.Name.Name,
+				Parameters: signature.Parameters,
+				Returns:    signature.Returns,
+				FilePath:   filePath,
+				LineNumber: fileSet.Position(typeSpec.Pos()).Line,
+				Comments:   commentLines(docGroup),
+				Imports:    referencedImports(funcType, imports),
+				TypeParams: s.typeParams(typeSpec.TypeParams),
+			})
+		}
+	}
+	return functions, nil
+}
@@ -0,0 +1,85 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoInterfaceScanner_ScanFunctionTypes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "handler.go")
+	require.NoError(t, os.WriteFile(path, []byte(`package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// HandlerFunc handles an event
+type HandlerFunc func(ctx context.Context, event *Event) error
+
+type (
+	// Middleware wraps a handler
+	Middleware func(HandlerFunc) HandlerFunc
+
+	Mapper[T any] func(T) (T, bool)
+
+	// Alias is not a defined type, so mockery can't mock it
+	Alias = func()
+)
+
+type Event struct{}
+
+type Writer interface {
+	io.Writer
+}
+
+var _ = http.StatusOK
+`), 0644))
+
+	functions, err := NewGoInterfaceScanner().ScanFunctionTypes([]string{path})
+	require.NoError(t, err)
+	require.Len(t, functions, 3)
+
+	handler := functions[0]
+	assert.Equal(t, "HandlerFunc", handler.Name)
+	assert.Equal(t, "handler", handler.Package)
+	assert.Equal(t, path, handler.FilePath)
+	assert.Equal(t, 10, handler.LineNumber)
+	assert.Equal(t, []string{"HandlerFunc handles an event"}, handler.Comments)
+	require.Len(t, handler.Parameters, 2)
+	assert.Equal(t, "ctx", handler.Parameters[0].Name)
+	assert.Equal(t, "context.Context", handler.Parameters[0].Type)
+	assert.Equal(t, "*Event", handler.Parameters[1].Type)
+	assert.Equal(t, "*handler.Event", handler.Parameters[1].MatcherType)
+	require.Len(t, handler.Returns, 1)
+	assert.Equal(t, "error", handler.Returns[0].Type)
+	// Only imports the signature uses are recorded
+	require.Len(t, handler.Imports, 1)
+	assert.Equal(t, "context", handler.Imports[0].Path)
+
+	middleware := functions[1]
+	assert.Equal(t, "Middleware", middleware.Name)
+	assert.Equal(t, []string{"Middleware wraps a handler"}, middleware.Comments)
+	assert.Equal(t, "HandlerFunc", middleware.Parameters[0].Type)
+	assert.Equal(t, "HandlerFunc", middleware.Returns[0].Type)
+	assert.Empty(t, middleware.Imports)
+
+	mapper := functions[2]
+	assert.Equal(t, "Mapper", mapper.Name)
+	require.Len(t, mapper.TypeParams, 1)
+	assert.Equal(t, "T", mapper.TypeParams[0].Name)
+	assert.Len(t, mapper.Returns, 2)
+}
+
+func TestGoInterfaceScanner_ScanFunctionTypesParseError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.go")
+	require.NoError(t, os.WriteFile(path, []byte("package broken\n\ntype F func(\n"), 0644))
+
+	_, err := NewGoInterfaceScanner().ScanFunctionTypes([]string{path})
+	assert.Error(t, err)
+}
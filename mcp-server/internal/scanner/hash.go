@@ -0,0 +1,52 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// InterfaceSignatureHash returns a SHA-256 hash of an interface's method set.
+// Only type parameters, method names and parameter and return types, and
+// embedded interfaces contribute, with methods and embeds in name order, so
+// comments, parameter names, formatting and declaration order can change
+// without changing the hash.
+func InterfaceSignatureHash(def types.InterfaceDefinition) string {
+	methods := make([]string, len(def.Methods))
+	for i, method := range def.Methods {
+		methods[i] = method.Name + "(" + joinTypes(method.Parameters) + ")(" + joinTypes(method.Returns) + ")"
+	}
+	sort.Strings(methods)
+
+	// Type parameters are part of the contract, in declaration order
+	var params []string
+	for _, param := range def.TypeParams {
+		params = append(params, param.Name+" "+param.Constraint)
+	}
+	if len(params) > 0 {
+		methods = append([]string{"[" + strings.Join(params, ",") + "]"}, methods...)
+	}
+
+	// Embeds are hashed as written, since those from other packages have no
+	// methods listed
+	if len(def.Embeds) > 0 {
+		embeds := slices.Sorted(slices.Values(def.Embeds))
+		methods = append(methods, "embeds "+strings.Join(embeds, ","))
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(methods, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// joinTypes joins the types of a parameter list, ignoring names
+func joinTypes(params []types.Parameter) string {
+	parts := make([]string, len(params))
+	for i, param := range params {
+		parts[i] = param.Type
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,63 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterfaceSignatureHash(t *testing.T) {
+	hashOf := func(t *testing.T, source string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "repo.go")
+		require.NoError(t, os.WriteFile(path, []byte(source), 0644))
+
+		iface, err := NewGoInterfaceScanner().ExtractInterfaceMetadata(path, "Repo")
+		require.NoError(t, err)
+		return InterfaceSignatureHash(*iface)
+	}
+
+	base := hashOf(t, `package repo
+
+type Repo interface {
+	Get(id string) (string, error)
+	Delete(id string) error
+}
+`)
+	assert.Len(t, base, 64)
+
+	// Comments, parameter names, formatting and method order don't matter
+	assert.Equal(t, base, hashOf(t, `package repo
+
+// Repo stores things
+type Repo interface {
+	// Delete removes a thing
+	Delete(key   string) error
+
+	Get(key string) (value string, err error)
+}
+`))
+
+	// Changing a type, a name or the method set does
+	assert.NotEqual(t, base, hashOf(t, "package repo\n\ntype Repo interface {\n\tGet(id int) (string, error)\n\tDelete(id string) error\n}\n"))
+	assert.NotEqual(t, base, hashOf(t, "package repo\n\ntype Repo interface {\n\tFetch(id string) (string, error)\n\tDelete(id string) error\n}\n"))
+	assert.NotEqual(t, base, hashOf(t, "package repo\n\ntype Repo interface {\n\tGet(id string) (string, error)\n}\n"))
+
+	// So do type parameter constraints
+	assert.NotEqual(t,
+		hashOf(t, "package repo\n\ntype Repo[T any] interface {\n\tGet(id T) error\n}\n"),
+		hashOf(t, "package repo\n\ntype Repo[T comparable] interface {\n\tGet(id T) error\n}\n"))
+
+	// And embedded interfaces from other packages, in any order
+	closer := hashOf(t, "package repo\n\nimport \"io\"\n\ntype Repo interface {\n\tio.Closer\n\tGet(id string) (string, error)\n\tDelete(id string) error\n}\n")
+	assert.NotEqual(t, base, closer)
+	assert.NotEqual(t,
+		hashOf(t, "package repo\n\nimport \"io\"\n\ntype Repo interface {\n\tio.Reader\n\tFoo()\n}\n"),
+		hashOf(t, "package repo\n\nimport \"io\"\n\ntype Repo interface {\n\tio.Writer\n\tFoo()\n}\n"))
+	assert.Equal(t,
+		hashOf(t, "package repo\n\nimport \"io\"\n\ntype Repo interface {\n\tio.Reader\n\tio.Writer\n}\n"),
+		hashOf(t, "package repo\n\nimport \"io\"\n\ntype Repo interface {\n\tio.Writer\n\tio.Reader\n}\n"))
+}
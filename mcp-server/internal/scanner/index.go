@@ -0,0 +1,31 @@
+package scanner
+
+import (
+	"fmt"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// QualifiedName returns the key an interface is indexed under: its package
+// name and interface name, e.g. domain.UserRepository
+func QualifiedName(def types.InterfaceDefinition) string {
+	return def.Package + "." + def.Name
+}
+
+// IndexInterfaces keys interfaces by qualified name for constant-time lookup.
+// Packages in different directories can share a name, so two interfaces may
+// collide; the first one keeps the key and a warning names both declarations.
+func IndexInterfaces(interfaces []types.InterfaceDefinition) (map[string]types.InterfaceDefinition, []string) {
+	index := make(map[string]types.InterfaceDefinition, len(interfaces))
+	var warnings []string
+	for _, iface := range interfaces {
+		key := QualifiedName(iface)
+		if existing, ok := index[key]; ok {
+			warnings = append(warnings, fmt.Sprintf("%s is declared in both %s:%d and %s:%d; keeping the first",
+				key, existing.FilePath, existing.LineNumber, iface.FilePath, iface.LineNumber))
+			continue
+		}
+		index[key] = iface
+	}
+	return index, warnings
+}
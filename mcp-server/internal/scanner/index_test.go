@@ -0,0 +1,30 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+func TestIndexInterfaces(t *testing.T) {
+	interfaces := []types.InterfaceDefinition{
+		{Name: "Repo", Package: "domain", FilePath: "/a/domain/repo.go", LineNumber: 3},
+		{Name: "Store", Package: "domain", FilePath: "/a/domain/store.go", LineNumber: 5},
+		{Name: "Repo", Package: "domain", FilePath: "/b/domain/repo.go", LineNumber: 7},
+	}
+
+	index, warnings := IndexInterfaces(interfaces)
+	require.Len(t, index, 2)
+	assert.Equal(t, "/a/domain/repo.go", index["domain.Repo"].FilePath)
+	assert.Equal(t, "/a/domain/store.go", index["domain.Store"].FilePath)
+	assert.Equal(t, []string{
+		"domain.Repo is declared in both /a/domain/repo.go:3 and /b/domain/repo.go:7; keeping the first",
+	}, warnings)
+
+	index, warnings = IndexInterfaces(nil)
+	assert.Empty(t, index)
+	assert.Empty(t, warnings)
+}
@@ -1,81 +1,264 @@
 package scanner
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/models"
 	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
 )
 
-// GoInterfaceScanner scans Go source code for interface definitions
+// ErrInterfaceNotFound is returned when a requested interface is not declared in a file
+var ErrInterfaceNotFound = errors.New("interface not found")
+
+// ErrFileTooLarge is returned for Go files larger than the scanner's maximum
+// file size, which are never parsed
+var ErrFileTooLarge = errors.New("file too large")
+
+// DefaultMaxFileSize is the largest Go file, in bytes, the scanner parses by
+// default. Parsing a file takes many times its size in memory, so huge
+// generated files could otherwise exhaust the server.
+const DefaultMaxFileSize int64 = 5 << 20
+
+// GoInterfaceScanner scans Go source code for interface definitions. It is
+// safe for concurrent use: every file is parsed into a FileSet of its own,
+// so positions from one scan never accumulate in or race with another.
 type GoInterfaceScanner struct {
-	fileSet *token.FileSet
+	maxFileSize int64
 }
 
 // NewGoInterfaceScanner creates a new interface scanner
 func NewGoInterfaceScanner() *GoInterfaceScanner {
 	return &GoInterfaceScanner{
-		fileSet: token.NewFileSet(),
+		maxFileSize: DefaultMaxFileSize,
+	}
+}
+
+// SetMaxFileSize sets the largest file, in bytes, the scanner will parse.
+// Zero or less removes the limit.
+func (s *GoInterfaceScanner) SetMaxFileSize(size int64) {
+	s.maxFileSize = size
+}
+
+// checkFileSize returns ErrFileTooLarge if a file exceeds the maximum size
+func (s *GoInterfaceScanner) checkFileSize(filePath string) error {
+	if s.maxFileSize <= 0 {
+		return nil
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat file %s: %w", filePath, err)
 	}
+	if info.Size() > s.maxFileSize {
+		return fmt.Errorf("%w: skipped %s (%d bytes, limit %d)", ErrFileTooLarge, filePath, info.Size(), s.maxFileSize)
+	}
+	return nil
+}
+
+// ScanOptions controls which files a project scan covers
+type ScanOptions struct {
+	// IncludeTests also scans _test.go files, which are skipped by default
+	IncludeTests bool
+
+	// FollowSymlinks walks into symlinked directories, which are skipped by
+	// default. Each directory is walked once however many links lead to it,
+	// so symlink cycles end the walk rather than recursing forever.
+	FollowSymlinks bool
+
+	// MaxDepth limits how many directory levels the walk covers: 1 scans
+	// only the root directory, 2 the root and its subdirectories, and so on.
+	// Zero means unlimited.
+	MaxDepth int
 }
 
-// ScanProject scans a Go project for interface definitions
+// ScanProject scans a Go project for interface definitions. Files and
+// directories that can't be read or parsed are skipped; use
+// ScanProjectResults to find out which.
 func (s *GoInterfaceScanner) ScanProject(projectPath string) ([]types.InterfaceDefinition, error) {
+	interfaces, _, err := s.ScanProjectResults(projectPath)
+	return interfaces, err
+}
+
+// ScanProjectResults scans a Go project for interface definitions and
+// reports scan statistics, using the default options and no deadline
+func (s *GoInterfaceScanner) ScanProjectResults(projectPath string) ([]types.InterfaceDefinition, models.ScanResults, error) {
+	return s.ScanProjectContext(context.Background(), projectPath, ScanOptions{})
+}
+
+// ScanProjectContext scans a Go project for interface definitions and
+// reports scan statistics. A directory that can't be read or a file that
+// can't be parsed, or is larger than the maximum file size, is recorded in
+// the results' Errors and the scan carries on; only a missing or unreadable
+// project root is a hard error. The walk stops as soon as ctx is done,
+// returning ctx.Err().
+func (s *GoInterfaceScanner) ScanProjectContext(ctx context.Context, projectPath string, opts ScanOptions) ([]types.InterfaceDefinition, models.ScanResults, error) {
 	var interfaces []types.InterfaceDefinition
+	var results models.ScanResults
+	started := time.Now()
+
+	if _, err := os.Stat(projectPath); err != nil {
+		return nil, results, fmt.Errorf("failed to scan project: %w", err)
+	}
+
+	// Real paths of the directories walked so far, when following symlinks
+	visited := make(map[string]bool)
 
 	// Parse all Go files in the project
-	err := filepath.Walk(projectPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
+	var visit filepath.WalkFunc
+	visit = func(path string, info os.FileInfo, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
 		}
 
-		// Skip non-Go files
-		if !strings.HasSuffix(path, ".go") {
+		if err != nil {
+			if path == projectPath {
+				return err
+			}
+			results.Errors = append(results.Errors, err.Error())
+			if info != nil && info.IsDir() {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 
-		// Skip test files for now (could be configurable)
-		if strings.HasSuffix(path, "_test.go") {
-			return nil
+		if opts.MaxDepth > 0 && info.IsDir() && dirDepth(projectPath, path) >= opts.MaxDepth {
+			return filepath.SkipDir
+		}
+
+		if opts.FollowSymlinks {
+			if info.IsDir() {
+				realPath, err := filepath.EvalSymlinks(path)
+				if err != nil {
+					results.Errors = append(results.Errors, err.Error())
+					return filepath.SkipDir
+				}
+				if visited[realPath] {
+					return filepath.SkipDir
+				}
+				visited[realPath] = true
+			} else if info.Mode()&os.ModeSymlink != 0 {
+				if followed, err := walkSymlinkedDir(path, visit); followed || err != nil {
+					return err
+				}
+			}
 		}
 
-		// Skip vendor directory
-		if strings.Contains(path, "vendor/") {
+		// Skip non-Go files, vendored files and tests unless asked for them
+		if !IsSourceFile(projectPath, path, opts.IncludeTests) {
 			return nil
 		}
 
 		// Parse the Go file
-		fileInterfaces, err := s.scanFile(path)
+		results.FilesScanned++
+		fileInterfaces, packageDoc, err := s.scanFile(path)
 		if err != nil {
-			// Log error but continue scanning other files
+			// Record the error but continue scanning other files
+			results.Errors = append(results.Errors, err.Error())
 			return nil
 		}
 
+		// A package is documented by its doc.go, or else by the first of its
+		// files with a package comment
+		if packageDoc != "" {
+			dir := filepath.Dir(path)
+			if _, ok := results.PackageDocs[dir]; !ok || filepath.Base(path) == "doc.go" {
+				if results.PackageDocs == nil {
+					results.PackageDocs = make(map[string]string)
+				}
+				results.PackageDocs[dir] = packageDoc
+			}
+		}
+
 		interfaces = append(interfaces, fileInterfaces...)
 		return nil
-	})
+	}
+	err := filepath.Walk(projectPath, visit)
 
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, results, ctxErr
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to scan project: %w", err)
+		return nil, results, fmt.Errorf("failed to scan project: %w", err)
 	}
 
-	return interfaces, nil
+	interfaces = resolveEmbeddedInterfaces(interfaces)
+	results.InterfacesFound = len(interfaces)
+	results.ScanDuration = time.Since(started)
+	return interfaces, results, nil
+}
+
+// dirDepth returns how many levels dir is below root, 0 for root itself
+func dirDepth(root, dir string) int {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == "." {
+		return 0
+	}
+	return strings.Count(rel, string(filepath.Separator)) + 1
 }
 
-// scanFile scans a single Go file for interface definitions
-func (s *GoInterfaceScanner) scanFile(filePath string) ([]types.InterfaceDefinition, error) {
+// walkSymlinkedDir walks the directory the symlink at path points to,
+// reporting entries beneath path as if the directory were there. It returns
+// false for links to files and broken links, which the caller handles as
+// ordinary entries.
+func walkSymlinkedDir(path string, visit filepath.WalkFunc) (bool, error) {
+	target, err := os.Stat(path)
+	if err != nil || !target.IsDir() {
+		return false, nil
+	}
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return false, nil
+	}
+
+	return true, filepath.Walk(realPath, func(walked string, info os.FileInfo, err error) error {
+		rel, relErr := filepath.Rel(realPath, walked)
+		if relErr != nil {
+			return relErr
+		}
+		return visit(filepath.Join(path, rel), info, err)
+	})
+}
+
+// ScanFiles scans the given Go files for interface definitions. Unlike
+// ScanProject it fails on the first file that cannot be parsed.
+func (s *GoInterfaceScanner) ScanFiles(paths []string) ([]types.InterfaceDefinition, error) {
+	var interfaces []types.InterfaceDefinition
+	for _, path := range paths {
+		fileInterfaces, _, err := s.scanFile(path)
+		if err != nil {
+			return nil, err
+		}
+		interfaces = append(interfaces, fileInterfaces...)
+	}
+	return resolveEmbeddedInterfaces(interfaces), nil
+}
+
+// scanFile scans a single Go file for interface definitions, also returning
+// the text of the file's package comment, if any
+func (s *GoInterfaceScanner) scanFile(filePath string) ([]types.InterfaceDefinition, string, error) {
+	if err := s.checkFileSize(filePath); err != nil {
+		return nil, "", err
+	}
+
 	// Parse the Go file
-	src, err := parser.ParseFile(s.fileSet, filePath, nil, parser.ParseComments)
+	fileSet := token.NewFileSet()
+	src, err := parser.ParseFile(fileSet, filePath, nil, parser.ParseComments)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse file %s: %w", filePath, err)
+		return nil, "", fmt.Errorf("failed to parse file %s: %w", filePath, err)
 	}
 
 	var interfaces []types.InterfaceDefinition
+	imports := fileImports(src)
 
 	// Walk the AST to find interface declarations
 	ast.Inspect(src, func(n ast.Node) bool {
@@ -87,14 +270,24 @@ func (s *GoInterfaceScanner) scanFile(filePath string) ([]types.InterfaceDefinit
 					if typeSpec, ok := spec.(*ast.TypeSpec); ok {
 						// Check if the type is an interface
 						if interfaceType, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+							// Grouped declarations document each spec individually
+							docGroup := typeSpec.Doc
+							if docGroup == nil && len(node.Specs) == 1 {
+								docGroup = node.Doc
+							}
+
 							interfaceDef := s.extractInterfaceDefinition(
 								typeSpec.Name.Name,
 								interfaceType,
 								src.Name.Name,
 								filePath,
-								s.fileSet.Position(typeSpec.Pos()).Line,
-								node.Doc,
+								fileSet.Position(typeSpec.Pos()),
+								fileSet.Position(typeSpec.End()),
+								docGroup,
+								newMatcherQualifier(src.Name.Name, imports, typeSpec.TypeParams),
 							)
+							interfaceDef.Imports = referencedImports(interfaceType, imports)
+							interfaceDef.TypeParams = s.typeParams(typeSpec.TypeParams)
 							interfaces = append(interfaces, interfaceDef)
 						}
 					}
@@ -104,7 +297,75 @@ func (s *GoInterfaceScanner) scanFile(filePath string) ([]types.InterfaceDefinit
 		return true
 	})
 
-	return interfaces, nil
+	return interfaces, strings.TrimSpace(src.Doc.Text()), nil
+}
+
+// fileImports returns the imports of a file under the names the file uses
+// for them, skipping blank imports
+func fileImports(file *ast.File) []types.Import {
+	var imports []types.Import
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		name := importName(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if name == "_" {
+			continue
+		}
+		imports = append(imports, types.Import{Name: name, Path: importPath})
+	}
+	return imports
+}
+
+// importName infers the package name of an import path from its last
+// element, skipping major version suffixes ("/v2", "yaml.v3")
+func importName(importPath string) string {
+	elems := strings.Split(importPath, "/")
+	name := elems[len(elems)-1]
+	if isMajorVersion(name) && len(elems) > 1 {
+		name = elems[len(elems)-2]
+	}
+	if i := strings.Index(name, ".v"); i > 0 && isMajorVersion(name[i+1:]) {
+		name = name[:i]
+	}
+	return name
+}
+
+// isMajorVersion reports whether elem looks like "v2", "v10" and so on
+func isMajorVersion(elem string) bool {
+	if len(elem) < 2 || elem[0] != 'v' {
+		return false
+	}
+	_, err := strconv.Atoi(elem[1:])
+	return err == nil
+}
+
+// referencedImports returns the imports a type declaration refers to
+// through qualified identifiers. Dot imports are always included since
+// unqualified names can't be attributed to them syntactically.
+func referencedImports(typeExpr ast.Node, imports []types.Import) []types.Import {
+	used := make(map[string]bool)
+	ast.Inspect(typeExpr, func(n ast.Node) bool {
+		if selector, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	var referenced []types.Import
+	for _, imp := range imports {
+		if imp.Name == "." || used[imp.Name] {
+			referenced = append(referenced, imp)
+		}
+	}
+	return referenced
 }
 
 // extractInterfaceDefinition extracts interface metadata from AST nodes
@@ -113,35 +374,40 @@ func (s *GoInterfaceScanner) extractInterfaceDefinition(
 	interfaceType *ast.InterfaceType,
 	packageName string,
 	filePath string,
-	lineNumber int,
+	start, end token.Position,
 	docGroup *ast.CommentGroup,
+	qualifier *matcherQualifier,
 ) types.InterfaceDefinition {
 	var methods []types.MethodSignature
-	var comments []string
+	var embeds []string
 
 	// Extract documentation comments
-	if docGroup != nil {
-		for _, comment := range docGroup.List {
-			comments = append(comments, strings.TrimPrefix(comment.Text, "//"))
-		}
-	}
+	comments := commentLines(docGroup)
 
 	// Extract method signatures
 	for _, method := range interfaceType.Methods.List {
 		if len(method.Names) > 0 {
 			methodName := method.Names[0].Name
-			methodSig := s.extractMethodSignature(methodName, method.Type, method.Doc)
+			methodSig := s.extractMethodSignature(methodName, method.Type, method.Doc, qualifier)
 			methods = append(methods, methodSig)
+		} else {
+			// Embedded interface or type constraint
+			embeds = append(embeds, s.typeToString(method.Type))
 		}
 	}
 
 	return types.InterfaceDefinition{
-		Name:       name,
-		Package:    packageName,
-		Methods:    methods,
-		FilePath:   filePath,
-		LineNumber: lineNumber,
-		Comments:   comments,
+		Name:        name,
+		Package:     packageName,
+		Methods:     methods,
+		Embeds:      embeds,
+		FilePath:    filePath,
+		LineNumber:  start.Line,
+		StartColumn: start.Column,
+		EndLine:     end.Line,
+		EndColumn:   end.Column,
+		Comments:    comments,
+		Directives:  parseDirectives(docGroup, name),
 	}
 }
 
@@ -150,17 +416,13 @@ func (s *GoInterfaceScanner) extractMethodSignature(
 	name string,
 	methodType ast.Expr,
 	docGroup *ast.CommentGroup,
+	qualifier *matcherQualifier,
 ) types.MethodSignature {
 	var parameters []types.Parameter
 	var returns []types.Parameter
-	var comments []string
 
 	// Extract method documentation
-	if docGroup != nil {
-		for _, comment := range docGroup.List {
-			comments = append(comments, strings.TrimPrefix(comment.Text, "//"))
-		}
-	}
+	comments := commentLines(docGroup)
 
 	// Extract function signature details
 	if funcType, ok := methodType.(*ast.FuncType); ok {
@@ -168,18 +430,24 @@ func (s *GoInterfaceScanner) extractMethodSignature(
 		if funcType.Params != nil {
 			for _, param := range funcType.Params.List {
 				paramType := s.typeToString(param.Type)
+				matcherType := qualifier.parameterType(param.Type)
+				typeArgs := s.typeArgs(param.Type)
 				if len(param.Names) > 0 {
 					for _, name := range param.Names {
 						parameters = append(parameters, types.Parameter{
-							Name: name.Name,
-							Type: paramType,
+							Name:        name.Name,
+							Type:        paramType,
+							MatcherType: matcherType,
+							TypeArgs:    typeArgs,
 						})
 					}
 				} else {
 					// Anonymous parameter
 					parameters = append(parameters, types.Parameter{
-						Name: "",
-						Type: paramType,
+						Name:        "",
+						Type:        paramType,
+						MatcherType: matcherType,
+						TypeArgs:    typeArgs,
 					})
 				}
 			}
@@ -189,18 +457,24 @@ func (s *GoInterfaceScanner) extractMethodSignature(
 		if funcType.Results != nil {
 			for _, result := range funcType.Results.List {
 				resultType := s.typeToString(result.Type)
+				matcherType := qualifier.matcherType(result.Type)
+				typeArgs := s.typeArgs(result.Type)
 				if len(result.Names) > 0 {
 					for _, name := range result.Names {
 						returns = append(returns, types.Parameter{
-							Name: name.Name,
-							Type: resultType,
+							Name:        name.Name,
+							Type:        resultType,
+							MatcherType: matcherType,
+							TypeArgs:    typeArgs,
 						})
 					}
 				} else {
 					// Anonymous return value
 					returns = append(returns, types.Parameter{
-						Name: "",
-						Type: resultType,
+						Name:        "",
+						Type:        resultType,
+						MatcherType: matcherType,
+						TypeArgs:    typeArgs,
 					})
 				}
 			}
@@ -215,6 +489,39 @@ func (s *GoInterfaceScanner) extractMethodSignature(
 	}
 }
 
+// commentLines normalizes a comment group into its text lines. Line comment
+// markers and block comment delimiters are removed along with exactly one
+// leading space, so indentation beyond that is preserved.
+func commentLines(group *ast.CommentGroup) []string {
+	if group == nil {
+		return nil
+	}
+
+	var lines []string
+	for _, comment := range group.List {
+		text := comment.Text
+		if strings.HasPrefix(text, "//") {
+			lines = append(lines, strings.TrimPrefix(strings.TrimPrefix(text, "//"), " "))
+			continue
+		}
+
+		// Block comment: strip the delimiters and drop blank edge lines
+		text = strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+		blockLines := strings.Split(text, "\n")
+		for len(blockLines) > 0 && strings.TrimSpace(blockLines[0]) == "" {
+			blockLines = blockLines[1:]
+		}
+		for len(blockLines) > 0 && strings.TrimSpace(blockLines[len(blockLines)-1]) == "" {
+			blockLines = blockLines[:len(blockLines)-1]
+		}
+		for _, line := range blockLines {
+			lines = append(lines, strings.TrimPrefix(strings.TrimRight(line, " \t"), " "))
+		}
+	}
+
+	return lines
+}
+
 // typeToString converts an AST type expression to string representation
 func (s *GoInterfaceScanner) typeToString(expr ast.Expr) string {
 	switch t := expr.(type) {
@@ -240,34 +547,238 @@ func (s *GoInterfaceScanner) typeToString(expr ast.Expr) string {
 		default:
 			return "chan " + s.typeToString(t.Value)
 		}
+	case *ast.Ellipsis:
+		return "..." + s.typeToString(t.Elt)
+	case *ast.ParenExpr:
+		return "(" + s.typeToString(t.X) + ")"
+	case *ast.UnaryExpr:
+		return t.Op.String() + s.typeToString(t.X)
+	case *ast.BinaryExpr:
+		return s.typeToString(t.X) + " " + t.Op.String() + " " + s.typeToString(t.Y)
 	case *ast.FuncType:
-		return "func" // Simplified for now
+		return "func" + s.signatureToString(t)
+	case *ast.StructType:
+		return "struct{" + s.bracedFields(t.Fields, s.structFieldToString) + "}"
 	case *ast.InterfaceType:
-		return "interface{}"
+		return "interface{" + s.bracedFields(t.Methods, s.interfaceElemToString) + "}"
+	case *ast.IndexExpr:
+		return s.typeToString(t.X) + "[" + s.typeToString(t.Index) + "]"
+	case *ast.IndexListExpr:
+		return s.typeToString(t.X) + "[" + strings.Join(s.typeArgs(t), ", ") + "]"
+	case *ast.BasicLit:
+		return t.Value
 	default:
 		return "unknown"
 	}
 }
 
-// ExtractInterfaceMetadata extracts detailed metadata for a specific interface
+// typeArgs returns the type arguments of a generic instantiation such as
+// Cache[K, V], looking through pointers, or nil for any other type
+func (s *GoInterfaceScanner) typeArgs(expr ast.Expr) []string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return s.typeArgs(t.X)
+	case *ast.ParenExpr:
+		return s.typeArgs(t.X)
+	case *ast.IndexExpr:
+		return []string{s.typeToString(t.Index)}
+	case *ast.IndexListExpr:
+		args := make([]string, len(t.Indices))
+		for i, index := range t.Indices {
+			args[i] = s.typeToString(index)
+		}
+		return args
+	default:
+		return nil
+	}
+}
+
+// typeParams returns a generic declaration's type parameters with their
+// constraints
+func (s *GoInterfaceScanner) typeParams(fields *ast.FieldList) []types.TypeParam {
+	if fields == nil {
+		return nil
+	}
+
+	var params []types.TypeParam
+	for _, field := range fields.List {
+		constraint := s.typeToString(field.Type)
+		for _, name := range field.Names {
+			params = append(params, types.TypeParam{Name: name.Name, Constraint: constraint})
+		}
+	}
+	return params
+}
+
+// bracedFields renders the fields of an inline struct or interface on one
+// line, the way gofmt does: "{ A; B }", or "{}" when empty
+func (s *GoInterfaceScanner) bracedFields(fields *ast.FieldList, render func(*ast.Field) string) string {
+	if fields == nil || len(fields.List) == 0 {
+		return ""
+	}
+
+	parts := make([]string, len(fields.List))
+	for i, field := range fields.List {
+		parts[i] = render(field)
+	}
+	return " " + strings.Join(parts, "; ") + " "
+}
+
+// structFieldToString renders a struct field, including embedded fields and tags
+func (s *GoInterfaceScanner) structFieldToString(field *ast.Field) string {
+	text := s.typeToString(field.Type)
+	if len(field.Names) > 0 {
+		text = identNames(field.Names) + " " + text
+	}
+	if field.Tag != nil {
+		text += " " + field.Tag.Value
+	}
+	return text
+}
+
+// interfaceElemToString renders an interface method or embedded type
+func (s *GoInterfaceScanner) interfaceElemToString(field *ast.Field) string {
+	if funcType, ok := field.Type.(*ast.FuncType); ok && len(field.Names) > 0 {
+		return field.Names[0].Name + s.signatureToString(funcType)
+	}
+	return s.typeToString(field.Type)
+}
+
+// signatureToString renders a function's parameters and results, without
+// the func keyword or name
+func (s *GoInterfaceScanner) signatureToString(funcType *ast.FuncType) string {
+	text := "(" + s.paramsToString(funcType.Params) + ")"
+
+	if funcType.Results == nil || len(funcType.Results.List) == 0 {
+		return text
+	}
+	results := funcType.Results.List
+	if len(results) == 1 && len(results[0].Names) == 0 {
+		return text + " " + s.typeToString(results[0].Type)
+	}
+	return text + " (" + s.paramsToString(funcType.Results) + ")"
+}
+
+// paramsToString renders a parameter or result list without its parentheses
+func (s *GoInterfaceScanner) paramsToString(fields *ast.FieldList) string {
+	if fields == nil {
+		return ""
+	}
+
+	parts := make([]string, len(fields.List))
+	for i, field := range fields.List {
+		parts[i] = s.typeToString(field.Type)
+		if len(field.Names) > 0 {
+			parts[i] = identNames(field.Names) + " " + parts[i]
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// identNames joins identifiers as they appear in a field list
+func identNames(idents []*ast.Ident) string {
+	names := make([]string, len(idents))
+	for i, ident := range idents {
+		names[i] = ident.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// ExtractInterfaceMetadata extracts detailed metadata for a specific
+// interface. Interfaces it embeds from the same package are resolved as
+// ScanProject resolves them, reading the file's sibling files when needed.
 func (s *GoInterfaceScanner) ExtractInterfaceMetadata(filePath, interfaceName string) (*types.InterfaceDefinition, error) {
-	interfaces, err := s.scanFile(filePath)
+	interfaces, _, err := s.scanFile(filePath)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, iface := range interfaces {
-		if iface.Name == interfaceName {
-			return &iface, nil
+	index := slices.IndexFunc(interfaces, func(iface types.InterfaceDefinition) bool { return iface.Name == interfaceName })
+	if index < 0 {
+		return nil, fmt.Errorf("%w: %s in file %s", ErrInterfaceNotFound, interfaceName, filePath)
+	}
+	if len(interfaces[index].Embeds) == 0 {
+		return &interfaces[index], nil
+	}
+
+	// Embedded interfaces may be declared in any file of the package.
+	// Siblings that can't be scanned are skipped, as ScanProject skips them.
+	dir := filepath.Dir(filePath)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+	for _, entry := range entries {
+		sibling := filepath.Join(dir, entry.Name())
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || sibling == filepath.Clean(filePath) {
+			continue
+		}
+		if siblingInterfaces, _, err := s.scanFile(sibling); err == nil {
+			interfaces = append(interfaces, siblingInterfaces...)
+		}
+	}
+
+	resolved := resolveEmbeddedInterfaces(interfaces)
+	return &resolved[index], nil
+}
+
+// ExtractInterfaceSource returns the raw source text of an interface declaration,
+// including its doc comments
+func (s *GoInterfaceScanner) ExtractInterfaceSource(filePath, interfaceName string) (string, error) {
+	if err := s.checkFileSize(filePath); err != nil {
+		return "", err
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	fileSet := token.NewFileSet()
+	src, err := parser.ParseFile(fileSet, filePath, content, parser.ParseComments)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse file %s: %w", filePath, err)
+	}
+
+	for _, decl := range src.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok || typeSpec.Name.Name != interfaceName {
+				continue
+			}
+			if _, ok := typeSpec.Type.(*ast.InterfaceType); !ok {
+				continue
+			}
+
+			// A standalone declaration spans the "type" keyword and its doc,
+			// while a grouped one only spans the spec itself
+			start, end := typeSpec.Pos(), typeSpec.End()
+			doc := typeSpec.Doc
+			if !genDecl.Lparen.IsValid() {
+				start, end = genDecl.Pos(), genDecl.End()
+				doc = genDecl.Doc
+			}
+			if doc != nil {
+				start = doc.Pos()
+			}
+
+			startOffset := fileSet.Position(start).Offset
+			endOffset := fileSet.Position(end).Offset
+			return string(content[startOffset:endOffset]), nil
 		}
 	}
 
-	return nil, fmt.Errorf("interface %s not found in file %s", interfaceName, filePath)
+	return "", fmt.Errorf("%w: %s in file %s", ErrInterfaceNotFound, interfaceName, filePath)
 }
 
 // DetectDependencies analyzes import dependencies for an interface
 func (s *GoInterfaceScanner) DetectDependencies(filePath string) ([]string, error) {
-	src, err := parser.ParseFile(s.fileSet, filePath, nil, parser.ParseComments)
+	src, err := parser.ParseFile(token.NewFileSet(), filePath, nil, parser.ParseComments)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse file %s: %w", filePath, err)
 	}
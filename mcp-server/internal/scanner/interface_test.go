@@ -1,12 +1,19 @@
 package scanner
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
 )
 
 func TestGoInterfaceScanner_ScanProject(t *testing.T) {
@@ -91,12 +98,72 @@ type EmailService interface {
 	
 	assert.Equal(t, "EmailService", metadata.Name)
 	assert.Equal(t, "service", metadata.Package)
-	assert.Contains(t, metadata.Comments, " EmailService handles email operations")
+	assert.Equal(t, []string{"EmailService handles email operations"}, metadata.Comments)
 	assert.Len(t, metadata.Methods, 1)
 
 	method := metadata.Methods[0]
 	assert.Equal(t, "SendEmail", method.Name)
 	assert.Len(t, method.Parameters, 3)
+	assert.Equal(t, []string{"SendEmail sends an email"}, method.Comments)
+}
+
+func TestGoInterfaceScanner_CommentExtraction(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "docs.go")
+	testContent := `package docs
+
+/*
+Cache stores values
+  with indentation kept
+*/
+type Cache interface {
+	// Get returns a value.
+	// It reports whether the key was found.
+	Get(key string) (string, bool)
+
+	/* Set stores a value */
+	Set(key, value string)
+
+	Delete(key string)
+}
+
+type (
+	// Reader reads bytes
+	Reader interface {
+		//Read reads without a space
+		Read() []byte
+	}
+
+	// Writer writes bytes
+	Writer interface {
+		Write(data []byte)
+	}
+)
+`
+
+	err := os.WriteFile(testFile, []byte(testContent), 0644)
+	require.NoError(t, err)
+
+	scanner := NewGoInterfaceScanner()
+	interfaces, err := scanner.ScanProject(tempDir)
+	require.NoError(t, err)
+	require.Len(t, interfaces, 3)
+
+	cache := interfaces[0]
+	assert.Equal(t, []string{"Cache stores values", " with indentation kept"}, cache.Comments)
+	require.Len(t, cache.Methods, 3)
+	assert.Equal(t, []string{"Get returns a value.", "It reports whether the key was found."}, cache.Methods[0].Comments)
+	assert.Equal(t, []string{"Set stores a value"}, cache.Methods[1].Comments)
+	assert.Empty(t, cache.Methods[2].Comments)
+
+	reader := interfaces[1]
+	assert.Equal(t, "Reader", reader.Name)
+	assert.Equal(t, []string{"Reader reads bytes"}, reader.Comments)
+	assert.Equal(t, []string{"Read reads without a space"}, reader.Methods[0].Comments)
+
+	writer := interfaces[2]
+	assert.Equal(t, "Writer", writer.Name)
+	assert.Equal(t, []string{"Writer writes bytes"}, writer.Comments)
 }
 
 func TestGoInterfaceScanner_DetectDependencies(t *testing.T) {
@@ -131,4 +198,569 @@ type TestInterface interface {
 	assert.Contains(t, deps, "fmt")
 	assert.Contains(t, deps, "time")
 	assert.Contains(t, deps, "github.com/example/external")
-}
\ No newline at end of file
+}
+
+func TestGoInterfaceScanner_ExtractInterfaceSource(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "source.go")
+	testContent := `package source
+
+import "context"
+
+// Notifier sends notifications
+// to registered users
+type Notifier interface {
+	// Notify sends a single notification
+	Notify(ctx context.Context, userID string) error
+}
+
+type (
+	// Reader reads things
+	Reader interface {
+		Read() ([]byte, error)
+	}
+
+	Writer interface {
+		Write(data []byte) error
+	}
+)
+`
+
+	err := os.WriteFile(testFile, []byte(testContent), 0644)
+	require.NoError(t, err)
+
+	scanner := NewGoInterfaceScanner()
+
+	t.Run("standalone declaration", func(t *testing.T) {
+		source, err := scanner.ExtractInterfaceSource(testFile, "Notifier")
+		require.NoError(t, err)
+		assert.Equal(t, `// Notifier sends notifications
+// to registered users
+type Notifier interface {
+	// Notify sends a single notification
+	Notify(ctx context.Context, userID string) error
+}`, source)
+	})
+
+	t.Run("grouped declaration", func(t *testing.T) {
+		source, err := scanner.ExtractInterfaceSource(testFile, "Reader")
+		require.NoError(t, err)
+		assert.Equal(t, `// Reader reads things
+	Reader interface {
+		Read() ([]byte, error)
+	}`, source)
+
+		source, err = scanner.ExtractInterfaceSource(testFile, "Writer")
+		require.NoError(t, err)
+		assert.Equal(t, `Writer interface {
+		Write(data []byte) error
+	}`, source)
+	})
+
+	t.Run("interface not found", func(t *testing.T) {
+		_, err := scanner.ExtractInterfaceSource(testFile, "Missing")
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, ErrInterfaceNotFound)
+		assert.Contains(t, err.Error(), "Missing")
+	})
+}
+
+func TestGoInterfaceScanner_InlineTypes(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "events.go")
+	testContent := `package events
+
+type Handler interface {
+	Handle(req struct {
+		ID   string ` + "`json:\"id\"`" + `
+		Tags []string
+	}) error
+	Subscribe(sink interface {
+		Send(topic string, payload []byte) (int, error)
+		Close()
+	}, opts ...Option) interface{}
+	Empty(struct{}) func(a, b int) bool
+}
+
+type Option struct{}
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644))
+
+	iface, err := NewGoInterfaceScanner().ExtractInterfaceMetadata(testFile, "Handler")
+	require.NoError(t, err)
+	require.Len(t, iface.Methods, 3)
+
+	handle := iface.Methods[0]
+	assert.Equal(t, "struct{ ID string `json:\"id\"`; Tags []string }", handle.Parameters[0].Type)
+
+	subscribe := iface.Methods[1]
+	require.Len(t, subscribe.Parameters, 2)
+	assert.Equal(t, "interface{ Send(topic string, payload []byte) (int, error); Close() }", subscribe.Parameters[0].Type)
+	assert.Equal(t, "...Option", subscribe.Parameters[1].Type)
+	assert.Equal(t, "interface{}", subscribe.Returns[0].Type)
+
+	empty := iface.Methods[2]
+	assert.Equal(t, "struct{}", empty.Parameters[0].Type)
+	assert.Equal(t, "func(a, b int) bool", empty.Returns[0].Type)
+}
+
+func TestGoInterfaceScanner_Imports(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "clock.go")
+	testContent := `package clock
+
+import (
+	"context"
+	m "time"
+	. "net/http"
+	_ "embed"
+	"gopkg.in/yaml.v3"
+	"github.com/example/lib/v2"
+	"strings"
+)
+
+type Clock interface {
+	Now(ctx context.Context) m.Time
+	Decode(node *yaml.Node) (*Request, error)
+	Client() lib.Client
+}
+
+var _ = strings.ToUpper
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644))
+
+	iface, err := NewGoInterfaceScanner().ExtractInterfaceMetadata(testFile, "Clock")
+	require.NoError(t, err)
+
+	// Only imports the interface uses are attached, under the names the file
+	// uses for them; unused and blank imports are left out
+	assert.Equal(t, []types.Import{
+		{Name: "context", Path: "context"},
+		{Name: "m", Path: "time"},
+		{Name: ".", Path: "net/http"},
+		{Name: "yaml", Path: "gopkg.in/yaml.v3"},
+		{Name: "lib", Path: "github.com/example/lib/v2"},
+	}, iface.Imports)
+	assert.Equal(t, "m.Time", iface.Methods[0].Returns[0].Type)
+}
+
+func TestGoInterfaceScanner_MatcherTypes(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "users.go")
+	testContent := `package interfaces
+
+import (
+	"context"
+	stdtime "time"
+	"gopkg.in/yaml.v3"
+)
+
+type User struct{}
+
+type UserStore interface {
+	Get(ctx context.Context, id string) (*User, error)
+	List(ids []string, data []byte) ([]*User, error)
+	Expire(after stdtime.Duration, nodes map[string]*yaml.Node, ch <-chan int)
+	Tag(labels ...string)
+}
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644))
+
+	iface, err := NewGoInterfaceScanner().ExtractInterfaceMetadata(testFile, "UserStore")
+	require.NoError(t, err)
+
+	matcherTypes := func(params []types.Parameter) []string {
+		var result []string
+		for _, param := range params {
+			result = append(result, param.MatcherType)
+		}
+		return result
+	}
+
+	get := iface.Methods[0]
+	assert.Equal(t, []string{"context.Context", "string"}, matcherTypes(get.Parameters))
+	assert.Equal(t, []string{"*interfaces.User", "error"}, matcherTypes(get.Returns))
+
+	// byte is reported as its underlying type, as reflect does
+	list := iface.Methods[1]
+	assert.Equal(t, []string{"[]string", "[]uint8"}, matcherTypes(list.Parameters))
+	assert.Equal(t, []string{"[]*interfaces.User", "error"}, matcherTypes(list.Returns))
+
+	// Aliased imports are qualified with the package name, not the alias
+	expire := iface.Methods[2]
+	assert.Equal(t, "stdtime.Duration", expire.Parameters[0].Type)
+	assert.Equal(t, []string{"time.Duration", "map[string]*yaml.Node", "<-chan int"}, matcherTypes(expire.Parameters))
+
+	// Variadic arguments reach the mock as a slice
+	tag := iface.Methods[3]
+	assert.Equal(t, "...string", tag.Parameters[0].Type)
+	assert.Equal(t, "[]string", tag.Parameters[0].MatcherType)
+}
+
+func TestGoInterfaceScanner_ScanProjectRecordsErrors(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "repo.go"), []byte("package app\n\ntype Repo interface{ Get() error }\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "broken.go"), []byte("package app\n\ntype Broken interface {"), 0644))
+
+	interfaces, results, err := NewGoInterfaceScanner().ScanProjectResults(root)
+	require.NoError(t, err)
+
+	require.Len(t, interfaces, 1)
+	assert.Equal(t, "Repo", interfaces[0].Name)
+	assert.Equal(t, 1, results.InterfacesFound)
+	assert.Equal(t, 2, results.FilesScanned)
+	require.Len(t, results.Errors, 1)
+	assert.Contains(t, results.Errors[0], "broken.go")
+
+	t.Run("unreadable subdirectory", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("directory permissions are not enforced on Windows")
+		}
+		if os.Geteuid() == 0 {
+			t.Skip("root can read directories regardless of permissions")
+		}
+
+		locked := filepath.Join(root, "locked")
+		require.NoError(t, os.Mkdir(locked, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(locked, "hidden.go"), []byte("package locked\n\ntype Hidden interface{}\n"), 0644))
+		require.NoError(t, os.Chmod(locked, 0))
+		t.Cleanup(func() { os.Chmod(locked, 0755) })
+
+		interfaces, results, err := NewGoInterfaceScanner().ScanProjectResults(root)
+		require.NoError(t, err)
+
+		require.Len(t, interfaces, 1)
+		require.Len(t, results.Errors, 2)
+		assert.Contains(t, results.Errors[1], "locked")
+	})
+
+	t.Run("missing root", func(t *testing.T) {
+		_, _, err := NewGoInterfaceScanner().ScanProjectResults(filepath.Join(root, "missing"))
+		assert.Error(t, err)
+	})
+}
+
+func TestGoInterfaceScanner_MaxFileSize(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "repo.go"), []byte("package app\n\ntype Repo interface{ Get() error }\n"), 0644))
+
+	// A generated file padded well past the limit
+	generated := "package app\n\ntype Generated interface{ Run() }\n\n" + strings.Repeat("// padding\n", 200)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "generated.go"), []byte(generated), 0644))
+
+	scanner := NewGoInterfaceScanner()
+	scanner.SetMaxFileSize(1024)
+
+	interfaces, results, err := scanner.ScanProjectResults(root)
+	require.NoError(t, err)
+
+	require.Len(t, interfaces, 1)
+	assert.Equal(t, "Repo", interfaces[0].Name)
+	require.Len(t, results.Errors, 1)
+	assert.Contains(t, results.Errors[0], "generated.go")
+	assert.Contains(t, results.Errors[0], "file too large")
+
+	_, err = scanner.ExtractInterfaceMetadata(filepath.Join(root, "generated.go"), "Generated")
+	assert.ErrorIs(t, err, ErrFileTooLarge)
+
+	t.Run("no limit", func(t *testing.T) {
+		scanner.SetMaxFileSize(0)
+		interfaces, results, err := scanner.ScanProjectResults(root)
+		require.NoError(t, err)
+		assert.Len(t, interfaces, 2)
+		assert.Empty(t, results.Errors)
+	})
+}
+
+// cancelAfter is a context that reports itself cancelled once Err has been
+// checked a given number of times, to cancel a scan partway through
+type cancelAfter struct {
+	context.Context
+	checks int
+}
+
+func (c *cancelAfter) Err() error {
+	if c.checks <= 0 {
+		return context.Canceled
+	}
+	c.checks--
+	return nil
+}
+
+func TestGoInterfaceScanner_ScanProjectContext(t *testing.T) {
+	root := t.TempDir()
+	for i := 0; i < 50; i++ {
+		dir := filepath.Join(root, fmt.Sprintf("pkg%02d", i))
+		require.NoError(t, os.Mkdir(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "repo.go"), []byte("package pkg\n\ntype Repo interface{ Get() error }\n"), 0644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(root, "pkg00", "repo_test.go"), []byte("package pkg\n\ntype fakeRepo interface{ Get() error }\n"), 0644))
+
+	scanner := NewGoInterfaceScanner()
+
+	t.Run("cancelled mid-scan", func(t *testing.T) {
+		ctx := &cancelAfter{Context: context.Background(), checks: 10}
+
+		interfaces, results, err := scanner.ScanProjectContext(ctx, root, ScanOptions{})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, interfaces)
+		assert.Less(t, results.FilesScanned, 10)
+	})
+
+	t.Run("already cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, results, err := scanner.ScanProjectContext(ctx, root, ScanOptions{})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Zero(t, results.FilesScanned)
+	})
+
+	t.Run("include tests", func(t *testing.T) {
+		interfaces, _, err := scanner.ScanProjectContext(context.Background(), root, ScanOptions{IncludeTests: true})
+		require.NoError(t, err)
+		assert.Len(t, interfaces, 51)
+
+		interfaces, _, err = scanner.ScanProjectContext(context.Background(), root, ScanOptions{})
+		require.NoError(t, err)
+		assert.Len(t, interfaces, 50)
+	})
+}
+
+func TestGoInterfaceScanner_Generics(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "cache.go")
+	testContent := `package cache
+
+type Cache[K comparable, V any] struct{}
+
+type Pair[T any] struct{}
+
+type Store[K comparable, V any, N ~int | ~int64] interface {
+	Put(key K, item V) error
+	Snapshot() *Cache[K, V]
+	Pairs(size N) []Pair[V]
+	Digest() [16]byte
+}
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644))
+
+	iface, err := NewGoInterfaceScanner().ExtractInterfaceMetadata(testFile, "Store")
+	require.NoError(t, err)
+
+	assert.Equal(t, []types.TypeParam{
+		{Name: "K", Constraint: "comparable"},
+		{Name: "V", Constraint: "any"},
+		{Name: "N", Constraint: "~int | ~int64"},
+	}, iface.TypeParams)
+
+	put := iface.Methods[0]
+	assert.Equal(t, "K", put.Parameters[0].Type)
+	assert.Equal(t, "V", put.Parameters[1].Type)
+	assert.Equal(t, "V", put.Parameters[1].MatcherType)
+	assert.Nil(t, put.Parameters[1].TypeArgs)
+
+	snapshot := iface.Methods[1].Returns[0]
+	assert.Equal(t, "*Cache[K, V]", snapshot.Type)
+	assert.Equal(t, []string{"K", "V"}, snapshot.TypeArgs)
+
+	// Type args are only reported for the instantiation itself, not for
+	// slices of it
+	pairs := iface.Methods[2].Returns[0]
+	assert.Equal(t, "[]Pair[V]", pairs.Type)
+	assert.Nil(t, pairs.TypeArgs)
+
+	assert.Equal(t, "[16]byte", iface.Methods[3].Returns[0].Type)
+}
+
+func TestGoInterfaceScanner_Directives(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "store.go")
+	testContent := `package store
+
+// Store persists values
+//
+//mockery:dir ./mocks/store
+//mockery:filename="store_mock.go"
+//mockery:with-expecter
+//mockery:unknown value
+type Store interface {
+	Get(key string) (string, error)
+}
+
+// Clock tells the time
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.53.0 --name Clock --output=./mocks/clock --mockname FakeClock --inpackage
+type Clock interface {
+	Now() int64
+}
+
+// Queue is generated along with another interface
+//
+//go:generate mockery --name=Other --output=./mocks/other
+//go:generate stringer -type=Kind
+type Queue interface {
+	Push(item string)
+}
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(testContent), 0644))
+
+	scanner := NewGoInterfaceScanner()
+	interfaces, err := scanner.ScanProject(tempDir)
+	require.NoError(t, err)
+	require.Len(t, interfaces, 3)
+
+	assert.Equal(t, map[string]string{
+		"dir":           "./mocks/store",
+		"filename":      "store_mock.go",
+		"with-expecter": "true",
+	}, interfaces[0].Directives)
+	assert.Equal(t, map[string]string{
+		"dir":       "./mocks/clock",
+		"mockname":  "FakeClock",
+		"inpackage": "true",
+	}, interfaces[1].Directives)
+	assert.Nil(t, interfaces[2].Directives)
+}
+
+func TestGoInterfaceScanner_FollowSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require elevated privileges on Windows")
+	}
+
+	shared := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(shared, "billing"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(shared, "billing", "invoice.go"), []byte("package billing\n\ntype Invoicer interface{ Send() error }\n"), 0644))
+
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "repo.go"), []byte("package app\n\ntype Repo interface{ Get() error }\n"), 0644))
+	require.NoError(t, os.Symlink(filepath.Join(shared, "billing"), filepath.Join(root, "billing")))
+	// A second link to the same package and a link back to the root form cycles
+	require.NoError(t, os.Symlink(filepath.Join(shared, "billing"), filepath.Join(root, "billing2")))
+	require.NoError(t, os.Symlink(root, filepath.Join(shared, "billing", "loop")))
+	require.NoError(t, os.Symlink(".", filepath.Join(root, "self")))
+
+	scanner := NewGoInterfaceScanner()
+
+	names := func(interfaces []types.InterfaceDefinition) []string {
+		var result []string
+		for _, iface := range interfaces {
+			result = append(result, iface.Name)
+		}
+		return result
+	}
+
+	t.Run("not followed by default", func(t *testing.T) {
+		interfaces, _, err := scanner.ScanProjectContext(context.Background(), root, ScanOptions{})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"Repo"}, names(interfaces))
+	})
+
+	t.Run("followed once each", func(t *testing.T) {
+		interfaces, results, err := scanner.ScanProjectContext(context.Background(), root, ScanOptions{FollowSymlinks: true})
+		require.NoError(t, err)
+		assert.Empty(t, results.Errors)
+		assert.ElementsMatch(t, []string{"Repo", "Invoicer"}, names(interfaces))
+		for _, iface := range interfaces {
+			if iface.Name == "Invoicer" {
+				// Files are reported under the link, inside the project
+				assert.Equal(t, filepath.Join(root, "billing", "invoice.go"), iface.FilePath)
+			}
+		}
+	})
+}
+
+func TestGoInterfaceScanner_ScanProjectPackageDocs(t *testing.T) {
+	root := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	// doc.go documents its package even though other files sort first
+	write("store/a.go", "// Package store is described twice.\npackage store\n\ntype Store interface{ Get() error }\n")
+	write("store/doc.go", "// Package store persists values.\n//\n// Values are kept in memory.\npackage store\n")
+	write("cache/cache.go", "//go:build linux\n\n// Package cache caches values.\npackage cache\n\ntype Cache interface{ Purge() }\n")
+	write("plain/plain.go", "package plain\n\ntype Plain interface{ Do() }\n")
+
+	_, results, err := NewGoInterfaceScanner().ScanProjectResults(root)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		filepath.Join(root, "store"): "Package store persists values.\n\nValues are kept in memory.",
+		filepath.Join(root, "cache"): "Package cache caches values.",
+	}, results.PackageDocs)
+}
+
+func TestGoInterfaceScanner_ScanProjectMaxDepth(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"", "a", "a/b", "a/b/c"} {
+		path := filepath.Join(root, dir, "iface.go")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("package p\n\ntype I interface{ M() }\n"), 0644))
+	}
+
+	for depth, want := range map[int]int{0: 4, 1: 1, 2: 2, 3: 3, 10: 4} {
+		interfaces, results, err := NewGoInterfaceScanner().ScanProjectContext(context.Background(), root, ScanOptions{MaxDepth: depth})
+		require.NoError(t, err)
+		assert.Len(t, interfaces, want, "depth %d", depth)
+		assert.Equal(t, want, results.FilesScanned, "depth %d", depth)
+	}
+}
+
+// TestGoInterfaceScanner_ConcurrentScans shares one scanner between
+// goroutines, as the server does. Run with -race to check nothing is shared.
+func TestGoInterfaceScanner_ConcurrentScans(t *testing.T) {
+	roots := make([]string, 4)
+	for i := range roots {
+		roots[i] = t.TempDir()
+		// Each project puts its interface on a different line
+		content := "package pkg\n" + strings.Repeat("\n", i) + "type Repo interface{ Get() error }\n"
+		require.NoError(t, os.WriteFile(filepath.Join(roots[i], "repo.go"), []byte(content), 0644))
+	}
+
+	scanner := NewGoInterfaceScanner()
+	var wg sync.WaitGroup
+	for i, root := range roots {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				interfaces, err := scanner.ScanProject(root)
+				if assert.NoError(t, err) && assert.Len(t, interfaces, 1) {
+					assert.Equal(t, i+2, interfaces[0].LineNumber)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestGoInterfaceScanner_DeclarationRange(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "repo.go")
+	content := `package repo
+
+type (
+	// Reader reads
+	Reader interface {
+		Read() error
+	}
+	Writer interface{ Write() error }
+)
+`
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+
+	interfaces, err := NewGoInterfaceScanner().ScanFiles([]string{file})
+	require.NoError(t, err)
+	require.Len(t, interfaces, 2)
+
+	// The range runs from the name to just past the closing brace
+	reader := interfaces[0]
+	assert.Equal(t, []int{5, 2, 7, 3}, []int{reader.LineNumber, reader.StartColumn, reader.EndLine, reader.EndColumn})
+	writer := interfaces[1]
+	assert.Equal(t, []int{8, 2, 8, 35}, []int{writer.LineNumber, writer.StartColumn, writer.EndLine, writer.EndColumn})
+
+	lines := strings.Split(content, "\n")
+	assert.Equal(t, "}", lines[reader.EndLine-1][reader.EndColumn-2:reader.EndColumn-1])
+	assert.True(t, strings.HasPrefix(lines[reader.LineNumber-1][reader.StartColumn-1:], "Reader interface"))
+}
@@ -0,0 +1,184 @@
+package scanner
+
+import (
+	"go/ast"
+	"strings"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// reflectNames maps predeclared types to the name reflect reports for them.
+// Aliases such as byte and rune are reported as their underlying type.
+var reflectNames = map[string]string{
+	"bool": "bool", "string": "string", "error": "error",
+	"int": "int", "int8": "int8", "int16": "int16", "int32": "int32", "int64": "int64",
+	"uint": "uint", "uint8": "uint8", "uint16": "uint16", "uint32": "uint32", "uint64": "uint64",
+	"uintptr": "uintptr", "float32": "float32", "float64": "float64",
+	"complex64": "complex64", "complex128": "complex128",
+	"byte": "uint8", "rune": "int32", "any": "interface {}",
+}
+
+// matcherQualifier renders parameter types the way reflect prints them,
+// which is what testify's mock.AnythingOfType compares against: named
+// types are qualified with their package name, never an import alias.
+type matcherQualifier struct {
+	packageName string
+	packages    map[string]string // File-local import name -> package name
+	typeParams  map[string]bool
+}
+
+// newMatcherQualifier creates a qualifier for types declared in packageName
+// and referenced through imports
+func newMatcherQualifier(packageName string, imports []types.Import, typeParams *ast.FieldList) *matcherQualifier {
+	q := &matcherQualifier{
+		packageName: packageName,
+		packages:    make(map[string]string),
+		typeParams:  make(map[string]bool),
+	}
+	for _, imp := range imports {
+		q.packages[imp.Name] = importName(imp.Path)
+	}
+	if typeParams != nil {
+		for _, field := range typeParams.List {
+			for _, name := range field.Names {
+				q.typeParams[name.Name] = true
+			}
+		}
+	}
+	return q
+}
+
+// parameterType returns the matcher type of a parameter. Variadic
+// parameters arrive in the mock as a slice.
+func (q *matcherQualifier) parameterType(expr ast.Expr) string {
+	if ellipsis, ok := expr.(*ast.Ellipsis); ok {
+		return "[]" + q.matcherType(ellipsis.Elt)
+	}
+	return q.matcherType(expr)
+}
+
+// matcherType renders a type expression as reflect would print it
+func (q *matcherQualifier) matcherType(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if name, ok := reflectNames[t.Name]; ok {
+			return name
+		}
+		if q.typeParams[t.Name] {
+			return t.Name
+		}
+		return q.packageName + "." + t.Name
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok {
+			if name, ok := q.packages[pkg.Name]; ok {
+				return name + "." + t.Sel.Name
+			}
+			return pkg.Name + "." + t.Sel.Name
+		}
+		return "unknown"
+	case *ast.StarExpr:
+		return "*" + q.matcherType(t.X)
+	case *ast.ParenExpr:
+		return q.matcherType(t.X)
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "[]" + q.matcherType(t.Elt)
+		}
+		if lit, ok := t.Len.(*ast.BasicLit); ok {
+			return "[" + lit.Value + "]" + q.matcherType(t.Elt)
+		}
+		return "[...]" + q.matcherType(t.Elt)
+	case *ast.Ellipsis:
+		return "..." + q.matcherType(t.Elt)
+	case *ast.MapType:
+		return "map[" + q.matcherType(t.Key) + "]" + q.matcherType(t.Value)
+	case *ast.ChanType:
+		switch t.Dir {
+		case ast.SEND:
+			return "chan<- " + q.matcherType(t.Value)
+		case ast.RECV:
+			return "<-chan " + q.matcherType(t.Value)
+		default:
+			return "chan " + q.matcherType(t.Value)
+		}
+	case *ast.FuncType:
+		return "func" + q.signature(t)
+	case *ast.StructType:
+		var fields []string
+		for _, field := range t.Fields.List {
+			fieldType := q.matcherType(field.Type)
+			if len(field.Names) == 0 {
+				fields = append(fields, fieldType)
+				continue
+			}
+			for _, name := range field.Names {
+				fields = append(fields, name.Name+" "+fieldType)
+			}
+		}
+		if len(fields) == 0 {
+			return "struct {}"
+		}
+		return "struct { " + strings.Join(fields, "; ") + " }"
+	case *ast.InterfaceType:
+		var methods []string
+		for _, field := range t.Methods.List {
+			if funcType, ok := field.Type.(*ast.FuncType); ok && len(field.Names) > 0 {
+				methods = append(methods, field.Names[0].Name+q.signature(funcType))
+			} else {
+				methods = append(methods, q.matcherType(field.Type))
+			}
+		}
+		if len(methods) == 0 {
+			return "interface {}"
+		}
+		return "interface { " + strings.Join(methods, "; ") + " }"
+	case *ast.IndexExpr:
+		return q.matcherType(t.X) + "[" + q.matcherType(t.Index) + "]"
+	case *ast.IndexListExpr:
+		args := make([]string, len(t.Indices))
+		for i, index := range t.Indices {
+			args[i] = q.matcherType(index)
+		}
+		return q.matcherType(t.X) + "[" + strings.Join(args, ",") + "]"
+	default:
+		return "unknown"
+	}
+}
+
+// signature renders a function's parameter and result types without names,
+// matching reflect's format
+func (q *matcherQualifier) signature(funcType *ast.FuncType) string {
+	params := q.fieldTypes(funcType.Params)
+	text := "(" + strings.Join(params, ", ") + ")"
+
+	results := q.fieldTypes(funcType.Results)
+	switch len(results) {
+	case 0:
+		return text
+	case 1:
+		return text + " " + results[0]
+	default:
+		return text + " (" + strings.Join(results, ", ") + ")"
+	}
+}
+
+// fieldTypes lists the type of every entry in a parameter or result list,
+// repeating it for fields that declare several names
+func (q *matcherQualifier) fieldTypes(fields *ast.FieldList) []string {
+	if fields == nil {
+		return nil
+	}
+
+	var result []string
+	for _, field := range fields.List {
+		fieldType := q.matcherType(field.Type)
+		count := len(field.Names)
+		if count == 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			result = append(result, fieldType)
+		}
+	}
+	return result
+}
@@ -0,0 +1,69 @@
+package scanner
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+)
+
+// ScanPreview summarizes what a scan would parse, without parsing anything
+type ScanPreview struct {
+	GoFiles     int   `json:"go_files"`
+	TotalBytes  int64 `json:"total_bytes"`
+	Directories int   `json:"directories"`
+	// LargeFiles counts the Go files over the maximum file size, which a
+	// scan skips; they are not included in GoFiles or TotalBytes
+	LargeFiles int `json:"large_files"`
+}
+
+// PreviewScan walks projectPath as ScanProjectContext would, counting the
+// Go files a scan would parse and their total size from directory entries
+// alone. Symlinked directories are not followed, and unreadable
+// directories are skipped. The walk stops as soon as ctx is done,
+// returning ctx.Err().
+func (s *GoInterfaceScanner) PreviewScan(ctx context.Context, projectPath string, opts ScanOptions) (ScanPreview, error) {
+	var preview ScanPreview
+	err := filepath.WalkDir(projectPath, func(path string, entry fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			if path == projectPath {
+				return err
+			}
+			if entry != nil && entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if entry.IsDir() {
+			if opts.MaxDepth > 0 && dirDepth(projectPath, path) >= opts.MaxDepth {
+				return filepath.SkipDir
+			}
+			preview.Directories++
+			return nil
+		}
+
+		// The same files the scan skips
+		if !IsSourceFile(projectPath, path, opts.IncludeTests) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		if s.maxFileSize > 0 && info.Size() > s.maxFileSize {
+			preview.LargeFiles++
+			return nil
+		}
+		preview.GoFiles++
+		preview.TotalBytes += info.Size()
+		return nil
+	})
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return preview, ctxErr
+	}
+	return preview, err
+}
@@ -0,0 +1,189 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// typeRef identifies a named type by the directory of its package
+type typeRef struct {
+	dir  string
+	name string
+}
+
+// FindUnusedInterfaces reports the interfaces in a project that are never
+// referenced as a field, embedded interface, parameter, return value or
+// variable type. References are resolved syntactically: same-package
+// identifiers and qualified identifiers whose import path lies within the
+// project's module. Every Go file is parsed, including tests, so this is
+// considerably more expensive than ScanProject.
+func (s *GoInterfaceScanner) FindUnusedInterfaces(projectPath string) ([]types.InterfaceDefinition, error) {
+	return s.FindUnusedInterfacesContext(context.Background(), projectPath)
+}
+
+// FindUnusedInterfacesContext is FindUnusedInterfaces, stopping as soon as
+// ctx is done and returning ctx.Err()
+func (s *GoInterfaceScanner) FindUnusedInterfacesContext(ctx context.Context, projectPath string) ([]types.InterfaceDefinition, error) {
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve project path: %w", err)
+	}
+
+	interfaces, _, err := s.ScanProjectContext(ctx, absPath, ScanOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	moduleRoot, modulePath := FindModule(absPath)
+	refs := make(map[typeRef]bool)
+
+	err = filepath.Walk(absPath, func(filePath string, info os.FileInfo, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			return err
+		}
+		if !IsSourceFile(absPath, filePath, true) {
+			return nil
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), filePath, nil, parser.SkipObjectResolution)
+		if err != nil {
+			// Unparseable files can't hold references; skip them like ScanProject does
+			return nil
+		}
+
+		collectTypeRefs(file, filepath.Dir(filePath), importDirs(file, moduleRoot, modulePath), refs)
+		return nil
+	})
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan project: %w", err)
+	}
+
+	var unused []types.InterfaceDefinition
+	for _, iface := range interfaces {
+		if !refs[typeRef{dir: filepath.Dir(iface.FilePath), name: iface.Name}] {
+			unused = append(unused, iface)
+		}
+	}
+
+	return unused, nil
+}
+
+// collectTypeRefs records every named type a file uses in a field, parameter,
+// result, embedded interface or variable declaration
+func collectTypeRefs(file *ast.File, dir string, imports map[string]string, refs map[typeRef]bool) {
+	var addRefs func(expr ast.Expr)
+	addRefs = func(expr ast.Expr) {
+		switch t := expr.(type) {
+		case *ast.Ident:
+			refs[typeRef{dir: dir, name: t.Name}] = true
+		case *ast.SelectorExpr:
+			if pkg, ok := t.X.(*ast.Ident); ok {
+				if importDir, ok := imports[pkg.Name]; ok {
+					refs[typeRef{dir: importDir, name: t.Sel.Name}] = true
+				}
+			}
+		case *ast.StarExpr:
+			addRefs(t.X)
+		case *ast.ArrayType:
+			addRefs(t.Elt)
+		case *ast.MapType:
+			addRefs(t.Key)
+			addRefs(t.Value)
+		case *ast.ChanType:
+			addRefs(t.Value)
+		case *ast.Ellipsis:
+			addRefs(t.Elt)
+		case *ast.IndexExpr:
+			addRefs(t.X)
+			addRefs(t.Index)
+		case *ast.IndexListExpr:
+			addRefs(t.X)
+			for _, index := range t.Indices {
+				addRefs(index)
+			}
+		case *ast.ParenExpr:
+			addRefs(t.X)
+		}
+		// Function, struct and interface literals are visited through their
+		// own fields by ast.Inspect
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.Field:
+			addRefs(node.Type)
+		case *ast.ValueSpec:
+			if node.Type != nil {
+				addRefs(node.Type)
+			}
+		}
+		return true
+	})
+}
+
+// importDirs maps the names a file imports packages under to the directories
+// of those packages, for imports that belong to the project's module
+func importDirs(file *ast.File, moduleRoot, modulePath string) map[string]string {
+	dirs := make(map[string]string)
+	if modulePath == "" {
+		return dirs
+	}
+
+	for _, imp := range file.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		if importPath != modulePath && !strings.HasPrefix(importPath, modulePath+"/") {
+			continue
+		}
+
+		name := path.Base(importPath)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if name == "_" || name == "." {
+			continue
+		}
+
+		rel := strings.TrimPrefix(strings.TrimPrefix(importPath, modulePath), "/")
+		dirs[name] = filepath.Join(moduleRoot, filepath.FromSlash(rel))
+	}
+
+	return dirs
+}
+
+// FindModule locates the go.mod in or above dir and returns the module's
+// root directory and path. Both are empty when dir is not inside a module.
+func FindModule(dir string) (string, string) {
+	for {
+		content, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			return dir, modfile.ModulePath(content)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ""
+		}
+		dir = parent
+	}
+}
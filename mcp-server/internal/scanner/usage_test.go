@@ -0,0 +1,75 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoInterfaceScanner_FindUnusedInterfaces(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.22\n",
+		"domain/domain.go": `package domain
+
+type UserRepository interface{ Get(id string) (*User, error) }
+
+type Clock interface{ Now() int64 }
+
+type Logger interface{ Log(msg string) }
+
+type Store interface {
+	Logger
+}
+
+type Orphan interface{ Do() }
+
+type User struct{}
+
+// Service uses Clock as a field within the same package
+type Service struct {
+	clock Clock
+}
+`,
+		"app/app.go": `package app
+
+import repo "example.com/app/domain"
+
+func New(r repo.UserRepository) *Handler { return &Handler{} }
+
+type Handler struct{}
+
+var store []repo.Store
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	unused, err := NewGoInterfaceScanner().FindUnusedInterfaces(root)
+	require.NoError(t, err)
+
+	var names []string
+	for _, iface := range unused {
+		names = append(names, iface.Name)
+	}
+	assert.Equal(t, []string{"Orphan"}, names)
+	assert.Equal(t, "domain", unused[0].Package)
+	assert.Equal(t, filepath.Join(root, "domain", "domain.go"), unused[0].FilePath)
+}
+
+func TestFindModule(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("// Service module\nmodule \"example.com/app\" // quoted\n\ngo 1.22\n"), 0644))
+	dir := filepath.Join(root, "internal", "store")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+
+	moduleRoot, modulePath := FindModule(dir)
+	assert.Equal(t, root, moduleRoot)
+	assert.Equal(t, "example.com/app", modulePath)
+}
@@ -0,0 +1,26 @@
+package scanner
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// IsSourceFile reports whether path, found walking root, is a Go file the
+// scanner parses: a .go file outside any vendor directory beneath root,
+// and not a test file unless includeTests is set. Directories named like
+// vendor, such as myvendor, and vendor directories above root don't count.
+func IsSourceFile(root, path string, includeTests bool) bool {
+	if !strings.HasSuffix(path, ".go") {
+		return false
+	}
+	if !includeTests && strings.HasSuffix(path, "_test.go") {
+		return false
+	}
+
+	rel, err := filepath.Rel(root, filepath.Dir(path))
+	if err != nil {
+		rel = filepath.Dir(path)
+	}
+	return !slices.Contains(strings.Split(filepath.ToSlash(rel), "/"), "vendor")
+}
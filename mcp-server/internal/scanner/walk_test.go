@@ -0,0 +1,22 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSourceFile(t *testing.T) {
+	root := filepath.Join("/src", "vendor", "app")
+
+	assert.True(t, IsSourceFile(root, filepath.Join(root, "main.go"), false))
+	assert.True(t, IsSourceFile(root, filepath.Join(root, "myvendor", "client.go"), false))
+	assert.True(t, IsSourceFile(root, filepath.Join(root, "vendored", "client.go"), false))
+	assert.False(t, IsSourceFile(root, filepath.Join(root, "vendor", "example.com", "lib", "lib.go"), false))
+	assert.False(t, IsSourceFile(root, filepath.Join(root, "internal", "vendor", "lib.go"), false))
+	assert.False(t, IsSourceFile(root, filepath.Join(root, "README.md"), false))
+
+	assert.False(t, IsSourceFile(root, filepath.Join(root, "main_test.go"), false))
+	assert.True(t, IsSourceFile(root, filepath.Join(root, "main_test.go"), true))
+}
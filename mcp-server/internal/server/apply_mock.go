@@ -0,0 +1,337 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+)
+
+// setupMocksFunc names the functions apply_mock_to_test wires mocks into, as
+// in the examples' table-driven tests
+const setupMocksFunc = "setupMocks"
+
+// mockPlaceholder is a nil a setupMocks function returns in place of a mock
+type mockPlaceholder struct {
+	Offset int
+	Line   int
+	// TestingT names the function's *testing.T parameter, if it has one
+	TestingT string
+}
+
+// textEdit replaces the bytes [Start, End) of a file with Text
+type textEdit struct {
+	Start, End int
+	Text       string
+}
+
+// applyTextEdits applies non-overlapping edits to content
+func applyTextEdits(content []byte, edits []textEdit) []byte {
+	sort.Slice(edits, func(i, j int) bool { return edits[i].Start > edits[j].Start })
+	result := append([]byte(nil), content...)
+	for _, edit := range edits {
+		result = append(result[:edit.Start], append([]byte(edit.Text), result[edit.End:]...)...)
+	}
+	return result
+}
+
+// namesType reports whether a result type is typeName, qualified or not
+func namesType(expr ast.Expr, typeName string) bool {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name == typeName
+	case *ast.SelectorExpr:
+		return expr.Sel.Name == typeName
+	}
+	return false
+}
+
+// testingTParam returns the name of a *testing.T parameter of fn, or ""
+func testingTParam(fn *ast.FuncType) string {
+	for _, field := range fn.Params.List {
+		star, ok := field.Type.(*ast.StarExpr)
+		if !ok || len(field.Names) == 0 {
+			continue
+		}
+		if selector, ok := star.X.(*ast.SelectorExpr); ok && selector.Sel.Name == "T" {
+			if pkg, ok := selector.X.(*ast.Ident); ok && pkg.Name == "testing" && field.Names[0].Name != "_" {
+				return field.Names[0].Name
+			}
+		}
+	}
+	return ""
+}
+
+// findMockPlaceholders returns the nils setupMocks functions in a test file
+// return where their results are of type interfaceName. setupMocks may be a
+// function, a variable or a struct field holding a function literal.
+func findMockPlaceholders(fileSet *token.FileSet, file *ast.File, interfaceName string) []mockPlaceholder {
+	type setupFunc struct {
+		typ  *ast.FuncType
+		body *ast.BlockStmt
+	}
+	var funcs []setupFunc
+	addLiteral := func(name *ast.Ident, value ast.Expr) {
+		if literal, ok := value.(*ast.FuncLit); ok && name.Name == setupMocksFunc {
+			funcs = append(funcs, setupFunc{literal.Type, literal.Body})
+		}
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			if n.Name.Name == setupMocksFunc && n.Recv == nil && n.Body != nil {
+				funcs = append(funcs, setupFunc{n.Type, n.Body})
+			}
+		case *ast.KeyValueExpr:
+			if key, ok := n.Key.(*ast.Ident); ok {
+				addLiteral(key, n.Value)
+			}
+		case *ast.AssignStmt:
+			if len(n.Lhs) == len(n.Rhs) {
+				for i, lhs := range n.Lhs {
+					if name, ok := lhs.(*ast.Ident); ok {
+						addLiteral(name, n.Rhs[i])
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == len(n.Values) {
+				for i, name := range n.Names {
+					addLiteral(name, n.Values[i])
+				}
+			}
+		}
+		return true
+	})
+
+	var placeholders []mockPlaceholder
+	for _, fn := range funcs {
+		if fn.typ.Results == nil {
+			continue
+		}
+		var positions []int
+		results := 0
+		for _, field := range fn.typ.Results.List {
+			count := max(len(field.Names), 1)
+			if namesType(field.Type, interfaceName) {
+				for i := 0; i < count; i++ {
+					positions = append(positions, results+i)
+				}
+			}
+			results += count
+		}
+		if len(positions) == 0 {
+			continue
+		}
+
+		testingT := testingTParam(fn.typ)
+		ast.Inspect(fn.body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				// Returns in nested closures are the closure's own
+				return false
+			case *ast.ReturnStmt:
+				if len(n.Results) != results {
+					return true
+				}
+				for _, position := range positions {
+					if ident, ok := n.Results[position].(*ast.Ident); ok && ident.Name == "nil" {
+						placeholders = append(placeholders, mockPlaceholder{
+							Offset:   fileSet.Position(ident.Pos()).Offset,
+							Line:     fileSet.Position(ident.Pos()).Line,
+							TestingT: testingT,
+						})
+					}
+				}
+			}
+			return true
+		})
+	}
+	sort.Slice(placeholders, func(i, j int) bool { return placeholders[i].Offset < placeholders[j].Offset })
+	return placeholders
+}
+
+// mockImport returns the qualifier a test file refers to the package at
+// importPath by, and the edit importing it when the file doesn't yet
+func mockImport(fileSet *token.FileSet, file *ast.File, importPath, packageName string) (string, *textEdit, error) {
+	for _, spec := range file.Imports {
+		existing, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path.Base(existing)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		switch {
+		case existing == importPath && name == ".":
+			return "", nil, nil
+		case existing == importPath && name != "_":
+			if spec.Name == nil {
+				name = packageName
+			}
+			return name, nil, nil
+		case existing != importPath && name == packageName:
+			return "", nil, fmt.Errorf("the test file already imports %s as %s", existing, packageName)
+		}
+	}
+
+	spec := strconv.Quote(importPath)
+	if path.Base(importPath) != packageName {
+		spec = packageName + " " + spec
+	}
+
+	// Append to the last import declaration; gofmt sorts it into place
+	var last *ast.GenDecl
+	for _, decl := range file.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.IMPORT {
+			last = genDecl
+		}
+	}
+	switch {
+	case last == nil:
+		offset := fileSet.Position(file.Name.End()).Offset
+		return packageName, &textEdit{Start: offset, End: offset, Text: "\n\nimport " + spec}, nil
+	case last.Lparen.IsValid():
+		offset := fileSet.Position(last.Rparen).Offset
+		return packageName, &textEdit{Start: offset, End: offset, Text: "\t" + spec + "\n"}, nil
+	default:
+		offset := fileSet.Position(last.End()).Offset
+		return packageName, &textEdit{Start: offset, End: offset, Text: "\nimport " + spec}, nil
+	}
+}
+
+// handleApplyMockToTest implements the apply_mock_to_test tool. It accepts
+// generate_mock's options for a single mock, generates it, then replaces the
+// nil placeholders for its interface in test_file's setupMocks functions
+// with the mock, keeping a .bak copy of the original test file.
+func (s *MockeryMCPServer) handleApplyMockToTest(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	testFile, ok := args["test_file"].(string)
+	if !ok || testFile == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid test_file", nil)
+	}
+	if !strings.HasSuffix(testFile, "_test.go") {
+		return s.errorResponse(requestID, -32602, "Invalid test_file", fmt.Sprintf("%s is not a _test.go file", testFile))
+	}
+	// Editing a user's source is opted into call by call
+	if confirm, _ := args["confirm"].(bool); !confirm {
+		return s.errorResponse(requestID, -32602, "Missing or invalid confirm",
+			"apply_mock_to_test edits test_file in place; pass confirm: true to allow it")
+	}
+
+	request, errResponse := s.parseGenerateMockArgs(requestID, args)
+	if errResponse != nil {
+		return errResponse
+	}
+	if request.Recursive {
+		return s.errorResponse(requestID, -32602, "Invalid recursive", "apply_mock_to_test wires a single mock; recursive is not supported")
+	}
+	if request.ReturnContent {
+		return s.errorResponse(requestID, -32602, "Invalid return_content", "apply_mock_to_test needs the mock written to disk")
+	}
+
+	absTest, err := filepath.Abs(testFile)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", testFile), err)
+	}
+	info, err := os.Stat(absTest)
+	if os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Test file does not exist: %s", absTest), fmt.Errorf("%w: %s", ErrPathNotFound, absTest))
+	} else if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to read test file", err)
+	}
+	original, err := os.ReadFile(absTest)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to read test file", err)
+	}
+
+	// Find what to replace before generating anything
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, absTest, original, parser.ParseComments)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to parse test file", err)
+	}
+	placeholders := findMockPlaceholders(fileSet, file, request.InterfaceName)
+	if len(placeholders) == 0 {
+		return s.errorResponse(requestID, -32602, "Invalid test_file",
+			fmt.Sprintf("no %s function in %s returns nil for %s", setupMocksFunc, absTest, request.InterfaceName))
+	}
+
+	result, err := s.GenerateMock(ctx, request)
+	if err != nil {
+		s.requestLogger(ctx).Error("Mock generation failed", zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to generate mock", err)
+	}
+
+	mockFile, err := parser.ParseFile(token.NewFileSet(), result.GeneratedFile, nil, parser.PackageClauseOnly)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to read generated mock", fmt.Errorf("%w: %v", ErrGenerationFailed, err))
+	}
+	mockDir := filepath.Dir(result.GeneratedFile)
+
+	var edits []textEdit
+	qualifier := ""
+	if mockDir != filepath.Dir(absTest) || mockFile.Name.Name != file.Name.Name {
+		moduleRoot, modulePath := scanner.FindModule(mockDir)
+		if modulePath == "" {
+			return s.toolErrorResponse(requestID, "Generated mock is not in a Go module",
+				fmt.Errorf("%w: no go.mod in or above %s", ErrModuleNotFound, mockDir))
+		}
+		var importEdit *textEdit
+		qualifier, importEdit, err = mockImport(fileSet, file, packagePath(mockDir, moduleRoot, modulePath), mockFile.Name.Name)
+		if err != nil {
+			return s.toolErrorResponse(requestID, "Failed to import the generated mock", err)
+		}
+		if importEdit != nil {
+			edits = append(edits, *importEdit)
+		}
+	}
+	if qualifier != "" {
+		qualifier += "."
+	}
+
+	mockName := resolveMockName(request)
+	if mockName == "" {
+		mockName = request.InterfaceName
+	}
+	lines := make([]string, len(placeholders))
+	for i, placeholder := range placeholders {
+		// Without a *testing.T in scope the mock's expectations are the
+		// test's to assert
+		replacement := fmt.Sprintf("&%s%s{}", qualifier, mockName)
+		if placeholder.TestingT != "" {
+			replacement = fmt.Sprintf("%sNew%s(%s)", qualifier, mockName, placeholder.TestingT)
+		}
+		edits = append(edits, textEdit{Start: placeholder.Offset, End: placeholder.Offset + len("nil"), Text: replacement})
+		lines[i] = strconv.Itoa(placeholder.Line)
+	}
+
+	edited, err := format.Source(applyTextEdits(original, edits))
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to format edited test file", err)
+	}
+
+	backup := absTest + ".bak"
+	if err := os.WriteFile(backup, original, info.Mode().Perm()); err != nil {
+		return s.toolErrorResponse(requestID, "Failed to back up test file", err)
+	}
+	if err := os.WriteFile(absTest, edited, info.Mode().Perm()); err != nil {
+		return s.toolErrorResponse(requestID, "Failed to write test file", err)
+	}
+
+	text := fmt.Sprintf("Mock generated and applied:\n- Interface: %s\n- Generated: %s\n- Test file: %s (lines %s)\n- Backup: %s",
+		request.InterfaceName, result.GeneratedFile, absTest, strings.Join(lines, ", "), backup)
+	return s.textResponse(requestID, text)
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupMocksTest is a test file with the examples' setupMocks placeholders
+const setupMocksTest = `package service
+
+import (
+	"testing"
+
+	"example.com/app/domain"
+)
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		setupMocks func() (domain.UserRepository, domain.EmailService)
+	}{
+		{
+			name: "success",
+			setupMocks: func() (domain.UserRepository, domain.EmailService) {
+				return nil, nil // Placeholder
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt.setupMocks()
+	}
+}
+
+func setupMocks(t *testing.T) (domain.UserRepository, error) {
+	check := func() (domain.UserRepository, error) { return nil, nil }
+	check()
+	return nil, nil
+}
+`
+
+func TestMockeryMCPServer_ApplyMockToTest(t *testing.T) {
+	writeProject := func(t *testing.T) string {
+		root := t.TempDir()
+		writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n")
+		writeFile(t, root, "domain/domain.go", "package domain\n\ntype UserRepository interface{ Get() error }\n\ntype EmailService interface{ Send() error }\n")
+		writeFile(t, root, "service/service_test.go", setupMocksTest)
+		return root
+	}
+
+	t.Run("wires the mock into setupMocks", func(t *testing.T) {
+		root := writeProject(t)
+		s := newTestServer(t)
+		useFakeMockery(t, s, fakeMockeryScript)
+		testFile := filepath.Join(root, "service", "service_test.go")
+
+		text := responseText(t, callTool(t, s, "apply_mock_to_test", map[string]interface{}{
+			"interface_name": "UserRepository",
+			"package_path":   filepath.Join(root, "domain"),
+			"output_dir":     filepath.Join(root, "mocks"),
+			"test_file":      testFile,
+			"confirm":        true,
+		}))
+		assert.Contains(t, text, "- Generated: "+filepath.Join(root, "mocks", "mock_user_repository.go"))
+		assert.Contains(t, text, "(lines 17, 29)")
+
+		content, err := os.ReadFile(testFile)
+		require.NoError(t, err)
+		edited := string(content)
+		assert.Contains(t, edited, "\t\"example.com/app/domain\"\n\t\"example.com/app/mocks\"\n")
+		assert.Contains(t, edited, "return &mocks.UserRepository{}, nil // Placeholder")
+		assert.Contains(t, edited, "return mocks.NewUserRepository(t), nil\n")
+		// Returns of nested closures are left alone
+		assert.Contains(t, edited, "check := func() (domain.UserRepository, error) { return nil, nil }")
+
+		backup, err := os.ReadFile(testFile + ".bak")
+		require.NoError(t, err)
+		assert.Equal(t, setupMocksTest, string(backup))
+
+		// The next interface reuses the import
+		responseText(t, callTool(t, s, "apply_mock_to_test", map[string]interface{}{
+			"interface_name": "EmailService",
+			"package_path":   filepath.Join(root, "domain"),
+			"output_dir":     filepath.Join(root, "mocks"),
+			"test_file":      testFile,
+			"confirm":        true,
+		}))
+		content, err = os.ReadFile(testFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "return &mocks.UserRepository{}, &mocks.EmailService{} // Placeholder")
+		assert.Equal(t, 1, strings.Count(string(content), `"example.com/app/mocks"`))
+	})
+
+	t.Run("requires confirm", func(t *testing.T) {
+		root := writeProject(t)
+		s := newTestServer(t)
+
+		response := callTool(t, s, "apply_mock_to_test", map[string]interface{}{
+			"interface_name": "UserRepository",
+			"package_path":   filepath.Join(root, "domain"),
+			"test_file":      filepath.Join(root, "service", "service_test.go"),
+		})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, "Missing or invalid confirm", response.Error.Message)
+	})
+
+	t.Run("no placeholders generates nothing", func(t *testing.T) {
+		root := writeProject(t)
+		writeFile(t, root, "domain/cache.go", "package domain\n\ntype Cache interface{ Get() error }\n")
+		s := newTestServer(t)
+		useFakeMockery(t, s, fakeMockeryScript)
+
+		response := callTool(t, s, "apply_mock_to_test", map[string]interface{}{
+			"interface_name": "Cache",
+			"package_path":   filepath.Join(root, "domain"),
+			"output_dir":     filepath.Join(root, "mocks"),
+			"test_file":      filepath.Join(root, "service", "service_test.go"),
+			"confirm":        true,
+		})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, "Invalid test_file", response.Error.Message)
+		assert.NoDirExists(t, filepath.Join(root, "mocks"))
+		assert.NoFileExists(t, filepath.Join(root, "service", "service_test.go.bak"))
+	})
+}
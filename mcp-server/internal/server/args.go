@@ -0,0 +1,56 @@
+package server
+
+import (
+	"fmt"
+)
+
+// stringSliceArg reads an optional array-of-strings argument. JSON arrays
+// decode as []interface{}, so each element is checked individually.
+func stringSliceArg(args map[string]interface{}, key string) ([]string, error) {
+	value, ok := args[key]
+	if !ok || value == nil {
+		return nil, nil
+	}
+
+	switch items := value.(type) {
+	case []string:
+		return items, nil
+	case []interface{}:
+		result := make([]string, 0, len(items))
+		for _, item := range items {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must be an array of strings", key)
+			}
+			result = append(result, str)
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("%s must be an array of strings", key)
+	}
+}
+
+// stringMapArg reads an optional object of strings from tool arguments
+func stringMapArg(args map[string]interface{}, key string) (map[string]string, error) {
+	value, ok := args[key]
+	if !ok || value == nil {
+		return nil, nil
+	}
+
+	switch items := value.(type) {
+	case map[string]string:
+		return items, nil
+	case map[string]interface{}:
+		result := make(map[string]string, len(items))
+		for name, item := range items {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must be an object of strings", key)
+			}
+			result[name] = str
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("%s must be an object of strings", key)
+	}
+}
@@ -0,0 +1,183 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// staleConfigEntry is an interface listed in a mockery config that its
+// package no longer declares
+type staleConfigEntry struct {
+	Package       string
+	InterfaceName string
+	Reason        string
+	// Similar names an interface the entry may have been renamed to
+	Similar []string
+}
+
+// configAudit is the result of checking a mockery config against the source
+type configAudit struct {
+	Checked int
+	Stale   []staleConfigEntry
+	// Unchecked lists packages whose source couldn't be parsed
+	Unchecked []string
+	// EmptyPackages are packages none of whose configured interfaces remain
+	EmptyPackages []string
+}
+
+// auditConfig confirms every interface listed in a mockery config is still
+// declared in its package, in package then interface order
+func (s *MockeryMCPServer) auditConfig(ctx context.Context, configPath string, mockeryConfig *types.MockeryConfig) *configAudit {
+	configDir := filepath.Dir(configPath)
+	moduleRoot, modulePath := scanner.FindModule(configDir)
+
+	packagePaths := make([]string, 0, len(mockeryConfig.Packages))
+	for packagePath := range mockeryConfig.Packages {
+		packagePaths = append(packagePaths, packagePath)
+	}
+	sort.Strings(packagePaths)
+
+	audit := &configAudit{}
+	for _, packagePath := range packagePaths {
+		configured := mockeryConfig.Packages[packagePath].Interfaces
+		names := make([]string, 0, len(configured))
+		for name := range configured {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		audit.Checked += len(names)
+
+		declared, err := s.declaredInterfaces(ctx, mockeryConfig, packagePath, configDir, moduleRoot, modulePath)
+		if err != nil {
+			audit.Unchecked = append(audit.Unchecked, fmt.Sprintf("%s: %v", packagePath, err))
+			continue
+		}
+		if declared == nil {
+			for _, name := range names {
+				audit.Stale = append(audit.Stale, staleConfigEntry{Package: packagePath, InterfaceName: name, Reason: "package not found"})
+			}
+			if len(names) > 0 {
+				audit.EmptyPackages = append(audit.EmptyPackages, packagePath)
+			}
+			continue
+		}
+
+		stale := 0
+		for _, name := range names {
+			if declared[name] {
+				continue
+			}
+			stale++
+			others := make([]string, 0, len(declared))
+			for other := range declared {
+				others = append(others, other)
+			}
+			audit.Stale = append(audit.Stale, staleConfigEntry{
+				Package:       packagePath,
+				InterfaceName: name,
+				Reason:        "interface not declared",
+				Similar:       similarNames(name, others),
+			})
+		}
+		if stale > 0 && stale == len(names) {
+			audit.EmptyPackages = append(audit.EmptyPackages, packagePath)
+		}
+	}
+	return audit
+}
+
+// declaredInterfaces returns the names of the interfaces declared in a
+// configured package, or nil when the package can't be found
+func (s *MockeryMCPServer) declaredInterfaces(ctx context.Context, mockeryConfig *types.MockeryConfig, packagePath, configDir, moduleRoot, modulePath string) (map[string]bool, error) {
+	request := configInterfaceRequest(mockeryConfig, types.InterfaceSettings{}, packagePath, "", configDir, moduleRoot, modulePath)
+	source, err := resolveMockSource(ctx, request)
+	if err != nil {
+		return nil, nil
+	}
+
+	// Test files count too, since mocks of test-only interfaces are valid
+	files, err := filepath.Glob(filepath.Join(source.dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	interfaces, err := s.scanner.ScanFiles(files)
+	if err != nil {
+		return nil, err
+	}
+
+	declared := make(map[string]bool, len(interfaces))
+	for _, iface := range interfaces {
+		declared[iface.Name] = true
+	}
+	return declared, nil
+}
+
+// formatConfigAudit renders an audit report with the config entries to
+// remove, suggesting renames where a similar interface exists
+func formatConfigAudit(path string, audit *configAudit) string {
+	var text strings.Builder
+	if len(audit.Stale) == 0 {
+		fmt.Fprintf(&text, "All %d configured interfaces in %s still exist", audit.Checked, path)
+	} else {
+		fmt.Fprintf(&text, "Found %d stale entries in %s (checked %d interfaces):\n", len(audit.Stale), path, audit.Checked)
+		for _, entry := range audit.Stale {
+			fmt.Fprintf(&text, "\n- %s %s: %s", entry.Package, entry.InterfaceName, entry.Reason)
+			if len(entry.Similar) > 0 {
+				fmt.Fprintf(&text, " (found: %s)", strings.Join(entry.Similar, ", "))
+			}
+		}
+
+		text.WriteString("\n\nSuggested removals:")
+		empty := make(map[string]bool, len(audit.EmptyPackages))
+		for _, packagePath := range audit.EmptyPackages {
+			empty[packagePath] = true
+			fmt.Fprintf(&text, "\n- packages.%s (no configured interfaces remain)", packagePath)
+		}
+		for _, entry := range audit.Stale {
+			if empty[entry.Package] {
+				continue
+			}
+			fmt.Fprintf(&text, "\n- packages.%s.interfaces.%s", entry.Package, entry.InterfaceName)
+			if len(entry.Similar) > 0 {
+				fmt.Fprintf(&text, " (or rename to %s)", entry.Similar[0])
+			}
+		}
+	}
+
+	if len(audit.Unchecked) > 0 {
+		fmt.Fprintf(&text, "\n\nCould not check %d packages:", len(audit.Unchecked))
+		for _, unchecked := range audit.Unchecked {
+			text.WriteString("\n- " + unchecked)
+		}
+	}
+	return text.String()
+}
+
+// handleAuditConfig implements the audit_config tool
+func (s *MockeryMCPServer) handleAuditConfig(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	configPath, _ := args["config_path"].(string)
+	projectPath, _ := args["project_path"].(string)
+	if configPath == "" && projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing project_path or config_path", nil)
+	}
+
+	absPath, err := resolveMockeryConfigPath(projectPath, configPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Mockery config not found", err)
+	}
+
+	// Only the listed interfaces matter here, so the rest of the config
+	// doesn't have to be valid
+	mockeryConfig, err := s.configManager.LoadConfigFile(absPath)
+	if err != nil {
+		return s.errorResponse(requestID, -32602, "Invalid mockery config", err.Error())
+	}
+
+	return s.textResponse(requestID, formatConfigAudit(absPath, s.auditConfig(ctx, absPath, mockeryConfig)))
+}
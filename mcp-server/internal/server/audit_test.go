@@ -0,0 +1,47 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockeryMCPServer_AuditConfig(t *testing.T) {
+	project := t.TempDir()
+	writeFile(t, project, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	writeFile(t, project, "domain/repo.go", "package domain\n\ntype UserRepo interface{ Get() error }\n\ntype Cache interface{ Flush() }\n")
+	writeFile(t, project, ".mockery.yaml", `filename: "mock_{{.InterfaceName}}.go"
+outpkg: mocks
+packages:
+  example.com/app/domain:
+    interfaces:
+      Cache:
+      UserRepository:
+  example.com/app/billing:
+    interfaces:
+      Invoices:
+`)
+
+	s := newTestServer(t)
+	text := responseText(t, callTool(t, s, "audit_config", map[string]interface{}{"project_path": project}))
+
+	assert.Contains(t, text, "Found 2 stale entries")
+	assert.Contains(t, text, "(checked 3 interfaces)")
+	assert.Contains(t, text, "- example.com/app/billing Invoices: package not found")
+	assert.Contains(t, text, "- example.com/app/domain UserRepository: interface not declared (found: UserRepo")
+	assert.Contains(t, text, "- packages.example.com/app/billing (no configured interfaces remain)")
+	assert.Contains(t, text, "- packages.example.com/app/domain.interfaces.UserRepository (or rename to UserRepo)")
+	assert.NotContains(t, text, "interfaces.Cache")
+
+	writeFile(t, project, ".mockery.yaml", `filename: "mock_{{.InterfaceName}}.go"
+outpkg: mocks
+packages:
+  example.com/app/domain:
+    interfaces:
+      Cache:
+      UserRepo:
+`)
+	text = responseText(t, callTool(t, s, "audit_config", map[string]interface{}{"project_path": project}))
+	assert.Contains(t, text, "All 2 configured interfaces")
+	assert.Contains(t, text, "still exist")
+}
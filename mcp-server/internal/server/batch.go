@@ -0,0 +1,272 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// batchConfig is the temporary mockery v2 config used to generate several
+// interfaces of one package in a single mockery run. Mockery v3 is given a
+// mockeryV3Config with settings per interface instead.
+type batchConfig struct {
+	Packages map[string]batchPackage `yaml:"packages"`
+}
+
+// batchPackage lists the interfaces to generate from one package
+type batchPackage struct {
+	Interfaces map[string]batchInterface `yaml:"interfaces"`
+}
+
+// batchInterface holds the per-interface settings of a batchConfig. Every
+// setting is given explicitly so mockery's packages-mode defaults, which
+// differ from its command line defaults, never apply.
+type batchInterface struct {
+	Config batchInterfaceSettings `yaml:"config"`
+}
+
+// batchInterfaceSettings mirrors the command line flags GenerateMock passes
+type batchInterfaceSettings struct {
+	Dir             string `yaml:"dir"`
+	Filename        string `yaml:"filename"`
+	MockName        string `yaml:"mockname"`
+	OutPkg          string `yaml:"outpkg"`
+	InPackage       bool   `yaml:"inpackage"`
+	WithExpecter    bool   `yaml:"with-expecter"`
+	BoilerplateFile string `yaml:"boilerplate-file,omitempty"`
+}
+
+// batchEntry is one interface of a batched mockery run
+type batchEntry struct {
+	index     int
+	request   *types.MockGenerationRequest
+	outputDir string
+	filename  string
+	// iface is the interface being mocked, nil when it wasn't looked up
+	iface *types.InterfaceDefinition
+}
+
+// GenerateMockBatch generates mocks for several requests, running mockery
+// once per package directory rather than once per interface so each package
+// is only loaded once. Requests that can't share a run (external packages,
+// packages outside a module, content-only requests or ones with extra
+// arguments) and directories with a single interface go through GenerateMock.
+// Results are returned in request order, with failures reported per result.
+func (s *MockeryMCPServer) GenerateMockBatch(ctx context.Context, requests []*types.MockGenerationRequest) []*types.MockGenerationResult {
+	results := make([]*types.MockGenerationResult, len(requests))
+
+	// Group batchable requests by package directory, keeping first-seen order
+	var dirs []string
+	groups := make(map[string][]int)
+	sources := make(map[string]*mockSource)
+	var single []int
+	for i, request := range requests {
+		if request.ReturnContent || len(request.ExtraArgs) > 0 {
+			single = append(single, i)
+			continue
+		}
+		source, err := resolveMockSource(ctx, request)
+		if err != nil || source.external {
+			single = append(single, i)
+			continue
+		}
+		if _, ok := groups[source.dir]; !ok {
+			dirs = append(dirs, source.dir)
+			sources[source.dir] = source
+		}
+		groups[source.dir] = append(groups[source.dir], i)
+	}
+
+	for _, dir := range dirs {
+		indexes := groups[dir]
+		if len(indexes) == 1 {
+			single = append(single, indexes[0])
+			continue
+		}
+		batch := make([]*types.MockGenerationRequest, len(indexes))
+		for j, i := range indexes {
+			batch[j] = requests[i]
+		}
+		for j, result := range s.generatePackageMocks(ctx, sources[dir], batch) {
+			results[indexes[j]] = result
+		}
+	}
+
+	for _, i := range single {
+		result, err := s.GenerateMock(ctx, requests[i])
+		if err != nil {
+			result = failedResult(requests[i], err)
+		}
+		results[i] = result
+	}
+
+	return results
+}
+
+// generatePackageMocks generates every request, all for interfaces of the
+// package in source, with one mockery run driven by a temporary config. It
+// falls back to one run per interface when the package isn't in a module,
+// since the config names packages by import path.
+func (s *MockeryMCPServer) generatePackageMocks(ctx context.Context, source *mockSource, requests []*types.MockGenerationRequest) []*types.MockGenerationResult {
+	startTime := time.Now()
+	cfg := s.config()
+	results := make([]*types.MockGenerationResult, len(requests))
+
+	moduleRoot, modulePath := scanner.FindModule(source.dir)
+	importPath := packagePath(source.dir, moduleRoot, modulePath)
+	if modulePath == "" || importPath == source.dir {
+		for i, request := range requests {
+			result, err := s.GenerateMock(ctx, request)
+			if err != nil {
+				result = failedResult(request, err)
+			}
+			results[i] = result
+		}
+		return results
+	}
+
+	s.requestLogger(ctx).Info("Generating mocks in one mockery run",
+		zap.String("package", importPath),
+		zap.Int("interfaces", len(requests)),
+	)
+
+	// Settings are resolved per interface exactly as GenerateMock would
+	var (
+		plans   []*generationPlan
+		entries []batchEntry
+	)
+	for i, request := range requests {
+		plan, err := s.planGeneration(ctx, cfg, request)
+		if err != nil {
+			results[i] = failedResult(request, err)
+			continue
+		}
+		request = plan.request
+		if err := cfg.makeOutputDir(plan.outputDir); err != nil {
+			results[i] = failedResult(request, fmt.Errorf("failed to create output directory: %w", err))
+			continue
+		}
+		if module := plan.mocksModule; module != nil && module.create {
+			if err := module.writeGoMod(); err != nil {
+				results[i] = failedResult(request, err)
+				continue
+			}
+		}
+		plans = append(plans, plan)
+		entries = append(entries, batchEntry{index: i, request: request, outputDir: plan.outputDir, filename: plan.filename, iface: plan.iface})
+	}
+
+	if len(entries) == 0 {
+		return results
+	}
+
+	fail := func(err error) []*types.MockGenerationResult {
+		for _, entry := range entries {
+			results[entry.index] = failedResult(entry.request, err)
+		}
+		return results
+	}
+
+	// The config is written in the form the installed mockery reads
+	content, errs, err := s.argsBuilder(ctx, cfg).packageConfig(plans, importPath)
+	if err != nil {
+		return fail(err)
+	}
+	batched := entries[:0]
+	for i, entry := range entries {
+		if errs[i] != nil {
+			results[entry.index] = failedResult(entry.request, errs[i])
+			continue
+		}
+		batched = append(batched, entry)
+	}
+	entries = batched
+	if len(entries) == 0 {
+		return results
+	}
+
+	configPath, err := writeBatchConfig(content)
+	if err != nil {
+		return fail(err)
+	}
+	defer os.Remove(configPath)
+
+	if _, err := exec.LookPath(cfg.MockeryCommand); err != nil {
+		return fail(fmt.Errorf("%w: mockery command not found in PATH. Please install mockery: go install github.com/vektra/mockery/v2@latest", ErrMockeryNotInstalled))
+	}
+
+	release, err := s.acquireGeneration(ctx)
+	if err != nil {
+		return fail(err)
+	}
+	defer release()
+
+	output, err := s.runMockery(ctx, cfg, source.workDir, []string{"--config=" + configPath})
+	if err != nil {
+		return fail(err)
+	}
+
+	version := s.mockeryVersion(ctx, cfg.MockeryCommand)
+	for _, entry := range entries {
+		generatedFile := filepath.Join(entry.outputDir, entry.filename)
+		if _, err := os.Stat(generatedFile); err != nil {
+			results[entry.index] = failedResult(entry.request,
+				fmt.Errorf("%w: mockery did not produce %s: %v", ErrGenerationFailed, entry.filename, err))
+			continue
+		}
+		if entry.request.BuildTag != "" {
+			if err := applyBuildTag(generatedFile, entry.request.BuildTag); err != nil {
+				results[entry.index] = failedResult(entry.request, fmt.Errorf("%w: %v", ErrGenerationFailed, err))
+				continue
+			}
+		}
+		if err := cfg.applyFileMode(generatedFile); err != nil {
+			results[entry.index] = failedResult(entry.request,
+				fmt.Errorf("%w: failed to set mode of %s: %v", ErrGenerationFailed, generatedFile, err))
+			continue
+		}
+
+		result := &types.MockGenerationResult{
+			Success:        true,
+			InterfaceName:  entry.request.InterfaceName,
+			GeneratedFile:  generatedFile,
+			GeneratedAt:    startTime,
+			MockeryOutput:  string(output),
+			MockeryVersion: version,
+		}
+		if entry.iface != nil {
+			result.SourceHash = scanner.InterfaceSignatureHash(*entry.iface)
+		}
+		s.recordGeneratedMock(ctx, entry.request, result)
+		results[entry.index] = result
+	}
+
+	return results
+}
+
+// writeBatchConfig writes a batch config to a temporary file and returns
+// its path
+func writeBatchConfig(content []byte) (string, error) {
+	file, err := os.CreateTemp("", "mockery-batch-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("failed to create batch config: %w", err)
+	}
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write batch config: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write batch config: %w", err)
+	}
+	return file.Name(), nil
+}
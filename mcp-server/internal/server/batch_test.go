@@ -0,0 +1,199 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// countingMockeryScript is fakeMockeryScript extended to generate every
+// interface of a --config run, logging each invocation to the file named by
+// its format argument. The config is copied to $CONFIG_COPY when set.
+const countingMockeryScript = `#!/bin/sh
+if [ "$1" = "--version" ]; then
+	echo "v2.53.3"
+	exit 0
+fi
+echo "$@" >> %q
+for arg in "$@"; do
+	case "$arg" in
+		--config=*) config="${arg#--config=}" ;;
+		--name=*) name="${arg#--name=}" ;;
+		--output=*) output="${arg#--output=}" ;;
+		--filename=*) filename="${arg#--filename=}" ;;
+	esac
+done
+if [ -n "$config" ]; then
+	[ -n "$CONFIG_COPY" ] && cp "$config" "$CONFIG_COPY"
+	awk '$1 == "dir:" { dir = $2 } $1 == "filename:" { print dir "/" $2 }' "$config" | while read -r file; do
+		printf 'package mocks\n' > "$file"
+	done
+	exit 0
+fi
+mkdir -p "$output"
+printf 'package mocks\n\n// Mock%%s is a mock\ntype Mock%%s struct{}\n' "$name" "$name" > "$output/$filename"
+`
+
+// useCountingMockery installs countingMockeryScript and returns a function
+// reporting the arguments of each mockery run so far
+func useCountingMockery(t *testing.T, s *MockeryMCPServer) func() []string {
+	t.Helper()
+	logFile := filepath.Join(t.TempDir(), "runs.log")
+	useFakeMockery(t, s, fmt.Sprintf(countingMockeryScript, logFile))
+	return func() []string {
+		data, err := os.ReadFile(logFile)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		require.NoError(t, err)
+		return strings.Split(strings.TrimSpace(string(data)), "\n")
+	}
+}
+
+// writeBatchModule writes a module with three interfaces in one package and
+// one in another
+func writeBatchModule(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	writeFile(t, root, "store/store.go", "package store\n\ntype Users interface{ Get() error }\n\ntype Orders interface{ List() error }\n\n//mockery:filename orders_cache.go\ntype Cache interface{ Flush() }\n")
+	writeFile(t, root, "notify/notify.go", "package notify\n\ntype Sender interface{ Send() error }\n")
+	return root
+}
+
+func TestMockeryMCPServer_GenerateMockBatch(t *testing.T) {
+	root := writeBatchModule(t)
+
+	s := newTestServer(t)
+	runs := useCountingMockery(t, s)
+
+	requests := []*types.MockGenerationRequest{
+		{InterfaceName: "Users", PackagePath: filepath.Join(root, "store")},
+		{InterfaceName: "Sender", PackagePath: filepath.Join(root, "notify")},
+		{InterfaceName: "Orders", PackagePath: filepath.Join(root, "store"), WithExpector: true},
+		{InterfaceName: "Cache", PackagePath: filepath.Join(root, "store")},
+		{InterfaceName: "Missing", PackagePath: filepath.Join(root, "store")},
+	}
+	results := s.GenerateMockBatch(context.Background(), requests)
+	require.Len(t, results, len(requests))
+
+	// One run for the store package and one for notify's single interface
+	require.Len(t, runs(), 2)
+	assert.Contains(t, runs()[0], "--config=")
+	assert.Contains(t, runs()[1], "--name=Sender")
+
+	mocksDir := filepath.Join(root, "store", "mocks")
+	for i, want := range []string{
+		filepath.Join(mocksDir, "mock_users.go"),
+		filepath.Join(root, "notify", "mocks", "mock_sender.go"),
+		filepath.Join(mocksDir, "mock_orders.go"),
+		filepath.Join(mocksDir, "orders_cache.go"),
+	} {
+		assert.True(t, results[i].Success, results[i].ErrorMessage)
+		assert.Equal(t, requests[i].InterfaceName, results[i].InterfaceName)
+		assert.Equal(t, want, results[i].GeneratedFile)
+		assert.FileExists(t, want)
+	}
+
+	// A missing interface fails on its own without failing the batch
+	assert.False(t, results[4].Success)
+	assert.Contains(t, results[4].ErrorMessage, "interface Missing not found")
+}
+
+func TestMockeryMCPServer_GenerateMocksRecursiveBatchesPackages(t *testing.T) {
+	root := writeBatchModule(t)
+
+	perInterface := newTestServer(t)
+	perInterfaceRuns := useCountingMockery(t, perInterface)
+	for _, name := range []string{"Users", "Orders", "Cache"} {
+		_, err := perInterface.GenerateMock(context.Background(), &types.MockGenerationRequest{
+			InterfaceName: name,
+			PackagePath:   filepath.Join(root, "store"),
+			OutputDir:     t.TempDir(),
+		})
+		require.NoError(t, err)
+	}
+
+	s := newTestServer(t)
+	runs := useCountingMockery(t, s)
+	results, err := s.GenerateMocksRecursive(context.Background(), &types.MockGenerationRequest{
+		PackagePath: root,
+		OutputDir:   filepath.Join(t.TempDir(), "mocks"),
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	for _, result := range results {
+		assert.True(t, result.Success, result.ErrorMessage)
+		assert.FileExists(t, result.GeneratedFile)
+	}
+
+	// Four interfaces in two packages take two runs rather than four
+	assert.Len(t, perInterfaceRuns(), 3)
+	assert.Len(t, runs(), 2)
+
+	// Batched mocks record the hash generate_mock_if_changed compares
+	mocks := s.projectManager.GetGeneratedMocks("")
+	require.Len(t, mocks, 4)
+	for _, mock := range mocks {
+		assert.Len(t, mock.SourceHash, 64, mock.InterfaceName)
+	}
+}
+
+func TestMockeryMCPServer_GenerateMocksRecursiveBuildTag(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	writeFile(t, root, "store/store.go", "package store\n\ntype Users interface{ Get() error }\n\ntype Orders interface{ List() error }\n")
+
+	s := newTestServer(t)
+	runs := useCountingMockery(t, s)
+	results, err := s.GenerateMocksRecursive(context.Background(), &types.MockGenerationRequest{
+		PackagePath: root,
+		BuildTag:    "integration",
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	// Both mocks share one run and still get the constraint
+	require.Len(t, runs(), 1)
+	assert.Contains(t, runs()[0], "--config=")
+	for _, result := range results {
+		require.True(t, result.Success, result.ErrorMessage)
+		content, err := os.ReadFile(result.GeneratedFile)
+		require.NoError(t, err)
+		assert.Equal(t, "//go:build integration\n// +build integration\n\npackage mocks\n", string(content))
+	}
+}
+
+func TestWriteBatchConfig(t *testing.T) {
+	content, errs, err := mockeryV2Args{}.packageConfig([]*generationPlan{{
+		request:   &types.MockGenerationRequest{InterfaceName: "Users"},
+		source:    &mockSource{dir: "/src/store", packageName: "store"},
+		outputDir: "/out",
+		filename:  "mock_users.go",
+	}}, "example.com/app/store")
+	require.NoError(t, err)
+	assert.Equal(t, []error{nil}, errs)
+
+	path, err := writeBatchConfig(content)
+	require.NoError(t, err)
+	defer os.Remove(path)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	text := string(data)
+	assert.Contains(t, text, "example.com/app/store:")
+	assert.Contains(t, text, "dir: /out")
+	assert.Contains(t, text, "filename: mock_users.go")
+	assert.Contains(t, text, "mockname: Users")
+	assert.Contains(t, text, "outpkg: mocks")
+	assert.Contains(t, text, "with-expecter: false")
+	assert.NotContains(t, text, "boilerplate-file")
+}
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// resolveBoilerplateFile returns the absolute path of a mockery boilerplate
+// file, failing early when it is missing rather than leaving mockery to
+// report it
+func resolveBoilerplateFile(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve boilerplate file: %w", err)
+	}
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: boilerplate file does not exist: %s", ErrPathNotFound, absPath)
+		}
+		return "", fmt.Errorf("failed to stat boilerplate file: %w", err)
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("boilerplate file %s is a directory", absPath)
+	}
+
+	return absPath, nil
+}
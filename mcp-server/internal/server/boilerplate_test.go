@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// argsMockeryScript records its arguments, one per line, in $ARGS_FILE and
+// then behaves like fakeMockeryScript
+const argsMockeryScript = `#!/bin/sh
+[ "$1" = "--version" ] && exec sh "$FAKE_MOCKERY" "$@"
+printf '%s\n' "$@" > "$ARGS_FILE"
+` + "exec sh \"$FAKE_MOCKERY\" \"$@\"\n"
+
+// recordingMockery installs argsMockeryScript and returns a function reporting
+// the arguments of the last run
+func recordingMockery(t *testing.T, s *MockeryMCPServer) func() []string {
+	t.Helper()
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args")
+	fake := filepath.Join(dir, "fake-mockery")
+	require.NoError(t, os.WriteFile(fake, []byte(fakeMockeryScript), 0755))
+	t.Setenv("ARGS_FILE", argsFile)
+	t.Setenv("FAKE_MOCKERY", fake)
+	useFakeMockery(t, s, argsMockeryScript)
+
+	return func() []string {
+		data, err := os.ReadFile(argsFile)
+		require.NoError(t, err)
+		return strings.Split(strings.TrimSpace(string(data)), "\n")
+	}
+}
+
+func TestMockeryMCPServer_GenerateMock_BoilerplateFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype UserRepository interface{ Get() error }\n")
+	boilerplate := writeFile(t, t.TempDir(), "header.txt", "// Copyright Example Corp\n")
+
+	t.Run("passes the file to mockery", func(t *testing.T) {
+		s := newTestServer(t)
+		lastArgs := recordingMockery(t, s)
+
+		_, err := s.GenerateMock(context.Background(), &types.MockGenerationRequest{
+			InterfaceName:   "UserRepository",
+			PackagePath:     dir,
+			OutputDir:       t.TempDir(),
+			BoilerplateFile: boilerplate,
+		})
+		require.NoError(t, err)
+		assert.Contains(t, lastArgs(), "--boilerplate-file="+boilerplate)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		s := newTestServer(t)
+		useFakeMockery(t, s, fakeMockeryScript)
+
+		missing := filepath.Join(t.TempDir(), "missing.txt")
+		response := callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name":   "UserRepository",
+			"package_path":     dir,
+			"output_dir":       t.TempDir(),
+			"boilerplate_file": missing,
+		})
+		requireErrorKind(t, response, CodePathNotFound, KindPathNotFound)
+		assert.Contains(t, response.Error.Data.(ErrorData).Detail, "boilerplate file does not exist: "+missing)
+	})
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"fmt"
+	"go/build/constraint"
+	"os"
+	"strings"
+)
+
+// validateBuildTag checks that tag is a valid build constraint expression,
+// e.g. !production or linux && amd64
+func validateBuildTag(tag string) error {
+	if strings.ContainsAny(tag, "\r\n") {
+		return fmt.Errorf("build tag %q must be a single line", tag)
+	}
+	_, err := buildConstraintHeader(tag)
+	return err
+}
+
+// buildConstraintHeader returns the //go:build line for tag followed by the
+// equivalent // +build lines older toolchains read
+func buildConstraintHeader(tag string) (string, error) {
+	expr, err := constraint.Parse("//go:build " + tag)
+	if err != nil {
+		return "", fmt.Errorf("invalid build tag %q: %v", tag, err)
+	}
+	lines := []string{"//go:build " + expr.String()}
+	plusBuild, err := constraint.PlusBuildLines(expr)
+	if err != nil {
+		return "", fmt.Errorf("build tag %q has no // +build form: %v", tag, err)
+	}
+	return strings.Join(append(lines, plusBuild...), "\n") + "\n", nil
+}
+
+// applyBuildTag puts tag's build constraint at the top of a generated file.
+// Constraint lines already above the package clause are replaced, so
+// regenerating into an existing file never stacks them.
+func applyBuildTag(path, tag string) error {
+	header, err := buildConstraintHeader(tag)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read generated mock: %w", err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	kept := make([]string, 0, len(lines))
+	inHeader, removed := true, false
+	for _, line := range lines {
+		if inHeader {
+			if strings.HasPrefix(line, "package ") {
+				inHeader = false
+			} else if constraint.IsGoBuild(line) || constraint.IsPlusBuild(line) {
+				removed = true
+				continue
+			} else if removed && strings.TrimSpace(line) == "" {
+				// Drop the blank line that separated the old constraint
+				removed = false
+				continue
+			}
+		}
+		removed = false
+		kept = append(kept, line)
+	}
+	body := strings.TrimLeft(strings.Join(kept, "\n"), "\n")
+
+	if err := os.WriteFile(path, []byte(header+"\n"+body), 0644); err != nil {
+		return fmt.Errorf("failed to write build tag: %w", err)
+	}
+	return nil
+}
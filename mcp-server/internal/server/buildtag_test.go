@@ -0,0 +1,59 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_GenerateMockBuildTag(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package domain\n\ntype Repo interface{ Get() error }\n")
+	outputDir := t.TempDir()
+
+	s := newTestServer(t)
+	useFakeMockery(t, s, fakeMockeryScript)
+
+	generate := func(tag string) *MCPResponse {
+		return callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "Repo",
+			"package_path":   dir,
+			"output_dir":     outputDir,
+			"build_tag":      tag,
+		})
+	}
+
+	const header = "//go:build !production\n// +build !production\n\npackage mocks\n"
+	require.Nil(t, generate("!production").Error)
+	content, err := os.ReadFile(filepath.Join(outputDir, "mock_repo.go"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), header), string(content))
+
+	t.Run("rerun", func(t *testing.T) {
+		require.Nil(t, generate("!production").Error)
+		require.NoError(t, applyBuildTag(filepath.Join(outputDir, "mock_repo.go"), "!production"))
+		content, err := os.ReadFile(filepath.Join(outputDir, "mock_repo.go"))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(content), header), string(content))
+		assert.Equal(t, 1, strings.Count(string(content), "//go:build"))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		response := generate("linux &&")
+		require.NotNil(t, response.Error)
+		assert.Equal(t, "Invalid build_tag", response.Error.Message)
+	})
+}
+
+func TestApplyBuildTag(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "mock.go", "// Code generated by mockery. DO NOT EDIT.\n\n//go:build old\n\npackage mocks\n\n// +build in a comment after the package clause stays\n")
+
+	require.NoError(t, applyBuildTag(path, "linux && amd64"))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "//go:build linux && amd64\n// +build linux,amd64\n\n// Code generated by mockery. DO NOT EDIT.\n\npackage mocks\n\n// +build in a comment after the package clause stays\n", string(content))
+}
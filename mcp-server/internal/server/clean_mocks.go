@@ -0,0 +1,307 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+)
+
+// generatedMockHeader marks files written by mockery, or by this server's
+// generate_testify_mock. Only such files are ever removed.
+var generatedMockHeader = regexp.MustCompile(`(?m)^// Code generated by mockery\b`)
+
+// mockTypeDoc matches the doc comment mockery puts on a mock type, e.g.
+// "// MockRepo is an autogenerated mock type for the Repo type"
+var mockTypeDoc = regexp.MustCompile(`(?m)^// \w+ is an? (?:autogenerated )?mock type for the (\w+) type`)
+
+// projectMock reports whether a generated mock file can be attributed to the
+// project, so that its mocked type would be declared there. Mockery also
+// mocks interfaces of dependencies and the standard library, whose names the
+// project never declares, so a mock only counts when it imports a package of
+// the project's module or sits in a project package as an in-package mock.
+func projectMock(path string, content []byte, modulePath string) bool {
+	file, err := parser.ParseFile(token.NewFileSet(), path, content, parser.ImportsOnly)
+	if err != nil {
+		return false
+	}
+	if modulePath != "" {
+		for _, spec := range file.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err == nil && (importPath == modulePath || strings.HasPrefix(importPath, modulePath+"/")) {
+				return true
+			}
+		}
+	}
+
+	// An in-package mock shares its package with hand-written source that
+	// declares types to mock; helpers kept beside mocks don't count
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		sibling := filepath.Join(filepath.Dir(path), entry.Name())
+		if entry.IsDir() || sibling == path || !strings.HasSuffix(entry.Name(), ".go") {
+			continue
+		}
+		siblingContent, err := os.ReadFile(sibling)
+		if err != nil || generatedMockHeader.Match(siblingContent) {
+			continue
+		}
+		siblingFile, err := parser.ParseFile(token.NewFileSet(), sibling, siblingContent, parser.SkipObjectResolution)
+		if err == nil && siblingFile.Name.Name == file.Name.Name && declaresMockableTypes(siblingFile) {
+			return true
+		}
+	}
+	return false
+}
+
+// declaresMockableTypes reports whether a file declares an interface or
+// function type
+func declaresMockableTypes(file *ast.File) bool {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			switch spec.(*ast.TypeSpec).Type.(type) {
+			case *ast.InterfaceType, *ast.FuncType:
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// orphanedMock is a generated mock file with no interface left to mock
+type orphanedMock struct {
+	Path string
+	// Mocked lists the types the file mocks, as read from its doc comments
+	// or inferred from its filename
+	Mocked []string
+}
+
+// mockedTypes returns the names of the types a generated mock file mocks,
+// from the doc comments on its mock types, or failing that from a
+// mock_<name>.go filename. ok is false for files that aren't generated
+// mocks or whose mocked type can't be told.
+func mockedTypes(path string, content []byte) (names []string, fromFilename bool, ok bool) {
+	if !generatedMockHeader.Match(content) {
+		return nil, false, false
+	}
+	for _, match := range mockTypeDoc.FindAllSubmatch(content, -1) {
+		names = append(names, string(match[1]))
+	}
+	if len(names) > 0 {
+		return names, false, true
+	}
+
+	base := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(path), ".go"), "_test")
+	if name, found := strings.CutPrefix(base, "mock_"); found && name != "" {
+		return []string{name}, true, true
+	}
+	return nil, false, false
+}
+
+// liveMockTargets returns the names of every interface and function type
+// declared in a project, tests included, in each casing the default mock
+// filenames use
+func (s *MockeryMCPServer) liveMockTargets(ctx context.Context, projectPath string) (map[string]bool, map[string]bool, error) {
+	interfaces, _, err := s.scanner.ScanProjectContext(ctx, projectPath, scanner.ScanOptions{IncludeTests: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	names := make([]string, 0, len(interfaces))
+	for _, iface := range interfaces {
+		names = append(names, iface.Name)
+	}
+
+	// Function types can be mocked too. Files that don't parse were already
+	// reported by the interface scan, so they are skipped here.
+	err = filepath.WalkDir(projectPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !scanner.IsSourceFile(projectPath, path, true) {
+			return nil
+		}
+		functions, err := s.scanner.ScanFunctionTypes([]string{path})
+		if err != nil {
+			return nil
+		}
+		for _, function := range functions {
+			names = append(names, function.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	live := make(map[string]bool, len(names))
+	filenames := make(map[string]bool, len(names)*len(filenameCases))
+	for _, name := range names {
+		live[name] = true
+		for _, style := range filenameCases {
+			filenames[filenameCase(name, style)] = true
+		}
+	}
+	return live, filenames, nil
+}
+
+// cleanScan is what findOrphanedMocks found in a mocks directory
+type cleanScan struct {
+	orphans []orphanedMock
+	// checked counts the generated mocks of project types
+	checked int
+	// unrecognized are generated files whose mocked type can't be told
+	unrecognized []string
+	// external are mocks that can't be attributed to the project, such as
+	// mocks of standard library or dependency interfaces
+	external []string
+}
+
+// findOrphanedMocks walks a mocks directory for generated mocks of project
+// types none of whose mocked types is still declared. Mocks that can't be
+// attributed to the project in modulePath are never orphans.
+func findOrphanedMocks(mocksDir, modulePath string, live, liveFilenames map[string]bool) (*cleanScan, error) {
+	scan := &cleanScan{}
+	err := filepath.WalkDir(mocksDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		names, fromFilename, ok := mockedTypes(path, content)
+		if !ok {
+			if generatedMockHeader.Match(content) {
+				scan.unrecognized = append(scan.unrecognized, path)
+			}
+			return nil
+		}
+		if !projectMock(path, content, modulePath) {
+			scan.external = append(scan.external, path)
+			return nil
+		}
+		scan.checked++
+
+		known := live
+		if fromFilename {
+			known = liveFilenames
+		}
+		for _, name := range names {
+			if known[name] {
+				return nil
+			}
+		}
+		scan.orphans = append(scan.orphans, orphanedMock{Path: path, Mocked: names})
+		return nil
+	})
+	return scan, err
+}
+
+// handleCleanMocks implements the clean_mocks tool
+func (s *MockeryMCPServer) handleCleanMocks(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	projectPath, ok := args["project_path"].(string)
+	if !ok || projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid project_path", nil)
+	}
+	outputDir, ok := args["output_dir"].(string)
+	if !ok || outputDir == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid output_dir", nil)
+	}
+	// Deleting is opt-in
+	dryRun := true
+	if value, ok := args["dry_run"].(bool); ok {
+		dryRun = value
+	}
+
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", projectPath), err)
+	}
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Project path does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	// Nothing outside project_path is deleted. project_path comes from the
+	// client, so this guards against mistakes rather than confining it.
+	mocksDir, err := resolveOutputPath(absPath, outputDir)
+	if err != nil {
+		return s.errorResponse(requestID, -32602, "Invalid output_dir", err.Error())
+	}
+	if info, err := os.Stat(mocksDir); err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Output directory does not exist: %s", mocksDir), fmt.Errorf("%w: %s", ErrPathNotFound, mocksDir))
+	} else if !info.IsDir() {
+		return s.errorResponse(requestID, -32602, "Invalid output_dir", fmt.Sprintf("%s is not a directory", mocksDir))
+	}
+
+	live, liveFilenames, err := s.liveMockTargets(ctx, absPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to scan project", fmt.Errorf("%w: %v", ErrScanFailed, err))
+	}
+
+	_, modulePath := scanner.FindModule(absPath)
+	scan, err := findOrphanedMocks(mocksDir, modulePath, live, liveFilenames)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to read mocks", err)
+	}
+	orphans := scan.orphans
+	sort.Slice(orphans, func(i, j int) bool { return orphans[i].Path < orphans[j].Path })
+
+	if !dryRun {
+		for i, orphan := range orphans {
+			if err := os.Remove(orphan.Path); err != nil {
+				s.logger.Error("Failed to remove orphaned mock", zap.String("file", orphan.Path), zap.Error(err))
+				return s.toolErrorResponse(requestID, fmt.Sprintf("Removed %d orphaned mocks before failing on %s", i, orphan.Path), err)
+			}
+		}
+	}
+
+	var text strings.Builder
+	switch {
+	case len(orphans) == 0:
+		fmt.Fprintf(&text, "No orphaned mocks in %s (checked %d mocks)", mocksDir, scan.checked)
+	case dryRun:
+		fmt.Fprintf(&text, "Would remove %d orphaned mocks from %s (dry run, nothing was deleted):", len(orphans), mocksDir)
+	default:
+		fmt.Fprintf(&text, "Removed %d orphaned mocks from %s:", len(orphans), mocksDir)
+	}
+	for _, orphan := range orphans {
+		rel, err := filepath.Rel(mocksDir, orphan.Path)
+		if err != nil {
+			rel = orphan.Path
+		}
+		fmt.Fprintf(&text, "\n- %s (%s)", rel, strings.Join(orphan.Mocked, ", "))
+	}
+	if len(scan.unrecognized) > 0 {
+		fmt.Fprintf(&text, "\n\nKept %d generated files whose mocked type couldn't be determined:", len(scan.unrecognized))
+		for _, path := range scan.unrecognized {
+			text.WriteString("\n- " + path)
+		}
+	}
+	if len(scan.external) > 0 {
+		fmt.Fprintf(&text, "\n\nKept %d mocks that can't be attributed to the project, such as mocks of dependency interfaces:", len(scan.external))
+		for _, path := range scan.external {
+			text.WriteString("\n- " + path)
+		}
+	}
+	return s.textResponse(requestID, text.String())
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockeryMock returns a mock file the way mockery writes them, importing
+// the given packages
+func mockeryMock(mockName, interfaceName string, imports ...string) string {
+	content := "// Code generated by mockery v2.53.3. DO NOT EDIT.\n\npackage mocks\n\n"
+	for _, importPath := range imports {
+		content += "import _ \"" + importPath + "\"\n\n"
+	}
+	return content + "// " + mockName + " is an autogenerated mock type for the " + interfaceName + " type\n" +
+		"type " + mockName + " struct{}\n"
+}
+
+func TestMockeryMCPServer_CleanMocks(t *testing.T) {
+	writeProject := func(t *testing.T) string {
+		root := t.TempDir()
+		writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n")
+		writeFile(t, root, "store/store.go", "package store\n\ntype UserRepository interface{ Get() error }\n\ntype HandlerFunc func() error\n")
+		writeFile(t, root, "store/store_test.go", "package store\n\ntype clock interface{ Now() int }\n")
+
+		// Live mocks, found by doc comment or by filename
+		writeFile(t, root, "mocks/mock_user_repository.go", mockeryMock("UserRepository", "UserRepository", "example.com/app/store"))
+		writeFile(t, root, "mocks/handler.go", mockeryMock("MockHandlerFunc", "HandlerFunc", "example.com/app/store"))
+		writeFile(t, root, "mocks/mock_clock.go", "// Code generated by mockery. DO NOT EDIT.\n\npackage mocks\n\nimport _ \"example.com/app/store\"\n")
+		// Orphans
+		writeFile(t, root, "mocks/mock_order_repository.go", mockeryMock("OrderRepository", "OrderRepository", "example.com/app/store"))
+		writeFile(t, root, "mocks/store/mock_Cache.go", "// Code generated by mockery. DO NOT EDIT.\n\npackage store\n\nimport _ \"example.com/app/store\"\n")
+		// Kept: hand-written, generated but of an unknown type, and mocks
+		// of interfaces the project doesn't own
+		writeFile(t, root, "mocks/helpers.go", "package mocks\n\n// Deleted is not a mock\nfunc Deleted() {}\n")
+		writeFile(t, root, "mocks/extra.go", "// Code generated by mockery. DO NOT EDIT.\n\npackage mocks\n")
+		writeFile(t, root, "mocks/mock_reader.go", mockeryMock("Reader", "Reader"))
+		writeFile(t, root, "mocks/mock_read_closer.go", mockeryMock("ReadCloser", "ReadCloser", "io"))
+		return root
+	}
+
+	t.Run("dry run by default", func(t *testing.T) {
+		root := writeProject(t)
+		s := newTestServer(t)
+
+		text := responseText(t, callTool(t, s, "clean_mocks", map[string]interface{}{
+			"project_path": root,
+			"output_dir":   "mocks",
+		}))
+		assert.Contains(t, text, "Would remove 2 orphaned mocks")
+		assert.Contains(t, text, "- mock_order_repository.go (OrderRepository)")
+		assert.Contains(t, text, "- "+filepath.Join("store", "mock_Cache.go")+" (Cache)")
+		assert.Contains(t, text, "Kept 1 generated files whose mocked type couldn't be determined:\n- "+filepath.Join(root, "mocks", "extra.go"))
+		assert.Contains(t, text, "Kept 2 mocks that can't be attributed to the project, such as mocks of dependency interfaces:\n- "+
+			filepath.Join(root, "mocks", "mock_read_closer.go")+"\n- "+filepath.Join(root, "mocks", "mock_reader.go"))
+		assert.FileExists(t, filepath.Join(root, "mocks", "mock_order_repository.go"))
+	})
+
+	t.Run("removes orphans", func(t *testing.T) {
+		root := writeProject(t)
+		s := newTestServer(t)
+
+		text := responseText(t, callTool(t, s, "clean_mocks", map[string]interface{}{
+			"project_path": root,
+			"output_dir":   "mocks",
+			"dry_run":      false,
+		}))
+		assert.Contains(t, text, "Removed 2 orphaned mocks")
+		assert.NoFileExists(t, filepath.Join(root, "mocks", "mock_order_repository.go"))
+		assert.NoFileExists(t, filepath.Join(root, "mocks", "store", "mock_Cache.go"))
+		for _, kept := range []string{"mock_user_repository.go", "handler.go", "mock_clock.go", "helpers.go", "extra.go", "mock_reader.go", "mock_read_closer.go"} {
+			assert.FileExists(t, filepath.Join(root, "mocks", kept))
+		}
+
+		text = responseText(t, callTool(t, s, "clean_mocks", map[string]interface{}{
+			"project_path": root,
+			"output_dir":   "mocks",
+			"dry_run":      false,
+		}))
+		assert.Contains(t, text, "No orphaned mocks in "+filepath.Join(root, "mocks")+" (checked 3 mocks)")
+	})
+
+	t.Run("in-package mocks", func(t *testing.T) {
+		root := writeProject(t)
+		writeFile(t, root, "store/mock_gone.go", "// Code generated by mockery. DO NOT EDIT.\n\npackage store\n\n// MockGone is an autogenerated mock type for the Gone type\ntype MockGone struct{}\n")
+		s := newTestServer(t)
+
+		text := responseText(t, callTool(t, s, "clean_mocks", map[string]interface{}{
+			"project_path": root,
+			"output_dir":   "store",
+			"dry_run":      false,
+		}))
+		assert.Contains(t, text, "Removed 1 orphaned mocks")
+		assert.NoFileExists(t, filepath.Join(root, "store", "mock_gone.go"))
+		assert.FileExists(t, filepath.Join(root, "store", "store.go"))
+	})
+
+	t.Run("output_dir must stay inside the project", func(t *testing.T) {
+		root := writeProject(t)
+		outside := t.TempDir()
+		writeFile(t, outside, "mock_gone.go", mockeryMock("Gone", "Gone"))
+		s := newTestServer(t)
+
+		for _, dir := range []string{outside, "../" + filepath.Base(outside), "."} {
+			response := callTool(t, s, "clean_mocks", map[string]interface{}{
+				"project_path": root,
+				"output_dir":   dir,
+			})
+			require.NotNil(t, response.Error, dir)
+			assert.Equal(t, "Invalid output_dir", response.Error.Message)
+		}
+		_, err := os.Stat(filepath.Join(outside, "mock_gone.go"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("missing output_dir", func(t *testing.T) {
+		s := newTestServer(t)
+		response := callTool(t, s, "clean_mocks", map[string]interface{}{
+			"project_path": writeProject(t),
+			"output_dir":   "missing",
+		})
+		requireErrorKind(t, response, CodePathNotFound, KindPathNotFound)
+	})
+}
@@ -0,0 +1,276 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// generateMocksCombined generates the mocks of a recursive request into a
+// scratch directory and merges them into request.CombinedFile. Results of
+// merged mocks name the combined file. Combined files aren't recorded as
+// generated mocks, since regenerating one mock would rewrite it alone.
+func (s *MockeryMCPServer) generateMocksCombined(ctx context.Context, request *types.MockGenerationRequest, rootPath string) ([]*types.MockGenerationResult, error) {
+	combinedFile := request.CombinedFile
+	if !filepath.IsAbs(combinedFile) {
+		combinedFile = filepath.Join(rootPath, combinedFile)
+	}
+
+	tempDir, err := os.MkdirTemp("", "mockery-mcp-combined-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary output directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	scratch := *request
+	scratch.CombinedFile = ""
+	scratch.OutputDir = tempDir
+	results, err := s.GenerateMocksRecursive(ctx, &scratch)
+	if err != nil {
+		return results, err
+	}
+
+	var files []string
+	for _, result := range results {
+		if result.Success {
+			files = append(files, result.GeneratedFile)
+			if mock, ok := s.projectManager.FindGeneratedMock(request.ProjectID, result.GeneratedFile); ok {
+				s.projectManager.RemoveGeneratedMock(mock.ID)
+			}
+		}
+	}
+	if len(files) == 0 {
+		return results, nil
+	}
+
+	content, err := combineMockFiles(files)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to combine mocks: %v", ErrGenerationFailed, err)
+	}
+
+	cfg := s.config()
+	if err := cfg.makeOutputDir(filepath.Dir(combinedFile)); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(combinedFile, content, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", combinedFile, err)
+	}
+	if err := cfg.applyFileMode(combinedFile); err != nil {
+		return nil, fmt.Errorf("%w: failed to set mode of %s: %v", ErrGenerationFailed, combinedFile, err)
+	}
+
+	for _, result := range results {
+		if result.Success {
+			result.GeneratedFile = combinedFile
+		}
+	}
+	return results, nil
+}
+
+// combinedImport is an import of a combined mock file
+type combinedImport struct {
+	name    string
+	path    string
+	aliased bool
+}
+
+// majorVersion matches the major version suffix of a module path
+var majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// gopkgVersion matches the version suffix of a gopkg.in path element
+var gopkgVersion = regexp.MustCompile(`\.v[0-9]+$`)
+
+// importName guesses the name an unaliased import is referred to by: the
+// last path element, skipping a major version suffix
+func importName(importPath string) string {
+	name := path.Base(importPath)
+	if majorVersion.MatchString(name) && path.Dir(importPath) != "." {
+		name = path.Base(path.Dir(importPath))
+	}
+	name = gopkgVersion.ReplaceAllString(strings.TrimPrefix(name, "go-"), "")
+	return strings.NewReplacer("-", "_", ".", "_").Replace(name)
+}
+
+// combineMockFiles merges generated mock files of one package into a single
+// gofmt-clean file. The header comments, such as the generated code notice
+// and build constraints, come from the first file. Imports are
+// de-duplicated, and an import whose name another path already uses is
+// given a new alias, with the file's references to it rewritten.
+func combineMockFiles(files []string) ([]byte, error) {
+	var (
+		header      string
+		packageName string
+		imports     []combinedImport
+		pathNames   = make(map[string]string)
+		namePaths   = make(map[string]string)
+		declared    = make(map[string]string)
+		bodies      strings.Builder
+	)
+
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		fset := token.NewFileSet()
+		parsed, err := parser.ParseFile(fset, file, src, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+
+		if packageName == "" {
+			packageName = parsed.Name.Name
+			header = string(src[:fset.Position(parsed.Package).Offset])
+		} else if parsed.Name.Name != packageName {
+			return nil, fmt.Errorf("%s is in package %s, not %s", file, parsed.Name.Name, packageName)
+		}
+
+		// Map this file's import names to the names used in the combined file
+		renames := make(map[string]string)
+		for _, spec := range parsed.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				return nil, err
+			}
+			name := importName(importPath)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			if name == "_" || name == "." {
+				key := name + " " + importPath
+				if _, ok := pathNames[key]; !ok {
+					pathNames[key] = name
+					imports = append(imports, combinedImport{name: name, path: importPath, aliased: true})
+				}
+				continue
+			}
+
+			combinedName, ok := pathNames[importPath]
+			if !ok {
+				combinedName = name
+				for i := 2; namePaths[combinedName] != ""; i++ {
+					combinedName = fmt.Sprintf("%s%d", name, i)
+				}
+				pathNames[importPath] = combinedName
+				namePaths[combinedName] = importPath
+				imports = append(imports, combinedImport{name: combinedName, path: importPath, aliased: spec.Name != nil || combinedName != name})
+			}
+			if combinedName != name {
+				renames[name] = combinedName
+			}
+		}
+
+		// Mocks of interfaces sharing a name in different packages clash
+		for _, decl := range parsed.Decls {
+			for _, name := range topLevelNames(decl) {
+				if previous, ok := declared[name]; ok {
+					return nil, fmt.Errorf("%s is declared in both %s and %s; use mock_name to tell the mocks apart", name, previous, file)
+				}
+				declared[name] = file
+			}
+		}
+
+		var edits []textEdit
+		ast.Inspect(parsed, func(node ast.Node) bool {
+			selector, ok := node.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			// Package references are the identifiers the parser left unresolved
+			if ident, ok := selector.X.(*ast.Ident); ok && ident.Obj == nil {
+				if renamed, ok := renames[ident.Name]; ok {
+					offset := fset.Position(ident.Pos()).Offset
+					edits = append(edits, textEdit{Start: offset, End: offset + len(ident.Name), Text: renamed})
+				}
+			}
+			return true
+		})
+
+		// The body is everything after the package clause and imports
+		bodyStart := parsed.Name.End()
+		for _, decl := range parsed.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+				bodyStart = gen.End()
+			}
+		}
+		body := applyTextEdits(src, edits)[fset.Position(bodyStart).Offset:]
+		bodies.WriteString("\n")
+		bodies.Write(body)
+	}
+
+	sort.Slice(imports, func(i, j int) bool { return imports[i].path < imports[j].path })
+
+	var combined strings.Builder
+	combined.WriteString(header)
+	combined.WriteString("package " + packageName + "\n")
+	if len(imports) > 0 {
+		combined.WriteString("\nimport (\n")
+		for _, imp := range imports {
+			if !imp.aliased {
+				combined.WriteString(fmt.Sprintf("\t%q\n", imp.path))
+			} else {
+				combined.WriteString(fmt.Sprintf("\t%s %q\n", imp.name, imp.path))
+			}
+		}
+		combined.WriteString(")\n")
+	}
+	combined.WriteString(bodies.String())
+
+	return format.Source([]byte(combined.String()))
+}
+
+// topLevelNames lists the package-level names a declaration introduces,
+// with methods qualified by their receiver type
+func topLevelNames(decl ast.Decl) []string {
+	var names []string
+	switch decl := decl.(type) {
+	case *ast.FuncDecl:
+		if decl.Recv == nil || len(decl.Recv.List) == 0 {
+			return []string{decl.Name.Name}
+		}
+		receiver := decl.Recv.List[0].Type
+		for {
+			switch expr := receiver.(type) {
+			case *ast.StarExpr:
+				receiver = expr.X
+				continue
+			case *ast.IndexExpr:
+				receiver = expr.X
+				continue
+			case *ast.IndexListExpr:
+				receiver = expr.X
+				continue
+			}
+			break
+		}
+		if ident, ok := receiver.(*ast.Ident); ok {
+			return []string{ident.Name + "." + decl.Name.Name}
+		}
+	case *ast.GenDecl:
+		for _, spec := range decl.Specs {
+			switch spec := spec.(type) {
+			case *ast.TypeSpec:
+				names = append(names, spec.Name.Name)
+			case *ast.ValueSpec:
+				for _, name := range spec.Names {
+					if name.Name != "_" {
+						names = append(names, name.Name)
+					}
+				}
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// importingMockeryScript writes mocks that import a package named store,
+// from a different path for each interface, along with testify's mock
+const importingMockeryScript = `#!/bin/sh
+if [ "$1" = "--version" ]; then
+	echo "v2.53.3"
+	exit 0
+fi
+for arg in "$@"; do
+	case "$arg" in
+		--name=*) name="${arg#--name=}" ;;
+		--output=*) output="${arg#--output=}" ;;
+		--filename=*) filename="${arg#--filename=}" ;;
+	esac
+done
+case "$name" in
+	Users) store="example.com/app/store"; result="User" ;;
+	*) store="example.com/app/notify/store"; result="Message" ;;
+esac
+mkdir -p "$output"
+cat > "$output/$filename" <<EOF
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	store "$store"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// $name is a mock
+type $name struct {
+	mock.Mock
+}
+
+// Call returns the stored $result
+func (m *$name) Call() store.$result {
+	return m.Called().Get(0).(store.$result)
+}
+EOF
+`
+
+func TestMockeryMCPServer_GenerateMocksCombined(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	writeFile(t, root, "notify/notify.go", "package notify\n\ntype Sender interface{ Send() error }\n")
+	writeFile(t, root, "store/store.go", "package store\n\ntype Users interface{ Get() error }\n")
+
+	t.Run("merges into one file", func(t *testing.T) {
+		s := newTestServer(t)
+		useFakeMockery(t, s, importingMockeryScript)
+		combined := filepath.Join(root, "mocks", "mocks.go")
+
+		text := responseText(t, callTool(t, s, "generate_mock", map[string]interface{}{
+			"package_path":  root,
+			"recursive":     true,
+			"combined_file": "mocks/mocks.go",
+		}))
+		assert.Contains(t, text, "Generated 2 of 2 mocks")
+		assert.Contains(t, text, "- Sender: "+combined)
+		assert.Contains(t, text, "- Users: "+combined)
+
+		content, err := os.ReadFile(combined)
+		require.NoError(t, err)
+		merged := string(content)
+		assert.True(t, strings.HasPrefix(merged, "// Code generated by mockery v2.53.3. DO NOT EDIT.\n\npackage mocks\n"), merged)
+		assert.Equal(t, 1, strings.Count(merged, "Code generated"))
+		// The second store import is aliased and its references follow
+		assert.Contains(t, merged, "import (\n\tstore \"example.com/app/notify/store\"\n\tstore2 \"example.com/app/store\"\n\tmock \"github.com/stretchr/testify/mock\"\n)\n")
+		assert.Contains(t, merged, "func (m *Sender) Call() store.Message {\n\treturn m.Called().Get(0).(store.Message)\n}")
+		assert.Contains(t, merged, "func (m *Users) Call() store2.User {\n\treturn m.Called().Get(0).(store2.User)\n}")
+
+		// Only the combined file is written
+		assert.NoDirExists(t, filepath.Join(root, "store", "mocks"))
+		assert.Empty(t, s.projectManager.GetGeneratedMocks(""))
+	})
+
+	t.Run("requires recursive", func(t *testing.T) {
+		s := newTestServer(t)
+		response := callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "Users",
+			"package_path":   filepath.Join(root, "store"),
+			"combined_file":  "mocks.go",
+		})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, "Invalid combined_file", response.Error.Message)
+	})
+}
+
+func TestCombineMockFiles_DuplicateDeclaration(t *testing.T) {
+	dir := t.TempDir()
+	first := writeFile(t, dir, "a.go", "package mocks\n\ntype Repository struct{}\n")
+	second := writeFile(t, dir, "b.go", "package mocks\n\ntype Repository struct{}\n")
+
+	_, err := combineMockFiles([]string{first, second})
+	assert.ErrorContains(t, err, "Repository is declared in both")
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// maxCompletionValues is the maximum number of values returned per completion
+const maxCompletionValues = 100
+
+// completionParams are the parameters of a completion/complete request
+type completionParams struct {
+	Ref struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+	} `json:"ref"`
+	Argument struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"argument"`
+	Context struct {
+		Arguments map[string]string `json:"arguments"`
+	} `json:"context"`
+}
+
+// handleCompletion handles completion/complete requests. Completing
+// interface_name scans the package_path (or project_path) already supplied in
+// the completion context and suggests interfaces matching the typed prefix.
+func (s *MockeryMCPServer) handleCompletion(request *MCPRequest) *MCPResponse {
+	var params completionParams
+
+	paramsBytes, err := json.Marshal(request.Params)
+	if err != nil {
+		return s.errorResponse(request.ID, -32602, "Invalid params", err.Error())
+	}
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		return s.errorResponse(request.ID, -32602, "Invalid params", err.Error())
+	}
+
+	var values []string
+	if params.Argument.Name == "interface_name" {
+		values = s.completeInterfaceName(params.Argument.Value, params.Context.Arguments)
+	}
+
+	total := len(values)
+	hasMore := total > maxCompletionValues
+	if hasMore {
+		values = values[:maxCompletionValues]
+	}
+	if values == nil {
+		values = []string{}
+	}
+
+	return &MCPResponse{
+		JSONRPC: "2.0",
+		ID:      request.ID,
+		Result: map[string]interface{}{
+			"completion": map[string]interface{}{
+				"values":  values,
+				"total":   total,
+				"hasMore": hasMore,
+			},
+		},
+	}
+}
+
+// completeInterfaceName returns sorted interface names under the path given in
+// arguments that start with prefix, ignoring case
+func (s *MockeryMCPServer) completeInterfaceName(prefix string, arguments map[string]string) []string {
+	path := arguments["package_path"]
+	if path == "" {
+		path = arguments["project_path"]
+	}
+	if path == "" {
+		return nil
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil
+	}
+	if info, err := os.Stat(absPath); err != nil || !info.IsDir() {
+		return nil
+	}
+
+	interfaces, err := s.scanner.ScanProject(absPath)
+	if err != nil {
+		s.logger.Debug("Completion scan failed", zap.String("path", absPath), zap.Error(err))
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	lowerPrefix := strings.ToLower(prefix)
+	for _, iface := range interfaces {
+		if seen[iface.Name] || !strings.HasPrefix(strings.ToLower(iface.Name), lowerPrefix) {
+			continue
+		}
+		seen[iface.Name] = true
+		names = append(names, iface.Name)
+	}
+
+	sort.Strings(names)
+	return names
+}
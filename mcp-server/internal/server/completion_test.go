@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// complete sends a completion/complete request for an argument
+func complete(t *testing.T, s *MockeryMCPServer, argument, value string, contextArgs map[string]string) map[string]interface{} {
+	t.Helper()
+	response := s.handleMCPRequest(context.Background(), &MCPRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "completion/complete",
+		Params: map[string]interface{}{
+			"ref":      map[string]interface{}{"type": "ref/tool", "name": "generate_mock"},
+			"argument": map[string]interface{}{"name": argument, "value": value},
+			"context":  map[string]interface{}{"arguments": contextArgs},
+		},
+	})
+	require.Nil(t, response.Error)
+
+	result := response.Result.(map[string]interface{})
+	return result["completion"].(map[string]interface{})
+}
+
+func TestMockeryMCPServer_Completion(t *testing.T) {
+	s := newTestServer(t)
+	dir := t.TempDir()
+	writeFile(t, dir, "user.go", "package user\n\ntype UserRepository interface{}\n\ntype UserService interface{}\n")
+	writeFile(t, dir, "email/email.go", "package email\n\ntype EmailSender interface{}\n")
+
+	t.Run("matches prefix case-insensitively", func(t *testing.T) {
+		completion := complete(t, s, "interface_name", "user", map[string]string{"package_path": dir})
+		assert.Equal(t, []string{"UserRepository", "UserService"}, completion["values"])
+		assert.Equal(t, 2, completion["total"])
+		assert.Equal(t, false, completion["hasMore"])
+	})
+
+	t.Run("empty prefix returns all", func(t *testing.T) {
+		completion := complete(t, s, "interface_name", "", map[string]string{"project_path": dir})
+		assert.Equal(t, []string{"EmailSender", "UserRepository", "UserService"}, completion["values"])
+	})
+
+	t.Run("no package path", func(t *testing.T) {
+		completion := complete(t, s, "interface_name", "User", nil)
+		assert.Equal(t, []string{}, completion["values"])
+	})
+
+	t.Run("unsupported argument", func(t *testing.T) {
+		completion := complete(t, s, "output_dir", "./", map[string]string{"package_path": dir})
+		assert.Equal(t, []string{}, completion["values"])
+	})
+
+	t.Run("capability advertised", func(t *testing.T) {
+		response := s.handleMCPRequest(context.Background(), &MCPRequest{JSONRPC: "2.0", ID: 1, Method: "initialize"})
+		capabilities := response.Result.(map[string]interface{})["capabilities"].(map[string]interface{})
+		assert.Contains(t, capabilities, "completions")
+	})
+}
@@ -0,0 +1,268 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// ServerConfig holds the runtime-tunable server settings. All of these are
+// hot-reloadable via the reload_config tool; the listen address, log
+// settings and stdio mode are fixed at startup and require a restart.
+type ServerConfig struct {
+	MockeryCommand string        `yaml:"mockery_command"`
+	OutputTemplate string        `yaml:"output_template"`
+	ReadTimeout    time.Duration `yaml:"ws_read_timeout"`
+	WriteTimeout   time.Duration `yaml:"ws_write_timeout"`
+	MockeryRetries int           `yaml:"mockery_retries"`
+	RetryBackoff   time.Duration `yaml:"mockery_retry_backoff"`
+	AllowedOrigins []string      `yaml:"allowed_origins"`
+
+	// ExcludeInterfaces lists interface names or globs always omitted from discovery
+	ExcludeInterfaces []string `yaml:"exclude_interfaces"`
+
+	// RequireModule rejects discovery of paths outside a Go module by default
+	RequireModule bool `yaml:"require_module"`
+
+	// DefaultWithExpecter is used for generate_mock requests that don't set
+	// with_expecter
+	DefaultWithExpecter bool `yaml:"default_with_expecter"`
+
+	// FilenameCase is the casing of the interface name in default mock
+	// filenames: snake, lower or original
+	FilenameCase string `yaml:"filename_case"`
+
+	// WSCompression offers permessage-deflate to WebSocket clients, which
+	// may accept or decline it. Existing connections keep what they negotiated.
+	WSCompression bool `yaml:"ws_compression"`
+
+	// FileMode and DirMode are octal permissions, e.g. 0664 and 0775, for
+	// generated mock files and the output directories created for them.
+	// Empty leaves files as mockery writes them and creates directories 0755.
+	FileMode string `yaml:"file_mode"`
+	DirMode  string `yaml:"dir_mode"`
+
+	// MockeryVersion selects the command line style: v2 flags, or a v3
+	// config file. auto, or empty, asks the mockery binary.
+	MockeryVersion string `yaml:"mockery_version"`
+
+	// ToolTimeout bounds every tools/call, zero leaving calls unbounded
+	ToolTimeout time.Duration `yaml:"tool_timeout"`
+}
+
+// DefaultServerConfig returns the configuration used when no flags or
+// config file override it
+func DefaultServerConfig() ServerConfig {
+	return ServerConfig{
+		MockeryCommand:      "mockery", // Default command, can be configured
+		DefaultWithExpecter: true,
+		FilenameCase:        filenameCaseSnake,
+		MockeryVersion:      mockeryVersionAuto,
+	}
+}
+
+// runtimeConfig is an immutable, validated snapshot of a ServerConfig.
+// Requests capture one snapshot and use it throughout, so a reload never
+// changes settings under an in-flight request.
+type runtimeConfig struct {
+	ServerConfig
+	outputTemplate *template.Template
+	// fileMode and dirMode are the parsed FileMode and DirMode, zero when unset
+	fileMode os.FileMode
+	dirMode  os.FileMode
+}
+
+// compileConfig validates cfg and prepares derived values
+func compileConfig(cfg ServerConfig) (*runtimeConfig, error) {
+	if cfg.MockeryCommand == "" {
+		return nil, fmt.Errorf("mockery_command cannot be empty")
+	}
+	if cfg.MockeryRetries < 0 {
+		return nil, fmt.Errorf("mockery_retries cannot be negative")
+	}
+	if cfg.ReadTimeout < 0 || cfg.WriteTimeout < 0 || cfg.RetryBackoff < 0 || cfg.ToolTimeout < 0 {
+		return nil, fmt.Errorf("timeouts and backoff cannot be negative")
+	}
+
+	if err := validateInterfacePatterns(cfg.ExcludeInterfaces); err != nil {
+		return nil, err
+	}
+	if err := validateFilenameCase(cfg.FilenameCase); err != nil {
+		return nil, err
+	}
+	if err := validateMockeryVersion(cfg.MockeryVersion); err != nil {
+		return nil, err
+	}
+
+	fileMode, err := parseMode("file_mode", cfg.FileMode, 0600)
+	if err != nil {
+		return nil, err
+	}
+	dirMode, err := parseMode("dir_mode", cfg.DirMode, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	compiled := &runtimeConfig{ServerConfig: cfg, fileMode: fileMode, dirMode: dirMode}
+
+	if cfg.OutputTemplate != "" {
+		tmpl, err := template.New("output-dir").Option("missingkey=error").Parse(cfg.OutputTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid output template: %w", err)
+		}
+
+		// Execute against sample data so unknown fields are caught up front
+		if err := tmpl.Execute(io.Discard, OutputDirData{PackageName: "pkg", PackagePath: "pkg", PackageDir: "/pkg"}); err != nil {
+			return nil, fmt.Errorf("invalid output template: %w", err)
+		}
+		compiled.outputTemplate = tmpl
+	}
+
+	return compiled, nil
+}
+
+// originAllowed reports whether a WebSocket origin may connect. An empty
+// allow list, or one containing "*", permits every origin.
+func (c *runtimeConfig) originAllowed(origin string) bool {
+	if len(c.AllowedOrigins) == 0 {
+		return true
+	}
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// config returns the active configuration snapshot
+func (s *MockeryMCPServer) config() *runtimeConfig {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+	return s.current
+}
+
+// Config returns a copy of the active configuration
+func (s *MockeryMCPServer) Config() ServerConfig {
+	cfg := s.config().ServerConfig
+	cfg.AllowedOrigins = append([]string(nil), cfg.AllowedOrigins...)
+	cfg.ExcludeInterfaces = append([]string(nil), cfg.ExcludeInterfaces...)
+	return cfg
+}
+
+// ApplyConfig validates cfg and atomically makes it the active configuration.
+// The previous configuration stays active if validation fails.
+func (s *MockeryMCPServer) ApplyConfig(cfg ServerConfig) error {
+	compiled, err := compileConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+	s.current = compiled
+	return nil
+}
+
+// updateConfig applies a modification of the active configuration
+func (s *MockeryMCPServer) updateConfig(modify func(cfg *ServerConfig)) error {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	cfg := s.current.ServerConfig
+	modify(&cfg)
+
+	compiled, err := compileConfig(cfg)
+	if err != nil {
+		return err
+	}
+	s.current = compiled
+	return nil
+}
+
+// SetMockeryCommand sets the mockery executable to run
+func (s *MockeryMCPServer) SetMockeryCommand(command string) error {
+	return s.updateConfig(func(cfg *ServerConfig) {
+		cfg.MockeryCommand = command
+	})
+}
+
+// SetConfigFile loads the YAML config file at path on top of the current
+// configuration and remembers it for later reloads. Settings absent from the
+// file keep the values configured before this call (e.g. from flags).
+func (s *MockeryMCPServer) SetConfigFile(path string) error {
+	base := s.Config()
+
+	cfg, err := LoadServerConfig(path, base)
+	if err != nil {
+		return err
+	}
+	if err := s.ApplyConfig(cfg); err != nil {
+		return fmt.Errorf("invalid configuration in %s: %w", path, err)
+	}
+
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+	s.baseConfig = base
+	s.configFile = path
+	return nil
+}
+
+// ReloadConfig re-reads the config file set with SetConfigFile and swaps in
+// the result, returning the new active configuration
+func (s *MockeryMCPServer) ReloadConfig() (ServerConfig, error) {
+	s.configMu.RLock()
+	path, base := s.configFile, s.baseConfig
+	s.configMu.RUnlock()
+
+	if path == "" {
+		return ServerConfig{}, fmt.Errorf("no config file configured; start the server with -config")
+	}
+
+	cfg, err := LoadServerConfig(path, base)
+	if err != nil {
+		return ServerConfig{}, err
+	}
+	if err := s.ApplyConfig(cfg); err != nil {
+		return ServerConfig{}, fmt.Errorf("invalid configuration in %s: %w", path, err)
+	}
+
+	s.logger.Info("Configuration reloaded", zap.String("path", path))
+	return s.Config(), nil
+}
+
+// LoadServerConfig reads a YAML config file, using base for any settings the
+// file does not specify
+func LoadServerConfig(path string, base ServerConfig) (ServerConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ServerConfig{}, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	cfg := base
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return ServerConfig{}, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// handleReloadConfig implements the reload_config tool
+func (s *MockeryMCPServer) handleReloadConfig(requestID interface{}, args map[string]interface{}) *MCPResponse {
+	cfg, err := s.ReloadConfig()
+	if err != nil {
+		s.logger.Error("Failed to reload configuration", zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to reload configuration", err)
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to render configuration", err)
+	}
+
+	return s.textResponse(requestID, "Configuration reloaded:\n\n"+string(data))
+}
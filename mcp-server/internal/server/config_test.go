@@ -0,0 +1,138 @@
+package server
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadServerConfig(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "server.yaml", `mockery_command: /opt/bin/mockery
+ws_read_timeout: 30s
+mockery_retries: 2
+allowed_origins:
+  - https://ide.example.com
+`)
+
+	base := DefaultServerConfig()
+	base.OutputTemplate = "./mocks/{{.PackageName}}"
+	base.WriteTimeout = 5 * time.Second
+
+	cfg, err := LoadServerConfig(path, base)
+	require.NoError(t, err)
+	assert.Equal(t, "/opt/bin/mockery", cfg.MockeryCommand)
+	assert.Equal(t, 30*time.Second, cfg.ReadTimeout)
+	assert.Equal(t, 2, cfg.MockeryRetries)
+	assert.Equal(t, []string{"https://ide.example.com"}, cfg.AllowedOrigins)
+
+	// Settings absent from the file keep their base values
+	assert.Equal(t, "./mocks/{{.PackageName}}", cfg.OutputTemplate)
+	assert.Equal(t, 5*time.Second, cfg.WriteTimeout)
+}
+
+func TestMockeryMCPServer_ReloadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "server.yaml", "mockery_retries: 1\n")
+
+	s := newTestServer(t)
+	s.SetWebSocketTimeouts(time.Minute, 0)
+	require.NoError(t, s.SetConfigFile(path))
+
+	// The tool is only offered once enabled
+	response := callTool(t, s, "reload_config", map[string]interface{}{})
+	require.NotNil(t, response.Error)
+	assert.Equal(t, -32601, response.Error.Code)
+	assert.NotContains(t, listToolNames(t, s), "reload_config")
+	s.SetReloadConfigEnabled(true)
+	assert.Contains(t, listToolNames(t, s), "reload_config")
+
+	assert.Equal(t, 1, s.Config().MockeryRetries)
+	assert.Equal(t, time.Minute, s.Config().ReadTimeout)
+
+	// A request in flight holds the snapshot taken when it started
+	inFlight := s.config()
+
+	writeFile(t, dir, "server.yaml", "mockery_retries: 3\noutput_template: ./mocks/{{.PackageName}}\n")
+	response = callTool(t, s, "reload_config", map[string]interface{}{})
+	assert.Contains(t, responseText(t, response), "mockery_retries: 3")
+
+	assert.Equal(t, 3, s.Config().MockeryRetries)
+	assert.NotNil(t, s.config().outputTemplate)
+	assert.Equal(t, time.Minute, s.Config().ReadTimeout)
+	assert.Equal(t, 1, inFlight.MockeryRetries)
+	assert.Nil(t, inFlight.outputTemplate)
+
+	t.Run("invalid file keeps previous config", func(t *testing.T) {
+		writeFile(t, dir, "server.yaml", "output_template: ./mocks/{{.Nope}}\n")
+		response := callTool(t, s, "reload_config", map[string]interface{}{})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, 3, s.Config().MockeryRetries)
+	})
+
+	t.Run("removed settings revert to startup values", func(t *testing.T) {
+		writeFile(t, dir, "server.yaml", "{}\n")
+		_, err := s.ReloadConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 0, s.Config().MockeryRetries)
+		assert.Equal(t, "", s.Config().OutputTemplate)
+	})
+}
+
+func TestMockeryMCPServer_ReloadConfigWithoutFile(t *testing.T) {
+	s := newTestServer(t)
+	_, err := s.ReloadConfig()
+	assert.Error(t, err)
+
+	err = s.SetConfigFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestRuntimeConfig_OriginAllowed(t *testing.T) {
+	cfg, err := compileConfig(DefaultServerConfig())
+	require.NoError(t, err)
+	assert.True(t, cfg.originAllowed("https://anything.example.com"))
+
+	restricted := DefaultServerConfig()
+	restricted.AllowedOrigins = []string{"https://ide.example.com"}
+	cfg, err = compileConfig(restricted)
+	require.NoError(t, err)
+	assert.True(t, cfg.originAllowed("https://ide.example.com"))
+	assert.False(t, cfg.originAllowed("https://evil.example.com"))
+}
+
+func TestMockeryMCPServer_DefaultWithExpecter(t *testing.T) {
+	dir := filepath.Dir(writeFile(t, t.TempDir(), "repo.go", "package repo\n\ntype Repo interface{ Get() error }\n"))
+
+	generate := func(t *testing.T, s *MockeryMCPServer, args map[string]interface{}) {
+		args["interface_name"] = "Repo"
+		args["package_path"] = dir
+		args["output_dir"] = t.TempDir()
+		require.Nil(t, callTool(t, s, "generate_mock", args).Error)
+	}
+
+	t.Run("enabled by default", func(t *testing.T) {
+		s := newTestServer(t)
+		lastArgs := recordingMockery(t, s)
+
+		generate(t, s, map[string]interface{}{})
+		assert.Contains(t, lastArgs(), "--with-expecter")
+	})
+
+	t.Run("server default disabled", func(t *testing.T) {
+		s := newTestServer(t)
+		lastArgs := recordingMockery(t, s)
+		cfg := s.Config()
+		cfg.DefaultWithExpecter = false
+		require.NoError(t, s.ApplyConfig(cfg))
+
+		generate(t, s, map[string]interface{}{})
+		assert.NotContains(t, lastArgs(), "--with-expecter")
+
+		// An explicit request value still wins
+		generate(t, s, map[string]interface{}{"with_expecter": true})
+		assert.Contains(t, lastArgs(), "--with-expecter")
+	})
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// maxQueuedRequests bounds how many requests a connection reads ahead of
+// the one being handled
+const maxQueuedRequests = 64
+
+// connection is the state of a WebSocket connection or stdio session
+type connection struct {
+	id      uint64
+	limiter *tokenBucket // nil when tool calls aren't rate limited
+
+	// notify sends a notification to the client, nil when the connection
+	// can't receive them
+	notify func(*MCPNotification) error
+
+	watchesMu sync.Mutex
+	watches   map[string]*projectWatch
+}
+
+// connectionKey is the context key of a request's connection
+type connectionKey struct{}
+
+// requestKey identifies an in-flight request. Request IDs are chosen by the
+// client, so they are only unique within a connection.
+type requestKey struct {
+	connection uint64
+	id         string
+}
+
+// inflightRequest is a request that is being handled
+type inflightRequest struct {
+	cancel    context.CancelFunc
+	cancelled bool // Cancelled by the client, guarded by requestsMu
+}
+
+// cancelledParams are the parameters of a notifications/cancelled message
+type cancelledParams struct {
+	RequestID interface{} `json:"requestId"`
+	Reason    string      `json:"reason,omitempty"`
+}
+
+// newConnectionContext returns a context carrying the state of a new
+// connection, which lives as long as the connection does
+func (s *MockeryMCPServer) newConnectionContext(ctx context.Context) context.Context {
+	conn := &connection{id: s.connectionIDs.Add(1)}
+	if s.toolCallRate > 0 {
+		conn.limiter = newTokenBucket(s.toolCallRate, s.toolCallBurst)
+	}
+	return context.WithValue(ctx, connectionKey{}, conn)
+}
+
+// connectionFrom returns the connection a request arrived on. Requests
+// handled outside a transport share an anonymous connection.
+func connectionFrom(ctx context.Context) *connection {
+	if conn, ok := ctx.Value(connectionKey{}).(*connection); ok {
+		return conn
+	}
+	return &connection{}
+}
+
+// newRequestKey returns the key of a request with the given ID on the
+// connection carried by ctx
+func newRequestKey(ctx context.Context, id interface{}) requestKey {
+	return requestKey{connection: connectionFrom(ctx).id, id: fmt.Sprintf("%T:%v", id, id)}
+}
+
+// trackRequest registers a request as in flight until finish is called, so
+// a cancellation notification can cancel the returned context. finish
+// reports whether the client cancelled the request.
+func (s *MockeryMCPServer) trackRequest(ctx context.Context, id interface{}) (context.Context, func() bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	key := newRequestKey(ctx, id)
+	request := &inflightRequest{cancel: cancel}
+
+	s.requestsMu.Lock()
+	s.requests[key] = request
+	s.requestsMu.Unlock()
+
+	return ctx, func() bool {
+		s.requestsMu.Lock()
+		defer s.requestsMu.Unlock()
+		if s.requests[key] == request {
+			delete(s.requests, key)
+		}
+		cancel()
+		return request.cancelled
+	}
+}
+
+// handleCancelled cancels the in-flight request named by a
+// notifications/cancelled message. Unknown or finished requests are
+// ignored, since the request may have completed while the notification was
+// in transit.
+func (s *MockeryMCPServer) handleCancelled(ctx context.Context, request *MCPRequest) *MCPResponse {
+	var params cancelledParams
+	paramsBytes, err := json.Marshal(request.Params)
+	if err == nil {
+		err = json.Unmarshal(paramsBytes, &params)
+	}
+	if err != nil || params.RequestID == nil {
+		s.requestLogger(ctx).Warn("Ignoring invalid cancellation", zap.Any("params", request.Params))
+		return nil
+	}
+
+	s.requestsMu.Lock()
+	inflight, ok := s.requests[newRequestKey(ctx, params.RequestID)]
+	if ok {
+		inflight.cancelled = true
+		inflight.cancel()
+	}
+	s.requestsMu.Unlock()
+
+	s.requestLogger(ctx).Info("Request cancelled by client",
+		zap.Any("request_id", params.RequestID),
+		zap.String("reason", params.Reason),
+		zap.Bool("in_flight", ok))
+	return nil
+}
+
+// serveConnection handles the requests returned by next in order, passing
+// each response to respond. Requests are read ahead while one is handled,
+// so a cancellation notification takes effect at once rather than after
+// the request it cancels. Server-initiated notifications go to notify,
+// possibly concurrently with respond. It returns next's first error, or
+// respond's.
+func (s *MockeryMCPServer) serveConnection(ctx context.Context, next func() (*MCPRequest, error), respond func(*MCPResponse) error, notify func(*MCPNotification) error) error {
+	ctx, cancel := context.WithCancel(s.newConnectionContext(ctx))
+	defer cancel()
+
+	conn := connectionFrom(ctx)
+	conn.notify = notify
+	defer s.stopWatches(conn)
+
+	queue := make(chan *MCPRequest, maxQueuedRequests)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(queue)
+		for {
+			request, err := next()
+			if err != nil {
+				readErr <- err
+				return
+			}
+			if request.Method == "notifications/cancelled" {
+				s.handleMCPRequest(ctx, request)
+				continue
+			}
+			select {
+			case queue <- request:
+			case <-ctx.Done():
+				readErr <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	for request := range queue {
+		response := s.handleMCPRequest(ctx, request)
+		// Notifications and cancelled requests get no response
+		if response == nil {
+			continue
+		}
+		if err := respond(response); err != nil {
+			return err
+		}
+	}
+	return <-readErr
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hangingMockeryScript signals that it started by creating $STARTED, then hangs
+const hangingMockeryScript = `#!/bin/sh
+if [ "$1" = "--version" ]; then
+  echo "v2.53.0"
+  exit 0
+fi
+touch "$STARTED"
+exec sleep 30
+`
+
+func TestMockeryMCPServer_CancelledRequest(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype UserRepository interface{ Get() error }\n")
+	started := filepath.Join(t.TempDir(), "started")
+	t.Setenv("STARTED", started)
+
+	s := newTestServer(t)
+	useFakeMockery(t, s, hangingMockeryScript)
+
+	in, input := io.Pipe()
+	output, out := io.Pipe()
+	served := make(chan error, 1)
+	go func() {
+		served <- s.ServeStdio(in, out)
+		out.Close()
+	}()
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		data, _ := io.ReadAll(output)
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			lines <- line
+		}
+	}()
+
+	send := func(message string) {
+		_, err := io.WriteString(input, message+"\n")
+		require.NoError(t, err)
+	}
+
+	begin := time.Now()
+	send(`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"generate_mock","arguments":{"interface_name":"UserRepository","package_path":"` + dir + `"}}}`)
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(started)
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond, "mockery never started")
+
+	send(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":5,"reason":"user aborted"}}`)
+	send(`{"jsonrpc":"2.0","id":6,"method":"ping"}`)
+	input.Close()
+
+	select {
+	case err := <-served:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("cancelled request kept running")
+	}
+	assert.Less(t, time.Since(begin), 10*time.Second)
+
+	// The cancelled request gets no response; later requests still do
+	var responses []string
+	for line := range lines {
+		responses = append(responses, line)
+	}
+	require.Len(t, responses, 1)
+	var response MCPResponse
+	require.NoError(t, json.Unmarshal([]byte(responses[0]), &response))
+	assert.Equal(t, float64(6), response.ID)
+	assert.Nil(t, response.Error)
+}
+
+func TestMockeryMCPServer_HandleCancelled(t *testing.T) {
+	s := newTestServer(t)
+	cancelNotification := func(id interface{}) *MCPRequest {
+		return &MCPRequest{JSONRPC: "2.0", Method: "notifications/cancelled", Params: map[string]interface{}{"requestId": id}}
+	}
+
+	first := s.newConnectionContext(context.Background())
+	second := s.newConnectionContext(context.Background())
+
+	ctx, finish := s.trackRequest(first, float64(1))
+	other, finishOther := s.trackRequest(second, float64(1))
+
+	// Notifications never get a response, whatever they name
+	assert.Nil(t, s.handleMCPRequest(first, cancelNotification("1")))
+	assert.Nil(t, s.handleMCPRequest(first, cancelNotification(float64(2))))
+	assert.Nil(t, s.handleMCPRequest(first, &MCPRequest{JSONRPC: "2.0", Method: "notifications/cancelled"}))
+	assert.NoError(t, ctx.Err())
+
+	assert.Nil(t, s.handleMCPRequest(first, cancelNotification(float64(1))))
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	assert.True(t, finish())
+
+	// Requests with the same ID on other connections are unaffected
+	assert.NoError(t, other.Err())
+	assert.False(t, finishOther())
+
+	s.requestsMu.Lock()
+	defer s.requestsMu.Unlock()
+	assert.Empty(t, s.requests, fmt.Sprintf("%v", s.requests))
+}
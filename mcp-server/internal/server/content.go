@@ -0,0 +1,36 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// mockContentResponse returns a generated mock's source alongside a short
+// summary, for requests that asked for content instead of a file
+func (s *MockeryMCPServer) mockContentResponse(requestID interface{}, request *types.MockGenerationRequest, result *types.MockGenerationResult) *MCPResponse {
+	text := fmt.Sprintf("Mock generated (not written to disk):\n- Interface: %s\n- Package: %s\n- Filename: %s",
+		request.InterfaceName,
+		request.PackagePath,
+		resolveMockFilename(request))
+	if result.MockeryVersion != "" {
+		text += fmt.Sprintf("\n- Mockery version: %s", result.MockeryVersion)
+	}
+
+	return &MCPResponse{
+		JSONRPC: "2.0",
+		ID:      requestID,
+		Result: map[string]interface{}{
+			"content": []map[string]interface{}{
+				{
+					"type": "text",
+					"text": text,
+				},
+				{
+					"type": "text",
+					"text": result.Content,
+				},
+			},
+		},
+	}
+}
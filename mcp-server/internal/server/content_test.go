@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// outputDirOf returns the --output argument mockery was run with
+func outputDirOf(t *testing.T, args []string) string {
+	t.Helper()
+	for _, arg := range args {
+		if output, ok := strings.CutPrefix(arg, "--output="); ok {
+			return output
+		}
+	}
+	require.Fail(t, "no --output argument", "%v", args)
+	return ""
+}
+
+func TestMockeryMCPServer_GenerateMock_ReturnContent(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype UserRepository interface{ Get() error }\n")
+
+	t.Run("returns the mock without writing it", func(t *testing.T) {
+		s := newTestServer(t)
+		lastArgs := recordingMockery(t, s)
+		outputDir := t.TempDir()
+
+		response := callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "UserRepository",
+			"package_path":   dir,
+			"output_dir":     outputDir,
+			"return_content": true,
+		})
+		require.Nil(t, response.Error)
+
+		content := response.Result.(map[string]interface{})["content"].([]map[string]interface{})
+		require.Len(t, content, 2)
+		assert.Contains(t, content[0]["text"], "not written to disk")
+		assert.Contains(t, content[0]["text"], "- Filename: mock_user_repository.go")
+		assert.Equal(t, "package mocks\n\n// MockUserRepository is a mock\ntype MockUserRepository struct{}\n", content[1]["text"])
+
+		// Nothing lands in the output directory and the scratch directory is gone
+		entries, err := os.ReadDir(outputDir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+		assert.NoDirExists(t, outputDirOf(t, lastArgs()))
+		assert.Empty(t, s.projectManager.GetGeneratedMocks(""))
+	})
+
+	t.Run("removes the scratch directory on failure", func(t *testing.T) {
+		s := newTestServer(t)
+		lastArgs := recordingMockery(t, s)
+		t.Setenv("FAKE_MOCKERY", writeFile(t, t.TempDir(), "failing-mockery", "#!/bin/sh\n[ \"$1\" = \"--version\" ] && exit 0\necho boom >&2\nexit 1\n"))
+
+		_, err := s.GenerateMock(context.Background(), &types.MockGenerationRequest{
+			InterfaceName: "UserRepository",
+			PackagePath:   dir,
+			ReturnContent: true,
+		})
+		require.ErrorIs(t, err, ErrGenerationFailed)
+		assert.NoDirExists(t, outputDirOf(t, lastArgs()))
+	})
+
+	t.Run("rejected for recursive generation", func(t *testing.T) {
+		s := newTestServer(t)
+
+		response := callTool(t, s, "generate_mock", map[string]interface{}{
+			"package_path":   dir,
+			"recursive":      true,
+			"return_content": true,
+		})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, -32602, response.Error.Code)
+	})
+}
@@ -0,0 +1,318 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// toolExample is a sample call of a tool and what it returns
+type toolExample struct {
+	Arguments map[string]interface{} `json:"arguments"`
+	Result    string                 `json:"result"`
+}
+
+// toolDescription is the describe_tool report
+type toolDescription struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	InputSchema interface{} `json:"inputSchema"`
+	Example     toolExample `json:"example"`
+}
+
+// toolExamples holds one example per tool. Tests check every tool has one
+// and that its arguments satisfy the tool's input schema.
+var toolExamples = map[string]toolExample{
+	"discover_interfaces": {
+		Arguments: map[string]interface{}{
+			"project_path":     "/workspace/myproject",
+			"exclude_patterns": []interface{}{"vendor/*"},
+		},
+		Result: "Text listing each interface with its package, file and method count, followed by the module path and a Version token to pass as if_none_match",
+	},
+	"generate_mock": {
+		Arguments: map[string]interface{}{
+			"interface_name": "UserRepository",
+			"package_path":   "github.com/example/myproject/internal/domain",
+			"output_dir":     "./mocks",
+			"with_expecter":  true,
+		},
+		Result: "Text naming the generated mock file, e.g. mocks/mock_user_repository.go",
+	},
+	"update_mockery_config": {
+		Arguments: map[string]interface{}{
+			"project_path": "/workspace/myproject",
+			"interfaces": map[string]interface{}{
+				"github.com/example/myproject/internal/domain": map[string]interface{}{
+					"UserRepository": map[string]interface{}{"dir": "mocks/domain"},
+				},
+			},
+		},
+		Result: "Text naming the config file written, with the number of global settings and interfaces set",
+	},
+	"validate_mockery_config": {
+		Arguments: map[string]interface{}{"project_path": "/workspace/myproject"},
+		Result:    "Text reporting the config as valid, or listing every problem found",
+	},
+	"get_interface_source": {
+		Arguments: map[string]interface{}{
+			"file_path":      "/workspace/myproject/internal/domain/user.go",
+			"interface_name": "UserRepository",
+		},
+		Result: "The interface declaration as written, including its doc comment",
+	},
+	"generate_expect_scaffold": {
+		Arguments: map[string]interface{}{
+			"file_path":      "/workspace/myproject/internal/domain/user.go",
+			"interface_name": "UserRepository",
+		},
+		Result: "Go source with one EXPECT() call per method, using matchers derived from the parameter types",
+	},
+	"interface_hash": {
+		Arguments: map[string]interface{}{
+			"file_path":      "/workspace/myproject/internal/domain/user.go",
+			"interface_name": "UserRepository",
+		},
+		Result: "The hex SHA-256 hash of the interface's method set",
+	},
+	"init_project": {
+		Arguments: map[string]interface{}{
+			"project_path": "/workspace/myproject",
+			"generate":     true,
+		},
+		Result: "Text listing the config written, the mocks directory created and any mocks generated",
+	},
+	"diff_interfaces": {
+		Arguments: map[string]interface{}{
+			"current_path": "/workspace/myproject",
+			"base_path":    "/tmp/myproject-main",
+		},
+		Result: "JSON listing interfaces added, removed and changed, with a snapshot of the current state to pass as baseline later",
+	},
+	"generate_from_config": {
+		Arguments: map[string]interface{}{"project_path": "/workspace/myproject"},
+		Result:    "Text listing the mocks generated for every interface in the mockery config, and any failures",
+	},
+	"format_file": {
+		Arguments: map[string]interface{}{
+			"file_path": "/workspace/myproject/internal/domain/user.go",
+			"dry_run":   true,
+		},
+		Result: "A unified diff of the formatting changes, or a note that the file is already formatted",
+	},
+	"lint_interfaces": {
+		Arguments: map[string]interface{}{
+			"project_path":  "/workspace/myproject",
+			"disable_rules": []interface{}{"repository-suffix"},
+		},
+		Result: "Text listing each violation with its rule, file and line",
+	},
+	"run_tests": {
+		Arguments: map[string]interface{}{
+			"project_path": "/workspace/myproject",
+			"packages":     []interface{}{"./internal/..."},
+			"format":       "json",
+		},
+		Result: "JSON with the pass, fail and skip result of every test, and the output of failing ones",
+	},
+	"scaffold_layout": {
+		Arguments: map[string]interface{}{
+			"project_path": "/workspace/myproject",
+			"package_name": "billing",
+		},
+		Result: "Text listing the files created: internal/billing with a starter interface, a .mockery.yaml and Makefile targets",
+	},
+	"export_interfaces": {
+		Arguments: map[string]interface{}{
+			"project_path": "/workspace/myproject",
+			"output_path":  "interfaces.json",
+		},
+		Result: "Text naming the file written and the number of interfaces exported",
+	},
+	"explain_generate": {
+		Arguments: map[string]interface{}{
+			"interface_name": "UserRepository",
+			"package_path":   "github.com/example/myproject/internal/domain",
+		},
+		Result: "Text showing the mockery command generate_mock would run and the file it would write",
+	},
+	"get_interface_methods": {
+		Arguments: map[string]interface{}{
+			"interface_name": "UserRepository",
+			"package_path":   "/workspace/myproject/internal/domain",
+		},
+		Result: "JSON listing each method's signature, parameters, returns and doc comment",
+	},
+	"interface_stats": {
+		Arguments: map[string]interface{}{
+			"project_path": "/workspace/myproject",
+			"interfaces":   []interface{}{"*Repository"},
+		},
+		Result: "JSON array of per-interface counts and complexity, most complex first",
+	},
+	"create_project": {
+		Arguments: map[string]interface{}{
+			"name": "myproject",
+			"path": "/workspace/myproject",
+		},
+		Result: "Text with the ID of the new project, to pass as project_id",
+	},
+	"get_project": {
+		Arguments: map[string]interface{}{"project_id": "20250101120000-a1b2c3d4"},
+		Result:    "JSON with the project and the mocks generated for it",
+	},
+	"get_job": {
+		Arguments: map[string]interface{}{"job_id": "20250101120000-a1b2c3d4"},
+		Result:    "JSON with the job's status (pending, running, completed, failed or cancelled) and, once finished, its generation result",
+	},
+	"list_projects": {
+		Arguments: map[string]interface{}{},
+		Result:    "JSON array of the projects created with create_project",
+	},
+	"watch_project": {
+		Arguments: map[string]interface{}{
+			"project_path": "/workspace/myproject",
+			"debounce_ms":  1000,
+		},
+		Result: "Text with a watch ID; changes then arrive as notifications/interfaces/changed",
+	},
+	"unwatch_project": {
+		Arguments: map[string]interface{}{"watch_id": "watch-1"},
+		Result:    "Text confirming the watch was stopped",
+	},
+	"suggest_mock_setup": {
+		Arguments: map[string]interface{}{
+			"file_path":      "/workspace/myproject/internal/domain/user.go",
+			"interface_name": "UserRepository",
+			"method_name":    "GetByID",
+			"scenario":       scenarioError,
+		},
+		Result: "Go source constructing the mock and setting up an EXPECT() call that returns an error",
+	},
+	"audit_config": {
+		Arguments: map[string]interface{}{"project_path": "/workspace/myproject"},
+		Result:    "Text listing configured interfaces that no longer exist, with suggested removals or renames",
+	},
+	"generate_testify_mock": {
+		Arguments: map[string]interface{}{
+			"file_path":      "/workspace/myproject/internal/domain/user.go",
+			"interface_name": "UserRepository",
+		},
+		Result: "gofmt-formatted Go source of a testify mock, to be saved by the caller",
+	},
+	"generate_mock_if_changed": {
+		Arguments: map[string]interface{}{
+			"interface_name": "UserRepository",
+			"package_path":   "github.com/example/myproject/internal/domain",
+			"output_dir":     "./mocks",
+		},
+		Result: "Text saying the mock is up to date, or that it was regenerated and why",
+	},
+	"generate_func_mock": {
+		Arguments: map[string]interface{}{
+			"function_name": "HandlerFunc",
+			"package_path":  "github.com/example/myproject/internal/events",
+			"output_dir":    "./mocks",
+		},
+		Result: "Text naming the generated mock file, e.g. mocks/mock_handler_func.go",
+	},
+	"clean_mocks": {
+		Arguments: map[string]interface{}{
+			"project_path": "/workspace/myproject",
+			"output_dir":   "mocks",
+			"dry_run":      false,
+		},
+		Result: "Text listing the generated mocks whose interface no longer exists, which are only deleted when dry_run is false",
+	},
+	"project_info": {
+		Arguments: map[string]interface{}{"project_path": "/workspace/myproject"},
+		Result:    "JSON with the module root and path, the go and toolchain directives, the required modules and the version go version reports",
+	},
+	"apply_mock_to_test": {
+		Arguments: map[string]interface{}{
+			"interface_name": "UserRepository",
+			"package_path":   "github.com/example/myproject/internal/domain",
+			"test_file":      "/workspace/myproject/internal/service/user_service_test.go",
+			"output_dir":     "./mocks",
+			"confirm":        true,
+		},
+		Result: "Text naming the generated mock, the test file lines now returning it and the backup of the test file",
+	},
+	"show_mockery_config": {
+		Arguments: map[string]interface{}{"project_path": "/workspace/myproject"},
+		Result:    "JSON with the config path, a count of configured packages and interfaces, the parsed config and the raw YAML",
+	},
+	"find_structural_duplicates": {
+		Arguments: map[string]interface{}{"project_path": "/workspace/myproject"},
+		Result:    "Text listing each group of interfaces sharing a method set, with their files",
+	},
+	"mock_status": {
+		Arguments: map[string]interface{}{"project_path": "/workspace/myproject"},
+		Result:    "JSON listing each interface with has_mock and, for mocked ones, the mock file and generation time",
+	},
+	"preview_scan": {
+		Arguments: map[string]interface{}{"project_path": "/workspace/myproject"},
+		Result:    "JSON with the number of Go files, their total bytes, the directories walked and any warning that the scan would be large",
+	},
+	"describe_tool": {
+		Arguments: map[string]interface{}{"tool_name": "generate_mock"},
+		Result:    "JSON with the tool's description, input schema and an example call",
+	},
+	"list_packages": {
+		Arguments: map[string]interface{}{"project_path": "/workspace/myproject"},
+		Result:    "Text listing each package's import path, directory and file count",
+	},
+	"scan_package": {
+		Arguments: map[string]interface{}{
+			"import_path":  "github.com/example/myproject/internal/domain",
+			"project_path": "/workspace/myproject",
+		},
+		Result: "Text listing the interfaces declared in the package",
+	},
+	"find_unused_interfaces": {
+		Arguments: map[string]interface{}{"project_path": "/workspace/myproject"},
+		Result:    "Text listing interfaces never referenced in the project",
+	},
+	"regenerate_mocks": {
+		Arguments: map[string]interface{}{"project_id": "20250101120000-a1b2c3d4"},
+		Result:    "Text listing each mock regenerated and any failures",
+	},
+	"reload_config": {
+		Arguments: map[string]interface{}{},
+		Result:    "Text listing the settings that changed",
+	},
+}
+
+// handleDescribeTool implements the describe_tool tool
+func (s *MockeryMCPServer) handleDescribeTool(requestID interface{}, args map[string]interface{}) *MCPResponse {
+	name, ok := args["tool_name"].(string)
+	if !ok || name == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid tool_name", nil)
+	}
+
+	// Tools hidden by read-only mode can't be described either
+	tools := s.availableTools(toolDefinitions())
+	names := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		if tool.Name != name {
+			names = append(names, tool.Name)
+			continue
+		}
+		report, err := json.MarshalIndent(toolDescription{
+			Name:        tool.Name,
+			Description: tool.Description,
+			InputSchema: tool.InputSchema,
+			Example:     toolExamples[tool.Name],
+		}, "", "  ")
+		if err != nil {
+			return s.toolErrorResponse(requestID, "Failed to encode tool description", err)
+		}
+		return s.textResponse(requestID, string(report))
+	}
+
+	detail := fmt.Sprintf("no tool named %s", name)
+	if suggestions := similarNames(name, names); len(suggestions) > 0 {
+		detail += fmt.Sprintf(" (found: %s)", strings.Join(suggestions, ", "))
+	}
+	return s.errorResponse(requestID, -32602, "Invalid tool_name", detail)
+}
@@ -0,0 +1,185 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonValue round-trips v through JSON, so schemas and arguments are checked
+// as a client would see them
+func jsonValue(t *testing.T, v interface{}) interface{} {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	var value interface{}
+	require.NoError(t, json.Unmarshal(data, &value))
+	return value
+}
+
+// schemaViolations checks value against the subset of JSON Schema the tool
+// schemas use: type, properties, required, items, enum and minimum.
+// Properties a schema doesn't declare are violations too, so examples
+// can't use arguments a tool doesn't accept.
+func schemaViolations(schema map[string]interface{}, value interface{}, path string) []string {
+	var violations []string
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return []string{path + ": want an object"}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		if properties == nil {
+			// Free-form objects such as mockery settings
+			return nil
+		}
+		required, _ := schema["required"].([]interface{})
+		for _, name := range required {
+			if _, ok := object[name.(string)]; !ok {
+				violations = append(violations, fmt.Sprintf("%s: missing required %s", path, name))
+			}
+		}
+		for name, property := range object {
+			propertySchema, ok := properties[name].(map[string]interface{})
+			if !ok {
+				violations = append(violations, fmt.Sprintf("%s: unknown property %s", path, name))
+				continue
+			}
+			violations = append(violations, schemaViolations(propertySchema, property, path+"."+name)...)
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return []string{path + ": want an array"}
+		}
+		itemSchema, _ := schema["items"].(map[string]interface{})
+		for i, item := range items {
+			violations = append(violations, schemaViolations(itemSchema, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return []string{path + ": want a string"}
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return []string{path + ": want a boolean"}
+		}
+	case "integer":
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			return []string{path + ": want an integer"}
+		}
+		if minimum, ok := schema["minimum"].(float64); ok && number < minimum {
+			violations = append(violations, fmt.Sprintf("%s: %v is below the minimum %v", path, number, minimum))
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			found = found || allowed == value
+		}
+		if !found {
+			violations = append(violations, fmt.Sprintf("%s: %v is not one of %v", path, value, enum))
+		}
+	}
+	return violations
+}
+
+func TestSchemaViolations(t *testing.T) {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name":  map[string]interface{}{"type": "string"},
+			"depth": map[string]interface{}{"type": "integer", "minimum": 0},
+			"mode":  map[string]interface{}{"type": "string", "enum": []string{"a", "b"}},
+			"tags":  map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+		},
+		"required": []string{"name"},
+	}
+	check := func(args map[string]interface{}) []string {
+		return schemaViolations(jsonValue(t, schema).(map[string]interface{}), jsonValue(t, args), "arguments")
+	}
+
+	assert.Empty(t, check(map[string]interface{}{"name": "x", "depth": 2, "mode": "b", "tags": []string{"t"}}))
+	assert.ElementsMatch(t, []string{
+		"arguments: missing required name",
+		"arguments: unknown property other",
+		"arguments.depth: -1 is below the minimum 0",
+		"arguments.mode: c is not one of [a b]",
+		"arguments.tags[0]: want a string",
+	}, check(map[string]interface{}{"other": true, "depth": -1, "mode": "c", "tags": []interface{}{1}}))
+}
+
+func TestToolExamples_MatchInputSchemas(t *testing.T) {
+	tools := toolDefinitions()
+	names := make(map[string]bool, len(tools))
+	for _, tool := range tools {
+		names[tool.Name] = true
+		example, ok := toolExamples[tool.Name]
+		if !assert.True(t, ok, "tool %s has no example", tool.Name) {
+			continue
+		}
+		assert.NotEmpty(t, example.Result, "tool %s example has no result", tool.Name)
+
+		schema := jsonValue(t, tool.InputSchema).(map[string]interface{})
+		assert.Empty(t, schemaViolations(schema, jsonValue(t, example.Arguments), "arguments"),
+			"tool %s example doesn't match its input schema", tool.Name)
+	}
+	for name := range toolExamples {
+		assert.True(t, names[name], "example for unknown tool %s", name)
+	}
+}
+
+func TestMockeryMCPServer_DescribeTool(t *testing.T) {
+	s := newTestServer(t)
+
+	t.Run("describes a tool", func(t *testing.T) {
+		text := responseText(t, callTool(t, s, "describe_tool", map[string]interface{}{"tool_name": "generate_mock"}))
+
+		var description struct {
+			Name        string                 `json:"name"`
+			Description string                 `json:"description"`
+			InputSchema map[string]interface{} `json:"inputSchema"`
+			Example     struct {
+				Arguments map[string]interface{} `json:"arguments"`
+				Result    string                 `json:"result"`
+			} `json:"example"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(text), &description))
+		assert.Equal(t, "generate_mock", description.Name)
+		assert.Equal(t, "Generate mock using Mockery tool", description.Description)
+		assert.Contains(t, description.InputSchema["properties"], "interface_name")
+		assert.Equal(t, "UserRepository", description.Example.Arguments["interface_name"])
+		assert.NotEmpty(t, description.Example.Result)
+	})
+
+	t.Run("unknown tool suggests similar names", func(t *testing.T) {
+		response := callTool(t, s, "describe_tool", map[string]interface{}{"tool_name": "generate_mocks"})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, -32602, response.Error.Code)
+		assert.Equal(t, "Invalid tool_name", response.Error.Message)
+		assert.Contains(t, response.Error.Data, "(found: generate_mock")
+	})
+
+	t.Run("missing tool_name", func(t *testing.T) {
+		response := callTool(t, s, "describe_tool", map[string]interface{}{})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, "Missing or invalid tool_name", response.Error.Message)
+	})
+
+	t.Run("read-only mode hides mutating tools", func(t *testing.T) {
+		s := newTestServer(t)
+		s.SetReadOnly(true)
+		response := callTool(t, s, "describe_tool", map[string]interface{}{"tool_name": "generate_mock"})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, "Invalid tool_name", response.Error.Message)
+
+		responseText(t, callTool(t, s, "describe_tool", map[string]interface{}{"tool_name": "discover_interfaces"}))
+	})
+}
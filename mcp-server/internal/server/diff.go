@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+)
+
+// InterfaceChange describes an interface that differs between two scans
+type InterfaceChange struct {
+	Interface string `json:"interface"`
+	FilePath  string `json:"file_path,omitempty"`
+	OldHash   string `json:"old_hash,omitempty"`
+	NewHash   string `json:"new_hash,omitempty"`
+}
+
+// InterfaceDiff is the result of comparing two scans. Snapshot maps every
+// interface of the current scan to its signature hash and can be passed back
+// as a later comparison's baseline.
+type InterfaceDiff struct {
+	Added     []InterfaceChange `json:"added"`
+	Removed   []InterfaceChange `json:"removed"`
+	Changed   []InterfaceChange `json:"changed"`
+	Unchanged int               `json:"unchanged"`
+	Snapshot  map[string]string `json:"snapshot"`
+}
+
+// scannedInterface is an interface's signature hash and location
+type scannedInterface struct {
+	hash     string
+	filePath string
+}
+
+// handleDiffInterfaces implements the diff_interfaces tool
+func (s *MockeryMCPServer) handleDiffInterfaces(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	currentPath, ok := args["current_path"].(string)
+	if !ok || currentPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid current_path", nil)
+	}
+
+	var base map[string]scannedInterface
+	if basePath, ok := args["base_path"].(string); ok && basePath != "" {
+		scanned, err := s.scanInterfaceHashes(ctx, basePath)
+		if err != nil {
+			return s.toolErrorResponse(requestID, "Failed to scan base_path", err)
+		}
+		base = scanned
+	} else if baseline, ok := args["baseline"].(map[string]interface{}); ok {
+		base = make(map[string]scannedInterface, len(baseline))
+		for key, value := range baseline {
+			hash, ok := value.(string)
+			if !ok {
+				return s.errorResponse(requestID, -32602, "Invalid baseline", fmt.Sprintf("hash for %s must be a string", key))
+			}
+			base[key] = scannedInterface{hash: hash}
+		}
+	} else {
+		return s.errorResponse(requestID, -32602, "Missing base_path or baseline", nil)
+	}
+
+	current, err := s.scanInterfaceHashes(ctx, currentPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to scan current_path", err)
+	}
+
+	data, err := json.MarshalIndent(diffInterfaces(base, current), "", "  ")
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to encode diff", err)
+	}
+	return s.textResponse(requestID, string(data))
+}
+
+// scanInterfaceHashes scans a project and keys each interface's signature
+// hash by a location-independent name, so two checkouts of the same project
+// can be compared. The scan stops as soon as ctx is done, returning
+// ctx.Err().
+func (s *MockeryMCPServer) scanInterfaceHashes(ctx context.Context, projectPath string) (map[string]scannedInterface, error) {
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path %s: %w", projectPath, err)
+	}
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrPathNotFound, absPath)
+	}
+
+	interfaces, _, err := s.scanner.ScanProjectContext(ctx, absPath, scanner.ScanOptions{})
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return nil, err
+	}
+	if err != nil {
+		s.logger.Error("Failed to scan project", zap.String("path", absPath), zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrScanFailed, err)
+	}
+
+	moduleRoot, modulePath := scanner.FindModule(absPath)
+	hashes := make(map[string]scannedInterface, len(interfaces))
+	for _, iface := range interfaces {
+		dir := filepath.Dir(iface.FilePath)
+		var key string
+		if modulePath != "" {
+			key = packagePath(dir, moduleRoot, modulePath) + "." + iface.Name
+		} else if rel, err := filepath.Rel(absPath, dir); err == nil && rel != "." {
+			key = filepath.ToSlash(rel) + "." + iface.Name
+		} else {
+			key = iface.Name
+		}
+		hashes[key] = scannedInterface{hash: scanner.InterfaceSignatureHash(iface), filePath: iface.FilePath}
+	}
+	return hashes, nil
+}
+
+// diffInterfaces compares two sets of interface hashes, sorting each kind of
+// change by interface name
+func diffInterfaces(base, current map[string]scannedInterface) *InterfaceDiff {
+	diff := &InterfaceDiff{
+		Added:    []InterfaceChange{},
+		Removed:  []InterfaceChange{},
+		Changed:  []InterfaceChange{},
+		Snapshot: make(map[string]string, len(current)),
+	}
+
+	for key, now := range current {
+		diff.Snapshot[key] = now.hash
+		before, ok := base[key]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, InterfaceChange{Interface: key, FilePath: now.filePath, NewHash: now.hash})
+		case before.hash != now.hash:
+			diff.Changed = append(diff.Changed, InterfaceChange{Interface: key, FilePath: now.filePath, OldHash: before.hash, NewHash: now.hash})
+		default:
+			diff.Unchanged++
+		}
+	}
+	for key, before := range base {
+		if _, ok := current[key]; !ok {
+			diff.Removed = append(diff.Removed, InterfaceChange{Interface: key, FilePath: before.filePath, OldHash: before.hash})
+		}
+	}
+
+	for _, changes := range [][]InterfaceChange{diff.Added, diff.Removed, diff.Changed} {
+		sort.Slice(changes, func(i, j int) bool {
+			return changes[i].Interface < changes[j].Interface
+		})
+	}
+	return diff
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_DiffInterfaces(t *testing.T) {
+	base := t.TempDir()
+	writeFile(t, base, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	writeFile(t, base, "domain/repo.go", "package domain\n\ntype Repo interface{ Get(id string) error }\n\ntype Cache interface{ Flush() }\n\ntype Legacy interface{ Old() }\n")
+
+	current := t.TempDir()
+	writeFile(t, current, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	writeFile(t, current, "domain/repo.go", `package domain
+
+// Repo gained a comment, which doesn't count as a change
+type Repo interface{ Get(key string) error }
+
+type Cache interface{ Flush() error }
+`)
+	writeFile(t, current, "notify/sender.go", "package notify\n\ntype Sender interface{ Send() error }\n")
+
+	s := newTestServer(t)
+
+	diffOf := func(t *testing.T, args map[string]interface{}) InterfaceDiff {
+		t.Helper()
+		var diff InterfaceDiff
+		require.NoError(t, json.Unmarshal([]byte(responseText(t, callTool(t, s, "diff_interfaces", args))), &diff))
+		return diff
+	}
+
+	diff := diffOf(t, map[string]interface{}{
+		"base_path":    base,
+		"current_path": current,
+	})
+
+	require.Len(t, diff.Added, 1)
+	assert.Equal(t, "example.com/app/notify.Sender", diff.Added[0].Interface)
+	assert.Equal(t, filepath.Join(current, "notify", "sender.go"), diff.Added[0].FilePath)
+
+	require.Len(t, diff.Removed, 1)
+	assert.Equal(t, "example.com/app/domain.Legacy", diff.Removed[0].Interface)
+
+	require.Len(t, diff.Changed, 1)
+	assert.Equal(t, "example.com/app/domain.Cache", diff.Changed[0].Interface)
+	assert.NotEqual(t, diff.Changed[0].OldHash, diff.Changed[0].NewHash)
+
+	assert.Equal(t, 1, diff.Unchanged)
+	assert.Len(t, diff.Snapshot, 3)
+
+	t.Run("against a snapshot", func(t *testing.T) {
+		baseline := make(map[string]interface{})
+		for key, hash := range diff.Snapshot {
+			baseline[key] = hash
+		}
+
+		diff := diffOf(t, map[string]interface{}{
+			"baseline":     baseline,
+			"current_path": current,
+		})
+		assert.Empty(t, diff.Added)
+		assert.Empty(t, diff.Removed)
+		assert.Empty(t, diff.Changed)
+		assert.Equal(t, 3, diff.Unchanged)
+	})
+
+	t.Run("requires a baseline", func(t *testing.T) {
+		response := callTool(t, s, "diff_interfaces", map[string]interface{}{
+			"current_path": current,
+		})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, -32602, response.Error.Code)
+	})
+}
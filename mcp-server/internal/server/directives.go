@@ -0,0 +1,41 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// applyDirectives fills in the settings a request leaves unset from the
+// comment directives on the interface it mocks. Arguments given explicitly
+// always win, and boolean directives can only enable an option. A relative
+// dir is resolved from the package directory, where go generate runs.
+func (s *MockeryMCPServer) applyDirectives(ctx context.Context, request *types.MockGenerationRequest, directives map[string]string, source *mockSource) *types.MockGenerationRequest {
+	if len(directives) == 0 {
+		return request
+	}
+
+	s.requestLogger(ctx).Debug("Applying interface directives",
+		zap.String("interface", request.InterfaceName), zap.Any("directives", directives))
+
+	applied := *request
+	if dir := directives["dir"]; applied.OutputDir == "" && dir != "" {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(source.dir, dir)
+		}
+		applied.OutputDir = dir
+	}
+	if applied.FilenameFormat == "" {
+		applied.FilenameFormat = directives["filename"]
+	}
+	if applied.MockName == "" {
+		applied.MockName = directives["mockname"]
+	}
+	applied.WithExpector = applied.WithExpector || directives["with-expecter"] == "true"
+	applied.InPackage = applied.InPackage || directives["inpackage"] == "true"
+	applied.TestOnly = applied.TestOnly || directives["testonly"] == "true"
+	return &applied
+}
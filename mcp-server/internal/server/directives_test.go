@@ -0,0 +1,74 @@
+package server
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockeryMCPServer_GenerateMock_Directives(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "store.go", `package store
+
+// Store persists values
+//
+//mockery:dir ./testdata/mocks
+//mockery:filename store_mock.go
+//mockery:mockname FakeStore
+//mockery:outpkg storemocks
+type Store interface {
+	Get(key string) (string, error)
+}
+
+type Plain interface {
+	Get() error
+}
+`)
+
+	t.Run("directives set defaults", func(t *testing.T) {
+		s := newTestServer(t)
+		lastArgs := recordingMockery(t, s)
+
+		text := responseText(t, callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "Store",
+			"package_path":   dir,
+		}))
+		assert.Contains(t, text, filepath.Join(dir, "testdata", "mocks", "store_mock.go"))
+
+		args := lastArgs()
+		assert.Contains(t, args, "--output="+filepath.Join(dir, "testdata", "mocks"))
+		assert.Contains(t, args, "--filename=store_mock.go")
+		assert.Contains(t, args, "--mockname=FakeStore")
+		assert.Contains(t, args, "--outpkg=storemocks")
+	})
+
+	t.Run("arguments override directives", func(t *testing.T) {
+		s := newTestServer(t)
+		lastArgs := recordingMockery(t, s)
+		output := t.TempDir()
+
+		responseText(t, callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "Store",
+			"package_path":   dir,
+			"output_dir":     output,
+			"mock_name":      "StoreMock",
+		}))
+
+		args := lastArgs()
+		assert.Contains(t, args, "--output="+output)
+		assert.Contains(t, args, "--filename=store_mock.go")
+		assert.Contains(t, args, "--mockname=StoreMock")
+	})
+
+	t.Run("interfaces without directives", func(t *testing.T) {
+		s := newTestServer(t)
+		lastArgs := recordingMockery(t, s)
+
+		responseText(t, callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "Plain",
+			"package_path":   dir,
+		}))
+		assert.Contains(t, lastArgs(), "--output="+filepath.Join(dir, "mocks"))
+	})
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// maxDiscoveryVersions caps the discovery versions remembered; past it, the
+// least recently used one is forgotten and its next discovery rescans
+const maxDiscoveryVersions = 256
+
+// discoveryVersion pairs the source fingerprint a scan saw with the version
+// token computed from its results
+type discoveryVersion struct {
+	key         string
+	fingerprint string
+	version     string
+}
+
+// sourceFingerprint hashes the path, size and modification time of every
+// file discovery would parse. It only stats files, so an unchanged tree can
+// be recognized without re-parsing it. Like the scan, it skips entries it
+// can't read, failing only when the root itself can't be read. The walk
+// stops as soon as ctx is done, returning ctx.Err().
+func sourceFingerprint(ctx context.Context, root string) (string, error) {
+	hash := sha256.New()
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			if path == root {
+				return err
+			}
+			if info != nil && info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		// Mirror the files ScanProject considers
+		if !scanner.IsSourceFile(root, path, false) {
+			return nil
+		}
+		fmt.Fprintf(hash, "%s\x00%d\x00%d\n", path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// computeDiscoveryVersion derives the version token for a discovery result
+// from the sorted interface names, the source fingerprint (which covers
+// mtimes) and the exclude patterns applied
+func computeDiscoveryVersion(fingerprint string, interfaces []types.InterfaceDefinition, patterns []string) string {
+	names := make([]string, len(interfaces))
+	for i, iface := range interfaces {
+		names[i] = filepath.Dir(iface.FilePath) + "." + iface.Name
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%s\n%s", fingerprint, strings.Join(names, "\n"), strings.Join(patterns, "\n"))
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// discoveryVersionKey identifies a discovery by its root and exclude patterns
+func discoveryVersionKey(root string, patterns []string) string {
+	return root + "\x00" + strings.Join(patterns, "\x00")
+}
+
+// cachedDiscoveryVersion returns the version of the last discovery for key
+// if the source fingerprint is unchanged since then
+func (s *MockeryMCPServer) cachedDiscoveryVersion(key, fingerprint string) (string, bool) {
+	s.discoveryMu.Lock()
+	defer s.discoveryMu.Unlock()
+
+	element, ok := s.discoveryVersions[key]
+	if !ok {
+		return "", false
+	}
+	s.discoveryOrder.MoveToFront(element)
+	cached := element.Value.(*discoveryVersion)
+	if cached.fingerprint != fingerprint {
+		return "", false
+	}
+	return cached.version, true
+}
+
+// storeDiscoveryVersion records the version of a completed discovery,
+// evicting the least recently used one once maxDiscoveryVersions are held
+func (s *MockeryMCPServer) storeDiscoveryVersion(key, fingerprint, version string) {
+	s.discoveryMu.Lock()
+	defer s.discoveryMu.Unlock()
+
+	if s.discoveryVersions == nil {
+		s.discoveryVersions = make(map[string]*list.Element)
+		s.discoveryOrder = list.New()
+	}
+	if element, ok := s.discoveryVersions[key]; ok {
+		element.Value = &discoveryVersion{key: key, fingerprint: fingerprint, version: version}
+		s.discoveryOrder.MoveToFront(element)
+		return
+	}
+
+	s.discoveryVersions[key] = s.discoveryOrder.PushFront(&discoveryVersion{key: key, fingerprint: fingerprint, version: version})
+	if s.discoveryOrder.Len() > maxDiscoveryVersions {
+		oldest := s.discoveryOrder.Back()
+		s.discoveryOrder.Remove(oldest)
+		delete(s.discoveryVersions, oldest.Value.(*discoveryVersion).key)
+	}
+}
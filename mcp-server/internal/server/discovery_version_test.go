@@ -0,0 +1,106 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var versionLine = regexp.MustCompile(`Version: ([0-9a-f]+)`)
+
+// discoveryVersionOf extracts the version token from a discovery response
+func discoveryVersionOf(t *testing.T, text string) string {
+	t.Helper()
+	match := versionLine.FindStringSubmatch(text)
+	require.NotNil(t, match, "no version in %q", text)
+	return match[1]
+}
+
+func TestMockeryMCPServer_DiscoverIfNoneMatch(t *testing.T) {
+	dir := t.TempDir()
+	repoFile := writeFile(t, dir, "repo.go", "package repo\n\ntype UserRepository interface{ Get() error }\n")
+	writeFile(t, dir, "domain/orders.go", "package domain\n\ntype Orders interface{ List() error }\n")
+
+	s := newTestServer(t)
+	discover := func(args map[string]interface{}) string {
+		args["project_path"] = dir
+		return responseText(t, callTool(t, s, "discover_interfaces", args))
+	}
+
+	first := discover(map[string]interface{}{})
+	version := discoveryVersionOf(t, first)
+	assert.Contains(t, first, "UserRepository")
+
+	t.Run("unchanged", func(t *testing.T) {
+		text := discover(map[string]interface{}{"if_none_match": version})
+		assert.Contains(t, text, "Not modified")
+		assert.Equal(t, version, discoveryVersionOf(t, text))
+		assert.NotContains(t, text, "UserRepository")
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		text := discover(map[string]interface{}{"if_none_match": "0123456789abcdef"})
+		assert.NotContains(t, text, "Not modified")
+		assert.Equal(t, version, discoveryVersionOf(t, text))
+	})
+
+	t.Run("different excludes", func(t *testing.T) {
+		text := discover(map[string]interface{}{
+			"if_none_match":      version,
+			"exclude_interfaces": []interface{}{"User*"},
+		})
+		assert.NotContains(t, text, "Not modified")
+		assert.NotEqual(t, version, discoveryVersionOf(t, text))
+	})
+
+	t.Run("different depth", func(t *testing.T) {
+		text := discover(map[string]interface{}{
+			"if_none_match": version,
+			"max_depth":     float64(1),
+		})
+		assert.NotContains(t, text, "Not modified")
+		assert.NotContains(t, text, "Orders")
+		assert.NotEqual(t, version, discoveryVersionOf(t, text))
+
+		// The depth-limited query keeps its own entry
+		text = discover(map[string]interface{}{"if_none_match": version})
+		assert.Contains(t, text, "Not modified")
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(repoFile, []byte("package repo\n\ntype UserRepository interface{ Get() error }\n\ntype Cache interface{ Get() error }\n"), 0644))
+		later := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(repoFile, later, later))
+
+		text := discover(map[string]interface{}{"if_none_match": version})
+		assert.NotContains(t, text, "Not modified")
+		assert.Contains(t, text, "Cache")
+		assert.NotEqual(t, version, discoveryVersionOf(t, text))
+	})
+}
+
+func TestMockeryMCPServer_DiscoveryVersionEviction(t *testing.T) {
+	s := newTestServer(t)
+
+	for i := 0; i < maxDiscoveryVersions; i++ {
+		s.storeDiscoveryVersion(fmt.Sprintf("root-%d", i), "fingerprint", "version")
+	}
+	// Using the oldest entry keeps it over the next one in line
+	_, ok := s.cachedDiscoveryVersion("root-0", "fingerprint")
+	require.True(t, ok)
+
+	s.storeDiscoveryVersion("root-new", "fingerprint", "version")
+	assert.Len(t, s.discoveryVersions, maxDiscoveryVersions)
+
+	_, ok = s.cachedDiscoveryVersion("root-0", "fingerprint")
+	assert.True(t, ok)
+	_, ok = s.cachedDiscoveryVersion("root-1", "fingerprint")
+	assert.False(t, ok)
+	_, ok = s.cachedDiscoveryVersion("root-new", "fingerprint")
+	assert.True(t, ok)
+}
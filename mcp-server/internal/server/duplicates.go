@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// structuralDuplicates groups interfaces by signature hash, which covers
+// method names, parameter and return types and embedded interfaces but not
+// the interface's own name. Only groups of two or more are returned, largest
+// first, each in file order. Empty interfaces are left out, since every one
+// of them would match.
+func structuralDuplicates(interfaces []types.InterfaceDefinition) [][]types.InterfaceDefinition {
+	groups := make(map[string][]types.InterfaceDefinition)
+	var hashes []string
+	for _, iface := range interfaces {
+		if len(iface.Methods) == 0 && len(iface.Embeds) == 0 {
+			continue
+		}
+		hash := scanner.InterfaceSignatureHash(iface)
+		if _, ok := groups[hash]; !ok {
+			hashes = append(hashes, hash)
+		}
+		groups[hash] = append(groups[hash], iface)
+	}
+
+	var duplicates [][]types.InterfaceDefinition
+	for _, hash := range hashes {
+		group := groups[hash]
+		if len(group) < 2 {
+			continue
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			if group[i].FilePath != group[j].FilePath {
+				return group[i].FilePath < group[j].FilePath
+			}
+			return group[i].LineNumber < group[j].LineNumber
+		})
+		duplicates = append(duplicates, group)
+	}
+	sort.SliceStable(duplicates, func(i, j int) bool { return len(duplicates[i]) > len(duplicates[j]) })
+	return duplicates
+}
+
+// handleFindStructuralDuplicates implements the find_structural_duplicates tool
+func (s *MockeryMCPServer) handleFindStructuralDuplicates(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	projectPath, ok := args["project_path"].(string)
+	if !ok || projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid project_path", nil)
+	}
+
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", projectPath), err)
+	}
+
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Project path does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	interfaces, _, err := s.scanner.ScanProjectContext(ctx, absPath, scanner.ScanOptions{})
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return s.toolErrorResponse(requestID, "Scan cancelled", err)
+	}
+	if err != nil {
+		s.requestLogger(ctx).Error("Failed to scan project", zap.String("path", absPath), zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to scan project", fmt.Errorf("%w: %v", ErrScanFailed, err))
+	}
+	interfaces = excludeInterfaces(interfaces, s.config().ExcludeInterfaces)
+
+	duplicates := structuralDuplicates(interfaces)
+	if len(duplicates) == 0 {
+		return s.textResponse(requestID, fmt.Sprintf("No structurally identical interfaces found in %s", absPath))
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "Found %d groups of interfaces with identical method sets in %s:\n", len(duplicates), absPath)
+	for i, group := range duplicates {
+		methods := make([]string, len(group[0].Methods))
+		for j, method := range group[0].Methods {
+			methods[j] = method.Name
+		}
+		sort.Strings(methods)
+		fmt.Fprintf(&text, "\n%d. %d interfaces with methods %s\n", i+1, len(group), strings.Join(methods, ", "))
+		for _, iface := range group {
+			fmt.Fprintf(&text, "- %s (%s package)\n  File: %s:%d\n", iface.Name, iface.Package, iface.FilePath, iface.LineNumber)
+		}
+	}
+
+	return s.textResponse(requestID, strings.TrimSuffix(text.String(), "\n"))
+}
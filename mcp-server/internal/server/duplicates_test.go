@@ -0,0 +1,77 @@
+package server
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockeryMCPServer_FindStructuralDuplicates(t *testing.T) {
+	dir := t.TempDir()
+	repo := writeFile(t, dir, "repo/repo.go", `package repo
+
+import "context"
+
+type UserStore interface {
+	Get(ctx context.Context, id string) (string, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// Same methods, different parameter names and order
+type AccountStore interface {
+	Delete(c context.Context, key string) error
+	Get(c context.Context, key string) (string, error)
+}
+
+type Reader interface{ Get(ctx context.Context, id string) (string, error) }
+
+type Marker interface{}
+`)
+	cache := writeFile(t, dir, "cache/cache.go", `package cache
+
+import "io"
+
+type Closer interface{ Close() error }
+
+// Same methods, but different embedded interfaces
+type Source interface {
+	io.Reader
+	Flush() error
+}
+
+type Sink interface {
+	io.Writer
+	Flush() error
+}
+
+type Releaser interface{ Release() error }
+
+type Marker interface{}
+`)
+
+	s := newTestServer(t)
+	text := responseText(t, callTool(t, s, "find_structural_duplicates", map[string]interface{}{
+		"project_path": dir,
+	}))
+	assert.Contains(t, text, "Found 1 groups of interfaces with identical method sets")
+	assert.Contains(t, text, "1. 2 interfaces with methods Delete, Get\n"+
+		"- UserStore (repo package)\n  File: "+repo+":5\n"+
+		"- AccountStore (repo package)\n  File: "+repo+":11")
+	// Matching method names matter, and empty interfaces are never grouped
+	assert.NotContains(t, text, "Reader")
+	assert.NotContains(t, text, "Closer")
+	assert.NotContains(t, text, "Marker")
+	assert.NotContains(t, text, "Source")
+	assert.NotContains(t, text, cache)
+
+	text = responseText(t, callTool(t, s, "find_structural_duplicates", map[string]interface{}{
+		"project_path": filepath.Join(dir, "cache"),
+	}))
+	assert.Contains(t, text, "No structurally identical interfaces found")
+
+	response := callTool(t, s, "find_structural_duplicates", map[string]interface{}{
+		"project_path": dir + "/missing",
+	})
+	requireErrorKind(t, response, CodePathNotFound, KindPathNotFound)
+}
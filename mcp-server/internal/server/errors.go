@@ -0,0 +1,102 @@
+package server
+
+import (
+	"errors"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+)
+
+// Sentinel errors returned by tool operations. Failures wrap one of these
+// so handlers can map them to a distinct error code and kind.
+var (
+	ErrPathNotFound        = errors.New("path not found")
+	ErrInterfaceNotFound   = scanner.ErrInterfaceNotFound
+	ErrMockeryNotInstalled = errors.New("mockery not installed")
+	ErrScanFailed          = errors.New("scan failed")
+	ErrGenerationFailed    = errors.New("generation failed")
+	ErrModuleNotFound      = errors.New("go module not found")
+	ErrReadOnly            = errors.New("server is read-only")
+	ErrRateLimited         = errors.New("rate limited")
+	ErrProjectNotFound     = errors.New("project not found")
+	ErrTimeout             = errors.New("tool call timed out")
+)
+
+// JSON-RPC error codes for classified tool failures, taken from the
+// implementation-defined server error range
+const (
+	CodePathNotFound        = -32001
+	CodeInterfaceNotFound   = -32002
+	CodeMockeryNotInstalled = -32003
+	CodeScanFailed          = -32004
+	CodeGenerationFailed    = -32005
+	CodeModuleNotFound      = -32006
+	CodeReadOnly            = -32007
+	CodeRateLimited         = -32008
+	CodeProjectNotFound     = -32009
+	CodeTimeout             = -32000
+	CodeInternalError       = -32603
+)
+
+// ErrorKind is the machine-readable failure category reported in MCPError.Data
+type ErrorKind string
+
+const (
+	KindPathNotFound        ErrorKind = "path_not_found"
+	KindInterfaceNotFound   ErrorKind = "interface_not_found"
+	KindMockeryNotInstalled ErrorKind = "mockery_not_installed"
+	KindScanFailed          ErrorKind = "scan_failed"
+	KindGenerationFailed    ErrorKind = "generation_failed"
+	KindModuleNotFound      ErrorKind = "module_not_found"
+	KindReadOnly            ErrorKind = "read_only"
+	KindRateLimited         ErrorKind = "rate_limited"
+	KindProjectNotFound     ErrorKind = "project_not_found"
+	KindTimeout             ErrorKind = "timeout"
+	KindInternal            ErrorKind = "internal"
+)
+
+// ErrorData is the structured payload attached to classified errors
+type ErrorData struct {
+	Kind   ErrorKind `json:"kind"`
+	Detail string    `json:"detail,omitempty"`
+
+	// RetryAfterMS is how long to wait before retrying a rate limited call
+	RetryAfterMS int64 `json:"retry_after_ms,omitempty"`
+}
+
+// errorClasses maps sentinel errors to their code and kind, checked in order
+var errorClasses = []struct {
+	err  error
+	code int
+	kind ErrorKind
+}{
+	{ErrPathNotFound, CodePathNotFound, KindPathNotFound},
+	{ErrInterfaceNotFound, CodeInterfaceNotFound, KindInterfaceNotFound},
+	{ErrMockeryNotInstalled, CodeMockeryNotInstalled, KindMockeryNotInstalled},
+	{ErrScanFailed, CodeScanFailed, KindScanFailed},
+	{ErrGenerationFailed, CodeGenerationFailed, KindGenerationFailed},
+	{ErrModuleNotFound, CodeModuleNotFound, KindModuleNotFound},
+	{ErrReadOnly, CodeReadOnly, KindReadOnly},
+	{ErrRateLimited, CodeRateLimited, KindRateLimited},
+	{ErrProjectNotFound, CodeProjectNotFound, KindProjectNotFound},
+	{ErrTimeout, CodeTimeout, KindTimeout},
+}
+
+// classifyError returns the error code and kind for err
+func classifyError(err error) (int, ErrorKind) {
+	for _, class := range errorClasses {
+		if errors.Is(err, class.err) {
+			return class.code, class.kind
+		}
+	}
+	return CodeInternalError, KindInternal
+}
+
+// toolErrorResponse creates an error response whose code and data kind are
+// derived from the sentinel error wrapped by err
+func (s *MockeryMCPServer) toolErrorResponse(id interface{}, message string, err error) *MCPResponse {
+	code, kind := classifyError(err)
+	return s.errorResponse(id, code, message, ErrorData{
+		Kind:   kind,
+		Detail: err.Error(),
+	})
+}
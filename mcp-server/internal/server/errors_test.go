@@ -0,0 +1,102 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requireErrorKind asserts that a response failed with the given code and kind
+func requireErrorKind(t *testing.T, response *MCPResponse, code int, kind ErrorKind) {
+	t.Helper()
+	require.NotNil(t, response)
+	require.NotNil(t, response.Error, "expected an error response")
+	assert.Equal(t, code, response.Error.Code)
+
+	data, ok := response.Error.Data.(ErrorData)
+	require.True(t, ok, "expected ErrorData, got %T", response.Error.Data)
+	assert.Equal(t, kind, data.Kind)
+	assert.NotEmpty(t, data.Detail)
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err  error
+		code int
+		kind ErrorKind
+	}{
+		{fmt.Errorf("%w: /missing", ErrPathNotFound), CodePathNotFound, KindPathNotFound},
+		{fmt.Errorf("%w: Foo", ErrInterfaceNotFound), CodeInterfaceNotFound, KindInterfaceNotFound},
+		{fmt.Errorf("%w: not in PATH", ErrMockeryNotInstalled), CodeMockeryNotInstalled, KindMockeryNotInstalled},
+		{fmt.Errorf("%w: walk failed", ErrScanFailed), CodeScanFailed, KindScanFailed},
+		{fmt.Errorf("%w: exit status 1", ErrGenerationFailed), CodeGenerationFailed, KindGenerationFailed},
+		{fmt.Errorf("%w: no go.mod", ErrModuleNotFound), CodeModuleNotFound, KindModuleNotFound},
+		{fmt.Errorf("%w: generate_mock writes files", ErrReadOnly), CodeReadOnly, KindReadOnly},
+		{errors.New("something else"), CodeInternalError, KindInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			code, kind := classifyError(tt.err)
+			assert.Equal(t, tt.code, code)
+			assert.Equal(t, tt.kind, kind)
+		})
+	}
+}
+
+func TestMockeryMCPServer_ToolErrorKinds(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype Repo interface {\n\tGet() error\n}\n")
+
+	t.Run("discover missing path", func(t *testing.T) {
+		s := newTestServer(t)
+		response := callTool(t, s, "discover_interfaces", map[string]interface{}{
+			"project_path": filepath.Join(dir, "missing"),
+		})
+		requireErrorKind(t, response, CodePathNotFound, KindPathNotFound)
+	})
+
+	t.Run("generate missing path", func(t *testing.T) {
+		s := newTestServer(t)
+		response := callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "Repo",
+			"package_path":   filepath.Join(dir, "missing"),
+		})
+		requireErrorKind(t, response, CodePathNotFound, KindPathNotFound)
+	})
+
+	t.Run("mockery not installed", func(t *testing.T) {
+		s := newTestServer(t)
+		require.NoError(t, s.SetMockeryCommand(filepath.Join(dir, "no-such-mockery")))
+		response := callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "Repo",
+			"package_path":   dir,
+			"output_dir":     t.TempDir(),
+		})
+		requireErrorKind(t, response, CodeMockeryNotInstalled, KindMockeryNotInstalled)
+	})
+
+	t.Run("mockery fails", func(t *testing.T) {
+		s := newTestServer(t)
+		useFakeMockery(t, s, "#!/bin/sh\necho 'boom' >&2\nexit 1\n")
+		response := callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "Repo",
+			"package_path":   dir,
+			"output_dir":     t.TempDir(),
+		})
+		requireErrorKind(t, response, CodeGenerationFailed, KindGenerationFailed)
+	})
+
+	t.Run("interface not found", func(t *testing.T) {
+		s := newTestServer(t)
+		response := callTool(t, s, "get_interface_source", map[string]interface{}{
+			"file_path":      filepath.Join(dir, "repo.go"),
+			"interface_name": "Missing",
+		})
+		requireErrorKind(t, response, CodeInterfaceNotFound, KindInterfaceNotFound)
+	})
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// validateInterfacePatterns checks that every pattern is a valid glob
+func validateInterfacePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid interface pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// interfaceMatches reports whether name equals or glob-matches any pattern
+func interfaceMatches(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if pattern == name {
+			return true
+		}
+		if matched, err := path.Match(pattern, name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// excludeInterfaces drops interfaces whose name matches any of the patterns
+func excludeInterfaces(interfaces []types.InterfaceDefinition, patterns []string) []types.InterfaceDefinition {
+	if len(patterns) == 0 {
+		return interfaces
+	}
+
+	filtered := make([]types.InterfaceDefinition, 0, len(interfaces))
+	for _, iface := range interfaces {
+		if !interfaceMatches(iface.Name, patterns) {
+			filtered = append(filtered, iface)
+		}
+	}
+	return filtered
+}
+
+// filterMethodCount keeps interfaces with at least minMethods and, unless
+// maxMethods is negative, at most maxMethods methods
+func filterMethodCount(interfaces []types.InterfaceDefinition, minMethods, maxMethods int) []types.InterfaceDefinition {
+	if minMethods == 0 && maxMethods < 0 {
+		return interfaces
+	}
+
+	filtered := make([]types.InterfaceDefinition, 0, len(interfaces))
+	for _, iface := range interfaces {
+		count := len(iface.Methods)
+		if count >= minMethods && (maxMethods < 0 || count <= maxMethods) {
+			filtered = append(filtered, iface)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+func TestExcludeInterfaces(t *testing.T) {
+	interfaces := []types.InterfaceDefinition{
+		{Name: "UserRepository"},
+		{Name: "MockUserRepository"},
+		{Name: "error"},
+		{Name: "EmailSender"},
+	}
+
+	names := func(defs []types.InterfaceDefinition) []string {
+		var result []string
+		for _, def := range defs {
+			result = append(result, def.Name)
+		}
+		return result
+	}
+
+	t.Run("exact", func(t *testing.T) {
+		assert.Equal(t, []string{"UserRepository", "MockUserRepository", "EmailSender"},
+			names(excludeInterfaces(interfaces, []string{"error"})))
+	})
+
+	t.Run("glob", func(t *testing.T) {
+		assert.Equal(t, []string{"UserRepository", "error", "EmailSender"},
+			names(excludeInterfaces(interfaces, []string{"Mock*"})))
+		assert.Equal(t, []string{"error", "EmailSender"},
+			names(excludeInterfaces(interfaces, []string{"*Repository"})))
+	})
+
+	t.Run("no patterns", func(t *testing.T) {
+		assert.Len(t, excludeInterfaces(interfaces, nil), 4)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		assert.Error(t, validateInterfacePatterns([]string{"[Mock"}))
+	})
+}
+
+func TestMockeryMCPServer_DiscoverExcludeInterfaces(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", `package repo
+
+type UserRepository interface{ Get() error }
+
+type MockUserRepository interface{ Get() error }
+
+type Generated_Client interface{ Do() error }
+`)
+
+	s := newTestServer(t)
+	cfg := s.Config()
+	cfg.ExcludeInterfaces = []string{"Generated_*"}
+	require.NoError(t, s.ApplyConfig(cfg))
+
+	response := callTool(t, s, "discover_interfaces", map[string]interface{}{
+		"project_path":       dir,
+		"exclude_interfaces": []interface{}{"Mock*"},
+	})
+	text := responseText(t, response)
+	assert.Contains(t, text, "Found 1 interfaces")
+	assert.Contains(t, text, "- UserRepository")
+	assert.NotContains(t, text, "MockUserRepository")
+	assert.NotContains(t, text, "Generated_Client")
+
+	t.Run("invalid argument", func(t *testing.T) {
+		response := callTool(t, s, "discover_interfaces", map[string]interface{}{
+			"project_path":       dir,
+			"exclude_interfaces": []interface{}{"[bad"},
+		})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, -32602, response.Error.Code)
+	})
+}
+
+func TestFilterMethodCount(t *testing.T) {
+	withMethods := func(name string, count int) types.InterfaceDefinition {
+		return types.InterfaceDefinition{Name: name, Methods: make([]types.MethodSignature, count)}
+	}
+	interfaces := []types.InterfaceDefinition{withMethods("Empty", 0), withMethods("Getter", 1), withMethods("Store", 3)}
+
+	names := func(defs []types.InterfaceDefinition) []string {
+		result := []string{}
+		for _, def := range defs {
+			result = append(result, def.Name)
+		}
+		return result
+	}
+
+	for name, test := range map[string]struct {
+		min, max int
+		want     []string
+	}{
+		"unbounded":         {0, -1, []string{"Empty", "Getter", "Store"}},
+		"min is inclusive":  {1, -1, []string{"Getter", "Store"}},
+		"max is inclusive":  {0, 1, []string{"Empty", "Getter"}},
+		"min equals max":    {3, 3, []string{"Store"}},
+		"max of zero":       {0, 0, []string{"Empty"}},
+		"min above all":     {4, -1, []string{}},
+		"range between two": {2, 2, []string{}},
+	} {
+		assert.Equal(t, test.want, names(filterMethodCount(interfaces, test.min, test.max)), name)
+	}
+}
+
+func TestMockeryMCPServer_DiscoverMethodCount(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", `package repo
+
+type Getter interface{ Get() error }
+
+type Store interface {
+	Get() error
+	Put() error
+}
+`)
+	s := newTestServer(t)
+
+	text := responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+		"project_path": dir,
+		"min_methods":  2,
+	}))
+	assert.Contains(t, text, "Found 1 interfaces")
+	assert.Contains(t, text, "- Store")
+
+	text = responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+		"project_path": dir,
+		"min_methods":  1,
+		"max_methods":  1,
+	}))
+	assert.Contains(t, text, "Found 1 interfaces")
+	assert.Contains(t, text, "- Getter")
+
+	for name, args := range map[string]map[string]interface{}{
+		"min above max": {"min_methods": 3, "max_methods": 2},
+		"negative":      {"max_methods": -1},
+		"fractional":    {"min_methods": 1.5},
+	} {
+		args["project_path"] = dir
+		response := callTool(t, s, "discover_interfaces", args)
+		require.NotNil(t, response.Error, name)
+		assert.Equal(t, -32602, response.Error.Code, name)
+	}
+}
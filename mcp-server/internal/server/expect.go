@@ -0,0 +1,202 @@
+package server
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// basicZeroValues maps the predeclared types mock.AnythingOfType can match
+// to their zero value
+var basicZeroValues = map[string]string{
+	"string": `""`, "bool": "false",
+	"int": "0", "int8": "0", "int16": "0", "int32": "0", "int64": "0",
+	"uint": "0", "uint8": "0", "uint16": "0", "uint32": "0", "uint64": "0", "uintptr": "0",
+	"float32": "0", "float64": "0", "complex64": "0", "complex128": "0",
+}
+
+// handleGenerateExpectScaffold implements the generate_expect_scaffold tool
+func (s *MockeryMCPServer) handleGenerateExpectScaffold(requestID interface{}, args map[string]interface{}) *MCPResponse {
+	filePath, ok := args["file_path"].(string)
+	if !ok || filePath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid file_path", nil)
+	}
+
+	interfaceName, ok := args["interface_name"].(string)
+	if !ok || interfaceName == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid interface_name", nil)
+	}
+
+	mockPackage := "mocks"
+	if value, ok := args["mock_package"].(string); ok && value != "" {
+		mockPackage = value
+	}
+	mockName := interfaceName
+	if value, ok := args["mock_name"].(string); ok && value != "" {
+		mockName = expandInterfaceName(value, interfaceName)
+	}
+	mockVar := "mock" + interfaceName
+	if value, ok := args["mock_var"].(string); ok && value != "" {
+		mockVar = value
+	}
+
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", filePath), err)
+	}
+
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("File does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	iface, err := s.scanner.ExtractInterfaceMetadata(absPath, interfaceName)
+	if err != nil {
+		s.logger.Error("Failed to extract interface metadata", zap.String("file", absPath), zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to extract interface metadata", err)
+	}
+
+	snippet, err := expectScaffold(iface, mockPackage, mockName, mockVar, declaredTypes(filepath.Dir(absPath)))
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to format scaffold", err)
+	}
+
+	return s.textResponse(requestID, snippet)
+}
+
+// declaredTypes returns the types declared in a package directory, keyed by
+// their package-qualified name, each reporting whether it is an interface.
+// Aliases are left out, since what they stand for isn't known without
+// resolving imports.
+func declaredTypes(packageDir string) map[string]bool {
+	declared := make(map[string]bool)
+	files, err := filepath.Glob(filepath.Join(packageDir, "*.go"))
+	if err != nil {
+		return declared
+	}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		node, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		for _, decl := range node.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if typeSpec.Assign.IsValid() {
+					continue
+				}
+				_, isInterface := typeSpec.Type.(*ast.InterfaceType)
+				declared[node.Name.Name+"."+typeSpec.Name.Name] = isInterface
+			}
+		}
+	}
+	return declared
+}
+
+// expectScaffold builds gofmt-formatted statements that construct a mock and
+// set up an EXPECT() call for every method of an interface
+func expectScaffold(iface *types.InterfaceDefinition, mockPackage, mockName, mockVar string, declared map[string]bool) (string, error) {
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s := %s.New%s(t)\n\n", mockVar, mockPackage, mockName)
+	for _, method := range iface.Methods {
+		args := make([]string, len(method.Parameters))
+		for i, param := range method.Parameters {
+			args[i] = argumentPlaceholder(param, declared)
+		}
+		returns := make([]string, len(method.Returns))
+		for i, result := range method.Returns {
+			returns[i] = zeroValue(matcherTypeOf(result), declared)
+		}
+		fmt.Fprintf(&body, "%s.EXPECT().%s(%s).Return(%s)\n",
+			mockVar, method.Name, strings.Join(args, ", "), strings.Join(returns, ", "))
+	}
+
+	return formatStatements(body.String())
+}
+
+// formatStatements gofmt-formats a sequence of statements
+func formatStatements(statements string) (string, error) {
+	// Format the statements inside a function so gofmt accepts them, then
+	// strip the wrapper again
+	wrapped := "package scaffold\n\nfunc _() {\n" + statements + "}\n"
+	formatted, err := format.Source([]byte(wrapped))
+	if err != nil {
+		return "", err
+	}
+
+	text := string(formatted)
+	text = text[strings.Index(text, "{\n")+2 : strings.LastIndex(text, "}")]
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, "\t")
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// argumentPlaceholder returns the matcher for a parameter: mock.AnythingOfType
+// for concrete types and mock.Anything where the dynamic type is unknown
+func argumentPlaceholder(param types.Parameter, declared map[string]bool) string {
+	matcherType := matcherTypeOf(param)
+	if strings.HasPrefix(param.Type, "...") || !concreteType(matcherType, declared) {
+		return "mock.Anything"
+	}
+	return fmt.Sprintf("mock.AnythingOfType(%q)", matcherType)
+}
+
+// concreteType reports whether every value of a type has that type as its
+// dynamic type, which mock.AnythingOfType compares against. Named types
+// count only when declared as a non-interface type in the scanned package:
+// a type from another package may be an interface, and without resolving
+// its import there is no telling.
+func concreteType(typeName string, declared map[string]bool) bool {
+	if _, ok := basicZeroValues[typeName]; ok {
+		return true
+	}
+	for _, prefix := range []string{"*", "[", "map[", "chan ", "<-chan ", "chan<- ", "struct"} {
+		if strings.HasPrefix(typeName, prefix) {
+			return true
+		}
+	}
+	// Instantiations of a generic type are matched by the generic type
+	name, _, _ := strings.Cut(typeName, "[")
+	isInterface, ok := declared[name]
+	return ok && !isInterface
+}
+
+// matcherTypeOf returns a parameter's matcher type, falling back to the
+// declared type for definitions that predate matcher types
+func matcherTypeOf(param types.Parameter) string {
+	if param.MatcherType != "" {
+		return param.MatcherType
+	}
+	return param.Type
+}
+
+// zeroValue returns a Go expression for the zero value of a type
+func zeroValue(typeName string, declared map[string]bool) string {
+	if value, ok := basicZeroValues[typeName]; ok {
+		return value
+	}
+	if typeName == "error" || declared[typeName] {
+		return "nil"
+	}
+	for _, prefix := range []string{"*", "[]", "map[", "chan ", "<-chan ", "chan<- ", "func", "interface"} {
+		if strings.HasPrefix(typeName, prefix) {
+			return "nil"
+		}
+	}
+	return fmt.Sprintf("*new(%s)", typeName)
+}
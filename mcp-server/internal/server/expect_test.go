@@ -0,0 +1,62 @@
+package server
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_GenerateExpectScaffold(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "user.go", "package interfaces\n\ntype User struct{}\n\ntype Status int\n\ntype Notifier interface{ Notify() }\n")
+	filePath := writeFile(t, dir, "repo.go", `package interfaces
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+type UserRepository interface {
+	Create(ctx context.Context, user *User) error
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	Set(key string, ttl time.Duration, n Notifier)
+	Count() (int, bool)
+	Expiry() time.Duration
+	Import(status Status, r io.Reader) Notifier
+}
+`)
+
+	s := newTestServer(t)
+
+	text := responseText(t, callTool(t, s, "generate_expect_scaffold", map[string]interface{}{
+		"file_path":      filePath,
+		"interface_name": "UserRepository",
+		"mock_var":       "mockRepo",
+	}))
+
+	assert.Equal(t, `mockRepo := mocks.NewUserRepository(t)
+
+mockRepo.EXPECT().Create(mock.Anything, mock.AnythingOfType("*interfaces.User")).Return(nil)
+mockRepo.EXPECT().GetByEmail(mock.Anything, mock.AnythingOfType("string")).Return(nil, nil)
+mockRepo.EXPECT().Set(mock.AnythingOfType("string"), mock.Anything, mock.Anything).Return()
+mockRepo.EXPECT().Count().Return(0, false)
+mockRepo.EXPECT().Expiry().Return(*new(time.Duration))
+mockRepo.EXPECT().Import(mock.AnythingOfType("interfaces.Status"), mock.Anything).Return(nil)
+`, text)
+
+	// The snippet is gofmt-clean once placed in a function body
+	wrapped := "package p\n\nfunc _() {\n" + text + "}\n"
+	_, err := format.Source([]byte(wrapped))
+	require.NoError(t, err)
+
+	t.Run("unknown interface", func(t *testing.T) {
+		response := callTool(t, s, "generate_expect_scaffold", map[string]interface{}{
+			"file_path":      filePath,
+			"interface_name": "Missing",
+		})
+		requireErrorKind(t, response, CodeInterfaceNotFound, KindInterfaceNotFound)
+	})
+}
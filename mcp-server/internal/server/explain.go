@@ -0,0 +1,184 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// generationPlan is everything GenerateMock resolves before running mockery
+type generationPlan struct {
+	request    *types.MockGenerationRequest // With interface directives applied
+	source     *mockSource
+	directives map[string]string
+	outputDir  string
+	filename   string
+	// mocksModule is set when the mocks go to a module other than the
+	// interface's, which then also sets outPkg. The request's OutPkg takes
+	// precedence, and an outpkg directive over both.
+	mocksModule *mocksModule
+	outPkg      string
+	// iface is the interface being mocked, nil when it wasn't looked up
+	iface *types.InterfaceDefinition
+}
+
+// planGeneration resolves the package, output directory and filename for a
+// request without touching the filesystem
+func (s *MockeryMCPServer) planGeneration(ctx context.Context, cfg *runtimeConfig, request *types.MockGenerationRequest) (*generationPlan, error) {
+	if err := validateExtraArgs(request.ExtraArgs); err != nil {
+		return nil, err
+	}
+
+	source, err := resolveMockSource(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Confirm the interface exists before running mockery, which reports a
+	// missing one poorly
+	var iface *types.InterfaceDefinition
+	if request.FunctionType {
+		_, err = s.lookupFunctionType(ctx, request, source)
+	} else {
+		iface, err = s.lookupInterface(ctx, request, source)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var directives map[string]string
+	if iface != nil {
+		directives = iface.Directives
+		request = s.applyDirectives(ctx, request, directives, source)
+	}
+
+	// Set default output directory if not specified
+	outputDir, err := s.resolveOutputDir(cfg, request, source)
+	if err != nil {
+		return nil, err
+	}
+
+	filename, err := renderMockFilename(request, source.packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &generationPlan{
+		request:    request,
+		source:     source,
+		directives: directives,
+		outputDir:  outputDir,
+		filename:   filename,
+		iface:      iface,
+	}
+	if module := resolveMocksModule(request, source, outputDir); module != nil {
+		plan.mocksModule = module
+		plan.outPkg = mocksPackageName(outputDir)
+	}
+	if request.OutPkg != "" {
+		plan.outPkg = request.OutPkg
+	}
+	if outPkg := directives["outpkg"]; outPkg != "" {
+		plan.outPkg = outPkg
+	}
+	return plan, nil
+}
+
+// shellQuote quotes an argument for display when the shell would split it
+func shellQuote(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\n'\"\\$`*?[]{}()<>|&;#~") {
+		return arg
+	}
+	return strconv.Quote(arg)
+}
+
+// handleExplainGenerate implements the explain_generate tool. It resolves a
+// generate_mock request exactly as generation would and reports the mockery
+// command, but runs nothing and creates no directories.
+func (s *MockeryMCPServer) handleExplainGenerate(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	request, errResponse := s.parseGenerateMockArgs(requestID, args)
+	if errResponse != nil {
+		return errResponse
+	}
+	if request.Recursive {
+		return s.errorResponse(requestID, -32602, "Invalid recursive", "explain_generate explains a single interface; recursive is not supported")
+	}
+
+	cfg := s.config()
+	plan, err := s.planGeneration(ctx, cfg, request)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to resolve mock generation", err)
+	}
+
+	outputDir := plan.outputDir
+	if plan.request.ReturnContent {
+		outputDir = "<temporary directory>"
+	}
+	builder := s.argsBuilder(ctx, cfg)
+	configPath := ""
+	if builder.usesConfig() {
+		configPath = "<temporary directory>/" + mockeryV3ConfigFilename
+	}
+	mockeryArguments, mockeryConfig, err := builder.args(plan, outputDir, configPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to resolve mock generation", err)
+	}
+
+	binary, err := exec.LookPath(cfg.MockeryCommand)
+	if err != nil {
+		binary = cfg.MockeryCommand + " (not found in PATH)"
+	}
+
+	mockName := resolveMockName(plan.request)
+	if mockName == "" {
+		mockName = "(mockery default)"
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("Mockery command for %s (nothing was run):\n", plan.request.InterfaceName))
+	text.WriteString(fmt.Sprintf("- Binary: %s\n", binary))
+	text.WriteString(fmt.Sprintf("- Working directory: %s\n", plan.source.workDir))
+	text.WriteString(fmt.Sprintf("- Source: %s\n", plan.source.arg))
+	text.WriteString(fmt.Sprintf("- Output directory: %s\n", outputDir))
+	text.WriteString(fmt.Sprintf("- Filename: %s\n", plan.filename))
+	text.WriteString(fmt.Sprintf("- Mock name: %s", mockName))
+	if module := plan.mocksModule; module != nil {
+		text.WriteString(fmt.Sprintf("\n- Mocks module: %s at %s", module.path, module.root))
+		if module.create {
+			text.WriteString(" (go.mod created on generation)")
+		}
+	}
+	if plan.request.BuildTag != "" {
+		text.WriteString(fmt.Sprintf("\n- Build tag: %s (added after generation)", plan.request.BuildTag))
+	}
+	if len(plan.request.ExtraArgs) > 0 {
+		text.WriteString(fmt.Sprintf("\n- Extra arguments: %s", strings.Join(plan.request.ExtraArgs, " ")))
+	}
+	if len(plan.directives) > 0 {
+		keys := make([]string, 0, len(plan.directives))
+		for key := range plan.directives {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for i, key := range keys {
+			keys[i] = key + "=" + plan.directives[key]
+		}
+		text.WriteString(fmt.Sprintf("\n- Directives: %s", strings.Join(keys, ", ")))
+	}
+
+	quoted := make([]string, 0, len(mockeryArguments)+1)
+	quoted = append(quoted, shellQuote(cfg.MockeryCommand))
+	for _, arg := range mockeryArguments {
+		quoted = append(quoted, shellQuote(arg))
+	}
+	text.WriteString("\n\nCommand:\n" + strings.Join(quoted, " "))
+	if mockeryConfig != nil {
+		text.WriteString("\n\nConfig (" + configPath + "):\n" + string(mockeryConfig))
+	}
+
+	return s.textResponse(requestID, text.String())
+}
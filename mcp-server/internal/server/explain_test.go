@@ -0,0 +1,69 @@
+package server
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_ExplainGenerate(t *testing.T) {
+	dir := filepath.Dir(writeFile(t, t.TempDir(), "repo.go", "package repo\n\n//mockery:filename repo_mock.go\ntype Repo interface{ Get() error }\n"))
+
+	s := newTestServer(t)
+	runs := useCountingMockery(t, s)
+
+	text := responseText(t, callTool(t, s, "explain_generate", map[string]interface{}{
+		"interface_name": "Repo",
+		"package_path":   dir,
+		"extra_args":     []interface{}{"--disable-func-mocks"},
+	}))
+
+	outputDir := filepath.Join(dir, "mocks")
+	assert.Contains(t, text, "Mockery command for Repo (nothing was run)")
+	assert.Contains(t, text, "- Working directory: "+dir)
+	assert.Contains(t, text, "- Source: --dir="+dir)
+	assert.Contains(t, text, "- Output directory: "+outputDir)
+	assert.Contains(t, text, "- Filename: repo_mock.go")
+	assert.Contains(t, text, "- Mock name: (mockery default)")
+	assert.Contains(t, text, "- Extra arguments: --disable-func-mocks")
+	assert.Contains(t, text, "- Directives: filename=repo_mock.go")
+	assert.Contains(t, text, "--name=Repo --dir="+dir+" --output="+outputDir+" --filename=repo_mock.go --with-expecter --disable-func-mocks")
+
+	// Nothing is run or created
+	assert.Empty(t, runs())
+	assert.NoDirExists(t, outputDir)
+
+	t.Run("quotes arguments with spaces", func(t *testing.T) {
+		text := responseText(t, callTool(t, s, "explain_generate", map[string]interface{}{
+			"interface_name": "Repo",
+			"package_path":   dir,
+			"output_dir":     filepath.Join(dir, "my mocks"),
+		}))
+		assert.Contains(t, text, `"--output=`+filepath.Join(dir, "my mocks")+`"`)
+	})
+
+	t.Run("reports missing interface", func(t *testing.T) {
+		response := callTool(t, s, "explain_generate", map[string]interface{}{
+			"interface_name": "Missing",
+			"package_path":   dir,
+		})
+		requireErrorKind(t, response, CodeInterfaceNotFound, KindInterfaceNotFound)
+	})
+
+	t.Run("rejects recursive", func(t *testing.T) {
+		response := callTool(t, s, "explain_generate", map[string]interface{}{
+			"package_path": dir,
+			"recursive":    true,
+		})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, -32602, response.Error.Code)
+	})
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, "--name=Repo", shellQuote("--name=Repo"))
+	assert.Equal(t, `"--output=/my mocks"`, shellQuote("--output=/my mocks"))
+	assert.Equal(t, `""`, shellQuote(""))
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+)
+
+// resolveOutputPath resolves path, relative to root unless absolute, and
+// rejects it unless it stays inside root once symlinks in its directory are
+// followed
+func resolveOutputPath(root, path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	path = filepath.Clean(path)
+
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", root, err)
+	}
+
+	// The file may not exist yet, so check the closest existing directory
+	dir := filepath.Dir(path)
+	rest := filepath.Base(path)
+	for {
+		realDir, err := filepath.EvalSymlinks(dir)
+		if err == nil {
+			dir = filepath.Join(realDir, rest)
+			break
+		}
+		if !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to resolve %s: %w", dir, err)
+		}
+		rest = filepath.Join(filepath.Base(dir), rest)
+		dir = filepath.Dir(dir)
+	}
+
+	rel, err := filepath.Rel(realRoot, dir)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("output path %s is outside %s", path, root)
+	}
+	return path, nil
+}
+
+// handleExportInterfaces implements the export_interfaces tool
+func (s *MockeryMCPServer) handleExportInterfaces(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	projectPath, ok := args["project_path"].(string)
+	if !ok || projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid project_path", nil)
+	}
+	outputPath, ok := args["output_path"].(string)
+	if !ok || outputPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid output_path", nil)
+	}
+
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", projectPath), err)
+	}
+
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Project path does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	outputPath, err = resolveOutputPath(absPath, outputPath)
+	if err != nil {
+		return s.errorResponse(requestID, -32602, "Invalid output_path", err.Error())
+	}
+
+	interfaces, _, err := s.scanner.ScanProjectContext(ctx, absPath, scanner.ScanOptions{})
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return s.toolErrorResponse(requestID, "Scan cancelled", err)
+	}
+	if err != nil {
+		s.logger.Error("Failed to scan project", zap.String("path", absPath), zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to scan project", fmt.Errorf("%w: %v", ErrScanFailed, err))
+	}
+	interfaces = excludeInterfaces(interfaces, s.config().ExcludeInterfaces)
+
+	data, err := json.MarshalIndent(interfaces, "", "  ")
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to encode interfaces", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return s.toolErrorResponse(requestID, "Failed to create output directory", err)
+	}
+	if err := os.WriteFile(outputPath, append(data, '\n'), 0644); err != nil {
+		return s.toolErrorResponse(requestID, "Failed to write export", err)
+	}
+
+	return s.textResponse(requestID, fmt.Sprintf("Exported %d interfaces from %s to %s", len(interfaces), absPath, outputPath))
+}
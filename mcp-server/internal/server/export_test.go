@@ -0,0 +1,85 @@
+package server
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+func TestMockeryMCPServer_ExportInterfaces(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "repo/repo.go", `package repo
+
+import "context"
+
+// UserRepository loads users
+type UserRepository interface {
+	// Get returns a user by id
+	Get(ctx context.Context, id string) (name string, err error)
+}
+`)
+	writeFile(t, root, "notify/email.go", "package notify\n\ntype Sender interface{ Send() error }\n")
+
+	t.Run("writes the full definitions", func(t *testing.T) {
+		s := newTestServer(t)
+		text := responseText(t, callTool(t, s, "export_interfaces", map[string]interface{}{
+			"project_path": root,
+			"output_path":  "build/interfaces.json",
+		}))
+		output := filepath.Join(root, "build", "interfaces.json")
+		assert.Equal(t, "Exported 2 interfaces from "+root+" to "+output, text)
+
+		data, err := os.ReadFile(output)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "\n  {\n    \"name\":")
+
+		var interfaces []types.InterfaceDefinition
+		require.NoError(t, json.Unmarshal(data, &interfaces))
+		require.Len(t, interfaces, 2)
+
+		var repo types.InterfaceDefinition
+		for _, iface := range interfaces {
+			if iface.Name == "UserRepository" {
+				repo = iface
+			}
+		}
+		assert.Equal(t, filepath.Join(root, "repo", "repo.go"), repo.FilePath)
+		assert.Equal(t, 6, repo.LineNumber)
+		require.Len(t, repo.Methods, 1)
+		assert.Equal(t, []string{"Get returns a user by id"}, repo.Methods[0].Comments)
+		assert.Equal(t, "context.Context", repo.Methods[0].Parameters[0].Type)
+		assert.Equal(t, "err", repo.Methods[0].Returns[1].Name)
+		assert.Equal(t, []types.Import{{Name: "context", Path: "context"}}, repo.Imports)
+	})
+
+	t.Run("rejects paths outside the project", func(t *testing.T) {
+		s := newTestServer(t)
+		outside := t.TempDir()
+		require.NoError(t, os.Symlink(outside, filepath.Join(root, "link")))
+
+		for _, path := range []string{
+			"../interfaces.json",
+			filepath.Join(outside, "interfaces.json"),
+			"link/interfaces.json",
+			"link/nested/interfaces.json",
+			".",
+		} {
+			response := callTool(t, s, "export_interfaces", map[string]interface{}{
+				"project_path": root,
+				"output_path":  path,
+			})
+			require.NotNil(t, response.Error, path)
+			assert.Equal(t, -32602, response.Error.Code, path)
+		}
+
+		entries, err := os.ReadDir(outside)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// extraArgFlag describes a mockery flag requests may pass in extra_args
+type extraArgFlag struct {
+	// boolean flags take no value, or an explicit =true or =false
+	boolean bool
+	// values lists the accepted values of a flag that takes one; empty
+	// accepts any value
+	values []string
+}
+
+// allowedMockeryFlags are the mockery flags extra arguments may set. They
+// only shape the generated code or mockery's logging. Everything else is
+// rejected: the flags the server sets itself, which extra arguments would
+// silently override since they come last, flags that read or write other
+// paths such as --profile, and flags added by later mockery versions.
+var allowedMockeryFlags = map[string]extraArgFlag{
+	"quiet":                  {boolean: true},
+	"exported":               {boolean: true},
+	"disable-func-mocks":     {boolean: true},
+	"disable-version-string": {boolean: true},
+	"unroll-variadic":        {boolean: true},
+	"log-level":              {values: []string{"trace", "debug", "info", "warn", "error", "fatal", "panic"}},
+	"case":                   {values: []string{"camel", "snake", "underscore"}},
+	"tags":                   {},
+	"note":                   {},
+	"replace-type":           {},
+}
+
+// validateExtraArgs checks extra mockery arguments against
+// allowedMockeryFlags. Flags may be written as --flag, -flag or
+// --flag=value, and a value may also follow as the next argument.
+func validateExtraArgs(args []string) error {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			return fmt.Errorf("extra argument %s is not a flag", arg)
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		flag, ok := allowedMockeryFlags[name]
+		if !ok {
+			return fmt.Errorf("extra argument %s is not an allowed mockery flag", arg)
+		}
+
+		if flag.boolean {
+			if hasValue {
+				if _, err := strconv.ParseBool(value); err != nil {
+					return fmt.Errorf("extra argument %s needs true or false", arg)
+				}
+			}
+			continue
+		}
+
+		if !hasValue {
+			if i+1 == len(args) || strings.HasPrefix(args[i+1], "-") {
+				return fmt.Errorf("extra argument %s needs a value", arg)
+			}
+			i++
+			value = args[i]
+		}
+		if len(flag.values) > 0 && !slices.Contains(flag.values, value) {
+			return fmt.Errorf("extra argument %s has invalid value %q (expected one of %s)", arg, value, strings.Join(flag.values, ", "))
+		}
+	}
+	return nil
+}
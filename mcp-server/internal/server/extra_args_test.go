@@ -0,0 +1,65 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_GenerateMock_ExtraArgs(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype UserRepository interface{ Get() error }\n")
+
+	t.Run("passes extra arguments through", func(t *testing.T) {
+		s := newTestServer(t)
+		lastArgs := recordingMockery(t, s)
+
+		responseText(t, callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "UserRepository",
+			"package_path":   dir,
+			"extra_args":     []interface{}{"--disable-func-mocks", "--log-level", "debug"},
+		}))
+
+		args := lastArgs()
+		require.GreaterOrEqual(t, len(args), 3)
+		assert.Equal(t, []string{"--disable-func-mocks", "--log-level", "debug"}, args[len(args)-3:])
+	})
+
+	t.Run("rejects flags outside the allowlist", func(t *testing.T) {
+		s := newTestServer(t)
+		useFakeMockery(t, s, fakeMockeryScript)
+
+		for _, extra := range [][]interface{}{
+			// Set by the server
+			{"--output=/etc"}, {"--dir"}, {"-name=Other"}, {"--srcpkg=io"},
+			{"--boilerplate-file=/etc/passwd"}, {"--outpkg", "fakes"}, {"--keeptree"},
+			// Read or write other paths, or change what is generated
+			{"--profile=/tmp/cpu.out"}, {"--print"}, {"--exclude=internal"},
+			// Invalid values
+			{"--log-level"}, {"--log-level", "--exported"}, {"--log-level", "verbose"},
+			{"--case=kebab"}, {"--quiet=maybe"},
+			// A value without its flag
+			{"debug"},
+		} {
+			response := callTool(t, s, "generate_mock", map[string]interface{}{
+				"interface_name": "UserRepository",
+				"package_path":   dir,
+				"extra_args":     append([]interface{}{"--quiet"}, extra...),
+			})
+			require.NotNil(t, response.Error, extra)
+			assert.Equal(t, -32602, response.Error.Code, extra)
+		}
+	})
+
+	t.Run("rejects non-string arguments", func(t *testing.T) {
+		s := newTestServer(t)
+		response := callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "UserRepository",
+			"package_path":   dir,
+			"extra_args":     []interface{}{"--quiet", 1},
+		})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, -32602, response.Error.Code)
+	})
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// discoveryFields are the fields discover_interfaces can include per interface
+var discoveryFields = []string{"name", "package", "file_path", "method_count", "methods", "line_number", "range", "exported"}
+
+// sourceRange is where a declaration starts and ends in its file, with the
+// end just past its last character
+type sourceRange struct {
+	StartLine   int `json:"start_line"`
+	StartColumn int `json:"start_column"`
+	EndLine     int `json:"end_line"`
+	EndColumn   int `json:"end_column"`
+}
+
+// String renders the range as start_line:start_column-end_line:end_column
+func (r sourceRange) String() string {
+	return fmt.Sprintf("%d:%d-%d:%d", r.StartLine, r.StartColumn, r.EndLine, r.EndColumn)
+}
+
+// interfaceRange returns the range of an interface's declaration
+func interfaceRange(iface types.InterfaceDefinition) sourceRange {
+	return sourceRange{
+		StartLine:   iface.LineNumber,
+		StartColumn: iface.StartColumn,
+		EndLine:     iface.EndLine,
+		EndColumn:   iface.EndColumn,
+	}
+}
+
+// defaultDiscoveryFields are the fields listed when a request selects none
+var defaultDiscoveryFields = []string{"name", "package", "file_path", "method_count"}
+
+// validateDiscoveryFields checks that every requested field is known
+func validateDiscoveryFields(fields []string) error {
+	for _, field := range fields {
+		if !isDiscoveryField(field) {
+			return fmt.Errorf("unknown field %q (valid: %s)", field, strings.Join(discoveryFields, ", "))
+		}
+	}
+	return nil
+}
+
+// isDiscoveryField reports whether field is one of discoveryFields
+func isDiscoveryField(field string) bool {
+	for _, known := range discoveryFields {
+		if field == known {
+			return true
+		}
+	}
+	return false
+}
+
+// interfaceField returns the value of a discovery field for an interface
+func interfaceField(iface types.InterfaceDefinition, field string) interface{} {
+	switch field {
+	case "name":
+		return iface.Name
+	case "package":
+		return iface.Package
+	case "file_path":
+		return iface.FilePath
+	case "method_count":
+		return len(iface.Methods)
+	case "methods":
+		names := make([]string, len(iface.Methods))
+		for i, method := range iface.Methods {
+			names[i] = method.Name
+		}
+		return names
+	case "line_number":
+		return iface.LineNumber
+	case "range":
+		return interfaceRange(iface)
+	case "exported":
+		return token.IsExported(iface.Name)
+	}
+	return nil
+}
+
+// simplifyInterfaceFields reduces interface definitions to the given fields,
+// or to defaultDiscoveryFields when none are given
+func simplifyInterfaceFields(interfaces []types.InterfaceDefinition, fields []string) []map[string]interface{} {
+	if len(fields) == 0 {
+		fields = defaultDiscoveryFields
+	}
+
+	simplified := make([]map[string]interface{}, len(interfaces))
+	for i, iface := range interfaces {
+		entry := make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			entry[field] = interfaceField(iface, field)
+		}
+		simplified[i] = entry
+	}
+	return simplified
+}
+
+// relativeFilePaths rewrites the file_path of each entry relative to root,
+// leaving a path that can't be made relative as it is
+func relativeFilePaths(simplified []map[string]interface{}, root string) {
+	for _, entry := range simplified {
+		filePath, ok := entry["file_path"].(string)
+		if !ok {
+			continue
+		}
+		if rel, err := filepath.Rel(root, filePath); err == nil {
+			entry["file_path"] = rel
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockeryMCPServer_DiscoverFields(t *testing.T) {
+	dir := t.TempDir()
+	file := writeFile(t, dir, "repo.go", "package repo\n\ntype Repo interface {\n\tGet() error\n\tPut() error\n}\n\ntype cache interface{ Flush() }\n")
+
+	s := newTestServer(t)
+
+	t.Run("default fields", func(t *testing.T) {
+		text := responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+			"project_path": dir,
+		}))
+		assert.Contains(t, text, "- Repo (repo package) - 2 methods\n  File: "+file+"\n")
+		assert.NotContains(t, text, "Methods:")
+		assert.NotContains(t, text, "exported")
+	})
+
+	t.Run("selected fields", func(t *testing.T) {
+		text := responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+			"project_path": dir,
+			"fields":       []interface{}{"name", "methods", "line_number", "exported"},
+		}))
+		assert.Contains(t, text, "- Repo - exported\n  Line: 3\n  Methods: Get, Put")
+		assert.Contains(t, text, "- cache - unexported\n  Line: 8\n  Methods: Flush")
+		assert.NotContains(t, text, "repo package")
+		assert.NotContains(t, text, "File:")
+	})
+
+	t.Run("line number joins file path", func(t *testing.T) {
+		text := responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+			"project_path": dir,
+			"fields":       []interface{}{"name", "file_path", "line_number"},
+		}))
+		assert.Contains(t, text, "- Repo\n  File: "+filepath.Join(dir, "repo.go")+":3")
+	})
+
+	t.Run("relative paths", func(t *testing.T) {
+		root := t.TempDir()
+		writeFile(t, root, "repo.go", "package repo\n\ntype Repo interface{ Get() error }\n")
+		writeFile(t, root, "sub/store.go", "package sub\n\ntype Store interface{ Load() error }\n")
+
+		text := responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+			"project_path":   root,
+			"fields":         []interface{}{"name", "file_path", "line_number"},
+			"relative_paths": true,
+		}))
+		assert.Contains(t, text, "- Repo\n  File: repo.go:3")
+		assert.Contains(t, text, "- Store\n  File: "+filepath.Join("sub", "store.go")+":3")
+		assert.NotContains(t, text, "File: "+root)
+	})
+
+	t.Run("range", func(t *testing.T) {
+		text := responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+			"project_path": dir,
+			"fields":       []interface{}{"name", "range"},
+		}))
+		assert.Contains(t, text, "- Repo\n  Range: 3:6-6:2")
+		assert.Contains(t, text, "- cache\n  Range: 8:6-8:32")
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		response := callTool(t, s, "discover_interfaces", map[string]interface{}{
+			"project_path": dir,
+			"fields":       []interface{}{"name", "signature"},
+		})
+		assert.NotNil(t, response.Error)
+		assert.Equal(t, -32602, response.Error.Code)
+		assert.Contains(t, response.Error.Data, `unknown field "signature"`)
+	})
+}
@@ -0,0 +1,215 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/format"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// goimportsCommand is the goimports executable format_file runs when it is
+// installed
+var goimportsCommand = "goimports"
+
+// maxDiffCells bounds the size of the table lineDiff builds; larger changes
+// are summarized instead of diffed
+const maxDiffCells = 4 << 20
+
+// formatResult describes a formatted Go file
+type formatResult struct {
+	Tools   []string // Formatters that were applied, in order
+	Changed bool
+	Diff    string
+	Note    string // Why a formatter was skipped, if one was
+}
+
+// formatGoFile formats a Go file with go/format and, when it is installed,
+// goimports. The file is rewritten only if formatting changed it and write is
+// set. Formatting needs the file to parse but not to type-check; if goimports
+// fails the gofmt result is kept.
+func (s *MockeryMCPServer) formatGoFile(ctx context.Context, path string, write bool) (*formatResult, error) {
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	formatted, err := format.Source(original)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format %s: %w", path, err)
+	}
+	result := &formatResult{Tools: []string{"gofmt"}}
+
+	if _, err := exec.LookPath(goimportsCommand); err != nil {
+		result.Note = "goimports is not installed"
+	} else {
+		cmd := exec.CommandContext(ctx, goimportsCommand, "-srcdir", filepath.Dir(path))
+		cmd.Stdin = bytes.NewReader(formatted)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			s.requestLogger(ctx).Warn("goimports failed", zap.String("file", path), zap.Error(err))
+			result.Note = fmt.Sprintf("goimports failed: %s", strings.TrimSpace(stderr.String()))
+		} else {
+			formatted = output
+			result.Tools = append(result.Tools, "goimports")
+		}
+	}
+
+	result.Changed = !bytes.Equal(original, formatted)
+	if !result.Changed {
+		return result, nil
+	}
+	result.Diff = lineDiff(path, string(original), string(formatted))
+
+	if write {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat file %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, formatted, info.Mode().Perm()); err != nil {
+			return nil, fmt.Errorf("failed to write file %s: %w", path, err)
+		}
+	}
+	return result, nil
+}
+
+// handleFormatFile implements the format_file tool
+func (s *MockeryMCPServer) handleFormatFile(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	filePath, ok := args["file_path"].(string)
+	if !ok || filePath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid file_path", nil)
+	}
+	dryRun, _ := args["dry_run"].(bool)
+
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", filePath), err)
+	}
+
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("File does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	result, err := s.formatGoFile(ctx, absPath, !dryRun)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to format file", err)
+	}
+
+	var text strings.Builder
+	switch {
+	case !result.Changed:
+		fmt.Fprintf(&text, "%s is already formatted (%s)", absPath, strings.Join(result.Tools, ", "))
+	case dryRun:
+		fmt.Fprintf(&text, "%s needs formatting (%s); not written", absPath, strings.Join(result.Tools, ", "))
+	default:
+		fmt.Fprintf(&text, "Formatted %s (%s)", absPath, strings.Join(result.Tools, ", "))
+	}
+	if result.Note != "" {
+		fmt.Fprintf(&text, "\nNote: %s", result.Note)
+	}
+	if result.Diff != "" {
+		fmt.Fprintf(&text, "\n\n%s", result.Diff)
+	}
+	return s.textResponse(requestID, text.String())
+}
+
+// lineDiff returns a unified diff between two versions of a file, with three
+// lines of context around each change
+func lineDiff(path, before, after string) string {
+	a := strings.SplitAfter(before, "\n")
+	b := strings.SplitAfter(after, "\n")
+	if a[len(a)-1] == "" {
+		a = a[:len(a)-1]
+	}
+	if b[len(b)-1] == "" {
+		b = b[:len(b)-1]
+	}
+	if len(a)*len(b) > maxDiffCells {
+		return fmt.Sprintf("(diff omitted: %d lines before, %d after)", len(a), len(b))
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	// Each edit is a line prefixed with ' ', '-' or '+'
+	type edit struct {
+		op           byte
+		line         string
+		aLine, bLine int
+	}
+	var edits []edit
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			edits = append(edits, edit{' ', a[i], i, j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, edit{'-', a[i], i, j})
+			i++
+		default:
+			edits = append(edits, edit{'+', b[j], i, j})
+			j++
+		}
+	}
+
+	const contextLines = 3
+	var text strings.Builder
+	fmt.Fprintf(&text, "--- a/%s\n+++ b/%s\n", filepath.Base(path), filepath.Base(path))
+	for start := 0; start < len(edits); {
+		// Find the next change and extend the hunk while changes are close
+		first := start
+		for first < len(edits) && edits[first].op == ' ' {
+			first++
+		}
+		if first == len(edits) {
+			break
+		}
+		last := first
+		for k := first; k < len(edits) && k <= last+2*contextLines; k++ {
+			if edits[k].op != ' ' {
+				last = k
+			}
+		}
+
+		from := max(first-contextLines, start)
+		to := min(last+contextLines+1, len(edits))
+		aCount, bCount := 0, 0
+		for _, e := range edits[from:to] {
+			if e.op != '+' {
+				aCount++
+			}
+			if e.op != '-' {
+				bCount++
+			}
+		}
+		fmt.Fprintf(&text, "@@ -%d,%d +%d,%d @@\n", edits[from].aLine+1, aCount, edits[from].bLine+1, bCount)
+		for _, e := range edits[from:to] {
+			text.WriteByte(e.op)
+			text.WriteString(strings.TrimSuffix(e.line, "\n"))
+			text.WriteByte('\n')
+		}
+		start = to
+	}
+	return strings.TrimSuffix(text.String(), "\n")
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const unformattedMock = `package mocks
+
+type MockRepo struct{
+  calls   int
+}
+
+func (m *MockRepo) Get( ) error {
+return nil
+}
+`
+
+const formattedMock = `package mocks
+
+type MockRepo struct {
+	calls int
+}
+
+func (m *MockRepo) Get() error {
+	return nil
+}
+`
+
+// withoutGoimports points format_file at a goimports that doesn't exist
+func withoutGoimports(t *testing.T) {
+	t.Helper()
+	previous := goimportsCommand
+	goimportsCommand = filepath.Join(t.TempDir(), "goimports")
+	t.Cleanup(func() { goimportsCommand = previous })
+}
+
+func TestMockeryMCPServer_FormatFile(t *testing.T) {
+	withoutGoimports(t)
+	s := newTestServer(t)
+
+	t.Run("formats and returns a diff", func(t *testing.T) {
+		path := writeFile(t, t.TempDir(), "mock_repo.go", unformattedMock)
+
+		text := responseText(t, callTool(t, s, "format_file", map[string]interface{}{"file_path": path}))
+		assert.Contains(t, text, "Formatted "+path+" (gofmt)")
+		assert.Contains(t, text, "Note: goimports is not installed")
+		assert.Contains(t, text, "--- a/mock_repo.go\n+++ b/mock_repo.go\n@@ -1,9 +1,9 @@")
+		assert.Contains(t, text, "-type MockRepo struct{\n-  calls   int\n+type MockRepo struct {\n+\tcalls int")
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, formattedMock, string(content))
+
+		text = responseText(t, callTool(t, s, "format_file", map[string]interface{}{"file_path": path}))
+		assert.Contains(t, text, "is already formatted")
+	})
+
+	t.Run("dry run leaves the file alone", func(t *testing.T) {
+		path := writeFile(t, t.TempDir(), "mock_repo.go", unformattedMock)
+
+		text := responseText(t, callTool(t, s, "format_file", map[string]interface{}{"file_path": path, "dry_run": true}))
+		assert.Contains(t, text, "needs formatting")
+		assert.Contains(t, text, "+\treturn nil")
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, unformattedMock, string(content))
+	})
+
+	t.Run("code that doesn't type-check is still formatted", func(t *testing.T) {
+		path := writeFile(t, t.TempDir(), "stub.go", "package stub\n\nfunc Get( ) Missing {\nreturn undefined\n}\n")
+
+		responseText(t, callTool(t, s, "format_file", map[string]interface{}{"file_path": path}))
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "package stub\n\nfunc Get() Missing {\n\treturn undefined\n}\n", string(content))
+	})
+
+	t.Run("syntax errors fail", func(t *testing.T) {
+		path := writeFile(t, t.TempDir(), "broken.go", "package broken\n\nfunc {\n")
+
+		response := callTool(t, s, "format_file", map[string]interface{}{"file_path": path})
+		require.NotNil(t, response.Error)
+		assert.Contains(t, response.Error.Message, "Failed to format file")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		response := callTool(t, s, "format_file", map[string]interface{}{"file_path": filepath.Join(t.TempDir(), "missing.go")})
+		requireErrorKind(t, response, CodePathNotFound, KindPathNotFound)
+	})
+}
+
+func TestMockeryMCPServer_FormatFile_Goimports(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake goimports requires a POSIX shell")
+	}
+	s := newTestServer(t)
+
+	previous := goimportsCommand
+	t.Cleanup(func() { goimportsCommand = previous })
+
+	t.Run("applies goimports output", func(t *testing.T) {
+		goimportsCommand = writeFile(t, t.TempDir(), "goimports", "#!/bin/sh\ncat\necho '// imports fixed'\n")
+		require.NoError(t, os.Chmod(goimportsCommand, 0755))
+		path := writeFile(t, t.TempDir(), "mock_repo.go", unformattedMock)
+
+		text := responseText(t, callTool(t, s, "format_file", map[string]interface{}{"file_path": path}))
+		assert.Contains(t, text, "(gofmt, goimports)")
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, formattedMock+"// imports fixed\n", string(content))
+	})
+
+	t.Run("keeps the gofmt result when goimports fails", func(t *testing.T) {
+		goimportsCommand = writeFile(t, t.TempDir(), "goimports", "#!/bin/sh\necho 'could not resolve imports' >&2\nexit 1\n")
+		require.NoError(t, os.Chmod(goimportsCommand, 0755))
+		path := writeFile(t, t.TempDir(), "mock_repo.go", unformattedMock)
+
+		text := responseText(t, callTool(t, s, "format_file", map[string]interface{}{"file_path": path}))
+		assert.Contains(t, text, "Note: goimports failed: could not resolve imports")
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, formattedMock, string(content))
+	})
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// handleGenerateFuncMock implements the generate_func_mock tool. It accepts
+// generate_mock's options, with function_name in place of interface_name,
+// and generates a mock of a named function type.
+func (s *MockeryMCPServer) handleGenerateFuncMock(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	functionName, ok := args["function_name"].(string)
+	if !ok || functionName == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid function_name", nil)
+	}
+	if recursive, _ := args["recursive"].(bool); recursive {
+		return s.errorResponse(requestID, -32602, "Invalid recursive", "generate_func_mock generates a single function type mock; recursive is not supported")
+	}
+
+	generateArgs := make(map[string]interface{}, len(args))
+	for name, value := range args {
+		generateArgs[name] = value
+	}
+	generateArgs["interface_name"] = functionName
+
+	request, errResponse := s.parseGenerateMockArgs(requestID, generateArgs)
+	if errResponse != nil {
+		return errResponse
+	}
+	request.FunctionType = true
+
+	result, err := s.GenerateMock(ctx, request)
+	if err != nil {
+		s.requestLogger(ctx).Error("Function mock generation failed", zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to generate mock", err)
+	}
+
+	if request.ReturnContent {
+		return s.mockContentResponse(requestID, request, result)
+	}
+
+	text := fmt.Sprintf("Mock generated successfully:\n- Function type: %s\n- Package: %s\n- Generated: %s",
+		request.InterfaceName, request.PackagePath, result.GeneratedFile)
+	if result.MockeryVersion != "" {
+		text += fmt.Sprintf("\n- Mockery version: %s", result.MockeryVersion)
+	}
+	return s.textResponse(requestID, text)
+}
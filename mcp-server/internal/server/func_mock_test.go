@@ -0,0 +1,80 @@
+package server
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_GenerateFuncMock(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "handler.go", "package handler\n\ntype HandlerFunc func(event string) error\n\ntype Handler interface{ Handle(event string) error }\n")
+
+	t.Run("generates a function type mock", func(t *testing.T) {
+		s := newTestServer(t)
+		lastArgs := recordingMockery(t, s)
+		outputDir := t.TempDir()
+
+		text := responseText(t, callTool(t, s, "generate_func_mock", map[string]interface{}{
+			"function_name": "HandlerFunc",
+			"package_path":  dir,
+			"output_dir":    outputDir,
+		}))
+		assert.Contains(t, text, "- Function type: HandlerFunc")
+		assert.Contains(t, text, filepath.Join(outputDir, "mock_handler_func.go"))
+
+		args := lastArgs()
+		assert.Contains(t, args, "--name=HandlerFunc")
+		assert.Contains(t, args, "--disable-func-mocks=false")
+
+		// The request is recorded so regenerate_mocks mocks a function type too
+		mocks := s.projectManager.GetGeneratedMocks("")
+		require.Len(t, mocks, 1)
+		assert.True(t, mocks[0].Request.FunctionType)
+		assert.Empty(t, mocks[0].SourceHash)
+	})
+
+	t.Run("interfaces are not function types", func(t *testing.T) {
+		s := newTestServer(t)
+		useFakeMockery(t, s, fakeMockeryScript)
+
+		response := callTool(t, s, "generate_func_mock", map[string]interface{}{
+			"function_name": "Handler",
+			"package_path":  dir,
+			"output_dir":    t.TempDir(),
+		})
+		requireErrorKind(t, response, CodeInterfaceNotFound, KindInterfaceNotFound)
+		assert.Contains(t, response.Error.Data.(ErrorData).Detail, "function type Handler not found")
+		assert.Contains(t, response.Error.Data.(ErrorData).Detail, "found: HandlerFunc")
+	})
+
+	t.Run("generate_mock doesn't pass the flag", func(t *testing.T) {
+		s := newTestServer(t)
+		lastArgs := recordingMockery(t, s)
+
+		responseText(t, callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "Handler",
+			"package_path":   dir,
+			"output_dir":     t.TempDir(),
+		}))
+		assert.NotContains(t, lastArgs(), "--disable-func-mocks=false")
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		s := newTestServer(t)
+
+		response := callTool(t, s, "generate_func_mock", map[string]interface{}{"package_path": dir})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, "Missing or invalid function_name", response.Error.Message)
+
+		response = callTool(t, s, "generate_func_mock", map[string]interface{}{
+			"function_name": "HandlerFunc",
+			"package_path":  dir,
+			"recursive":     true,
+		})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, "Invalid recursive", response.Error.Message)
+	})
+}
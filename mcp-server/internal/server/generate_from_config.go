@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// ConfigGenerationResult reports the outcome of generating one interface
+// listed in a mockery config
+type ConfigGenerationResult struct {
+	Package       string `json:"package"`
+	InterfaceName string `json:"interface_name"`
+	FilePath      string `json:"file_path,omitempty"`
+	Success       bool   `json:"success"`
+	Error         string `json:"error,omitempty"`
+}
+
+// GenerateFromConfig generates a mock for every interface listed in a
+// mockery config, reported in package then interface order. Interfaces of
+// the same package share a mockery run. Relative dir and boilerplate-file
+// entries are resolved from the config file's directory, as mockery does.
+func (s *MockeryMCPServer) GenerateFromConfig(ctx context.Context, configPath string, mockeryConfig *types.MockeryConfig) []ConfigGenerationResult {
+	configDir := filepath.Dir(configPath)
+	moduleRoot, modulePath := scanner.FindModule(configDir)
+
+	packagePaths := make([]string, 0, len(mockeryConfig.Packages))
+	for packagePath := range mockeryConfig.Packages {
+		packagePaths = append(packagePaths, packagePath)
+	}
+	sort.Strings(packagePaths)
+
+	var (
+		results  []ConfigGenerationResult
+		requests []*types.MockGenerationRequest
+	)
+	for _, packagePath := range packagePaths {
+		interfaces := mockeryConfig.Packages[packagePath].Interfaces
+		names := make([]string, 0, len(interfaces))
+		for name := range interfaces {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			results = append(results, ConfigGenerationResult{Package: packagePath, InterfaceName: name})
+			requests = append(requests, configInterfaceRequest(mockeryConfig, interfaces[name].Config, packagePath, name, configDir, moduleRoot, modulePath))
+		}
+	}
+
+	for i, result := range s.GenerateMockBatch(ctx, requests) {
+		if !result.Success {
+			s.requestLogger(ctx).Warn("Failed to generate mock from config", zap.String("interface", results[i].InterfaceName), zap.String("error", result.ErrorMessage))
+			results[i].Error = result.ErrorMessage
+			continue
+		}
+		results[i].Success = true
+		results[i].FilePath = result.GeneratedFile
+	}
+	return results
+}
+
+// configInterfaceRequest builds the generation request for an interface
+// listed in a mockery config. Packages inside the config's module are
+// generated from their directory; anything else is resolved as an import
+// path from the config's directory.
+func configInterfaceRequest(mockeryConfig *types.MockeryConfig, settings types.InterfaceSettings, packagePath, interfaceName, configDir, moduleRoot, modulePath string) *types.MockGenerationRequest {
+	request := &types.MockGenerationRequest{
+		InterfaceName:   interfaceName,
+		PackagePath:     packagePath,
+		ProjectPath:     configDir,
+		OutputDir:       resolveConfigPath(configDir, settings.Dir),
+		FilenameFormat:  settings.Filename,
+		OutPkg:          settings.OutPkg,
+		WithExpector:    mockeryConfig.WithExpector,
+		BoilerplateFile: resolveConfigPath(configDir, mockeryConfig.BoilerplateFile),
+	}
+	if request.FilenameFormat == "" {
+		request.FilenameFormat = mockeryConfig.Filename
+	}
+	if request.OutPkg == "" {
+		request.OutPkg = mockeryConfig.OutPkg
+	}
+
+	if modulePath != "" {
+		if packagePath == modulePath {
+			request.PackagePath = moduleRoot
+		} else if rest, ok := strings.CutPrefix(packagePath, modulePath+"/"); ok {
+			request.PackagePath = filepath.Join(moduleRoot, filepath.FromSlash(rest))
+		}
+	}
+	return request
+}
+
+// resolveConfigPath resolves a path from a mockery config against the
+// config file's directory
+func resolveConfigPath(configDir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(configDir, path)
+}
+
+// handleGenerateFromConfig implements the generate_from_config tool
+func (s *MockeryMCPServer) handleGenerateFromConfig(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	configPath, _ := args["config_path"].(string)
+	projectPath, _ := args["project_path"].(string)
+	if configPath == "" && projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing project_path or config_path", nil)
+	}
+
+	absPath, err := resolveMockeryConfigPath(projectPath, configPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Mockery config not found", err)
+	}
+
+	mockeryConfig, err := s.configManager.ReadConfigFile(absPath)
+	if err != nil {
+		return s.errorResponse(requestID, -32602, "Invalid mockery config", err.Error())
+	}
+
+	results := s.GenerateFromConfig(ctx, absPath, mockeryConfig)
+
+	succeeded := 0
+	for _, result := range results {
+		if result.Success {
+			succeeded++
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Generation cancelled after %d of %d mocks", succeeded, len(results)), err)
+	}
+
+	report, err := json.MarshalIndent(map[string]interface{}{
+		"config":    absPath,
+		"total":     len(results),
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+		"results":   results,
+	}, "", "  ")
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to render generation report", err)
+	}
+
+	return s.textResponse(requestID, string(report))
+}
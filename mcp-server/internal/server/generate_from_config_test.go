@@ -0,0 +1,99 @@
+package server
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_GenerateFromConfig(t *testing.T) {
+	project := t.TempDir()
+	writeFile(t, project, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	writeFile(t, project, "domain/repo.go", "package domain\n\ntype Repo interface{ Get() error }\n\ntype Cache interface{ Flush() }\n")
+	writeFile(t, project, "billing/billing.go", "package billing\n\ntype Invoicer interface{ Send() error }\n")
+	writeFile(t, project, ".mockery.yaml", `with-expecter: true
+filename: "mock_{{.InterfaceName}}.go"
+outpkg: fakes
+packages:
+  example.com/app/billing:
+    interfaces:
+      Invoicer:
+        config:
+          dir: mocks/billing
+  example.com/app/domain:
+    interfaces:
+      Repo:
+        config:
+          dir: mocks/domain
+      Cache:
+        config:
+          dir: mocks/domain
+          filename: cache_mock.go
+          outpkg: cachemocks
+  example.com/app/missing:
+    interfaces:
+      Gone:
+        config:
+          dir: mocks/missing
+`)
+
+	s := newTestServer(t)
+	configCopy := filepath.Join(t.TempDir(), "batch.yaml")
+	t.Setenv("CONFIG_COPY", configCopy)
+	runs := useCountingMockery(t, s)
+
+	response := callTool(t, s, "generate_from_config", map[string]interface{}{
+		"project_path": project,
+	})
+
+	var report struct {
+		Total     int                      `json:"total"`
+		Succeeded int                      `json:"succeeded"`
+		Failed    int                      `json:"failed"`
+		Results   []ConfigGenerationResult `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(responseText(t, response)), &report))
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, 3, report.Succeeded)
+	assert.Equal(t, 1, report.Failed)
+
+	require.Len(t, report.Results, 4)
+	assert.Equal(t, "Invoicer", report.Results[0].InterfaceName)
+	assert.Equal(t, filepath.Join(project, "mocks", "billing", "mock_Invoicer.go"), report.Results[0].FilePath)
+	assert.Equal(t, "Cache", report.Results[1].InterfaceName)
+	assert.Equal(t, filepath.Join(project, "mocks", "domain", "cache_mock.go"), report.Results[1].FilePath)
+	assert.Equal(t, "Repo", report.Results[2].InterfaceName)
+	assert.Equal(t, filepath.Join(project, "mocks", "domain", "mock_Repo.go"), report.Results[2].FilePath)
+	assert.True(t, report.Results[2].Success)
+
+	assert.Equal(t, "example.com/app/missing", report.Results[3].Package)
+	assert.False(t, report.Results[3].Success)
+	assert.NotEmpty(t, report.Results[3].Error)
+
+	// The interfaces of one package share a run driven by a config, with
+	// outpkg taken from the interface or else the top level
+	require.Len(t, runs(), 2)
+	assert.Contains(t, runs()[0], "--config=")
+	config, err := os.ReadFile(configCopy)
+	require.NoError(t, err)
+	assert.Contains(t, string(config), "example.com/app/domain:")
+	assert.Contains(t, string(config), "outpkg: cachemocks")
+	assert.Contains(t, string(config), "outpkg: fakes")
+	assert.Contains(t, string(config), "with-expecter: true")
+
+	// A package with a single interface gets a run of its own, generated
+	// from its directory
+	assert.Contains(t, runs()[1], "--dir="+filepath.Join(project, "billing"))
+	assert.Contains(t, runs()[1], "--outpkg=fakes")
+
+	t.Run("missing config", func(t *testing.T) {
+		response := callTool(t, s, "generate_from_config", map[string]interface{}{
+			"project_path": t.TempDir(),
+		})
+		requireErrorKind(t, response, CodePathNotFound, KindPathNotFound)
+	})
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// packageGroup holds the listing entries for the interfaces of one package
+type packageGroup struct {
+	path       string
+	name       string
+	doc        string // Package comment, if the package has one
+	interfaces []map[string]interface{}
+}
+
+// groupInterfacesByPackage nests listing entries under the package they are
+// declared in, sorted by package path. Packages are identified by import path
+// when the project is inside a module, and by directory otherwise. docs maps
+// package directories to package comments, as reported by the scanner.
+func groupInterfacesByPackage(interfaces []types.InterfaceDefinition, simplified []map[string]interface{}, docs map[string]string, moduleRoot, modulePath string) []*packageGroup {
+	groups := make(map[string]*packageGroup)
+	for i, iface := range interfaces {
+		dir := filepath.Dir(iface.FilePath)
+		pkgPath := packagePath(dir, moduleRoot, modulePath)
+		group, ok := groups[pkgPath]
+		if !ok {
+			group = &packageGroup{path: pkgPath, name: iface.Package, doc: docs[dir]}
+			groups[pkgPath] = group
+		}
+		group.interfaces = append(group.interfaces, simplified[i])
+	}
+
+	result := make([]*packageGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].path < result[j].path
+	})
+	return result
+}
+
+// packagePath returns the import path of the package in dir, or dir itself
+// when it is outside the module
+func packagePath(dir, moduleRoot, modulePath string) string {
+	if modulePath == "" {
+		return dir
+	}
+	rel, err := filepath.Rel(moduleRoot, dir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return dir
+	}
+	if rel == "." {
+		return modulePath
+	}
+	return path.Join(modulePath, filepath.ToSlash(rel))
+}
+
+// formatGroupedInterfaceList formats package groups for display, listing each
+// package's interfaces beneath it
+func formatGroupedInterfaceList(groups []*packageGroup) string {
+	var result strings.Builder
+	for i, group := range groups {
+		if i > 0 {
+			result.WriteString("\n\n")
+		}
+		result.WriteString(fmt.Sprintf("%s (%s package) - %d interfaces\n", group.path, group.name, len(group.interfaces)))
+		if group.doc != "" {
+			result.WriteString(formatPackageDoc(group.doc, "  ") + "\n")
+		}
+		listing := formatInterfaceList(group.interfaces)
+		result.WriteString("  " + strings.ReplaceAll(listing, "\n", "\n  "))
+	}
+	return result.String()
+}
+
+// formatPackageDoc renders a package comment as indented // comment lines
+func formatPackageDoc(doc, indent string) string {
+	lines := strings.Split(doc, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(indent+"// "+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatPackageDocs formats the package comments of the listed packages as
+// a section of their own, for listings that aren't grouped by package
+func formatPackageDocs(groups []*packageGroup) string {
+	var result strings.Builder
+	for _, group := range groups {
+		if group.doc == "" {
+			continue
+		}
+		if result.Len() == 0 {
+			result.WriteString("Package documentation:")
+		}
+		result.WriteString(fmt.Sprintf("\n- %s\n%s", group.path, formatPackageDoc(group.doc, "  ")))
+	}
+	return result.String()
+}
+
+// discoveryIndex is the keyed form of a discovery result, for clients that
+// cache interfaces by qualified name
+type discoveryIndex struct {
+	Module     string                            `json:"module,omitempty"`
+	Version    string                            `json:"version"`
+	Interfaces map[string]map[string]interface{} `json:"interfaces"`
+	Warnings   []string                          `json:"warnings,omitempty"`
+	Skipped    []string                          `json:"skipped,omitempty"`
+}
+
+// indexDiscovery keys listing entries by qualified name. When two interfaces
+// share a name only the first is kept, and the collision becomes a warning.
+func indexDiscovery(interfaces []types.InterfaceDefinition, simplified []map[string]interface{}, modulePath, version string, scanErrors []string) discoveryIndex {
+	index, warnings := scanner.IndexInterfaces(interfaces)
+	result := discoveryIndex{
+		Module:     modulePath,
+		Version:    version,
+		Interfaces: make(map[string]map[string]interface{}, len(index)),
+		Warnings:   warnings,
+		Skipped:    scanErrors,
+	}
+	for i, iface := range interfaces {
+		key := scanner.QualifiedName(iface)
+		kept := index[key]
+		if kept.FilePath != iface.FilePath || kept.LineNumber != iface.LineNumber {
+			continue
+		}
+		result.Interfaces[key] = simplified[i]
+	}
+	return result
+}
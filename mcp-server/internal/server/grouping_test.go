@@ -0,0 +1,118 @@
+package server
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_DiscoverGroupByPackage(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	writeFile(t, root, "domain/repo.go", "package domain\n\ntype Repo interface{ Get() error }\n\ntype Cache interface{ Flush() }\n")
+	writeFile(t, root, "notify/email.go", "package notify\n\ntype Sender interface{ Send(to string) error }\n")
+
+	s := newTestServer(t)
+
+	text := responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+		"project_path":     root,
+		"group_by_package": true,
+	}))
+
+	assert.Contains(t, text, "Found 3 interfaces")
+	assert.Contains(t, text, "Packages: 2")
+	assert.Contains(t, text, "example.com/app/domain (domain package) - 2 interfaces\n"+
+		"  - Repo (domain package) - 1 methods\n"+
+		"    File: "+filepath.Join(root, "domain", "repo.go")+"\n"+
+		"  - Cache (domain package) - 1 methods")
+	assert.Contains(t, text, "\n\nexample.com/app/notify (notify package) - 1 interfaces\n  - Sender")
+
+	t.Run("flat by default", func(t *testing.T) {
+		text := responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+			"project_path": root,
+		}))
+		assert.NotContains(t, text, "Packages:")
+		assert.NotContains(t, text, "example.com/app/domain (")
+	})
+}
+
+func TestPackagePath(t *testing.T) {
+	root := filepath.Join(string(filepath.Separator), "work", "app")
+
+	assert.Equal(t, "example.com/app", packagePath(root, root, "example.com/app"))
+	assert.Equal(t, "example.com/app/internal/domain", packagePath(filepath.Join(root, "internal", "domain"), root, "example.com/app"))
+	// Directories outside the module, or without one, fall back to the path
+	outside := filepath.Join(string(filepath.Separator), "elsewhere")
+	assert.Equal(t, outside, packagePath(outside, root, "example.com/app"))
+	assert.Equal(t, outside, packagePath(outside, "", ""))
+}
+
+func TestMockeryMCPServer_DiscoverPackageDocs(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	writeFile(t, root, "domain/doc.go", "// Package domain holds the core types.\n//\n// It has no dependencies.\npackage domain\n")
+	writeFile(t, root, "domain/repo.go", "package domain\n\ntype Repo interface{ Get() error }\n")
+	writeFile(t, root, "notify/email.go", "package notify\n\ntype Sender interface{ Send(to string) error }\n")
+	writeFile(t, root, "util/util.go", "// Package util has no interfaces.\npackage util\n")
+
+	s := newTestServer(t)
+
+	text := responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+		"project_path": root,
+	}))
+	assert.Contains(t, text, "\n\nPackage documentation:\n"+
+		"- example.com/app/domain\n"+
+		"  // Package domain holds the core types.\n"+
+		"  //\n"+
+		"  // It has no dependencies.")
+	assert.NotContains(t, text, "notify\n")
+	assert.NotContains(t, text, "Package util")
+
+	text = responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+		"project_path":     root,
+		"group_by_package": true,
+	}))
+	assert.Contains(t, text, "example.com/app/domain (domain package) - 1 interfaces\n"+
+		"  // Package domain holds the core types.\n"+
+		"  //\n"+
+		"  // It has no dependencies.\n"+
+		"  - Repo")
+	assert.NotContains(t, text, "Package documentation:")
+}
+
+func TestMockeryMCPServer_DiscoverIndex(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.21\n")
+	writeFile(t, root, "domain/repo.go", "package domain\n\ntype Repo interface{ Get() error }\n")
+	writeFile(t, root, "domain/store.go", "package domain\n\ntype Store interface{ Put() error }\n")
+	writeFile(t, root, "legacy/domain/repo.go", "package domain\n\ntype Repo interface{ Find() error }\n")
+
+	s := newTestServer(t)
+	text := responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+		"project_path": root,
+		"index":        true,
+		"fields":       []interface{}{"name", "file_path"},
+	}))
+
+	var index struct {
+		Module     string                            `json:"module"`
+		Version    string                            `json:"version"`
+		Interfaces map[string]map[string]interface{} `json:"interfaces"`
+		Warnings   []string                          `json:"warnings"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(text), &index))
+	assert.Equal(t, "example.com/app", index.Module)
+	assert.NotEmpty(t, index.Version)
+	require.Len(t, index.Interfaces, 2)
+	assert.Equal(t, map[string]interface{}{
+		"name":      "Repo",
+		"file_path": filepath.Join(root, "domain", "repo.go"),
+	}, index.Interfaces["domain.Repo"])
+	assert.Equal(t, "Store", index.Interfaces["domain.Store"]["name"])
+	require.Len(t, index.Warnings, 1)
+	assert.Contains(t, index.Warnings[0], "domain.Repo is declared in both")
+	assert.Contains(t, index.Warnings[0], filepath.Join(root, "legacy", "domain", "repo.go"))
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+func TestMockeryMCPServer_InterfaceHash(t *testing.T) {
+	filePath := writeFile(t, t.TempDir(), "repo.go", "package repo\n\ntype Repo interface{ Get(id string) error }\n")
+
+	s := newTestServer(t)
+
+	iface, err := scanner.NewGoInterfaceScanner().ExtractInterfaceMetadata(filePath, "Repo")
+	require.NoError(t, err)
+
+	text := responseText(t, callTool(t, s, "interface_hash", map[string]interface{}{
+		"file_path":      filePath,
+		"interface_name": "Repo",
+	}))
+	assert.Equal(t, "Interface: Repo\nMethods: 1\nHash: "+scanner.InterfaceSignatureHash(*iface), text)
+
+	t.Run("embedded interfaces", func(t *testing.T) {
+		dir := t.TempDir()
+		filePath := writeFile(t, dir, "rw.go", "package rw\n\ntype Reader interface{ Read() error }\n\ntype ReadWriter interface {\n\tReader\n\tWrite() error\n}\n")
+
+		// The hash matches the one discovery and mock_status compute
+		interfaces, err := scanner.NewGoInterfaceScanner().ScanProject(dir)
+		require.NoError(t, err)
+		var discovered *types.InterfaceDefinition
+		for i := range interfaces {
+			if interfaces[i].Name == "ReadWriter" {
+				discovered = &interfaces[i]
+			}
+		}
+		require.NotNil(t, discovered)
+
+		text := responseText(t, callTool(t, s, "interface_hash", map[string]interface{}{
+			"file_path":      filePath,
+			"interface_name": "ReadWriter",
+		}))
+		assert.Equal(t, "Interface: ReadWriter\nMethods: 2\nHash: "+scanner.InterfaceSignatureHash(*discovered), text)
+	})
+
+	t.Run("unknown interface", func(t *testing.T) {
+		response := callTool(t, s, "interface_hash", map[string]interface{}{
+			"file_path":      filePath,
+			"interface_name": "Missing",
+		})
+		requireErrorKind(t, response, CodeInterfaceNotFound, KindInterfaceNotFound)
+	})
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// changedGenerationOption names the first option affecting a mock's content
+// that differs between the requests of two generations of the same file, or
+// returns "" if there is none. Options choosing the file are already the
+// same, since the file is.
+func changedGenerationOption(previous, current *types.MockGenerationRequest) string {
+	switch {
+	case previous.WithExpector != current.WithExpector:
+		return "with_expecter"
+	case previous.MockName != current.MockName:
+		return "mock_name"
+	case previous.OutPkg != current.OutPkg:
+		return "outpkg"
+	case previous.InPackage != current.InPackage:
+		return "in_package"
+	case previous.TestOnly != current.TestOnly:
+		return "test_only"
+	case previous.FunctionType != current.FunctionType:
+		return "function_type"
+	case previous.BoilerplateFile != current.BoilerplateFile:
+		return "boilerplate_file"
+	case previous.BuildTag != current.BuildTag:
+		return "build_tag"
+	case !slices.Equal(previous.ExtraArgs, current.ExtraArgs):
+		return "extra_args"
+	}
+	return ""
+}
+
+// handleGenerateMockIfChanged implements the generate_mock_if_changed tool.
+// It runs mockery only when the interface's signature hash or the request's
+// options differ from those recorded when its mock was last generated, or
+// when there is no usable earlier mock, so it is cheap to call on every
+// build.
+func (s *MockeryMCPServer) handleGenerateMockIfChanged(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	request, errResponse := s.parseGenerateMockArgs(requestID, args)
+	if errResponse != nil {
+		return errResponse
+	}
+	if request.Recursive {
+		return s.errorResponse(requestID, -32602, "Invalid recursive", "generate_mock_if_changed checks a single interface; recursive is not supported")
+	}
+	if request.ReturnContent {
+		return s.errorResponse(requestID, -32602, "Invalid return_content", "generate_mock_if_changed writes the mock it checks; return_content is not supported")
+	}
+
+	plan, err := s.planGeneration(ctx, s.config(), request)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to resolve mock generation", err)
+	}
+	mockFile := filepath.Join(plan.outputDir, plan.filename)
+
+	var sourceHash string
+	if plan.iface != nil {
+		sourceHash = scanner.InterfaceSignatureHash(*plan.iface)
+	}
+
+	reason := "no earlier generation recorded"
+	if previous, ok := s.projectManager.FindGeneratedMock(request.ProjectID, mockFile); ok {
+		option := changedGenerationOption(&previous.Request, plan.request)
+		switch {
+		case sourceHash == "":
+			reason = "interface signature unavailable for comparison"
+		case previous.SourceHash != sourceHash:
+			reason = "interface changed"
+		case option != "":
+			reason = option + " changed"
+		default:
+			if _, err := os.Stat(mockFile); err != nil {
+				reason = "mock file missing"
+				break
+			}
+			return s.textResponse(requestID, fmt.Sprintf("Mock is up to date:\n- Interface: %s\n- Mock: %s\n- Source hash: %s",
+				request.InterfaceName, mockFile, sourceHash))
+		}
+	}
+
+	result, err := s.GenerateMock(ctx, request)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to generate mock", err)
+	}
+
+	text := fmt.Sprintf("Mock regenerated (%s):\n- Interface: %s\n- Package: %s\n- Generated: %s",
+		reason, request.InterfaceName, request.PackagePath, result.GeneratedFile)
+	if result.SourceHash != "" {
+		text += fmt.Sprintf("\n- Source hash: %s", result.SourceHash)
+	}
+	return s.textResponse(requestID, text)
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_GenerateMockIfChanged(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\n// Repo stores things\ntype Repo interface{ Get() error }\n")
+	outputDir := t.TempDir()
+
+	s := newTestServer(t)
+	runs := useCountingMockery(t, s)
+	generate := func() string {
+		return responseText(t, callTool(t, s, "generate_mock_if_changed", map[string]interface{}{
+			"interface_name": "Repo",
+			"package_path":   dir,
+			"output_dir":     outputDir,
+		}))
+	}
+
+	assert.Contains(t, generate(), "Mock regenerated (no earlier generation recorded)")
+	require.Len(t, runs(), 1)
+
+	mocks := s.projectManager.GetGeneratedMocks("")
+	require.Len(t, mocks, 1)
+	assert.Len(t, mocks[0].SourceHash, 64)
+
+	t.Run("unchanged", func(t *testing.T) {
+		text := generate()
+		assert.Contains(t, text, "Mock is up to date")
+		assert.Contains(t, text, mocks[0].SourceHash)
+		assert.Len(t, runs(), 1)
+
+		// Comments and formatting don't change the signature hash
+		writeFile(t, dir, "repo.go", "package repo\n\n// Repo stores things durably\ntype Repo interface {\n\tGet() error\n}\n")
+		assert.Contains(t, generate(), "Mock is up to date")
+		assert.Len(t, runs(), 1)
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		writeFile(t, dir, "repo.go", "package repo\n\ntype Repo interface{ Get(id string) error }\n")
+		assert.Contains(t, generate(), "Mock regenerated (interface changed)")
+		assert.Len(t, runs(), 2)
+		assert.Contains(t, generate(), "Mock is up to date")
+		assert.Len(t, runs(), 2)
+	})
+
+	t.Run("options changed", func(t *testing.T) {
+		text := responseText(t, callTool(t, s, "generate_mock_if_changed", map[string]interface{}{
+			"interface_name": "Repo",
+			"package_path":   dir,
+			"output_dir":     outputDir,
+			"with_expecter":  false,
+		}))
+		assert.Contains(t, text, "Mock regenerated (with_expecter changed)")
+		assert.Len(t, runs(), 3)
+
+		// The new options are what later calls compare against
+		assert.Contains(t, generate(), "Mock regenerated (with_expecter changed)")
+		assert.Len(t, runs(), 4)
+	})
+
+	t.Run("mock deleted", func(t *testing.T) {
+		require.NoError(t, os.Remove(filepath.Join(outputDir, "mock_repo.go")))
+		assert.Contains(t, generate(), "Mock regenerated (mock file missing)")
+		assert.Len(t, runs(), 5)
+	})
+}
@@ -0,0 +1,203 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/config"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// defaultMocksDir is the directory, relative to the project, that
+// init_project generates mocks into
+const defaultMocksDir = "mocks"
+
+// handleInitProject implements the init_project tool
+func (s *MockeryMCPServer) handleInitProject(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	projectPath, ok := args["project_path"].(string)
+	if !ok || projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid project_path", nil)
+	}
+
+	outputDir := defaultMocksDir
+	if value, ok := args["output_dir"].(string); ok && value != "" {
+		outputDir = value
+	}
+	overwrite, _ := args["overwrite"].(bool)
+	generate, _ := args["generate"].(bool)
+
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", projectPath), err)
+	}
+
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Project path does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	// Mockery configs list packages by import path, so a module is required
+	moduleRoot, modulePath := scanner.FindModule(absPath)
+	if moduleRoot == "" {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("No go.mod found in or above %s", absPath),
+			fmt.Errorf("%w: no go.mod in or above %s; run go mod init first", ErrModuleNotFound, absPath))
+	}
+
+	configPath := filepath.Join(absPath, mockeryConfigFilename)
+	if value, ok := args["config_path"].(string); ok && value != "" {
+		configPath = value
+		if !filepath.IsAbs(configPath) {
+			configPath = filepath.Join(absPath, configPath)
+		}
+	}
+
+	// Any config mockery would pick up counts, not just the one being written
+	existing, found := config.FindConfigFile(absPath)
+	if _, err := os.Stat(configPath); err == nil {
+		existing, found = configPath, true
+	}
+	if found && !overwrite {
+		return s.errorResponse(requestID, -32602, "Project already initialized",
+			fmt.Sprintf("%s already exists; pass overwrite to replace it", existing))
+	}
+
+	if !filepath.IsAbs(outputDir) {
+		outputDir = filepath.Join(absPath, outputDir)
+	}
+
+	interfaces, _, err := s.scanner.ScanProjectContext(ctx, absPath, scanner.ScanOptions{})
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return s.toolErrorResponse(requestID, "Scan cancelled", err)
+	}
+	if err != nil {
+		s.logger.Error("Failed to scan project", zap.String("path", absPath), zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to scan project", fmt.Errorf("%w: %v", ErrScanFailed, err))
+	}
+	interfaces = excludeInterfaces(interfaces, s.config().ExcludeInterfaces)
+
+	// Previously generated mocks are not interfaces worth mocking
+	var mockable []types.InterfaceDefinition
+	for _, iface := range interfaces {
+		if !strings.HasPrefix(iface.FilePath, outputDir+string(filepath.Separator)) {
+			mockable = append(mockable, iface)
+		}
+	}
+
+	mockeryConfig, err := s.initialMockeryConfig(mockable, absPath, outputDir, moduleRoot, modulePath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to build mockery config", err)
+	}
+	if err := s.configManager.WriteConfigFile(mockeryConfig, configPath); err != nil {
+		return s.toolErrorResponse(requestID, "Failed to write mockery config", err)
+	}
+	if err := s.config().makeOutputDir(outputDir); err != nil {
+		return s.toolErrorResponse(requestID, "Failed to create output directory", err)
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "Initialized mockery for %s:\n- Module: %s\n- Config: %s (%d interfaces in %d packages)\n- Output directory: %s",
+		absPath, modulePath, configPath, len(mockable), len(mockeryConfig.Packages), outputDir)
+
+	if generate {
+		succeeded, failures := s.generateInitialMocks(ctx, mockable, absPath, outputDir)
+		if err := ctx.Err(); err != nil {
+			return s.toolErrorResponse(requestID, fmt.Sprintf("Generation cancelled after %d of %d mocks", succeeded, len(mockable)), err)
+		}
+		fmt.Fprintf(&text, "\n- Generated: %d succeeded, %d failed", succeeded, len(failures))
+		for _, failure := range failures {
+			fmt.Fprintf(&text, "\n  - %s", failure)
+		}
+	}
+
+	return s.textResponse(requestID, text.String())
+}
+
+// initialMockeryConfig builds a config listing every interface, with each
+// package's mocks mirrored under outputDir
+func (s *MockeryMCPServer) initialMockeryConfig(interfaces []types.InterfaceDefinition, projectPath, outputDir, moduleRoot, modulePath string) (*types.MockeryConfig, error) {
+	mockeryConfig := s.configManager.GetDefaultConfig()
+	mockeryConfig.Packages = make(map[string]types.Package)
+
+	for _, iface := range interfaces {
+		packageDir := filepath.Dir(iface.FilePath)
+		mockDir, err := mirroredMockDir(projectPath, outputDir, packageDir)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.configManager.UpdateInterfaceConfig(&mockeryConfig,
+			packagePath(packageDir, moduleRoot, modulePath),
+			iface.Name,
+			types.InterfaceSettings{
+				Dir:      mockDir,
+				Filename: resolveMockFilename(&types.MockGenerationRequest{InterfaceName: iface.Name, FilenameCase: s.config().FilenameCase}),
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &mockeryConfig, nil
+}
+
+// mirroredMockDir returns the directory for a package's mocks, mirroring its
+// location in the project under outputDir. The result is relative to the
+// project when outputDir is inside it, as mockery resolves dir entries from
+// the config file's directory.
+func mirroredMockDir(projectPath, outputDir, packageDir string) (string, error) {
+	rel, err := filepath.Rel(projectPath, packageDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute relative package path: %w", err)
+	}
+	mockDir := filepath.Join(outputDir, rel)
+
+	if relMockDir, err := filepath.Rel(projectPath, mockDir); err == nil && !strings.HasPrefix(relMockDir, "..") {
+		return filepath.ToSlash(relMockDir), nil
+	}
+	return mockDir, nil
+}
+
+// generateInitialMocks generates a mock for every interface, returning the
+// number generated and a description of each failure
+func (s *MockeryMCPServer) generateInitialMocks(ctx context.Context, interfaces []types.InterfaceDefinition, projectPath, outputDir string) (int, []string) {
+	sorted := append([]types.InterfaceDefinition(nil), interfaces...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].FilePath < sorted[j].FilePath
+	})
+
+	succeeded := 0
+	var failures []string
+	for _, iface := range sorted {
+		if ctx.Err() != nil {
+			break
+		}
+		packageDir := filepath.Dir(iface.FilePath)
+		mockDir, err := mirroredMockDir(projectPath, outputDir, packageDir)
+		if err == nil && !filepath.IsAbs(mockDir) {
+			mockDir = filepath.Join(projectPath, mockDir)
+		}
+		if err == nil {
+			_, err = s.GenerateMock(ctx, &types.MockGenerationRequest{
+				InterfaceName: iface.Name,
+				PackagePath:   packageDir,
+				OutputDir:     mockDir,
+				WithExpector:  true,
+				FilenameCase:  s.config().FilenameCase,
+			})
+		}
+		if err != nil {
+			s.logger.Warn("Initial mock generation failed", zap.String("interface", iface.Name), zap.Error(err))
+			failures = append(failures, fmt.Sprintf("%s: %v", iface.Name, err))
+			continue
+		}
+		succeeded++
+	}
+	return succeeded, failures
+}
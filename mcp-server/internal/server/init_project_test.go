@@ -0,0 +1,116 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/config"
+)
+
+func TestMockeryMCPServer_InitProject(t *testing.T) {
+	newProject := func(t *testing.T) string {
+		root := t.TempDir()
+		writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n")
+		writeFile(t, root, "internal/domain/repo.go", "package domain\n\ntype UserRepository interface{ Get() error }\n")
+		writeFile(t, root, "notify/email.go", "package notify\n\ntype Sender interface{ Send() error }\n")
+		return root
+	}
+
+	t.Run("writes config and output directory", func(t *testing.T) {
+		root := newProject(t)
+		s := newTestServer(t)
+
+		text := responseText(t, callTool(t, s, "init_project", map[string]interface{}{
+			"project_path": root,
+		}))
+		assert.Contains(t, text, "- Module: example.com/app")
+		assert.Contains(t, text, "(2 interfaces in 2 packages)")
+		assert.NotContains(t, text, "Generated:")
+		assert.DirExists(t, filepath.Join(root, "mocks"))
+
+		mockeryConfig, err := config.NewMockeryConfigManager().ReadConfigFile(filepath.Join(root, ".mockery.yaml"))
+		require.NoError(t, err)
+		assert.True(t, mockeryConfig.WithExpector)
+		settings := mockeryConfig.Packages["example.com/app/internal/domain"].Interfaces["UserRepository"].Config
+		assert.Equal(t, "mocks/internal/domain", settings.Dir)
+		assert.Equal(t, "mock_user_repository.go", settings.Filename)
+		assert.Contains(t, mockeryConfig.Packages["example.com/app/notify"].Interfaces, "Sender")
+	})
+
+	t.Run("generates the first mocks", func(t *testing.T) {
+		root := newProject(t)
+		s := newTestServer(t)
+		useFakeMockery(t, s, fakeMockeryScript)
+
+		text := responseText(t, callTool(t, s, "init_project", map[string]interface{}{
+			"project_path": root,
+			"generate":     true,
+		}))
+		assert.Contains(t, text, "- Generated: 2 succeeded, 0 failed")
+		assert.FileExists(t, filepath.Join(root, "mocks", "internal", "domain", "mock_user_repository.go"))
+		assert.FileExists(t, filepath.Join(root, "mocks", "notify", "mock_sender.go"))
+
+		// Re-initializing skips the mocks directory when scanning
+		writeFile(t, root, "mocks/notify/iface.go", "package mocks\n\ntype Stray interface{ X() }\n")
+		text = responseText(t, callTool(t, s, "init_project", map[string]interface{}{
+			"project_path": root,
+			"overwrite":    true,
+		}))
+		assert.Contains(t, text, "(2 interfaces in 2 packages)")
+	})
+
+	t.Run("refuses to replace an existing config", func(t *testing.T) {
+		root := newProject(t)
+		writeFile(t, root, ".mockery.yaml", "with-expecter: false\n")
+		s := newTestServer(t)
+
+		response := callTool(t, s, "init_project", map[string]interface{}{
+			"project_path": root,
+		})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, -32602, response.Error.Code)
+
+		data, err := os.ReadFile(filepath.Join(root, ".mockery.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, "with-expecter: false\n", string(data))
+	})
+
+	t.Run("detects configs under alternate names", func(t *testing.T) {
+		root := newProject(t)
+		existing := writeFile(t, root, ".mockery.yml", "with-expecter: false\n")
+		s := newTestServer(t)
+
+		response := callTool(t, s, "init_project", map[string]interface{}{
+			"project_path": root,
+		})
+		require.NotNil(t, response.Error)
+		assert.Contains(t, response.Error.Data, existing)
+	})
+
+	t.Run("writes to config_path", func(t *testing.T) {
+		root := newProject(t)
+		s := newTestServer(t)
+
+		text := responseText(t, callTool(t, s, "init_project", map[string]interface{}{
+			"project_path": root,
+			"config_path":  "mockery.yaml",
+		}))
+		assert.Contains(t, text, "- Config: "+filepath.Join(root, "mockery.yaml"))
+		assert.FileExists(t, filepath.Join(root, "mockery.yaml"))
+		assert.NoFileExists(t, filepath.Join(root, ".mockery.yaml"))
+	})
+
+	t.Run("requires a module", func(t *testing.T) {
+		dir := filepath.Dir(writeFile(t, t.TempDir(), "repo.go", "package repo\n\ntype Repo interface{ Get() error }\n"))
+		s := newTestServer(t)
+
+		response := callTool(t, s, "init_project", map[string]interface{}{
+			"project_path": dir,
+		})
+		requireErrorKind(t, response, CodeModuleNotFound, KindModuleNotFound)
+	})
+}
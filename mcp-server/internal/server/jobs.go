@@ -0,0 +1,170 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/models"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// ErrDraining is returned when a job is submitted after shutdown has begun
+var ErrDraining = errors.New("server is shutting down and not accepting new jobs")
+
+// defaultJobConcurrency bounds how many async jobs generate mocks at once
+const defaultJobConcurrency = 4
+
+// jobRunner executes mock generation jobs in the background. Jobs wait for
+// one of a fixed number of slots, so a job is pending until it gets one.
+type jobRunner struct {
+	server *MockeryMCPServer
+	slots  chan struct{}
+
+	// ctx is cancelled when a drain runs out of time, stopping running jobs
+	// and cancelling pending ones
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	draining bool
+	wg       sync.WaitGroup
+}
+
+// newJobRunner creates a runner that runs up to concurrency jobs at a time
+func newJobRunner(server *MockeryMCPServer, concurrency int) *jobRunner {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &jobRunner{
+		server: server,
+		slots:  make(chan struct{}, concurrency),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// submit records a job for request and starts it in the background
+func (r *jobRunner) submit(projectID string, request types.MockGenerationRequest) (*models.MockGenerationJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.draining {
+		return nil, ErrDraining
+	}
+
+	// The job's mock belongs to the job's project unless the request names one
+	if request.ProjectID == "" {
+		request.ProjectID = projectID
+	}
+	job := r.server.projectManager.CreateJob(projectID, request)
+	r.wg.Add(1)
+	go r.run(job.ID, request)
+	return job, nil
+}
+
+// run waits for a slot and generates the job's mock. Jobs still waiting
+// when a drain runs out of time are cancelled without running.
+func (r *jobRunner) run(jobID string, request types.MockGenerationRequest) {
+	defer r.wg.Done()
+	projectManager := r.server.projectManager
+
+	select {
+	case r.slots <- struct{}{}:
+	case <-r.ctx.Done():
+		projectManager.UpdateJobStatus(jobID, models.JobStatusCancelled)
+		return
+	}
+	defer func() { <-r.slots }()
+
+	// The deadline may have passed while this job won the slot
+	if r.ctx.Err() != nil {
+		projectManager.UpdateJobStatus(jobID, models.JobStatusCancelled)
+		return
+	}
+	projectManager.UpdateJobStatus(jobID, models.JobStatusRunning)
+
+	result, err := r.server.GenerateMock(r.ctx, &request)
+	switch {
+	case err == nil:
+		projectManager.SetJobResult(jobID, models.JobStatusCompleted, result)
+	case r.ctx.Err() != nil:
+		projectManager.SetJobResult(jobID, models.JobStatusCancelled, failedResult(&request, err))
+	default:
+		r.server.logger.Warn("Async mock generation failed", zap.String("job", jobID), zap.Error(err))
+		projectManager.SetJobResult(jobID, models.JobStatusFailed, failedResult(&request, err))
+	}
+}
+
+// drain stops accepting jobs and waits for those already submitted, pending
+// or running, to finish. If ctx ends first, the remaining jobs are cancelled
+// and ctx's error is returned once they have stopped.
+func (r *jobRunner) drain(ctx context.Context) error {
+	r.mu.Lock()
+	r.draining = true
+	r.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		r.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// failedResult describes a generation that did not succeed, whether run
+// as a job or as part of a batch
+func failedResult(request *types.MockGenerationRequest, err error) *types.MockGenerationResult {
+	return &types.MockGenerationResult{
+		Success:       false,
+		InterfaceName: request.InterfaceName,
+		ErrorMessage:  err.Error(),
+		GeneratedAt:   time.Now(),
+	}
+}
+
+// SubmitJob queues asynchronous generation of a mock and returns the job ID,
+// which the get_job tool reports on
+func (s *MockeryMCPServer) SubmitJob(projectID string, request types.MockGenerationRequest) (string, error) {
+	job, err := s.jobs.submit(projectID, request)
+	if err != nil {
+		return "", err
+	}
+	return job.ID, nil
+}
+
+// Drain stops accepting async jobs and waits for submitted ones to finish.
+// If ctx expires first, jobs still pending or running are cancelled.
+func (s *MockeryMCPServer) Drain(ctx context.Context) error {
+	return s.jobs.drain(ctx)
+}
+
+// handleGetJob implements the get_job tool
+func (s *MockeryMCPServer) handleGetJob(requestID interface{}, args map[string]interface{}) *MCPResponse {
+	jobID, ok := args["job_id"].(string)
+	if !ok || jobID == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid job_id", nil)
+	}
+
+	job, ok := s.projectManager.GetJob(jobID)
+	if !ok {
+		return s.errorResponse(requestID, -32602, "Invalid job_id", fmt.Sprintf("no job %s", jobID))
+	}
+
+	data, err := json.MarshalIndent(job, "", "  ")
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to encode job", err)
+	}
+	return s.textResponse(requestID, string(data))
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/models"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// slowMockeryScript takes $MOCKERY_DELAY seconds before behaving like fakeMockeryScript
+const slowMockeryScript = `#!/bin/sh
+[ "$1" = "--version" ] && exec sh "$FAKE_MOCKERY" "$@"
+sleep "$MOCKERY_DELAY"
+` + "exec sh \"$FAKE_MOCKERY\" \"$@\"\n"
+
+// useSlowMockery installs slowMockeryScript with the given delay
+func useSlowMockery(t *testing.T, s *MockeryMCPServer, delay string) {
+	t.Helper()
+	fake := filepath.Join(t.TempDir(), "fake-mockery")
+	require.NoError(t, os.WriteFile(fake, []byte(fakeMockeryScript), 0755))
+	t.Setenv("FAKE_MOCKERY", fake)
+	t.Setenv("MOCKERY_DELAY", delay)
+	useFakeMockery(t, s, slowMockeryScript)
+}
+
+// jobStatus returns the current status of a job
+func jobStatus(t *testing.T, s *MockeryMCPServer, id string) models.JobStatus {
+	t.Helper()
+	job, ok := s.projectManager.GetJob(id)
+	require.True(t, ok)
+	return job.Status
+}
+
+func TestMockeryMCPServer_Drain(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype UserRepository interface{ Get() error }\n")
+	request := types.MockGenerationRequest{
+		InterfaceName: "UserRepository",
+		PackagePath:   dir,
+		OutputDir:     t.TempDir(),
+	}
+
+	t.Run("running and pending jobs finish before the deadline", func(t *testing.T) {
+		s := newTestServer(t)
+		s.jobs = newJobRunner(s, 1)
+		useSlowMockery(t, s, "0.3")
+
+		running, err := s.SubmitJob("", request)
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			return jobStatus(t, s, running) == models.JobStatusRunning
+		}, 2*time.Second, 10*time.Millisecond)
+
+		pending, err := s.SubmitJob("", request)
+		require.NoError(t, err)
+		assert.Equal(t, models.JobStatusPending, jobStatus(t, s, pending))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, s.Drain(ctx))
+
+		for _, id := range []string{running, pending} {
+			finished, _ := s.projectManager.GetJob(id)
+			assert.Equal(t, models.JobStatusCompleted, finished.Status)
+			require.NotNil(t, finished.Result)
+			assert.True(t, finished.Result.Success)
+		}
+
+		_, err = s.SubmitJob("", request)
+		assert.ErrorIs(t, err, ErrDraining)
+	})
+
+	t.Run("deadline cancels running and pending jobs", func(t *testing.T) {
+		s := newTestServer(t)
+		s.jobs = newJobRunner(s, 1)
+		fake := filepath.Join(t.TempDir(), "fake-mockery")
+		require.NoError(t, os.WriteFile(fake, []byte(fakeMockeryScript), 0755))
+		t.Setenv("FAKE_MOCKERY", fake)
+		useFakeMockery(t, s, "#!/bin/sh\n[ \"$1\" = \"--version\" ] && exec sh \"$FAKE_MOCKERY\" \"$@\"\nexec sleep 10\n")
+
+		id, err := s.SubmitJob("", request)
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			return jobStatus(t, s, id) == models.JobStatusRunning
+		}, 2*time.Second, 10*time.Millisecond)
+
+		pending, err := s.SubmitJob("", request)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		assert.ErrorIs(t, s.Drain(ctx), context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.Equal(t, models.JobStatusCancelled, jobStatus(t, s, id))
+		assert.Equal(t, models.JobStatusCancelled, jobStatus(t, s, pending))
+	})
+}
+
+func TestMockeryMCPServer_GenerateMockAsync(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype UserRepository interface{ Get() error }\n")
+	output := t.TempDir()
+
+	s := newTestServer(t)
+	useFakeMockery(t, s, fakeMockeryScript)
+
+	text := responseText(t, callTool(t, s, "generate_mock", map[string]interface{}{
+		"interface_name": "UserRepository",
+		"package_path":   dir,
+		"output_dir":     output,
+		"async":          true,
+	}))
+	match := regexp.MustCompile(`- Job ID: (\S+)`).FindStringSubmatch(text)
+	require.Len(t, match, 2, text)
+
+	var job models.MockGenerationJob
+	require.Eventually(t, func() bool {
+		text := responseText(t, callTool(t, s, "get_job", map[string]interface{}{"job_id": match[1]}))
+		require.NoError(t, json.Unmarshal([]byte(text), &job))
+		return job.Status == models.JobStatusCompleted
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NotNil(t, job.Result)
+	assert.Equal(t, filepath.Join(output, "mock_user_repository.go"), job.Result.GeneratedFile)
+	assert.FileExists(t, job.Result.GeneratedFile)
+
+	t.Run("unknown job", func(t *testing.T) {
+		response := callTool(t, s, "get_job", map[string]interface{}{"job_id": "missing"})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, -32602, response.Error.Code)
+	})
+
+	t.Run("not with return_content", func(t *testing.T) {
+		response := callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "UserRepository",
+			"package_path":   dir,
+			"async":          true,
+			"return_content": true,
+		})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, -32602, response.Error.Code)
+	})
+
+	t.Run("refused while draining", func(t *testing.T) {
+		require.NoError(t, s.Drain(context.Background()))
+		response := callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "UserRepository",
+			"package_path":   dir,
+			"async":          true,
+		})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, "Failed to queue mock generation", response.Error.Message)
+	})
+}
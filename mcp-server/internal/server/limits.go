@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// defaultMaxGenerations caps concurrent mockery runs when no limit is set
+var defaultMaxGenerations = runtime.NumCPU()
+
+// SetMaxConnections limits the number of simultaneous WebSocket connections.
+// Connections beyond the limit are closed with code 1013 (try again later).
+// Zero or less removes the limit.
+func (s *MockeryMCPServer) SetMaxConnections(n int) {
+	s.maxConnections.Store(int64(n))
+}
+
+// SetMaxConcurrentGenerations limits how many mockery runs may execute at
+// once across all connections and async jobs. It must be called before the
+// server starts handling requests.
+func (s *MockeryMCPServer) SetMaxConcurrentGenerations(n int) error {
+	if n < 1 {
+		return fmt.Errorf("max concurrent generations must be at least 1, got %d", n)
+	}
+	s.generations = make(chan struct{}, n)
+	return nil
+}
+
+// acquireConnection reserves a connection slot, reporting false when the
+// server is at its connection limit
+func (s *MockeryMCPServer) acquireConnection() bool {
+	active := s.activeConnections.Add(1)
+	if limit := s.maxConnections.Load(); limit > 0 && active > limit {
+		s.activeConnections.Add(-1)
+		return false
+	}
+	return true
+}
+
+// releaseConnection frees a slot reserved by acquireConnection
+func (s *MockeryMCPServer) releaseConnection() {
+	s.activeConnections.Add(-1)
+}
+
+// rejectConnection completes the WebSocket handshake only to close it with a
+// try-again-later code, so clients can tell a full server from a failure
+func (s *MockeryMCPServer) rejectConnection(w http.ResponseWriter, r *http.Request) {
+	s.logger.Warn("Rejecting WebSocket connection, limit reached",
+		zap.Int64("max_connections", s.maxConnections.Load()))
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	message := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too many connections")
+	conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(time.Second))
+}
+
+// acquireGeneration waits for a slot to run mockery in, returning a function
+// that releases it
+func (s *MockeryMCPServer) acquireGeneration(ctx context.Context) (func(), error) {
+	select {
+	case s.generations <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	s.activeGenerations.Add(1)
+	return func() {
+		s.activeGenerations.Add(-1)
+		<-s.generations
+	}, nil
+}
+
+// handleHealth handles health check requests, reporting current load
+// alongside the configured limits
+func (s *MockeryMCPServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":          "healthy",
+		"connections":     s.activeConnections.Load(),
+		"max_connections": s.maxConnections.Load(),
+		"generations":     s.activeGenerations.Load(),
+		"max_generations": cap(s.generations),
+	})
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// healthOf fetches and decodes the /health response
+func healthOf(t *testing.T, url string) map[string]interface{} {
+	t.Helper()
+	resp, err := http.Get(url + "/health")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var health map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&health))
+	return health
+}
+
+func TestMockeryMCPServer_MaxConnections(t *testing.T) {
+	s := newTestServer(t)
+	s.SetMaxConnections(1)
+
+	mux := http.NewServeMux()
+	s.RegisterHandlers(mux)
+	httpServer := httptest.NewServer(mux)
+	t.Cleanup(httpServer.Close)
+	wsURL := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/mcp"
+
+	first, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	require.NoError(t, first.WriteJSON(MCPRequest{JSONRPC: "2.0", ID: 1, Method: "ping"}))
+	var response MCPResponse
+	require.NoError(t, first.ReadJSON(&response))
+
+	health := healthOf(t, httpServer.URL)
+	assert.Equal(t, float64(1), health["connections"])
+	assert.Equal(t, float64(1), health["max_connections"])
+
+	// A second connection is closed with try-again-later
+	second, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	_, _, err = second.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseTryAgainLater), "unexpected error: %v", err)
+	second.Close()
+
+	// Closing the first connection frees its slot
+	first.Close()
+	require.Eventually(t, func() bool {
+		return healthOf(t, httpServer.URL)["connections"] == float64(0)
+	}, 2*time.Second, 10*time.Millisecond)
+
+	third, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	defer third.Close()
+	require.NoError(t, third.WriteJSON(MCPRequest{JSONRPC: "2.0", ID: 3, Method: "ping"}))
+	require.NoError(t, third.ReadJSON(&response))
+	assert.EqualValues(t, 3, response.ID)
+}
+
+func TestMockeryMCPServer_GenerationSemaphore(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype Repo interface{ Get() error }\n\ntype Cache interface{ Flush() }\n")
+
+	s := newTestServer(t)
+	require.NoError(t, s.SetMaxConcurrentGenerations(1))
+	useSlowMockery(t, s, "0.3")
+
+	// Track the highest number of mockery runs seen at once
+	var peak int64
+	stop := make(chan struct{})
+	var watcher sync.WaitGroup
+	watcher.Add(1)
+	go func() {
+		defer watcher.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if active := s.activeGenerations.Load(); active > peak {
+				peak = active
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for _, name := range []string{"Repo", "Cache"} {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			_, err := s.GenerateMock(context.Background(), &types.MockGenerationRequest{
+				InterfaceName: name,
+				PackagePath:   dir,
+				OutputDir:     t.TempDir(),
+			})
+			assert.NoError(t, err)
+		}(name)
+	}
+	wg.Wait()
+	close(stop)
+	watcher.Wait()
+
+	assert.EqualValues(t, 1, peak)
+	assert.GreaterOrEqual(t, time.Since(start), 600*time.Millisecond, "generations ran concurrently")
+
+	t.Run("waiting respects cancellation", func(t *testing.T) {
+		release, err := s.acquireGeneration(context.Background())
+		require.NoError(t, err)
+		defer release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err = s.GenerateMock(ctx, &types.MockGenerationRequest{
+			InterfaceName: "Repo",
+			PackagePath:   dir,
+			OutputDir:     t.TempDir(),
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("rejects a zero limit", func(t *testing.T) {
+		assert.Error(t, s.SetMaxConcurrentGenerations(0))
+	})
+}
@@ -0,0 +1,219 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// LintRule is a naming convention for interfaces. The selectors pick the
+// interfaces a rule applies to (all that are set must match); the checks
+// then require or forbid a pattern in the interface name.
+type LintRule struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+
+	NamePattern    string `json:"name_pattern,omitempty"`    // Interface name matches this regexp
+	PackagePattern string `json:"package_pattern,omitempty"` // Package name matches this regexp
+	Methods        int    `json:"methods,omitempty"`         // Exact method count; 0 for any
+
+	Require string `json:"require,omitempty"` // Name must match this regexp
+	Forbid  string `json:"forbid,omitempty"`  // Name must not match this regexp
+}
+
+// DefaultLintRules are the conventions lint_interfaces checks unless a
+// request supplies its own rules
+var DefaultLintRules = []LintRule{
+	{
+		Name:    "single-method-er",
+		Message: "single-method interfaces should be named after their method with an -er suffix",
+		Methods: 1,
+		Require: `(er|or)$`,
+	},
+	{
+		Name:        "repository-suffix",
+		Message:     "repository interfaces should end in Repository",
+		NamePattern: `Repo`,
+		Require:     `Repository$`,
+	},
+	{
+		Name:    "no-i-prefix",
+		Message: "interface names should not carry an I prefix",
+		Forbid:  `^I[A-Z]`,
+	},
+}
+
+// LintViolation is an interface that breaks a naming rule
+type LintViolation struct {
+	Rule       string `json:"rule"`
+	Interface  string `json:"interface"`
+	FilePath   string `json:"file_path"`
+	LineNumber int    `json:"line_number"`
+	Message    string `json:"message"`
+}
+
+// compiledLintRule is a LintRule with its patterns compiled
+type compiledLintRule struct {
+	LintRule
+	name, pkg, require, forbid *regexp.Regexp
+}
+
+// compileLintRules validates rules and compiles their patterns
+func compileLintRules(rules []LintRule) ([]compiledLintRule, error) {
+	compiled := make([]compiledLintRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("lint rule is missing a name")
+		}
+		if rule.Require == "" && rule.Forbid == "" {
+			return nil, fmt.Errorf("lint rule %s needs a require or forbid pattern", rule.Name)
+		}
+
+		c := compiledLintRule{LintRule: rule}
+		for _, pattern := range []struct {
+			source string
+			target **regexp.Regexp
+		}{
+			{rule.NamePattern, &c.name},
+			{rule.PackagePattern, &c.pkg},
+			{rule.Require, &c.require},
+			{rule.Forbid, &c.forbid},
+		} {
+			if pattern.source == "" {
+				continue
+			}
+			re, err := regexp.Compile(pattern.source)
+			if err != nil {
+				return nil, fmt.Errorf("lint rule %s: invalid pattern %q: %w", rule.Name, pattern.source, err)
+			}
+			*pattern.target = re
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled, nil
+}
+
+// appliesTo reports whether an interface is selected by the rule
+func (r *compiledLintRule) appliesTo(iface types.InterfaceDefinition) bool {
+	if r.name != nil && !r.name.MatchString(iface.Name) {
+		return false
+	}
+	if r.pkg != nil && !r.pkg.MatchString(iface.Package) {
+		return false
+	}
+	return r.Methods == 0 || len(iface.Methods) == r.Methods
+}
+
+// lintInterfaces checks every interface against every rule, returning the
+// violations ordered by file and line
+func lintInterfaces(interfaces []types.InterfaceDefinition, rules []compiledLintRule) []LintViolation {
+	var violations []LintViolation
+	for _, iface := range interfaces {
+		for _, rule := range rules {
+			if !rule.appliesTo(iface) {
+				continue
+			}
+			if (rule.require != nil && !rule.require.MatchString(iface.Name)) ||
+				(rule.forbid != nil && rule.forbid.MatchString(iface.Name)) {
+				violations = append(violations, LintViolation{
+					Rule:       rule.Name,
+					Interface:  iface.Name,
+					FilePath:   iface.FilePath,
+					LineNumber: iface.LineNumber,
+					Message:    rule.Message,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].FilePath != violations[j].FilePath {
+			return violations[i].FilePath < violations[j].FilePath
+		}
+		return violations[i].LineNumber < violations[j].LineNumber
+	})
+	return violations
+}
+
+// handleLintInterfaces implements the lint_interfaces tool
+func (s *MockeryMCPServer) handleLintInterfaces(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	projectPath, ok := args["project_path"].(string)
+	if !ok || projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid project_path", nil)
+	}
+
+	rules := DefaultLintRules
+	if value, ok := args["rules"]; ok && value != nil {
+		data, err := json.Marshal(value)
+		if err == nil {
+			rules = nil
+			err = json.Unmarshal(data, &rules)
+		}
+		if err != nil {
+			return s.errorResponse(requestID, -32602, "Invalid rules", err.Error())
+		}
+	}
+
+	disabled, err := stringSliceArg(args, "disable_rules")
+	if err != nil {
+		return s.errorResponse(requestID, -32602, "Invalid disable_rules", err.Error())
+	}
+	enabled := make([]LintRule, 0, len(rules))
+	for _, rule := range rules {
+		if !interfaceMatches(rule.Name, disabled) {
+			enabled = append(enabled, rule)
+		}
+	}
+
+	compiled, err := compileLintRules(enabled)
+	if err != nil {
+		return s.errorResponse(requestID, -32602, "Invalid rules", err.Error())
+	}
+
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", projectPath), err)
+	}
+
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Project path does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	interfaces, _, err := s.scanner.ScanProjectContext(ctx, absPath, scanner.ScanOptions{})
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return s.toolErrorResponse(requestID, "Scan cancelled", err)
+	}
+	if err != nil {
+		s.logger.Error("Failed to scan project", zap.String("path", absPath), zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to scan project", fmt.Errorf("%w: %v", ErrScanFailed, err))
+	}
+	interfaces = excludeInterfaces(interfaces, s.config().ExcludeInterfaces)
+
+	return s.textResponse(requestID, formatLintViolations(absPath, len(interfaces), lintInterfaces(interfaces, compiled)))
+}
+
+// formatLintViolations renders a lint report
+func formatLintViolations(path string, checked int, violations []LintViolation) string {
+	if len(violations) == 0 {
+		return fmt.Sprintf("No naming violations in %d interfaces in %s", checked, path)
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "Found %d naming violations in %d interfaces in %s:\n", len(violations), checked, path)
+	for _, violation := range violations {
+		fmt.Fprintf(&text, "\n- %s:%d: %s [%s] %s",
+			violation.FilePath, violation.LineNumber, violation.Interface, violation.Rule, violation.Message)
+	}
+	return text.String()
+}
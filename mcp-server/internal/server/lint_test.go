@@ -0,0 +1,98 @@
+package server
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_LintInterfaces(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "domain/repo.go", `package domain
+
+type UserRepo interface {
+	Get(id string) error
+	Save(id string) error
+}
+
+type OrderRepository interface {
+	Get(id string) error
+	Save(id string) error
+}
+
+type Fetch interface{ Fetch() error }
+
+type Reader interface{ Read() error }
+
+type IClock interface {
+	Now() int64
+	Since() int64
+}
+`)
+	file := filepath.Join(dir, "domain", "repo.go")
+
+	s := newTestServer(t)
+
+	t.Run("default rules", func(t *testing.T) {
+		text := responseText(t, callTool(t, s, "lint_interfaces", map[string]interface{}{"project_path": dir}))
+		assert.Contains(t, text, "Found 3 naming violations in 5 interfaces")
+		assert.Contains(t, text, fmt.Sprintf("\n- %s:3: UserRepo [repository-suffix] repository interfaces should end in Repository", file))
+		assert.Contains(t, text, fmt.Sprintf("\n- %s:13: Fetch [single-method-er]", file))
+		assert.Contains(t, text, fmt.Sprintf("\n- %s:17: IClock [no-i-prefix]", file))
+		assert.NotContains(t, text, "OrderRepository")
+		assert.NotContains(t, text, "Reader")
+	})
+
+	t.Run("disabled rules", func(t *testing.T) {
+		text := responseText(t, callTool(t, s, "lint_interfaces", map[string]interface{}{
+			"project_path":  dir,
+			"disable_rules": []interface{}{"repository-*", "no-i-prefix"},
+		}))
+		assert.Contains(t, text, "Found 1 naming violations")
+		assert.Contains(t, text, "Fetch [single-method-er]")
+	})
+
+	t.Run("custom rules by package", func(t *testing.T) {
+		text := responseText(t, callTool(t, s, "lint_interfaces", map[string]interface{}{
+			"project_path": dir,
+			"rules": []interface{}{
+				map[string]interface{}{
+					"name":            "domain-store",
+					"message":         "domain interfaces with two methods should end in Store",
+					"package_pattern": "^domain$",
+					"methods":         2,
+					"require":         "Store$",
+				},
+			},
+		}))
+		assert.Contains(t, text, "Found 3 naming violations")
+		assert.Contains(t, text, "UserRepo [domain-store]")
+		assert.Contains(t, text, "OrderRepository [domain-store]")
+		assert.Contains(t, text, "IClock [domain-store]")
+	})
+
+	t.Run("clean project", func(t *testing.T) {
+		clean := t.TempDir()
+		writeFile(t, clean, "io.go", "package io\n\ntype Reader interface{ Read() error }\n")
+		text := responseText(t, callTool(t, s, "lint_interfaces", map[string]interface{}{"project_path": clean}))
+		assert.Equal(t, fmt.Sprintf("No naming violations in 1 interfaces in %s", clean), text)
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		for _, rule := range []map[string]interface{}{
+			{"name": "broken", "require": "("},
+			{"name": "no-check", "name_pattern": "Repo"},
+			{"require": "er$"},
+		} {
+			response := callTool(t, s, "lint_interfaces", map[string]interface{}{
+				"project_path": dir,
+				"rules":        []interface{}{rule},
+			})
+			require.NotNil(t, response.Error, rule)
+			assert.Equal(t, -32602, response.Error.Code)
+		}
+	})
+}
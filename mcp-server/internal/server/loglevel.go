@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// requestLoggerKey is the context key of a request's own logger
+type requestLoggerKey struct{}
+
+// levelOverrideCore passes entries at or above its level to the wrapped
+// core even when the wrapped core's own level would drop them. It can only
+// make logging more verbose, never less.
+type levelOverrideCore struct {
+	zapcore.Core
+	level zap.AtomicLevel
+}
+
+// Enabled implements zapcore.LevelEnabler
+func (c *levelOverrideCore) Enabled(level zapcore.Level) bool {
+	return c.level.Enabled(level) || c.Core.Enabled(level)
+}
+
+// With implements zapcore.Core
+func (c *levelOverrideCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelOverrideCore{Core: c.Core.With(fields), level: c.level}
+}
+
+// Check implements zapcore.Core. The entry is added with this core rather
+// than the wrapped one's Check, which would apply its own level.
+func (c *levelOverrideCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+// requestLogLevel returns the log level a request asks for in
+// params._meta.logLevel, if any
+func requestLogLevel(params interface{}) (zapcore.Level, bool, error) {
+	paramsMap, _ := params.(map[string]interface{})
+	meta, _ := paramsMap["_meta"].(map[string]interface{})
+	value, ok := meta["logLevel"]
+	if !ok {
+		return zapcore.InfoLevel, false, nil
+	}
+
+	name, ok := value.(string)
+	if !ok {
+		return zapcore.InfoLevel, false, fmt.Errorf("logLevel must be a string")
+	}
+	level, err := zapcore.ParseLevel(name)
+	if err != nil {
+		return zapcore.InfoLevel, false, fmt.Errorf("unknown log level %q (want debug, info, warn or error)", name)
+	}
+	return level, true, nil
+}
+
+// withRequestLogger returns a context carrying a logger that also writes
+// entries at level, for the request handled under ctx
+func (s *MockeryMCPServer) withRequestLogger(ctx context.Context, level zapcore.Level) context.Context {
+	override := zap.NewAtomicLevelAt(level)
+	logger := s.logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &levelOverrideCore{Core: core, level: override}
+	}))
+	return context.WithValue(ctx, requestLoggerKey{}, logger)
+}
+
+// requestLogger returns the logger of the request handled under ctx, or the
+// server's logger when the request didn't ask for its own level
+func (s *MockeryMCPServer) requestLogger(ctx context.Context) *zap.Logger {
+	if logger, ok := ctx.Value(requestLoggerKey{}).(*zap.Logger); ok {
+		return logger
+	}
+	return s.logger
+}
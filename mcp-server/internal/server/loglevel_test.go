@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestMockeryMCPServer_RequestLogLevel(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	s := NewMockeryMCPServer(zap.New(core))
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype Repo interface{ Get() error }\n")
+
+	discover := func(meta map[string]interface{}) *MCPResponse {
+		params := map[string]interface{}{
+			"name":      "discover_interfaces",
+			"arguments": map[string]interface{}{"project_path": dir},
+		}
+		if meta != nil {
+			params["_meta"] = meta
+		}
+		return s.handleMCPRequest(context.Background(), &MCPRequest{JSONRPC: "2.0", ID: 1, Method: "tools/call", Params: params})
+	}
+
+	require.Nil(t, discover(nil).Error)
+	assert.Zero(t, logs.FilterLevelExact(zapcore.DebugLevel).Len())
+	assert.NotZero(t, logs.FilterMessage("Discovering interfaces").Len())
+
+	logs.TakeAll()
+	require.Nil(t, discover(map[string]interface{}{"logLevel": "debug"}).Error)
+	assert.NotZero(t, logs.FilterMessage("Handling tools/call").Len())
+	assert.NotZero(t, logs.FilterMessage("Response generated").Len())
+
+	// The override only lasts for the request that asked for it
+	logs.TakeAll()
+	require.Nil(t, discover(nil).Error)
+	assert.Zero(t, logs.FilterLevelExact(zapcore.DebugLevel).Len())
+
+	// Asking for a quieter level doesn't hide what the server logs anyway
+	logs.TakeAll()
+	require.Nil(t, discover(map[string]interface{}{"logLevel": "error"}).Error)
+	assert.NotZero(t, logs.FilterMessage("Discovering interfaces").Len())
+
+	response := discover(map[string]interface{}{"logLevel": "verbose"})
+	require.NotNil(t, response.Error)
+	assert.Equal(t, "Invalid _meta.logLevel", response.Error.Message)
+}
@@ -2,14 +2,20 @@ package server
 
 import (
 	"bufio"
+	"container/list"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime/debug"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -23,12 +29,42 @@ import (
 
 // MockeryMCPServer implements the MCP protocol for mockery operations
 type MockeryMCPServer struct {
-	configManager    *config.MockeryConfigManager
-	scanner          *scanner.GoInterfaceScanner
-	projectManager   *models.ProjectManager
-	logger           *zap.Logger
-	upgrader         websocket.Upgrader
-	mockeryCommand   string
+	configManager   *config.MockeryConfigManager
+	scanner         *scanner.GoInterfaceScanner
+	projectManager  *models.ProjectManager
+	logger          *zap.Logger
+	upgrader        websocket.Upgrader
+	configMu        sync.RWMutex
+	current         *runtimeConfig
+	baseConfig      ServerConfig
+	configFile      string
+	versionMu       sync.Mutex
+	mockeryVersions map[string]cachedMockeryVersion
+
+	discoveryMu       sync.Mutex
+	discoveryVersions map[string]*list.Element
+	discoveryOrder    *list.List
+
+	jobs       *jobRunner
+	httpMu     sync.Mutex
+	httpServer *http.Server
+
+	maxConnections    atomic.Int64
+	activeConnections atomic.Int64
+	generations       chan struct{}
+	activeGenerations atomic.Int64
+
+	readOnly            atomic.Bool
+	reloadConfigEnabled atomic.Bool
+	toolCallRate        float64
+	toolCallBurst       int
+
+	connectionIDs atomic.Uint64
+	requestsMu    sync.Mutex
+	requests      map[requestKey]*inflightRequest
+
+	watchIDs      atomic.Uint64
+	activeWatches atomic.Int64
 }
 
 // MCPRequest represents an MCP protocol request
@@ -47,10 +83,17 @@ type MCPResponse struct {
 	Error   *MCPError   `json:"error,omitempty"`
 }
 
+// MCPNotification is a message the server sends without expecting a reply
+type MCPNotification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
 // MCPError represents an MCP protocol error
 type MCPError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
 }
 
@@ -68,79 +111,201 @@ type ToolsListResponse struct {
 
 // NewMockeryMCPServer creates a new MCP server instance
 func NewMockeryMCPServer(logger *zap.Logger) *MockeryMCPServer {
-	return &MockeryMCPServer{
+	s := &MockeryMCPServer{
 		configManager:  config.NewMockeryConfigManager(),
 		scanner:        scanner.NewGoInterfaceScanner(),
 		projectManager: models.NewProjectManager(),
 		logger:         logger,
-		upgrader: websocket.Upgrader{
-			CheckOrigin: func(r *http.Request) bool {
-				return true // Allow all origins for development
-			},
+		requests:       make(map[requestKey]*inflightRequest),
+	}
+	s.upgrader = websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool {
+			return s.config().originAllowed(r.Header.Get("Origin"))
 		},
-		mockeryCommand: "mockery", // Default command, can be configured
 	}
+
+	s.jobs = newJobRunner(s, defaultJobConcurrency)
+	s.generations = make(chan struct{}, defaultMaxGenerations)
+
+	// The default configuration always compiles
+	s.current, _ = compileConfig(DefaultServerConfig())
+	return s
+}
+
+// SetOutputTemplate sets the template used to compute the output directory
+// when a request does not specify one. The template is evaluated with
+// OutputDirData. An empty template restores the <package>/mocks default.
+func (s *MockeryMCPServer) SetOutputTemplate(text string) error {
+	return s.updateConfig(func(cfg *ServerConfig) {
+		cfg.OutputTemplate = text
+	})
+}
+
+// SetConfigEnvExpansion controls how environment variable references in
+// mockery config files are handled: expanded or kept literally, and whether
+// an unset variable is an error
+func (s *MockeryMCPServer) SetConfigEnvExpansion(expand, strict bool) {
+	s.configManager.SetExpandEnv(expand)
+	s.configManager.SetStrictEnv(strict)
+}
+
+// SetMaxScanFileSize sets the largest Go file, in bytes, the scanner parses.
+// Larger files are skipped and reported as scan errors; zero removes the limit.
+func (s *MockeryMCPServer) SetMaxScanFileSize(size int64) {
+	s.scanner.SetMaxFileSize(size)
+}
+
+// RegisterHandlers mounts the MCP WebSocket endpoint at /mcp and the health
+// check at /health on mux, so the server can be embedded in a larger application
+func (s *MockeryMCPServer) RegisterHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/mcp", s.handleWebSocket)
+	mux.HandleFunc("/health", s.handleHealth)
 }
 
-// Start starts the MCP server
+// Start starts the MCP server. After Shutdown it returns http.ErrServerClosed.
 func (s *MockeryMCPServer) Start(addr string) error {
-	http.HandleFunc("/mcp", s.handleWebSocket)
-	http.HandleFunc("/health", s.handleHealth)
-	
+	mux := http.NewServeMux()
+	s.RegisterHandlers(mux)
+
+	httpServer := &http.Server{Addr: addr, Handler: mux}
+	s.httpMu.Lock()
+	s.httpServer = httpServer
+	s.httpMu.Unlock()
+
 	s.logger.Info("Starting MCP server", zap.String("address", addr))
-	return http.ListenAndServe(addr, nil)
+	return httpServer.ListenAndServe()
+}
+
+// Shutdown gracefully stops the server: it drains async jobs, then stops
+// the HTTP listener started by Start, both bounded by ctx
+func (s *MockeryMCPServer) Shutdown(ctx context.Context) error {
+	drainErr := s.Drain(ctx)
+	if drainErr != nil {
+		s.logger.Warn("Async jobs did not finish before the shutdown deadline", zap.Error(drainErr))
+	}
+
+	s.httpMu.Lock()
+	httpServer := s.httpServer
+	s.httpMu.Unlock()
+
+	if httpServer != nil {
+		if err := httpServer.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+	return drainErr
 }
 
 // HandleStdio handles stdio-based MCP communication for clients like Roo
 func (s *MockeryMCPServer) HandleStdio() error {
-	scanner := bufio.NewScanner(os.Stdin)
-	
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
-		}
+	return s.ServeStdio(os.Stdin, os.Stdout)
+}
 
-		s.logger.Debug("Received stdin message", zap.String("message", line))
+// HandleStdioOnce handles a single stdio request, for scripts and test
+// harnesses that pipe one message in
+func (s *MockeryMCPServer) HandleStdioOnce() error {
+	return s.ServeStdioOnce(os.Stdin, os.Stdout)
+}
 
-		var request MCPRequest
-		if err := json.Unmarshal([]byte(line), &request); err != nil {
-			s.logger.Error("Failed to parse request", zap.Error(err))
-			continue
-		}
+// ServeStdio serves newline-delimited MCP requests read from in, writing
+// responses to out until in is exhausted
+func (s *MockeryMCPServer) ServeStdio(in io.Reader, out io.Writer) error {
+	return s.serveStdio(in, out, false)
+}
 
-		response := s.handleMCPRequest(&request)
-		
-		// Don't send response for notifications (when response is nil)
-		if response == nil {
-			continue
-		}
-		
-		responseBytes, err := json.Marshal(response)
+// ServeStdioOnce reads one message from in, writes its response to out,
+// if it has one, and returns. Anything after the first message is left
+// unread.
+func (s *MockeryMCPServer) ServeStdioOnce(in io.Reader, out io.Writer) error {
+	return s.serveStdio(in, out, true)
+}
+
+// serveStdio serves requests read from in, stopping after the first
+// message when once is set
+func (s *MockeryMCPServer) serveStdio(in io.Reader, out io.Writer, once bool) error {
+	scanner := bufio.NewScanner(in)
+
+	// Parse errors are answered from the reading goroutine and watches send
+	// notifications from their own, so writes are serialized
+	var writeMu sync.Mutex
+	write := func(message interface{}) error {
+		responseBytes, err := json.Marshal(message)
 		if err != nil {
 			s.logger.Error("Failed to marshal response", zap.Error(err))
-			continue
+			return nil
 		}
 
+		writeMu.Lock()
+		defer writeMu.Unlock()
+
 		// Write response to stdout
-		if _, err := os.Stdout.Write(responseBytes); err != nil {
+		if _, err := out.Write(responseBytes); err != nil {
 			s.logger.Error("Failed to write response", zap.Error(err))
-			continue
+			return nil
 		}
-		
+
 		// Add newline for proper message separation
-		if _, err := os.Stdout.Write([]byte("\n")); err != nil {
+		if _, err := out.Write([]byte("\n")); err != nil {
 			s.logger.Error("Failed to write newline", zap.Error(err))
-			continue
 		}
+		return nil
+	}
+	respond := func(response *MCPResponse) error {
+		return write(response)
+	}
+	notify := func(notification *MCPNotification) error {
+		return write(notification)
+	}
+
+	done := false
+	next := func() (*MCPRequest, error) {
+		for !done && scanner.Scan() {
+			line := scanner.Text()
+			if line == "" {
+				continue
+			}
+			done = once
+
+			s.logger.Debug("Received stdin message", zap.String("message", line))
+
+			var request MCPRequest
+			if err := json.Unmarshal([]byte(line), &request); err != nil {
+				s.logger.Error("Failed to parse request", zap.Error(err))
+				// Answer so the client isn't left waiting for a response
+				if response := s.parseErrorResponse([]byte(line), err); response != nil {
+					respond(response)
+				}
+				continue
+			}
+			return &request, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
 	}
 
-	return scanner.Err()
+	if err := s.serveConnection(context.Background(), next, respond, notify); err != io.EOF {
+		return err
+	}
+	return nil
 }
 
 // handleWebSocket handles WebSocket connections for MCP protocol
 func (s *MockeryMCPServer) handleWebSocket(w http.ResponseWriter, r *http.Request) {
-	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if !s.acquireConnection() {
+		s.rejectConnection(w, r)
+		return
+	}
+	defer s.releaseConnection()
+
+	// Connections keep the timeouts and compression in effect when they
+	// were established
+	cfg := s.config()
+
+	upgrader := s.upgrader
+	upgrader.EnableCompression = cfg.WSCompression
+	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		s.logger.Error("Failed to upgrade connection", zap.Error(err))
 		return
@@ -149,47 +314,131 @@ func (s *MockeryMCPServer) handleWebSocket(w http.ResponseWriter, r *http.Reques
 
 	s.logger.Info("New MCP connection established")
 
-	for {
+	// Requests on this connection are cancelled once the client is gone,
+	// which the keepalive notices when a ping can't be delivered
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	stopKeepalive := s.startKeepalive(conn, cfg, cancel)
+	defer stopKeepalive()
+
+	next := func() (*MCPRequest, error) {
+		cfg.extendReadDeadline(conn)
+
 		var request MCPRequest
-		err := conn.ReadJSON(&request)
+		if err := conn.ReadJSON(&request); err != nil {
+			if isTimeout(err) {
+				s.logger.Warn("WebSocket read deadline exceeded, closing connection", zap.Error(err))
+			} else {
+				s.logger.Error("Failed to read message", zap.Error(err))
+			}
+			return nil, err
+		}
+		return &request, nil
+	}
+
+	// Notifications are written from watch goroutines, and a WebSocket
+	// connection supports one writer at a time
+	var writeMu sync.Mutex
+	write := func(message interface{}) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+
+		data, err := json.Marshal(message)
 		if err != nil {
-			s.logger.Error("Failed to read message", zap.Error(err))
-			break
+			s.logger.Error("Failed to encode message", zap.Error(err))
+			return err
 		}
 
-		response := s.handleMCPRequest(&request)
-		
-		err = conn.WriteJSON(response)
+		// Small messages aren't worth deflating; this has no effect unless
+		// the client negotiated compression
+		conn.EnableWriteCompression(len(data) >= compressionThreshold)
+
+		cfg.extendWriteDeadline(conn)
+		err = conn.WriteMessage(websocket.TextMessage, data)
 		if err != nil {
-			s.logger.Error("Failed to write message", zap.Error(err))
-			break
+			if isTimeout(err) {
+				s.logger.Warn("WebSocket write deadline exceeded, closing connection", zap.Error(err))
+			} else {
+				s.logger.Error("Failed to write message", zap.Error(err))
+			}
 		}
+		return err
+	}
+
+	respond := func(response *MCPResponse) error {
+		return write(response)
+	}
+	notify := func(notification *MCPNotification) error {
+		return write(notification)
 	}
-}
 
-// handleHealth handles health check requests
-func (s *MockeryMCPServer) handleHealth(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{"status": "healthy"})
+	s.serveConnection(ctx, next, respond, notify)
 }
 
-// handleMCPRequest processes MCP requests
-func (s *MockeryMCPServer) handleMCPRequest(request *MCPRequest) *MCPResponse {
-	s.logger.Debug("Handling MCP request", zap.String("method", request.Method))
+// handleMCPRequest processes MCP requests. ctx is cancelled when the
+// request's client goes away. A panicking handler is turned into an internal
+// error response so it can't take the connection down.
+func (s *MockeryMCPServer) handleMCPRequest(ctx context.Context, request *MCPRequest) (response *MCPResponse) {
+	// A request can ask for more verbose logs while it is handled, without
+	// changing the level for anything else
+	level, override, err := requestLogLevel(request.Params)
+	if err != nil && request.ID != nil {
+		return s.errorResponse(request.ID, -32602, "Invalid _meta.logLevel", err.Error())
+	}
+	if override {
+		ctx = s.withRequestLogger(ctx, level)
+	}
+	logger := s.requestLogger(ctx)
+	logger.Debug("Handling MCP request", zap.String("method", request.Method))
+
+	// Requests, unlike notifications, can be cancelled by the client, which
+	// then expects no response
+	if request.ID != nil {
+		var finish func() bool
+		ctx, finish = s.trackRequest(ctx, request.ID)
+		defer func() {
+			if finish() {
+				logger.Debug("Dropping response to cancelled request", zap.Any("request_id", request.ID))
+				response = nil
+			}
+		}()
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			logger.Error("Recovered from panic in request handler",
+				zap.Any("request_id", request.ID),
+				zap.String("method", request.Method),
+				zap.Any("panic", recovered),
+				zap.ByteString("stack", debug.Stack()))
+			response = s.errorResponse(request.ID, CodeInternalError, "Internal error", ErrorData{
+				Kind:   KindInternal,
+				Detail: fmt.Sprintf("panic: %v", recovered),
+			})
+		}
+	}()
 
 	switch request.Method {
 	case "initialize":
 		return s.handleInitialize(request)
 	case "notifications/initialized":
 		return s.handleInitialized(request)
+	case "notifications/cancelled":
+		return s.handleCancelled(ctx, request)
 	case "ping":
 		return s.handlePing(request)
 	case "tools/list":
 		return s.handleToolsList(request)
 	case "tools/call":
-		return s.handleToolsCall(request)
+		return s.handleToolsCall(ctx, request)
+	case "completion/complete":
+		return s.handleCompletion(request)
+	case "prompts/list":
+		return s.handlePromptsList(request)
+	case "prompts/get":
+		return s.handlePromptsGet(request)
 	default:
-		s.logger.Warn("Unknown method", zap.String("method", request.Method))
+		logger.Warn("Unknown method", zap.String("method", request.Method))
 		return &MCPResponse{
 			JSONRPC: "2.0",
 			ID:      request.ID,
@@ -201,100 +450,1183 @@ func (s *MockeryMCPServer) handleMCPRequest(request *MCPRequest) *MCPResponse {
 	}
 }
 
-// handleToolsList returns the list of available tools
-func (s *MockeryMCPServer) handleToolsList(request *MCPRequest) *MCPResponse {
-	tools := []Tool{
+// toolDefinitions returns every tool the server implements, whether or
+// not read-only mode makes it available
+func toolDefinitions() []Tool {
+	return []Tool{
+		{
+			Name:        "discover_interfaces",
+			Description: "Scan Go project for interface definitions",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the Go project to scan",
+					},
+					"include_patterns": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "File patterns to include in scan",
+					},
+					"exclude_patterns": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "File patterns to exclude from scan",
+					},
+					"exclude_interfaces": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Interface names or globs (e.g. Mock*) to omit from the results",
+					},
+					"if_none_match": map[string]interface{}{
+						"type":        "string",
+						"description": "Version from a previous response; if nothing changed since, a short \"Not modified\" result is returned instead of the list",
+					},
+					"require_module": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Fail if project_path is not inside a Go module (defaults to the server's -require-module setting)",
+					},
+					"include_signatures": map[string]interface{}{
+						"type":        "boolean",
+						"description": "List each method with its parameter and return types as reflect prints them",
+					},
+					"group_by_package": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Nest interfaces under their package with per-package counts instead of a flat list",
+					},
+					"index": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Return a JSON object keyed by package.InterfaceName instead of a list, with warnings for colliding names",
+					},
+					"follow_symlinks": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Also scan symlinked directories, each real directory once (if_none_match is ignored when set)",
+					},
+					"relative_paths": map[string]interface{}{
+						"type":        "boolean",
+						"default":     false,
+						"description": "Report file_path relative to project_path instead of as an absolute path",
+					},
+					"max_depth": map[string]interface{}{
+						"type":        "integer",
+						"minimum":     0,
+						"description": "Directory levels to scan: 1 scans only project_path, 2 also its subdirectories (default: 0, unlimited)",
+					},
+					"min_methods": map[string]interface{}{
+						"type":        "integer",
+						"minimum":     0,
+						"description": "Only return interfaces with at least this many methods",
+					},
+					"max_methods": map[string]interface{}{
+						"type":        "integer",
+						"minimum":     0,
+						"description": "Only return interfaces with at most this many methods (default: unlimited)",
+					},
+					"fields": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string", "enum": discoveryFields},
+						"description": "Fields to show per interface: name, package, file_path, method_count, methods, line_number, exported (defaults to name, package, file_path and method_count)",
+					},
+				},
+				"required": []string{"project_path"},
+			},
+		},
+		{
+			Name:        "generate_mock",
+			Description: "Generate mock using Mockery tool",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"interface_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the interface to mock",
+					},
+					"package_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Package path containing the interface",
+					},
+					"output_dir": map[string]interface{}{
+						"type":        "string",
+						"description": "Directory to output generated mocks",
+					},
+					"with_expecter": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Generate with expecter methods (defaults to the server's -default-with-expecter setting, true unless changed)",
+					},
+					"filename_format": map[string]interface{}{
+						"type":        "string",
+						"default":     "mock_{{.InterfaceName}}.go",
+						"description": "Template for generated mock filename; may use {{.InterfaceName}}, {{.PackageName}}, {{.Date}} and template_vars",
+					},
+					"template_vars": map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": map[string]interface{}{"type": "string"},
+						"description":          "Custom variables for filename_format, e.g. {\"Team\": \"payments\"} for {{.Team}}",
+					},
+					"filename_case": map[string]interface{}{
+						"type":        "string",
+						"enum":        filenameCases,
+						"description": "Casing of the interface name in the default filename (defaults to the server's -filename-case setting, snake unless changed)",
+					},
+					"recursive": map[string]interface{}{
+						"type":        "boolean",
+						"default":     false,
+						"description": "Generate mocks for every interface beneath package_path (interface_name becomes an optional filter)",
+					},
+					"combined_file": map[string]interface{}{
+						"type":        "string",
+						"description": "With recursive, merge all the mocks into this one file, e.g. mocks/mocks.go, relative to package_path unless absolute",
+					},
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Module directory used to resolve package_path when it is an import path (default: the server's working directory)",
+					},
+					"in_package": map[string]interface{}{
+						"type":        "boolean",
+						"default":     false,
+						"description": "Generate the mock inside the interface's own package (written to the package directory unless output_dir is set)",
+					},
+					"test_only": map[string]interface{}{
+						"type":        "boolean",
+						"default":     false,
+						"description": "Generate a mock only visible to tests (default filename ends in _test.go)",
+					},
+					"mock_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Template for the mock type name (default: Mock{{.InterfaceName}} for in-package mocks)",
+					},
+					"boilerplate_file": map[string]interface{}{
+						"type":        "string",
+						"description": "File whose contents (e.g. a license header) are prepended to the generated mock",
+					},
+					"build_tag": map[string]interface{}{
+						"type":        "string",
+						"description": "Build constraint to put at the top of the generated mock, e.g. !production",
+					},
+					"return_content": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Return the generated mock source in the response instead of writing it to disk",
+					},
+					"async": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Queue the generation and return a job ID at once; poll get_job for the result. Can't be combined with recursive or return_content",
+					},
+					"extra_args": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Additional mockery arguments, e.g. [\"--disable-func-mocks\"]; only --quiet, --exported, --disable-func-mocks, --disable-version-string, --unroll-variadic, --log-level, --case, --tags, --note and --replace-type are accepted",
+					},
+					"skip_precheck": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Run mockery without first checking that the interface is declared in package_path",
+					},
+					"mocks_module": map[string]interface{}{
+						"type":        "string",
+						"description": "Module path for a go.mod created in output_dir, so mocks live in their own module; ignored when output_dir is already in a module other than the interface's",
+					},
+					"project_id": map[string]interface{}{
+						"type":        "string",
+						"description": "Project from create_project to record the generated mocks under",
+					},
+				},
+				"required": []string{"package_path"},
+			},
+		},
+		{
+			Name:        "update_mockery_config",
+			Description: "Create or update a project's mockery config, keeping settings, comments and environment variable references it doesn't change",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Project whose mockery config to update; .mockery.yaml, .mockery.yml and mockery.yaml are tried in that order, and .mockery.yaml is created if there is none",
+					},
+					"config_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the config file, used instead of looking it up in project_path; created if it doesn't exist",
+					},
+					"interfaces": map[string]interface{}{
+						"type":        "object",
+						"description": "Per-interface settings keyed by package path, then interface name, e.g. {\"example.com/app/store\": {\"Store\": {\"dir\": \"mocks/store\"}}}",
+					},
+					"global_config": map[string]interface{}{
+						"type":        "object",
+						"description": "Top-level settings to set, e.g. {\"with-expecter\": true, \"outpkg\": \"mocks\"}",
+					},
+				},
+			},
+		},
+		{
+			Name:        "validate_mockery_config",
+			Description: "Check a .mockery.yaml file and report every problem found",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Project whose mockery config to validate; .mockery.yaml, .mockery.yml and mockery.yaml are tried in that order",
+					},
+					"config_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Explicit path to the config file (overrides project_path)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "get_interface_source",
+			Description: "Return the raw source of an interface declaration, including doc comments",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the Go file containing the interface",
+					},
+					"interface_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the interface",
+					},
+				},
+				"required": []string{"file_path", "interface_name"},
+			},
+		},
+		{
+			Name:        "generate_expect_scaffold",
+			Description: "Generate EXPECT() call stubs for every method of an interface, with argument matchers derived from the parameter types",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the Go file containing the interface",
+					},
+					"interface_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the interface",
+					},
+					"mock_package": map[string]interface{}{
+						"type":        "string",
+						"description": "Package name the mock lives in (default: mocks)",
+					},
+					"mock_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Mock type name, may use {{.InterfaceName}} (default: the interface name)",
+					},
+					"mock_var": map[string]interface{}{
+						"type":        "string",
+						"description": "Variable name for the mock (default: mock<Interface>)",
+					},
+				},
+				"required": []string{"file_path", "interface_name"},
+			},
+		},
+		{
+			Name:        "interface_hash",
+			Description: "Compute a SHA-256 hash of an interface's method set that only changes when its contract does",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the Go file containing the interface",
+					},
+					"interface_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the interface",
+					},
+				},
+				"required": []string{"file_path", "interface_name"},
+			},
+		},
+		{
+			Name:        "init_project",
+			Description: "Set up mockery for a project: write a .mockery.yaml listing its interfaces, create the mocks directory and optionally generate the first mocks",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the Go project root",
+					},
+					"output_dir": map[string]interface{}{
+						"type":        "string",
+						"description": "Directory for mocks, relative to the project (default: mocks)",
+					},
+					"generate": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Generate a mock for every discovered interface",
+					},
+					"overwrite": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Replace an existing mockery config",
+					},
+					"config_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Where to write the config, relative to the project (default: .mockery.yaml)",
+					},
+				},
+				"required": []string{"project_path"},
+			},
+		},
+		{
+			Name:        "diff_interfaces",
+			Description: "Compare two scans and report interfaces added, removed and changed (by signature hash)",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"current_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Project to scan for the current state",
+					},
+					"base_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Project to scan for the baseline, e.g. a checkout of the previous commit",
+					},
+					"baseline": map[string]interface{}{
+						"type":        "object",
+						"description": "Snapshot from an earlier diff_interfaces response, used instead of base_path",
+					},
+				},
+				"required": []string{"current_path"},
+			},
+		},
+		{
+			Name:        "generate_from_config",
+			Description: "Generate a mock for every interface listed in a project's mockery config",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Project directory containing .mockery.yaml, .mockery.yml or mockery.yaml",
+					},
+					"config_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the mockery config, used instead of looking it up in project_path",
+					},
+				},
+			},
+		},
+		{
+			Name:        "format_file",
+			Description: "Format a Go file with gofmt and, if installed, goimports, returning a diff of the changes",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the Go file to format",
+					},
+					"dry_run": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Return the diff without rewriting the file",
+					},
+				},
+				"required": []string{"file_path"},
+			},
+		},
+		{
+			Name:        "lint_interfaces",
+			Description: "Check discovered interfaces against naming conventions and report violations with file and line",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the Go project to lint",
+					},
+					"rules": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "object"},
+						"description": "Rules replacing the defaults: {name, message, name_pattern, package_pattern, methods, require, forbid}",
+					},
+					"disable_rules": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Names or globs of rules to skip",
+					},
+				},
+				"required": []string{"project_path"},
+			},
+		},
+		{
+			Name:        "run_tests",
+			Description: "Run go test -json and report per-test pass, fail and skip results as text, JSON or JUnit XML",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Directory to run go test in",
+					},
+					"packages": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Package patterns to test (default: ./...)",
+					},
+					"run": map[string]interface{}{
+						"type":        "string",
+						"description": "Only run tests matching this regular expression (go test -run)",
+					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"text", "json", "junit"},
+						"default":     "text",
+						"description": "Report format: a text summary with failing output inline, structured JSON, or JUnit XML",
+					},
+				},
+				"required": []string{"project_path"},
+			},
+		},
+		{
+			Name:        "scaffold_layout",
+			Description: "Create the internal/<package> and mocks layout with a starter interface, a .mockery.yaml and Makefile targets for mock generation",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the Go project root",
+					},
+					"package_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the interface package to create under internal/",
+					},
+					"interface_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the starter interface (default: Service)",
+					},
+					"overwrite": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Replace an existing interface file and mockery config",
+					},
+				},
+				"required": []string{"project_path", "package_name"},
+			},
+		},
+		{
+			Name:        "export_interfaces",
+			Description: "Scan a project and write every discovered interface, with full method, parameter and position detail, to a JSON file inside the project",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the Go project root",
+					},
+					"output_path": map[string]interface{}{
+						"type":        "string",
+						"description": "File to write, relative to the project; must stay inside it",
+					},
+				},
+				"required": []string{"project_path", "output_path"},
+			},
+		},
+		{
+			Name:        "explain_generate",
+			Description: "Show the mockery command generate_mock would run for a request, without running it",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"interface_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the interface to mock",
+					},
+					"package_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Package path containing the interface",
+					},
+					"output_dir": map[string]interface{}{
+						"type":        "string",
+						"description": "Directory to output generated mocks",
+					},
+					"with_expecter": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Generate with expecter methods (defaults to the server's -default-with-expecter setting, true unless changed)",
+					},
+					"filename_format": map[string]interface{}{
+						"type":        "string",
+						"description": "Template for generated mock filename",
+					},
+					"filename_case": map[string]interface{}{
+						"type":        "string",
+						"enum":        filenameCases,
+						"description": "Casing of the interface name in the default filename (defaults to the server's -filename-case setting, snake unless changed)",
+					},
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Module directory used to resolve package_path when it is an import path",
+					},
+					"in_package": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Generate the mock inside the interface's own package",
+					},
+					"test_only": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Generate a mock only visible to tests",
+					},
+					"mock_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Template for the mock type name",
+					},
+					"boilerplate_file": map[string]interface{}{
+						"type":        "string",
+						"description": "File whose contents are prepended to the generated mock",
+					},
+					"build_tag": map[string]interface{}{
+						"type":        "string",
+						"description": "Build constraint to put at the top of the generated mock, e.g. !production",
+					},
+					"return_content": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Explain a content-only request, which generates into a temporary directory",
+					},
+					"extra_args": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Additional mockery arguments, validated as generate_mock does",
+					},
+					"skip_precheck": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Don't check that the interface is declared in package_path",
+					},
+					"mocks_module": map[string]interface{}{
+						"type":        "string",
+						"description": "Module path for a separate mocks module created in output_dir",
+					},
+				},
+				"required": []string{"interface_name", "package_path"},
+			},
+		},
+		{
+			Name:        "get_interface_methods",
+			Description: "List one interface's methods with signatures, parameters, returns and doc comments",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"interface_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the interface",
+					},
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Go file declaring the interface (or give package_path)",
+					},
+					"package_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Package directory declaring the interface (or give file_path)",
+					},
+				},
+				"required": []string{"interface_name"},
+			},
+		},
+		{
+			Name:        "interface_stats",
+			Description: "Count methods, parameters, variadic and callback methods and generics per interface to estimate mock complexity",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the Go project",
+					},
+					"interfaces": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Interface names or globs to report on (default: all)",
+					},
+				},
+				"required": []string{"project_path"},
+			},
+		},
+		{
+			Name:        "create_project",
+			Description: "Create a project that generated mocks can be associated with, returning its ID",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the project",
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the Go project root",
+					},
+				},
+				"required": []string{"name", "path"},
+			},
+		},
+		{
+			Name:        "get_project",
+			Description: "Get a project and the mocks generated for it",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_id": map[string]interface{}{
+						"type":        "string",
+						"description": "ID returned by create_project",
+					},
+				},
+				"required": []string{"project_id"},
+			},
+		},
+		{
+			Name:        "get_job",
+			Description: "Get the status and result of a mock generation queued with generate_mock async",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"job_id": map[string]interface{}{
+						"type":        "string",
+						"description": "Job ID returned by generate_mock",
+					},
+				},
+				"required": []string{"job_id"},
+			},
+		},
+		{
+			Name:        "list_projects",
+			Description: "List the projects created with create_project",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+		},
+		{
+			Name:        "watch_project",
+			Description: "Watch a project's Go files and send notifications/interfaces/changed notifications listing added, removed and changed interfaces",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the Go project root",
+					},
+					"debounce_ms": map[string]interface{}{
+						"type":        "integer",
+						"minimum":     50,
+						"default":     500,
+						"description": "How long the project's files must go unchanged before changes are reported, in milliseconds",
+					},
+				},
+				"required": []string{"project_path"},
+			},
+		},
+		{
+			Name:        "unwatch_project",
+			Description: "Stop a watch started with watch_project",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"watch_id": map[string]interface{}{
+						"type":        "string",
+						"description": "ID returned by watch_project",
+					},
+				},
+				"required": []string{"watch_id"},
+			},
+		},
+		{
+			Name:        "suggest_mock_setup",
+			Description: "Generate the mock construction and EXPECT() call for one method of an interface in a success or error scenario, with return values inferred from the method's result types",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the Go file containing the interface",
+					},
+					"interface_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the interface",
+					},
+					"method_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Method to set up a call for",
+					},
+					"scenario": map[string]interface{}{
+						"type":        "string",
+						"enum":        mockSetupScenarios,
+						"default":     scenarioSuccess,
+						"description": "success returns nil errors; error returns an error",
+					},
+					"mock_package": map[string]interface{}{
+						"type":        "string",
+						"description": "Package name the mock lives in (default: mocks)",
+					},
+					"mock_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Mock type name, may use {{.InterfaceName}} (default: the interface name)",
+					},
+					"mock_var": map[string]interface{}{
+						"type":        "string",
+						"description": "Variable name for the mock (default: mock<Interface>)",
+					},
+				},
+				"required": []string{"file_path", "interface_name", "method_name"},
+			},
+		},
+		{
+			Name:        "audit_config",
+			Description: "Check that every interface listed in a mockery config is still declared in its package, suggesting removals for stale entries",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Project whose mockery config to audit; .mockery.yaml, .mockery.yml and mockery.yaml are tried in that order",
+					},
+					"config_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Explicit path to the config file (overrides project_path)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "generate_testify_mock",
+			Description: "Generate a testify mock for an interface without running mockery, returning its source",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the Go file containing the interface",
+					},
+					"interface_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the interface to mock",
+					},
+					"mock_package": map[string]interface{}{
+						"type":        "string",
+						"description": "Package clause of the generated file (default: mocks); the interface's own package generates an in-package mock",
+					},
+					"mock_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Mock type name; may use {{.InterfaceName}} (default: the interface name, or Mock<Interface> in the interface's own package)",
+					},
+				},
+				"required": []string{"file_path", "interface_name"},
+			},
+		},
+		{
+			Name:        "generate_mock_if_changed",
+			Description: "Generate a mock only if the interface's signature changed since its mock was last generated",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"interface_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the interface to mock",
+					},
+					"package_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Package path containing the interface",
+					},
+					"output_dir": map[string]interface{}{
+						"type":        "string",
+						"description": "Directory to output generated mocks",
+					},
+					"with_expecter": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Generate with expecter methods (defaults to the server's -default-with-expecter setting, true unless changed)",
+					},
+					"filename_format": map[string]interface{}{
+						"type":        "string",
+						"description": "Template for generated mock filename",
+					},
+					"filename_case": map[string]interface{}{
+						"type":        "string",
+						"enum":        filenameCases,
+						"description": "Casing of the interface name in the default filename (defaults to the server's -filename-case setting, snake unless changed)",
+					},
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Module directory used to resolve package_path when it is an import path",
+					},
+					"in_package": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Generate the mock inside the interface's own package",
+					},
+					"test_only": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Generate a mock only visible to tests",
+					},
+					"mock_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Template for the mock type name",
+					},
+					"boilerplate_file": map[string]interface{}{
+						"type":        "string",
+						"description": "File whose contents are prepended to the generated mock",
+					},
+					"build_tag": map[string]interface{}{
+						"type":        "string",
+						"description": "Build constraint to put at the top of the generated mock, e.g. !production",
+					},
+					"extra_args": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Additional mockery arguments, validated as generate_mock does",
+					},
+					"mocks_module": map[string]interface{}{
+						"type":        "string",
+						"description": "Module path for a separate mocks module created in output_dir",
+					},
+					"project_id": map[string]interface{}{
+						"type":        "string",
+						"description": "Project the mock is recorded under, as for generate_mock",
+					},
+				},
+				"required": []string{"interface_name", "package_path"},
+			},
+		},
+		{
+			Name:        "describe_tool",
+			Description: "Describe a tool with its input schema, an example arguments object and the result to expect",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"tool_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the tool to describe",
+					},
+				},
+				"required": []string{"tool_name"},
+			},
+		},
+		{
+			Name:        "generate_func_mock",
+			Description: "Generate a mock of a named function type (e.g. type HandlerFunc func(...)) using Mockery",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"function_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the function type to mock",
+					},
+					"package_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Package path containing the function type",
+					},
+					"output_dir": map[string]interface{}{
+						"type":        "string",
+						"description": "Directory to output generated mocks",
+					},
+					"with_expecter": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Generate with expecter methods (defaults to the server's -default-with-expecter setting, true unless changed)",
+					},
+					"filename_format": map[string]interface{}{
+						"type":        "string",
+						"description": "Template for generated mock filename",
+					},
+					"filename_case": map[string]interface{}{
+						"type":        "string",
+						"enum":        filenameCases,
+						"description": "Casing of the function type name in the default filename (defaults to the server's -filename-case setting, snake unless changed)",
+					},
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Module directory used to resolve package_path when it is an import path",
+					},
+					"in_package": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Generate the mock inside the function type's own package",
+					},
+					"test_only": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Generate a mock only visible to tests",
+					},
+					"mock_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Template for the mock type name",
+					},
+					"boilerplate_file": map[string]interface{}{
+						"type":        "string",
+						"description": "File whose contents are prepended to the generated mock",
+					},
+					"build_tag": map[string]interface{}{
+						"type":        "string",
+						"description": "Build constraint to put at the top of the generated mock, e.g. !production",
+					},
+					"return_content": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Return the generated mock source in the response instead of writing it to disk",
+					},
+					"extra_args": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Additional mockery arguments, validated as generate_mock does",
+					},
+					"skip_precheck": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Run mockery without first checking that the function type is declared in package_path",
+					},
+					"mocks_module": map[string]interface{}{
+						"type":        "string",
+						"description": "Module path for a separate mocks module created in output_dir",
+					},
+					"project_id": map[string]interface{}{
+						"type":        "string",
+						"description": "Project the mock is recorded under, as for generate_mock",
+					},
+				},
+				"required": []string{"function_name", "package_path"},
+			},
+		},
+		{
+			Name:        "clean_mocks",
+			Description: "List, and with dry_run false delete, generated mocks of project interfaces that no longer exist. Mocks that can't be attributed to the project, such as mocks of dependency interfaces, are kept",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the Go project root, scanned for the interfaces that still exist",
+					},
+					"output_dir": map[string]interface{}{
+						"type":        "string",
+						"description": "Mocks directory to clean, relative to the project; must stay inside project_path. This guards against mistakes only: project_path is chosen by the caller, so it doesn't confine which files can be deleted",
+					},
+					"dry_run": map[string]interface{}{
+						"type":        "boolean",
+						"default":     true,
+						"description": "List the orphaned mocks without deleting them; pass false to delete",
+					},
+				},
+				"required": []string{"project_path", "output_dir"},
+			},
+		},
+		{
+			Name:        "project_info",
+			Description: "Report a project's module path, go directive and requirements from go.mod, and the Go version installed, to help diagnose generation failures",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path inside the Go module to report on",
+					},
+				},
+				"required": []string{"project_path"},
+			},
+		},
+		{
+			Name:        "apply_mock_to_test",
+			Description: "Generate a mock and wire it into a test file, replacing the nil placeholders its setupMocks functions return for the interface. Edits the test file in place (keeping a .bak copy), so confirm must be true.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"interface_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the interface to mock",
+					},
+					"package_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Package path containing the interface",
+					},
+					"test_file": map[string]interface{}{
+						"type":        "string",
+						"description": "The _test.go file whose setupMocks functions return the mock",
+					},
+					"confirm": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Must be true to allow test_file to be edited",
+					},
+					"output_dir": map[string]interface{}{
+						"type":        "string",
+						"description": "Directory to output generated mocks",
+					},
+					"with_expecter": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Generate with expecter methods (defaults to the server's -default-with-expecter setting, true unless changed)",
+					},
+					"filename_case": map[string]interface{}{
+						"type":        "string",
+						"enum":        filenameCases,
+						"description": "Casing of the interface name in the default filename (defaults to the server's -filename-case setting, snake unless changed)",
+					},
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Module directory used to resolve package_path when it is an import path",
+					},
+					"in_package": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Generate the mock inside the interface's own package",
+					},
+					"mock_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Template for the mock type name",
+					},
+					"skip_precheck": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Run mockery without first checking that the interface is declared in package_path",
+					},
+					"project_id": map[string]interface{}{
+						"type":        "string",
+						"description": "Project the mock is recorded under, as for generate_mock",
+					},
+				},
+				"required": []string{"interface_name", "package_path", "test_file", "confirm"},
+			},
+		},
+		{
+			Name:        "show_mockery_config",
+			Description: "Show a project's mockery config as parsed JSON and raw YAML, with a count of the packages and interfaces it configures",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Project whose mockery config to show; .mockery.yaml, .mockery.yml and mockery.yaml are tried in that order",
+					},
+					"config_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Explicit path to the config file (overrides project_path)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "find_structural_duplicates",
+			Description: "Group interfaces that have different names but identical method sets, as candidates for merging",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the Go project root",
+					},
+				},
+				"required": []string{"project_path"},
+			},
+		},
+		{
+			Name:        "mock_status",
+			Description: "List each discovered interface with whether a mock has been generated for it, and the mock's file and generation time",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the Go project to scan",
+					},
+					"project_id": map[string]interface{}{
+						"type":        "string",
+						"description": "Project from create_project whose recorded mocks to match (default: mocks generated without a project_id)",
+					},
+				},
+				"required": []string{"project_path"},
+			},
+		},
+		{
+			Name:        "preview_scan",
+			Description: "Count the Go files and bytes discover_interfaces would parse under a path, without parsing them, and warn when the scan would be unusually large",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path that would be passed to discover_interfaces",
+					},
+					"max_depth": map[string]interface{}{
+						"type":        "integer",
+						"minimum":     0,
+						"description": "Directory levels to count, as for discover_interfaces (default: 0, unlimited)",
+					},
+				},
+				"required": []string{"project_path"},
+			},
+		},
 		{
-			Name:        "discover_interfaces",
-			Description: "Scan Go project for interface definitions",
+			Name:        "list_packages",
+			Description: "List the Go packages in a project with their import paths and file counts",
 			InputSchema: map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
 					"project_path": map[string]interface{}{
 						"type":        "string",
-						"description": "Path to the Go project to scan",
-					},
-					"include_patterns": map[string]interface{}{
-						"type":        "array",
-						"items":       map[string]interface{}{"type": "string"},
-						"description": "File patterns to include in scan",
-					},
-					"exclude_patterns": map[string]interface{}{
-						"type":        "array",
-						"items":       map[string]interface{}{"type": "string"},
-						"description": "File patterns to exclude from scan",
+						"description": "Path to the Go project root",
 					},
 				},
 				"required": []string{"project_path"},
 			},
 		},
 		{
-			Name:        "generate_mock",
-			Description: "Generate mock using Mockery tool",
+			Name:        "scan_package",
+			Description: "Discover interfaces in a single package identified by its import path",
 			InputSchema: map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
-					"interface_name": map[string]interface{}{
-						"type":        "string",
-						"description": "Name of the interface to mock",
-					},
-					"package_path": map[string]interface{}{
-						"type":        "string",
-						"description": "Package path containing the interface",
-					},
-					"output_dir": map[string]interface{}{
+					"import_path": map[string]interface{}{
 						"type":        "string",
-						"description": "Directory to output generated mocks",
-					},
-					"with_expecter": map[string]interface{}{
-						"type":        "boolean",
-						"default":     true,
-						"description": "Generate with expecter methods",
+						"description": "Import path of the package (e.g. github.com/example/project/internal/domain)",
 					},
-					"filename_format": map[string]interface{}{
+					"project_path": map[string]interface{}{
 						"type":        "string",
-						"default":     "mock_{{.InterfaceName}}.go",
-						"description": "Template for generated mock filename",
+						"description": "Directory of the module to resolve the import path from (default: the server's working directory)",
 					},
 				},
-				"required": []string{"interface_name", "package_path"},
+				"required": []string{"import_path"},
 			},
 		},
 		{
-			Name:        "update_mockery_config",
-			Description: "Create or update .mockery.yaml configuration",
+			Name:        "find_unused_interfaces",
+			Description: "Report interfaces never used as a field, parameter, return or variable type in the project (parses every file, so slower than discover_interfaces)",
 			InputSchema: map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
 					"project_path": map[string]interface{}{
 						"type":        "string",
-						"description": "Path to the project",
-					},
-					"interfaces": map[string]interface{}{
-						"type":        "object",
-						"description": "Interface configurations",
-					},
-					"global_config": map[string]interface{}{
-						"type":        "object",
-						"description": "Global mockery settings",
+						"description": "Path to the Go project root",
 					},
 				},
 				"required": []string{"project_path"},
 			},
 		},
+		{
+			Name:        "regenerate_mocks",
+			Description: "Regenerate every recorded mock for a project using its original options",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project_id": map[string]interface{}{
+						"type":        "string",
+						"description": "Project whose mocks to regenerate (omit for mocks generated without a project)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "reload_config",
+			Description: "Admin: re-read the server config file and apply hot-reloadable settings",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+		},
 	}
+}
 
+// handleToolsList returns the list of available tools
+func (s *MockeryMCPServer) handleToolsList(request *MCPRequest) *MCPResponse {
 	return &MCPResponse{
 		JSONRPC: "2.0",
 		ID:      request.ID,
-		Result:  ToolsListResponse{Tools: tools},
+		Result:  ToolsListResponse{Tools: s.availableTools(toolDefinitions())},
 	}
 }
 
 // handleToolsCall handles tool execution requests
-func (s *MockeryMCPServer) handleToolsCall(request *MCPRequest) *MCPResponse {
-	s.logger.Debug("Handling tools/call", zap.Any("params", request.Params))
-	
+func (s *MockeryMCPServer) handleToolsCall(ctx context.Context, request *MCPRequest) *MCPResponse {
+	logger := s.requestLogger(ctx)
+	logger.Debug("Handling tools/call", zap.Any("params", request.Params))
+
 	// Parse the tool call parameters
 	var toolCall struct {
 		Name      string                 `json:"name"`
@@ -303,79 +1635,311 @@ func (s *MockeryMCPServer) handleToolsCall(request *MCPRequest) *MCPResponse {
 
 	paramsBytes, err := json.Marshal(request.Params)
 	if err != nil {
-		s.logger.Error("Failed to marshal params", zap.Error(err))
+		logger.Error("Failed to marshal params", zap.Error(err))
 		return s.errorResponse(request.ID, -32602, "Invalid params", err.Error())
 	}
 
 	err = json.Unmarshal(paramsBytes, &toolCall)
 	if err != nil {
-		s.logger.Error("Failed to unmarshal tool call", zap.Error(err))
+		logger.Error("Failed to unmarshal tool call", zap.Error(err))
 		return s.errorResponse(request.ID, -32602, "Invalid params", err.Error())
 	}
 
-	s.logger.Debug("Tool call parsed", zap.String("name", toolCall.Name), zap.Any("arguments", toolCall.Arguments))
+	logger.Debug("Tool call parsed", zap.String("name", toolCall.Name), zap.Any("arguments", toolCall.Arguments))
+
+	if s.readOnly.Load() && mutatingTools[toolCall.Name] {
+		return s.toolErrorResponse(request.ID, fmt.Sprintf("Tool %s is disabled in read-only mode", toolCall.Name),
+			fmt.Errorf("%w: %s modifies files or server state", ErrReadOnly, toolCall.Name))
+	}
+	if !s.toolEnabled(toolCall.Name) {
+		return s.errorResponse(request.ID, -32601, "Tool not found",
+			fmt.Sprintf("%s is disabled; start the server with -enable-reload-config to offer it", toolCall.Name))
+	}
+	if response := s.checkRateLimit(ctx, request.ID, toolCall.Name); response != nil {
+		return response
+	}
+
+	// Bound the whole call. Scans and mockery runs stop once the context
+	// is done, so a call that overruns fails instead of running on.
+	timeout := s.config().ToolTimeout
+	if timeout <= 0 {
+		return s.dispatchTool(ctx, request.ID, toolCall.Name, toolCall.Arguments)
+	}
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	response := s.dispatchTool(callCtx, request.ID, toolCall.Name, toolCall.Arguments)
+
+	// A call that still succeeded is reported as it is; a failure caused by
+	// the deadline rather than by the client cancelling becomes a timeout
+	if response.Error != nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		logger.Warn("Tool call timed out", zap.String("tool", toolCall.Name), zap.Duration("timeout", timeout))
+		return s.toolErrorResponse(request.ID, fmt.Sprintf("Tool %s timed out after %s", toolCall.Name, timeout),
+			fmt.Errorf("%w: %s exceeded the %s tool timeout", ErrTimeout, toolCall.Name, timeout))
+	}
+	return response
+}
+
+// dispatchTool routes a tool call to its handler
+func (s *MockeryMCPServer) dispatchTool(ctx context.Context, requestID interface{}, name string, args map[string]interface{}) *MCPResponse {
+	logger := s.requestLogger(ctx)
 
-	// Route to appropriate tool handler
-	switch toolCall.Name {
+	switch name {
 	case "discover_interfaces":
-		response := s.handleDiscoverInterfaces(request.ID, toolCall.Arguments)
-		s.logger.Debug("Response generated", zap.Any("response", response))
+		response := s.handleDiscoverInterfaces(ctx, requestID, args)
+		logger.Debug("Response generated", zap.Any("response", response))
 		return response
 	case "generate_mock":
-		return s.handleGenerateMock(request.ID, toolCall.Arguments)
+		return s.handleGenerateMock(ctx, requestID, args)
 	case "update_mockery_config":
-		return s.handleUpdateMockeryConfig(request.ID, toolCall.Arguments)
+		return s.handleUpdateMockeryConfig(requestID, args)
+	case "validate_mockery_config":
+		return s.handleValidateMockeryConfig(ctx, requestID, args)
+	case "get_interface_source":
+		return s.handleGetInterfaceSource(requestID, args)
+	case "list_packages":
+		return s.handleListPackages(ctx, requestID, args)
+	case "generate_expect_scaffold":
+		return s.handleGenerateExpectScaffold(requestID, args)
+	case "interface_hash":
+		return s.handleInterfaceHash(requestID, args)
+	case "init_project":
+		return s.handleInitProject(ctx, requestID, args)
+	case "diff_interfaces":
+		return s.handleDiffInterfaces(ctx, requestID, args)
+	case "generate_from_config":
+		return s.handleGenerateFromConfig(ctx, requestID, args)
+	case "format_file":
+		return s.handleFormatFile(ctx, requestID, args)
+	case "lint_interfaces":
+		return s.handleLintInterfaces(ctx, requestID, args)
+	case "run_tests":
+		return s.handleRunTests(ctx, requestID, args)
+	case "scaffold_layout":
+		return s.handleScaffoldLayout(requestID, args)
+	case "export_interfaces":
+		return s.handleExportInterfaces(ctx, requestID, args)
+	case "explain_generate":
+		return s.handleExplainGenerate(ctx, requestID, args)
+	case "get_interface_methods":
+		return s.handleGetInterfaceMethods(ctx, requestID, args)
+	case "interface_stats":
+		return s.handleInterfaceStats(ctx, requestID, args)
+	case "create_project":
+		return s.handleCreateProject(requestID, args)
+	case "get_project":
+		return s.handleGetProject(requestID, args)
+	case "get_job":
+		return s.handleGetJob(requestID, args)
+	case "list_projects":
+		return s.handleListProjects(requestID, args)
+	case "watch_project":
+		return s.handleWatchProject(ctx, requestID, args)
+	case "unwatch_project":
+		return s.handleUnwatchProject(ctx, requestID, args)
+	case "suggest_mock_setup":
+		return s.handleSuggestMockSetup(requestID, args)
+	case "audit_config":
+		return s.handleAuditConfig(ctx, requestID, args)
+	case "generate_testify_mock":
+		return s.handleGenerateTestifyMock(requestID, args)
+	case "generate_mock_if_changed":
+		return s.handleGenerateMockIfChanged(ctx, requestID, args)
+	case "generate_func_mock":
+		return s.handleGenerateFuncMock(ctx, requestID, args)
+	case "clean_mocks":
+		return s.handleCleanMocks(ctx, requestID, args)
+	case "project_info":
+		return s.handleProjectInfo(ctx, requestID, args)
+	case "apply_mock_to_test":
+		return s.handleApplyMockToTest(ctx, requestID, args)
+	case "show_mockery_config":
+		return s.handleShowMockeryConfig(requestID, args)
+	case "find_structural_duplicates":
+		return s.handleFindStructuralDuplicates(ctx, requestID, args)
+	case "mock_status":
+		return s.handleMockStatus(ctx, requestID, args)
+	case "preview_scan":
+		return s.handlePreviewScan(ctx, requestID, args)
+	case "describe_tool":
+		return s.handleDescribeTool(requestID, args)
+	case "scan_package":
+		return s.handleScanPackage(ctx, requestID, args)
+	case "find_unused_interfaces":
+		return s.handleFindUnusedInterfaces(ctx, requestID, args)
+	case "regenerate_mocks":
+		return s.handleRegenerateMocks(ctx, requestID, args)
+	case "reload_config":
+		return s.handleReloadConfig(requestID, args)
 	default:
-		return s.errorResponse(request.ID, -32601, "Tool not found", nil)
+		return s.errorResponse(requestID, -32601, "Tool not found", nil)
 	}
 }
 
 // handleDiscoverInterfaces implements the discover_interfaces tool
-func (s *MockeryMCPServer) handleDiscoverInterfaces(requestID interface{}, args map[string]interface{}) *MCPResponse {
-	s.logger.Info("Discovering interfaces", zap.Any("args", args))
-	
+func (s *MockeryMCPServer) handleDiscoverInterfaces(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	logger := s.requestLogger(ctx)
+	logger.Info("Discovering interfaces", zap.Any("args", args))
+
 	// Parse arguments
 	projectPath, ok := args["project_path"].(string)
 	if !ok {
-		s.logger.Error("Missing or invalid project_path", zap.Any("args", args))
+		logger.Error("Missing or invalid project_path", zap.Any("args", args))
 		return s.errorResponse(requestID, -32602, "Missing or invalid project_path", nil)
 	}
 
-	s.logger.Info("Scanning project", zap.String("path", projectPath))
+	excludes, err := stringSliceArg(args, "exclude_interfaces")
+	if err != nil {
+		return s.errorResponse(requestID, -32602, "Invalid exclude_interfaces", err.Error())
+	}
+	if err := validateInterfacePatterns(excludes); err != nil {
+		return s.errorResponse(requestID, -32602, "Invalid exclude_interfaces", err.Error())
+	}
+
+	fields, err := stringSliceArg(args, "fields")
+	if err != nil {
+		return s.errorResponse(requestID, -32602, "Invalid fields", err.Error())
+	}
+	if err := validateDiscoveryFields(fields); err != nil {
+		return s.errorResponse(requestID, -32602, "Invalid fields", err.Error())
+	}
+
+	maxDepth := 0
+	if value, ok := args["max_depth"]; ok {
+		depth, ok := value.(float64)
+		if !ok || depth < 0 || depth != float64(int(depth)) {
+			return s.errorResponse(requestID, -32602, "Invalid max_depth", "must be a non-negative integer")
+		}
+		maxDepth = int(depth)
+	}
+
+	// A max_methods of -1 leaves the method count unbounded
+	minMethods, maxMethods := 0, -1
+	for name, bound := range map[string]*int{"min_methods": &minMethods, "max_methods": &maxMethods} {
+		if value, ok := args[name]; ok {
+			count, ok := value.(float64)
+			if !ok || count < 0 || count != float64(int(count)) {
+				return s.errorResponse(requestID, -32602, "Invalid "+name, "must be a non-negative integer")
+			}
+			*bound = int(count)
+		}
+	}
+	if maxMethods >= 0 && minMethods > maxMethods {
+		return s.errorResponse(requestID, -32602, "Invalid max_methods",
+			fmt.Sprintf("max_methods (%d) is less than min_methods (%d)", maxMethods, minMethods))
+	}
+
+	logger.Info("Scanning project", zap.String("path", projectPath))
 
 	// Convert relative paths to absolute paths
 	absPath, err := filepath.Abs(projectPath)
 	if err != nil {
-		s.logger.Error("Failed to resolve absolute path", zap.String("path", projectPath), zap.Error(err))
-		return s.errorResponse(requestID, -32603, fmt.Sprintf("Failed to resolve path: %s", projectPath), err.Error())
+		logger.Error("Failed to resolve absolute path", zap.String("path", projectPath), zap.Error(err))
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", projectPath), err)
 	}
 
 	// Check if path exists
 	if _, err := os.Stat(absPath); os.IsNotExist(err) {
-		s.logger.Error("Project path does not exist", zap.String("path", absPath))
-		return s.errorResponse(requestID, -32603, fmt.Sprintf("Project path does not exist: %s", absPath), err)
+		logger.Error("Project path does not exist", zap.String("path", absPath))
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Project path does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
 	}
 
 	// Use the absolute path for scanning
 	projectPath = absPath
 
+	// Mockery needs a go.mod, so a path outside any module is caught here
+	// rather than surfacing as a confusing generation failure later
+	requireModule := s.config().RequireModule
+	if value, ok := args["require_module"].(bool); ok {
+		requireModule = value
+	}
+	moduleRoot, modulePath := scanner.FindModule(projectPath)
+	if requireModule && moduleRoot == "" {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("No go.mod found in or above %s", projectPath),
+			fmt.Errorf("%w: no go.mod in or above %s; run go mod init first", ErrModuleNotFound, projectPath))
+	}
+
+	// Apply the server-wide excludes along with the request's own
+	patterns := append(append([]string(nil), s.config().ExcludeInterfaces...), excludes...)
+
+	// An unchanged source tree yields the same result, so a client that
+	// already holds the current version can skip re-parsing entirely
+	versionKey := discoveryVersionKey(projectPath, patterns) + fmt.Sprintf("\x00%d-%d\x00%d", minMethods, maxMethods, maxDepth)
+	fingerprint, err := sourceFingerprint(ctx, projectPath)
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		logger.Info("Scan cancelled", zap.String("path", projectPath), zap.Error(err))
+		return s.toolErrorResponse(requestID, "Scan cancelled", err)
+	}
+	if err != nil {
+		logger.Error("Failed to fingerprint project", zap.String("path", projectPath), zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to scan project", fmt.Errorf("%w: %v", ErrScanFailed, err))
+	}
+	// The fingerprint doesn't look through symlinks, so it can't vouch for
+	// a scan that does
+	followSymlinks, _ := args["follow_symlinks"].(bool)
+	if ifNoneMatch, _ := args["if_none_match"].(string); ifNoneMatch != "" && !followSymlinks {
+		if version, ok := s.cachedDiscoveryVersion(versionKey, fingerprint); ok && version == ifNoneMatch {
+			return s.textResponse(requestID, fmt.Sprintf("Not modified: interfaces in %s are unchanged\nVersion: %s", projectPath, version))
+		}
+	}
+
 	// Scan for interfaces
-	interfaces, err := s.scanner.ScanProject(projectPath)
+	interfaces, scanResults, err := s.scanner.ScanProjectContext(ctx, projectPath, scanner.ScanOptions{FollowSymlinks: followSymlinks, MaxDepth: maxDepth})
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		logger.Info("Scan cancelled", zap.String("path", projectPath), zap.Error(err))
+		return s.toolErrorResponse(requestID, "Scan cancelled", err)
+	}
 	if err != nil {
-		s.logger.Error("Failed to scan project", zap.String("path", projectPath), zap.Error(err))
-		return s.errorResponse(requestID, -32603, "Failed to scan project", err.Error())
+		logger.Error("Failed to scan project", zap.String("path", projectPath), zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to scan project", fmt.Errorf("%w: %v", ErrScanFailed, err))
 	}
+	interfaces = excludeInterfaces(interfaces, patterns)
+	interfaces = filterMethodCount(interfaces, minMethods, maxMethods)
 
-	s.logger.Info("Found interfaces", zap.Int("count", len(interfaces)))
+	version := computeDiscoveryVersion(fingerprint, interfaces, patterns)
+	s.storeDiscoveryVersion(versionKey, fingerprint, version)
+
+	logger.Info("Found interfaces", zap.Int("count", len(interfaces)), zap.Int("scan_errors", len(scanResults.Errors)))
 
 	// Create a simplified response for testing
-	simplified := make([]map[string]interface{}, len(interfaces))
-	for i, iface := range interfaces {
-		simplified[i] = map[string]interface{}{
-			"name":    iface.Name,
-			"package": iface.Package,
-			"file_path": iface.FilePath,
-			"method_count": len(iface.Methods),
+	simplified := simplifyInterfaceFields(interfaces, fields)
+	if includeSignatures, _ := args["include_signatures"].(bool); includeSignatures {
+		for i, iface := range interfaces {
+			simplified[i]["signatures"] = matcherSignatures(iface)
+		}
+	}
+
+	if relativePaths, _ := args["relative_paths"].(bool); relativePaths {
+		relativeFilePaths(simplified, projectPath)
+	}
+
+	if indexed, _ := args["index"].(bool); indexed {
+		data, err := json.MarshalIndent(indexDiscovery(interfaces, simplified, modulePath, version, scanResults.Errors), "", "  ")
+		if err != nil {
+			return s.toolErrorResponse(requestID, "Failed to encode interface index", err)
+		}
+		return s.textResponse(requestID, string(data))
+	}
+
+	header := fmt.Sprintf("Found %d interfaces in %s:", len(interfaces), projectPath)
+	if modulePath != "" {
+		header += fmt.Sprintf("\nModule: %s", modulePath)
+	}
+	header += fmt.Sprintf("\nVersion: %s", version)
+
+	listing := formatInterfaceList(simplified)
+	groups := groupInterfacesByPackage(interfaces, simplified, scanResults.PackageDocs, moduleRoot, modulePath)
+	if groupByPackage, _ := args["group_by_package"].(bool); groupByPackage {
+		header += fmt.Sprintf("\nPackages: %d", len(groups))
+		listing = formatGroupedInterfaceList(groups)
+	} else if docs := formatPackageDocs(groups); docs != "" {
+		listing += "\n\n" + docs
+	}
+
+	// Paths that couldn't be read are reported rather than failing the scan
+	if len(scanResults.Errors) > 0 {
+		listing += fmt.Sprintf("\n\nSkipped %d paths with errors:", len(scanResults.Errors))
+		for _, scanError := range scanResults.Errors {
+			listing += "\n- " + scanError
 		}
 	}
 
@@ -386,47 +1950,151 @@ func (s *MockeryMCPServer) handleDiscoverInterfaces(requestID interface{}, args
 			"content": []map[string]interface{}{
 				{
 					"type": "text",
-					"text": fmt.Sprintf("Found %d interfaces in %s:\n\n%s", 
-						len(interfaces), 
-						projectPath,
-						formatInterfaceList(simplified)),
+					"text": fmt.Sprintf("%s\n\n%s",
+						header,
+						listing),
 				},
 			},
 		},
 	}
 }
 
-// formatInterfaceList formats the interface list for display
+// simplifyInterfaces reduces interface definitions to the fields shown in listings
+func simplifyInterfaces(interfaces []types.InterfaceDefinition) []map[string]interface{} {
+	return simplifyInterfaceFields(interfaces, defaultDiscoveryFields)
+}
+
+// formatInterfaceList formats the interface list for display, showing only
+// the fields present in each entry
 func formatInterfaceList(interfaces []map[string]interface{}) string {
 	var result strings.Builder
 	for i, iface := range interfaces {
 		if i > 0 {
 			result.WriteString("\n")
 		}
-		result.WriteString(fmt.Sprintf("- %s (%s package) - %d methods\n  File: %s", 
-			iface["name"], 
-			iface["package"], 
-			iface["method_count"], 
-			iface["file_path"]))
+		result.WriteString("-")
+		if name, ok := iface["name"]; ok {
+			result.WriteString(fmt.Sprintf(" %s", name))
+		}
+		if pkg, ok := iface["package"]; ok {
+			result.WriteString(fmt.Sprintf(" (%s package)", pkg))
+		}
+		if count, ok := iface["method_count"]; ok {
+			result.WriteString(fmt.Sprintf(" - %d methods", count))
+		}
+		if exported, ok := iface["exported"].(bool); ok {
+			if exported {
+				result.WriteString(" - exported")
+			} else {
+				result.WriteString(" - unexported")
+			}
+		}
+		filePath, hasFile := iface["file_path"]
+		if hasFile {
+			result.WriteString(fmt.Sprintf("\n  File: %s", filePath))
+		}
+		if line, ok := iface["line_number"]; ok {
+			if hasFile {
+				result.WriteString(fmt.Sprintf(":%d", line))
+			} else {
+				result.WriteString(fmt.Sprintf("\n  Line: %d", line))
+			}
+		}
+		if declared, ok := iface["range"].(sourceRange); ok {
+			result.WriteString("\n  Range: " + declared.String())
+		}
+		if methods, ok := iface["methods"].([]string); ok && len(methods) > 0 {
+			result.WriteString("\n  Methods: " + strings.Join(methods, ", "))
+		}
+		if signatures, ok := iface["signatures"].([]string); ok {
+			for _, signature := range signatures {
+				result.WriteString("\n    " + signature)
+			}
+		}
 	}
 	return result.String()
 }
 
 // handleGenerateMock implements the generate_mock tool
-func (s *MockeryMCPServer) handleGenerateMock(requestID interface{}, args map[string]interface{}) *MCPResponse {
-	// Parse arguments
+func (s *MockeryMCPServer) handleGenerateMock(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	request, errResponse := s.parseGenerateMockArgs(requestID, args)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	if async, _ := args["async"].(bool); async {
+		if request.Recursive || request.ReturnContent {
+			return s.errorResponse(requestID, -32602, "Invalid async", "async cannot be combined with recursive or return_content")
+		}
+		jobID, err := s.SubmitJob(request.ProjectID, *request)
+		if err != nil {
+			return s.toolErrorResponse(requestID, "Failed to queue mock generation", err)
+		}
+		return s.textResponse(requestID, fmt.Sprintf("Queued mock generation:\n- Interface: %s\n- Job ID: %s\n\nCall get_job with the job ID for its status and result.",
+			request.InterfaceName, jobID))
+	}
+
+	if request.Recursive {
+		if request.ReturnContent {
+			return s.errorResponse(requestID, -32602, "Invalid return_content", "return_content cannot be combined with recursive")
+		}
+		return s.handleGenerateMocksRecursive(ctx, requestID, request)
+	}
+
+	// Generate mock
+	result, err := s.GenerateMock(ctx, request)
+	if err != nil {
+		s.requestLogger(ctx).Error("Mock generation failed", zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to generate mock", err)
+	}
+
+	if request.ReturnContent {
+		return s.mockContentResponse(requestID, request, result)
+	}
+
+	text := fmt.Sprintf("Mock generated successfully:\n- Interface: %s\n- Package: %s\n- Generated: %s",
+		request.InterfaceName,
+		request.PackagePath,
+		result.GeneratedFile)
+	if result.MockeryVersion != "" {
+		text += fmt.Sprintf("\n- Mockery version: %s", result.MockeryVersion)
+	}
+
+	return &MCPResponse{
+		JSONRPC: "2.0",
+		ID:      requestID,
+		Result: map[string]interface{}{
+			"content": []map[string]interface{}{
+				{
+					"type": "text",
+					"text": text,
+				},
+			},
+		},
+	}
+}
+
+// parseGenerateMockArgs builds a generation request from generate_mock
+// arguments, returning an error response for invalid ones
+func (s *MockeryMCPServer) parseGenerateMockArgs(requestID interface{}, args map[string]interface{}) (*types.MockGenerationRequest, *MCPResponse) {
 	var request types.MockGenerationRequest
-	
+
+	if recursive, ok := args["recursive"].(bool); ok {
+		request.Recursive = recursive
+	}
+
+	// interface_name is optional for recursive generation, where it filters
+	// the discovered interfaces instead of naming a single one
 	if interfaceName, ok := args["interface_name"].(string); ok {
 		request.InterfaceName = interfaceName
-	} else {
-		return s.errorResponse(requestID, -32602, "Missing or invalid interface_name", nil)
+	} else if !request.Recursive {
+		return nil, s.errorResponse(requestID, -32602, "Missing or invalid interface_name", nil)
 	}
 
 	if packagePath, ok := args["package_path"].(string); ok {
 		request.PackagePath = packagePath
 	} else {
-		return s.errorResponse(requestID, -32602, "Missing or invalid package_path", nil)
+		return nil, s.errorResponse(requestID, -32602, "Missing or invalid package_path", nil)
 	}
 
 	if outputDir, ok := args["output_dir"].(string); ok {
@@ -436,130 +2104,228 @@ func (s *MockeryMCPServer) handleGenerateMock(requestID interface{}, args map[st
 	if withExpector, ok := args["with_expecter"].(bool); ok {
 		request.WithExpector = withExpector
 	} else {
-		request.WithExpector = true // Default
+		request.WithExpector = s.config().DefaultWithExpecter
 	}
 
 	if filenameFormat, ok := args["filename_format"].(string); ok {
 		request.FilenameFormat = filenameFormat
 	}
 
-	// Generate mock
-	result, err := s.GenerateMock(context.Background(), &request)
+	templateVars, err := stringMapArg(args, "template_vars")
 	if err != nil {
-		s.logger.Error("Mock generation failed", zap.Error(err))
-		return s.errorResponse(requestID, -32603, "Failed to generate mock", err.Error())
+		return nil, s.errorResponse(requestID, -32602, "Invalid template_vars", err.Error())
+	}
+	if err := validateTemplateVars(templateVars); err != nil {
+		return nil, s.errorResponse(requestID, -32602, "Invalid template_vars", err.Error())
+	}
+	request.TemplateVars = templateVars
+
+	// Catch syntax errors and undefined variables before anything runs;
+	// the package name is only known once the package is resolved
+	if request.FilenameFormat != "" {
+		vars := mockTemplateVars(&request, "pkg")
+		if _, err := renderMockTemplate(request.FilenameFormat, vars); err != nil {
+			return nil, s.errorResponse(requestID, -32602, "Invalid filename_format", err.Error())
+		}
 	}
 
-	return &MCPResponse{
-		JSONRPC: "2.0",
-		ID:      requestID,
-		Result: map[string]interface{}{
-			"content": []map[string]interface{}{
-				{
-					"type": "text",
-					"text": fmt.Sprintf("Mock generated successfully:\n- Interface: %s\n- Package: %s\n- Generated: %s", 
-						request.InterfaceName, 
-						request.PackagePath, 
-						result.GeneratedFile),
-				},
-			},
-		},
+	if filenameCase, ok := args["filename_case"].(string); ok {
+		if err := validateFilenameCase(filenameCase); err != nil {
+			return nil, s.errorResponse(requestID, -32602, "Invalid filename_case", err.Error())
+		}
+		request.FilenameCase = filenameCase
+	}
+	if request.FilenameCase == "" {
+		request.FilenameCase = s.config().FilenameCase
 	}
-}
 
-// handleUpdateMockeryConfig implements the update_mockery_config tool
-func (s *MockeryMCPServer) handleUpdateMockeryConfig(requestID interface{}, args map[string]interface{}) *MCPResponse {
-	// This would implement config file updates
-	// For now, return a simple success response
-	return &MCPResponse{
-		JSONRPC: "2.0",
-		ID:      requestID,
-		Result: map[string]interface{}{
-			"content": []map[string]interface{}{
-				{
-					"type": "text",
-					"text": "Mockery configuration updated successfully",
-				},
-			},
-		},
+	if boilerplateFile, ok := args["boilerplate_file"].(string); ok {
+		request.BoilerplateFile = boilerplateFile
+	}
+
+	if buildTag, ok := args["build_tag"].(string); ok && buildTag != "" {
+		if err := validateBuildTag(buildTag); err != nil {
+			return nil, s.errorResponse(requestID, -32602, "Invalid build_tag", err.Error())
+		}
+		request.BuildTag = buildTag
+	}
+
+	if projectPath, ok := args["project_path"].(string); ok {
+		request.ProjectPath = projectPath
+	}
+
+	if inPackage, ok := args["in_package"].(bool); ok {
+		request.InPackage = inPackage
+	}
+
+	if testOnly, ok := args["test_only"].(bool); ok {
+		request.TestOnly = testOnly
+	}
+
+	if mockName, ok := args["mock_name"].(string); ok {
+		request.MockName = mockName
+	}
+
+	if returnContent, ok := args["return_content"].(bool); ok {
+		request.ReturnContent = returnContent
+	}
+
+	if skipPrecheck, ok := args["skip_precheck"].(bool); ok {
+		request.SkipPrecheck = skipPrecheck
+	}
+
+	if mocksModule, ok := args["mocks_module"].(string); ok {
+		request.MocksModule = mocksModule
+	}
+
+	if projectID, ok := args["project_id"].(string); ok && projectID != "" {
+		if _, exists := s.projectManager.GetProject(projectID); !exists {
+			return nil, s.toolErrorResponse(requestID, "Unknown project_id", fmt.Errorf("%w: %s", ErrProjectNotFound, projectID))
+		}
+		request.ProjectID = projectID
+	}
+
+	if combinedFile, ok := args["combined_file"].(string); ok && combinedFile != "" {
+		if !request.Recursive {
+			return nil, s.errorResponse(requestID, -32602, "Invalid combined_file", "combined_file requires recursive")
+		}
+		request.CombinedFile = combinedFile
+	}
+
+	extraArgs, err := stringSliceArg(args, "extra_args")
+	if err != nil {
+		return nil, s.errorResponse(requestID, -32602, "Invalid extra_args", err.Error())
 	}
+	if err := validateExtraArgs(extraArgs); err != nil {
+		return nil, s.errorResponse(requestID, -32602, "Invalid extra_args", err.Error())
+	}
+	request.ExtraArgs = extraArgs
+
+	return &request, nil
 }
 
 // GenerateMock generates a mock using the mockery tool
 func (s *MockeryMCPServer) GenerateMock(ctx context.Context, request *types.MockGenerationRequest) (*types.MockGenerationResult, error) {
 	startTime := time.Now()
 
-	s.logger.Info("Generating mock",
+	// Use one configuration snapshot for the whole generation, even if the
+	// configuration is reloaded concurrently
+	cfg := s.config()
+
+	s.requestLogger(ctx).Info("Generating mock",
 		zap.String("interface", request.InterfaceName),
 		zap.String("package", request.PackagePath),
 	)
 
-	// Convert relative package path to absolute if needed
-	absPackagePath, err := filepath.Abs(request.PackagePath)
+	plan, err := s.planGeneration(ctx, cfg, request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolve package path: %w", err)
+		return nil, err
 	}
-
-	// Set default output directory if not specified
-	outputDir := request.OutputDir
-	if outputDir == "" {
-		outputDir = filepath.Join(absPackagePath, "mocks")
-	} else if !filepath.IsAbs(outputDir) {
-		outputDir, _ = filepath.Abs(outputDir)
+	request = plan.request
+	outputDir := plan.outputDir
+	mockFilename := plan.filename
+
+	// Content-only requests generate into a scratch directory that is
+	// removed however generation ends
+	if request.ReturnContent {
+		tempDir, err := os.MkdirTemp("", "mockery-mcp-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary output directory: %w", err)
+		}
+		defer os.RemoveAll(tempDir)
+		outputDir = tempDir
 	}
 
 	// Ensure output directory exists
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
+	if err := cfg.makeOutputDir(outputDir); err != nil {
 		return nil, fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	// Generate mock filename
-	mockFilename := request.FilenameFormat
-	if mockFilename == "" {
-		mockFilename = fmt.Sprintf("mock_%s.go", strings.ToLower(request.InterfaceName))
-	} else {
-		// Replace template variables
-		mockFilename = strings.ReplaceAll(mockFilename, "{{.InterfaceName}}", request.InterfaceName)
+	// Mocks in a module of their own need it to exist before mockery runs
+	if module := plan.mocksModule; module != nil && module.create && !request.ReturnContent {
+		if err := module.writeGoMod(); err != nil {
+			return nil, err
+		}
 	}
 
-	// Build mockery command
-	args := []string{
-		"--name=" + request.InterfaceName,
-		"--dir=" + absPackagePath,
-		"--output=" + outputDir,
-		"--filename=" + mockFilename,
+	// Mockery v3 reads the request from a config file of its own
+	builder := s.argsBuilder(ctx, cfg)
+	configPath := ""
+	if builder.usesConfig() {
+		configDir, err := os.MkdirTemp("", "mockery-mcp-config-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary config directory: %w", err)
+		}
+		defer os.RemoveAll(configDir)
+		configPath = filepath.Join(configDir, mockeryV3ConfigFilename)
 	}
-
-	if request.WithExpector {
-		args = append(args, "--with-expecter")
+	args, config, err := builder.args(plan, outputDir, configPath)
+	if err != nil {
+		return nil, err
+	}
+	if config != nil {
+		if err := os.WriteFile(configPath, config, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write mockery config: %w", err)
+		}
 	}
 
 	// Check if mockery is available
-	if _, err := exec.LookPath("mockery"); err != nil {
-		return nil, fmt.Errorf("mockery command not found in PATH. Please install mockery: go install github.com/vektra/mockery/v2@latest")
+	if _, err := exec.LookPath(cfg.MockeryCommand); err != nil {
+		return nil, fmt.Errorf("%w: mockery command not found in PATH. Please install mockery: go install github.com/vektra/mockery/v2@latest", ErrMockeryNotInstalled)
 	}
 
+	// Bound concurrent mockery processes across all connections
+	release, err := s.acquireGeneration(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Execute mockery command
-	s.logger.Info("Executing mockery", zap.Strings("args", args))
-	cmd := exec.Command("mockery", args...)
-	cmd.Dir = absPackagePath // Set working directory
-	output, err := cmd.CombinedOutput()
-	
-	s.logger.Debug("Mockery output", zap.String("output", string(output)))
-	
+	output, err := s.runMockery(ctx, cfg, plan.source.workDir, args)
 	if err != nil {
-		return nil, fmt.Errorf("mockery failed: %w\nOutput: %s", err, string(output))
+		return nil, err
 	}
 
 	generatedFile := filepath.Join(outputDir, mockFilename)
 
+	if request.BuildTag != "" {
+		if err := applyBuildTag(generatedFile, request.BuildTag); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrGenerationFailed, err)
+		}
+	}
+
+	if !request.ReturnContent {
+		if err := cfg.applyFileMode(generatedFile); err != nil {
+			return nil, fmt.Errorf("%w: failed to set mode of %s: %v", ErrGenerationFailed, generatedFile, err)
+		}
+	}
+
 	result := &types.MockGenerationResult{
-		Success:       true,
-		GeneratedFile: generatedFile,
-		GeneratedAt:   startTime,
-		MockeryOutput: string(output),
+		Success:        true,
+		InterfaceName:  request.InterfaceName,
+		GeneratedFile:  generatedFile,
+		GeneratedAt:    startTime,
+		MockeryOutput:  string(output),
+		MockeryVersion: s.mockeryVersion(ctx, cfg.MockeryCommand),
+	}
+	if plan.iface != nil {
+		result.SourceHash = scanner.InterfaceSignatureHash(*plan.iface)
+	}
+
+	if request.ReturnContent {
+		content, err := os.ReadFile(generatedFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: mockery did not produce %s: %v", ErrGenerationFailed, mockFilename, err)
+		}
+		// Nothing was written to disk, so there is no file to record
+		result.GeneratedFile = ""
+		result.Content = string(content)
+		return result, nil
 	}
 
+	s.recordGeneratedMock(ctx, request, result)
+
 	return result, nil
 }
 
@@ -569,8 +2335,12 @@ func (s *MockeryMCPServer) handleInitialize(request *MCPRequest) *MCPResponse {
 		"tools": map[string]interface{}{
 			"listChanged": false,
 		},
+		"completions": map[string]interface{}{},
+		"prompts": map[string]interface{}{
+			"listChanged": false,
+		},
 	}
-	
+
 	return &MCPResponse{
 		JSONRPC: "2.0",
 		ID:      request.ID,
@@ -608,6 +2378,22 @@ func (s *MockeryMCPServer) handlePing(request *MCPRequest) *MCPResponse {
 	}
 }
 
+// textResponse creates a successful tool response with a single text content block
+func (s *MockeryMCPServer) textResponse(id interface{}, text string) *MCPResponse {
+	return &MCPResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Result: map[string]interface{}{
+			"content": []map[string]interface{}{
+				{
+					"type": "text",
+					"text": text,
+				},
+			},
+		},
+	}
+}
+
 // errorResponse creates an error response
 func (s *MockeryMCPServer) errorResponse(id interface{}, code int, message string, data interface{}) *MCPResponse {
 	return &MCPResponse{
@@ -619,4 +2405,4 @@ func (s *MockeryMCPServer) errorResponse(id interface{}, code int, message strin
 			Data:    data,
 		},
 	}
-}
\ No newline at end of file
+}
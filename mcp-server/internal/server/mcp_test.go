@@ -0,0 +1,571 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/client"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// newTestServer creates a server with a no-op logger
+func newTestServer(t *testing.T) *MockeryMCPServer {
+	t.Helper()
+	return NewMockeryMCPServer(zap.NewNop())
+}
+
+// callTool invokes a tool through the tools/call method
+func callTool(t *testing.T, s *MockeryMCPServer, name string, args map[string]interface{}) *MCPResponse {
+	t.Helper()
+	return s.handleMCPRequest(context.Background(), &MCPRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "tools/call",
+		Params: map[string]interface{}{
+			"name":      name,
+			"arguments": args,
+		},
+	})
+}
+
+// responseText returns the text of the first content block of a tool response
+func responseText(t *testing.T, response *MCPResponse) string {
+	t.Helper()
+	require.NotNil(t, response)
+	require.Nil(t, response.Error, "unexpected error: %+v", response.Error)
+
+	result, ok := response.Result.(map[string]interface{})
+	require.True(t, ok)
+	content, ok := result["content"].([]map[string]interface{})
+	require.True(t, ok)
+	require.NotEmpty(t, content)
+
+	text, ok := content[0]["text"].(string)
+	require.True(t, ok)
+	return text
+}
+
+// writeFile writes a file under dir, creating parent directories as needed
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestMockeryMCPServer_GetInterfaceSource(t *testing.T) {
+	s := newTestServer(t)
+	file := writeFile(t, t.TempDir(), "repo.go", `package repo
+
+// Store persists values
+type Store interface {
+	Put(key string, value []byte) error
+}
+`)
+
+	t.Run("returns declaration with doc comment", func(t *testing.T) {
+		response := callTool(t, s, "get_interface_source", map[string]interface{}{
+			"file_path":      file,
+			"interface_name": "Store",
+		})
+
+		assert.Equal(t, "// Store persists values\ntype Store interface {\n\tPut(key string, value []byte) error\n}", responseText(t, response))
+	})
+
+	t.Run("unknown interface", func(t *testing.T) {
+		response := callTool(t, s, "get_interface_source", map[string]interface{}{
+			"file_path":      file,
+			"interface_name": "Missing",
+		})
+
+		require.NotNil(t, response.Error)
+		assert.Equal(t, CodeInterfaceNotFound, response.Error.Code)
+	})
+
+	t.Run("missing arguments", func(t *testing.T) {
+		response := callTool(t, s, "get_interface_source", map[string]interface{}{
+			"file_path": file,
+		})
+
+		require.NotNil(t, response.Error)
+		assert.Equal(t, -32602, response.Error.Code)
+	})
+}
+
+func TestMockeryMCPServer_GenerateMock_PackagePathValidation(t *testing.T) {
+	s := newTestServer(t)
+	dir := t.TempDir()
+	file := writeFile(t, dir, "repo.go", "package repo\n\ntype Repo interface{}\n")
+
+	t.Run("file instead of directory", func(t *testing.T) {
+		_, err := s.GenerateMock(context.Background(), &types.MockGenerationRequest{
+			InterfaceName: "Repo",
+			PackagePath:   file,
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is a file, but mockery --dir expects a directory")
+		assert.Contains(t, err.Error(), "use "+dir+" instead")
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		_, err := s.GenerateMock(context.Background(), &types.MockGenerationRequest{
+			InterfaceName: "Repo",
+			PackagePath:   filepath.Join(dir, "missing"),
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "package path does not exist")
+	})
+}
+
+// fakeMockeryScript is a stand-in for mockery that writes a stub mock file
+// to the location given by --output and --filename
+const fakeMockeryScript = `#!/bin/sh
+if [ "$1" = "--version" ]; then
+	echo "v2.53.3"
+	exit 0
+fi
+for arg in "$@"; do
+	case "$arg" in
+		--name=*) name="${arg#--name=}" ;;
+		--output=*) output="${arg#--output=}" ;;
+		--filename=*) filename="${arg#--filename=}" ;;
+	esac
+done
+mkdir -p "$output"
+printf 'package mocks\n\n// Mock%s is a mock\ntype Mock%s struct{}\n' "$name" "$name" > "$output/$filename"
+echo "generated $name"
+`
+
+// useFakeMockery points the server at a fake mockery executable with the given script
+func useFakeMockery(t *testing.T, s *MockeryMCPServer, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake mockery requires a POSIX shell")
+	}
+
+	path := filepath.Join(t.TempDir(), "mockery")
+	require.NoError(t, os.WriteFile(path, []byte(script), 0755))
+	require.NoError(t, s.SetMockeryCommand(path))
+	return path
+}
+
+func TestMockeryMCPServer_ClientOverWebSocket(t *testing.T) {
+	s := newTestServer(t)
+	httpServer := httptest.NewServer(http.HandlerFunc(s.handleWebSocket))
+	defer httpServer.Close()
+
+	ctx := context.Background()
+	c, err := client.DialWebSocket(ctx, "ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = c.Initialize(ctx)
+	require.NoError(t, err)
+
+	tools, err := c.ListTools(ctx)
+	require.NoError(t, err)
+	assert.NotEmpty(t, tools)
+
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype Repo interface {\n\tGet() error\n}\n")
+
+	result, err := c.DiscoverInterfaces(ctx, client.DiscoverInterfacesParams{ProjectPath: dir})
+	require.NoError(t, err)
+	assert.Contains(t, result.Text(), "Found 1 interfaces")
+
+	_, err = c.GenerateMock(ctx, client.GenerateMockParams{PackagePath: dir})
+	var mcpErr *client.Error
+	require.ErrorAs(t, err, &mcpErr)
+	assert.Equal(t, -32602, mcpErr.Code)
+}
+
+func TestMockeryMCPServer_ClientOverStdio(t *testing.T) {
+	s := newTestServer(t)
+
+	requestReader, requestWriter := io.Pipe()
+	responseReader, responseWriter := io.Pipe()
+	go func() {
+		s.ServeStdio(requestReader, responseWriter)
+		responseWriter.Close()
+	}()
+
+	c := client.NewStdio(responseReader, requestWriter)
+	defer c.Close()
+
+	ctx := context.Background()
+	_, err := c.Initialize(ctx)
+	require.NoError(t, err)
+	require.NoError(t, c.Ping(ctx))
+
+	result, err := c.UpdateConfig(ctx, client.UpdateConfigParams{
+		ProjectPath:  t.TempDir(),
+		GlobalConfig: map[string]interface{}{"outpkg": "fakes"},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, result.Text(), "Created mockery config")
+}
+
+func TestMockeryMCPServer_ServeStdioOnce(t *testing.T) {
+	t.Run("single request", func(t *testing.T) {
+		s := newTestServer(t)
+		in := strings.NewReader("\n" +
+			`{"jsonrpc":"2.0","id":1,"method":"ping"}` + "\n" +
+			`{"jsonrpc":"2.0","id":2,"method":"ping"}` + "\n")
+		var out strings.Builder
+		require.NoError(t, s.ServeStdioOnce(in, &out))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 1)
+		assert.Contains(t, lines[0], `"id":1`)
+	})
+
+	t.Run("notification", func(t *testing.T) {
+		s := newTestServer(t)
+		// The stream stays open, so returning shows the server stopped reading
+		requestReader, requestWriter := io.Pipe()
+		defer requestWriter.Close()
+		go requestWriter.Write([]byte(`{"jsonrpc":"2.0","method":"notifications/initialized"}` + "\n"))
+
+		var out strings.Builder
+		require.NoError(t, s.ServeStdioOnce(requestReader, &out))
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		s := newTestServer(t)
+		var out strings.Builder
+		require.NoError(t, s.ServeStdioOnce(strings.NewReader(`{"jsonrpc":`+"\n"), &out))
+		assert.Contains(t, out.String(), `"code":-32700`)
+	})
+}
+
+func TestMockeryMCPServer_DiscoverRequireModule(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	moduleDir := filepath.Dir(writeFile(t, root, "domain/repo.go", "package domain\n\ntype Repo interface{ Get() error }\n"))
+	looseDir := filepath.Dir(writeFile(t, t.TempDir(), "repo.go", "package repo\n\ntype Repo interface{ Get() error }\n"))
+
+	s := newTestServer(t)
+
+	t.Run("reports the module path", func(t *testing.T) {
+		text := responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+			"project_path":   moduleDir,
+			"require_module": true,
+		}))
+		assert.Contains(t, text, "Module: example.com/app")
+	})
+
+	t.Run("not required", func(t *testing.T) {
+		text := responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+			"project_path": looseDir,
+		}))
+		assert.Contains(t, text, "Found 1 interfaces")
+		assert.NotContains(t, text, "Module:")
+	})
+
+	t.Run("required per request", func(t *testing.T) {
+		response := callTool(t, s, "discover_interfaces", map[string]interface{}{
+			"project_path":   looseDir,
+			"require_module": true,
+		})
+		requireErrorKind(t, response, CodeModuleNotFound, KindModuleNotFound)
+	})
+
+	t.Run("required by config", func(t *testing.T) {
+		cfg := DefaultServerConfig()
+		cfg.RequireModule = true
+		require.NoError(t, s.ApplyConfig(cfg))
+
+		response := callTool(t, s, "discover_interfaces", map[string]interface{}{
+			"project_path": looseDir,
+		})
+		requireErrorKind(t, response, CodeModuleNotFound, KindModuleNotFound)
+	})
+}
+
+func TestMockeryMCPServer_DiscoverIncludeSignatures(t *testing.T) {
+	dir := filepath.Dir(writeFile(t, t.TempDir(), "store.go", `package store
+
+import "context"
+
+type User struct{}
+
+type Store interface {
+	Get(ctx context.Context, id string) (*User, error)
+	Close()
+}
+`))
+
+	s := newTestServer(t)
+
+	text := responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+		"project_path":       dir,
+		"include_signatures": true,
+	}))
+	assert.Contains(t, text, "    Get(context.Context, string) (*store.User, error)\n    Close()")
+
+	text = responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+		"project_path": dir,
+	}))
+	assert.NotContains(t, text, "Get(")
+}
+
+func TestMockeryMCPServer_DiscoverFollowSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require elevated privileges on Windows")
+	}
+	shared := filepath.Dir(writeFile(t, t.TempDir(), "billing/invoice.go", "package billing\n\ntype Invoicer interface{ Send() error }\n"))
+	root := filepath.Dir(writeFile(t, t.TempDir(), "repo.go", "package app\n\ntype Repo interface{ Get() error }\n"))
+	require.NoError(t, os.Symlink(shared, filepath.Join(root, "billing")))
+
+	s := newTestServer(t)
+
+	text := responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+		"project_path": root,
+	}))
+	assert.Contains(t, text, "Found 1 interfaces")
+
+	text = responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+		"project_path":    root,
+		"follow_symlinks": true,
+	}))
+	assert.Contains(t, text, "Found 2 interfaces")
+	assert.Contains(t, text, filepath.Join(root, "billing", "invoice.go"))
+}
+
+func TestMockeryMCPServer_DiscoverMaxDepth(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "repo.go", "package app\n\ntype Repo interface{ Get() error }\n")
+	writeFile(t, root, "domain/user.go", "package domain\n\ntype Users interface{ List() error }\n")
+	writeFile(t, root, "domain/deep/nested/store.go", "package nested\n\ntype Store interface{ Put() error }\n")
+
+	s := newTestServer(t)
+	discover := func(depth interface{}) string {
+		args := map[string]interface{}{"project_path": root}
+		if depth != nil {
+			args["max_depth"] = depth
+		}
+		return responseText(t, callTool(t, s, "discover_interfaces", args))
+	}
+
+	assert.Contains(t, discover(nil), "Found 3 interfaces")
+	assert.Contains(t, discover(float64(0)), "Found 3 interfaces")
+
+	text := discover(float64(1))
+	assert.Contains(t, text, "Found 1 interfaces")
+	assert.Contains(t, text, "Repo")
+
+	text = discover(float64(2))
+	assert.Contains(t, text, "Found 2 interfaces")
+	assert.NotContains(t, text, "Store")
+
+	response := callTool(t, s, "discover_interfaces", map[string]interface{}{"project_path": root, "max_depth": float64(-1)})
+	require.NotNil(t, response.Error)
+	assert.Equal(t, -32602, response.Error.Code)
+}
+
+func TestMockeryMCPServer_RecoversFromHandlerPanic(t *testing.T) {
+	s := newTestServer(t)
+	// A nil scanner makes discover_interfaces panic once it starts scanning
+	s.scanner = nil
+	dir := filepath.Dir(writeFile(t, t.TempDir(), "repo.go", "package repo\n\ntype Repo interface{ Get() error }\n"))
+
+	var out strings.Builder
+	in := strings.NewReader(
+		`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"discover_interfaces","arguments":{"project_path":"` + dir + `"}}}` + "\n" +
+			`{"jsonrpc":"2.0","id":8,"method":"ping"}` + "\n")
+	require.NoError(t, s.ServeStdio(in, &out))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var response MCPResponse
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &response))
+	require.NotNil(t, response.Error)
+	assert.Equal(t, float64(7), response.ID)
+	assert.Equal(t, CodeInternalError, response.Error.Code)
+	assert.Equal(t, "Internal error", response.Error.Message)
+	assert.Contains(t, lines[0], `"kind":"internal"`)
+
+	// The connection keeps serving requests after the panic
+	assert.Contains(t, lines[1], `"id":8`)
+	assert.NotContains(t, lines[1], `"error"`)
+}
+
+func TestMockeryMCPServer_DiscoverCancelled(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype Repo interface{ Get() error }\n")
+
+	s := newTestServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	response := s.handleMCPRequest(ctx, &MCPRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "tools/call",
+		Params: map[string]interface{}{
+			"name":      "discover_interfaces",
+			"arguments": map[string]interface{}{"project_path": dir},
+		},
+	})
+	require.NotNil(t, response.Error)
+	assert.Contains(t, response.Error.Message, "Scan cancelled")
+}
+
+func TestMockeryMCPServer_ToolTimeout(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	writeFile(t, root, "repo/repo.go", "package repo\n\ntype UserRepository interface{ Get() error }\n")
+
+	withTimeout := func(t *testing.T, timeout time.Duration) *MockeryMCPServer {
+		s := newTestServer(t)
+		cfg := s.Config()
+		cfg.ToolTimeout = timeout
+		require.NoError(t, s.ApplyConfig(cfg))
+		return s
+	}
+
+	t.Run("scan", func(t *testing.T) {
+		s := withTimeout(t, time.Nanosecond)
+		response := callTool(t, s, "discover_interfaces", map[string]interface{}{"project_path": root})
+		requireErrorKind(t, response, CodeTimeout, KindTimeout)
+		assert.Equal(t, "Tool discover_interfaces timed out after 1ns", response.Error.Message)
+	})
+
+	t.Run("generation", func(t *testing.T) {
+		s := withTimeout(t, 100*time.Millisecond)
+		// The fake answers --version, then hangs until it is killed
+		useFakeMockery(t, s, "#!/bin/sh\nif [ \"$1\" = \"--version\" ]; then echo v2.53.3; exit 0; fi\nexec sleep 10\n")
+
+		start := time.Now()
+		response := callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "UserRepository",
+			"package_path":   filepath.Join(root, "repo"),
+			"output_dir":     t.TempDir(),
+		})
+		requireErrorKind(t, response, CodeTimeout, KindTimeout)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("usage scan", func(t *testing.T) {
+		s := withTimeout(t, time.Nanosecond)
+		response := callTool(t, s, "find_unused_interfaces", map[string]interface{}{"project_path": root})
+		requireErrorKind(t, response, CodeTimeout, KindTimeout)
+	})
+
+	t.Run("project scans", func(t *testing.T) {
+		s := withTimeout(t, time.Nanosecond)
+		for _, tool := range []string{"lint_interfaces", "interface_stats", "list_packages", "init_project"} {
+			response := callTool(t, s, tool, map[string]interface{}{"project_path": root})
+			require.NotNil(t, response.Error, tool)
+			requireErrorKind(t, response, CodeTimeout, KindTimeout)
+		}
+	})
+
+	t.Run("initial mocks", func(t *testing.T) {
+		project := t.TempDir()
+		writeFile(t, project, "go.mod", "module example.com/app\n\ngo 1.22\n")
+		writeFile(t, project, "repo/repo.go", "package repo\n\ntype UserRepository interface{ Get() error }\n")
+		s := withTimeout(t, 100*time.Millisecond)
+		useFakeMockery(t, s, "#!/bin/sh\nif [ \"$1\" = \"--version\" ]; then echo v2.53.3; exit 0; fi\nexec sleep 10\n")
+
+		start := time.Now()
+		response := callTool(t, s, "init_project", map[string]interface{}{
+			"project_path": project,
+			"generate":     true,
+		})
+		requireErrorKind(t, response, CodeTimeout, KindTimeout)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("regeneration", func(t *testing.T) {
+		s := newTestServer(t)
+		useFakeMockery(t, s, fakeMockeryScript)
+		_, err := s.GenerateMock(context.Background(), &types.MockGenerationRequest{
+			InterfaceName: "UserRepository",
+			PackagePath:   filepath.Join(root, "repo"),
+			OutputDir:     t.TempDir(),
+		})
+		require.NoError(t, err)
+
+		// Regenerating the recorded mock hangs past the deadline
+		cfg := s.Config()
+		cfg.ToolTimeout = 100 * time.Millisecond
+		require.NoError(t, s.ApplyConfig(cfg))
+		useFakeMockery(t, s, "#!/bin/sh\nif [ \"$1\" = \"--version\" ]; then echo v2.53.3; exit 0; fi\nexec sleep 10\n")
+
+		start := time.Now()
+		response := callTool(t, s, "regenerate_mocks", map[string]interface{}{})
+		requireErrorKind(t, response, CodeTimeout, KindTimeout)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		s := withTimeout(t, 0)
+		responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{"project_path": root}))
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		s := newTestServer(t)
+		cfg := s.Config()
+		cfg.ToolTimeout = -time.Second
+		assert.Error(t, s.ApplyConfig(cfg))
+	})
+}
+
+func TestMockeryMCPServer_DiscoverReportsScanErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype Repo interface{ Get() error }\n")
+	broken := writeFile(t, dir, "broken.go", "package repo\n\ntype Broken interface {")
+
+	s := newTestServer(t)
+
+	text := responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{
+		"project_path": dir,
+	}))
+	assert.Contains(t, text, "Found 1 interfaces")
+	assert.Contains(t, text, "Skipped 1 paths with errors:\n- failed to parse file "+broken)
+}
+
+func TestMockeryMCPServer_DiscoverSkipsUnreadableDirectory(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("directory permissions are not enforced on Windows")
+	}
+	if os.Geteuid() == 0 {
+		t.Skip("root can read directories regardless of permissions")
+	}
+
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype Repo interface{ Get() error }\n")
+	locked := filepath.Dir(writeFile(t, dir, "locked/hidden.go", "package locked\n\ntype Hidden interface{ Peek() }\n"))
+	require.NoError(t, os.Chmod(locked, 0))
+	t.Cleanup(func() { os.Chmod(locked, 0755) })
+
+	s := newTestServer(t)
+
+	response := callTool(t, s, "discover_interfaces", map[string]interface{}{
+		"project_path": dir,
+	})
+	require.Nil(t, response.Error)
+	text := responseText(t, response)
+	assert.Contains(t, text, "Found 1 interfaces")
+	assert.Contains(t, text, "Skipped 1 paths with errors:")
+	assert.Contains(t, text, locked)
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// interfaceMethods is the get_interface_methods report
+type interfaceMethods struct {
+	Interface  string         `json:"interface"`
+	Package    string         `json:"package"`
+	FilePath   string         `json:"file_path"`
+	LineNumber int            `json:"line_number"`
+	Range      sourceRange    `json:"range"`
+	Methods    []methodDetail `json:"methods"`
+}
+
+// methodDetail describes one method of an interface
+type methodDetail struct {
+	Name       string            `json:"name"`
+	Signature  string            `json:"signature"`
+	Parameters []types.Parameter `json:"parameters"`
+	Returns    []types.Parameter `json:"returns"`
+	Doc        string            `json:"doc,omitempty"`
+	Origin     string            `json:"origin,omitempty"`
+}
+
+// methodSignature renders a method as declared, with parameter names when
+// the declaration has them, e.g. Get(ctx context.Context, id string) (*User, error)
+func methodSignature(method types.MethodSignature) string {
+	signature := method.Name + "(" + strings.Join(declaredParameters(method.Parameters), ", ") + ")"
+
+	returns := declaredParameters(method.Returns)
+	switch {
+	case len(returns) == 0:
+	case len(returns) == 1 && method.Returns[0].Name == "":
+		signature += " " + returns[0]
+	default:
+		signature += " (" + strings.Join(returns, ", ") + ")"
+	}
+	return signature
+}
+
+// declaredParameters renders parameters as "name Type", or just the type
+// when unnamed
+func declaredParameters(params []types.Parameter) []string {
+	result := make([]string, len(params))
+	for i, param := range params {
+		if param.Name == "" {
+			result[i] = param.Type
+		} else {
+			result[i] = param.Name + " " + param.Type
+		}
+	}
+	return result
+}
+
+// methodDetails builds the get_interface_methods report for an interface
+func methodDetails(iface *types.InterfaceDefinition) interfaceMethods {
+	report := interfaceMethods{
+		Interface:  iface.Name,
+		Package:    iface.Package,
+		FilePath:   iface.FilePath,
+		LineNumber: iface.LineNumber,
+		Range:      interfaceRange(*iface),
+		Methods:    make([]methodDetail, len(iface.Methods)),
+	}
+	for i, method := range iface.Methods {
+		detail := methodDetail{
+			Name:       method.Name,
+			Signature:  methodSignature(method),
+			Parameters: method.Parameters,
+			Returns:    method.Returns,
+			Doc:        strings.Join(method.Comments, "\n"),
+			Origin:     method.Origin,
+		}
+		if detail.Parameters == nil {
+			detail.Parameters = []types.Parameter{}
+		}
+		if detail.Returns == nil {
+			detail.Returns = []types.Parameter{}
+		}
+		report.Methods[i] = detail
+	}
+	return report
+}
+
+// handleGetInterfaceMethods implements the get_interface_methods tool. The
+// interface is looked up in a single file, or in the Go files of a package
+// directory, rather than scanning the whole project.
+func (s *MockeryMCPServer) handleGetInterfaceMethods(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	interfaceName, ok := args["interface_name"].(string)
+	if !ok || interfaceName == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid interface_name", nil)
+	}
+
+	filePath, _ := args["file_path"].(string)
+	packagePath, _ := args["package_path"].(string)
+	if (filePath == "") == (packagePath == "") {
+		return s.errorResponse(requestID, -32602, "Missing or invalid file_path", "exactly one of file_path or package_path is required")
+	}
+
+	path := filePath
+	if path == "" {
+		path = packagePath
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", path), err)
+	}
+	info, err := os.Stat(absPath)
+	if os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Path does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to stat path: %s", absPath), err)
+	}
+
+	var iface *types.InterfaceDefinition
+	if filePath != "" {
+		if info.IsDir() {
+			return s.errorResponse(requestID, -32602, "Invalid file_path", fmt.Sprintf("%s is a directory; use package_path", absPath))
+		}
+		iface, err = s.scanner.ExtractInterfaceMetadata(absPath, interfaceName)
+	} else {
+		if !info.IsDir() {
+			return s.errorResponse(requestID, -32602, "Invalid package_path", fmt.Sprintf("%s is a file; use file_path", absPath))
+		}
+		request := &types.MockGenerationRequest{InterfaceName: interfaceName}
+		iface, err = s.lookupInterface(ctx, request, &mockSource{dir: absPath})
+		if err == nil && iface == nil {
+			err = fmt.Errorf("%w: interface %s not found in %s", ErrInterfaceNotFound, interfaceName, absPath)
+		}
+	}
+	if err != nil {
+		s.requestLogger(ctx).Error("Failed to look up interface", zap.String("path", absPath), zap.String("interface", interfaceName), zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to get interface methods", err)
+	}
+
+	report, err := json.MarshalIndent(methodDetails(iface), "", "  ")
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to encode methods", err)
+	}
+	return s.textResponse(requestID, string(report))
+}
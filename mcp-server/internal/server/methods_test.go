@@ -0,0 +1,108 @@
+package server
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+const methodsSource = `package store
+
+import "context"
+
+type User struct{}
+
+// Store persists users
+type Store interface {
+	// Get loads a user by ID
+	Get(ctx context.Context, id string) (*User, error)
+	List(context.Context) ([]*User, error)
+	Count() (n int, err error)
+	Close()
+}
+`
+
+func TestMockeryMCPServer_GetInterfaceMethods(t *testing.T) {
+	file := writeFile(t, t.TempDir(), "store.go", methodsSource)
+	s := newTestServer(t)
+
+	for name, args := range map[string]map[string]interface{}{
+		"by file":    {"file_path": file},
+		"by package": {"package_path": filepath.Dir(file)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			args["interface_name"] = "Store"
+			text := responseText(t, callTool(t, s, "get_interface_methods", args))
+
+			var report interfaceMethods
+			require.NoError(t, json.Unmarshal([]byte(text), &report))
+			assert.Equal(t, "Store", report.Interface)
+			assert.Equal(t, "store", report.Package)
+			assert.Equal(t, file, report.FilePath)
+			assert.Equal(t, sourceRange{StartLine: 8, StartColumn: 6, EndLine: 14, EndColumn: 2}, report.Range)
+			require.Len(t, report.Methods, 4)
+
+			get := report.Methods[0]
+			assert.Equal(t, "Get", get.Name)
+			assert.Equal(t, "Get(ctx context.Context, id string) (*User, error)", get.Signature)
+			assert.Equal(t, "Get loads a user by ID", get.Doc)
+			require.Len(t, get.Parameters, 2)
+			assert.Equal(t, "id", get.Parameters[1].Name)
+			assert.Equal(t, "string", get.Parameters[1].Type)
+			require.Len(t, get.Returns, 2)
+
+			assert.Equal(t, "List(context.Context) ([]*User, error)", report.Methods[1].Signature)
+			assert.Equal(t, "Count() (n int, err error)", report.Methods[2].Signature)
+			assert.Equal(t, "Close()", report.Methods[3].Signature)
+			assert.Empty(t, report.Methods[3].Parameters)
+		})
+	}
+
+	t.Run("interface not found", func(t *testing.T) {
+		response := callTool(t, s, "get_interface_methods", map[string]interface{}{
+			"interface_name": "Stor",
+			"package_path":   filepath.Dir(file),
+		})
+		requireErrorKind(t, response, CodeInterfaceNotFound, KindInterfaceNotFound)
+		assert.Contains(t, response.Error.Data.(ErrorData).Detail, "found: Store")
+
+		response = callTool(t, s, "get_interface_methods", map[string]interface{}{
+			"interface_name": "Missing",
+			"file_path":      file,
+		})
+		requireErrorKind(t, response, CodeInterfaceNotFound, KindInterfaceNotFound)
+	})
+
+	t.Run("requires exactly one location", func(t *testing.T) {
+		response := callTool(t, s, "get_interface_methods", map[string]interface{}{
+			"interface_name": "Store",
+		})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, -32602, response.Error.Code)
+
+		response = callTool(t, s, "get_interface_methods", map[string]interface{}{
+			"interface_name": "Store",
+			"file_path":      file,
+			"package_path":   filepath.Dir(file),
+		})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, -32602, response.Error.Code)
+	})
+}
+
+func TestMethodSignature(t *testing.T) {
+	assert.Equal(t, "Send(to ...string) error", methodSignature(types.MethodSignature{
+		Name:       "Send",
+		Parameters: []types.Parameter{{Name: "to", Type: "...string"}},
+		Returns:    []types.Parameter{{Type: "error"}},
+	}))
+	assert.Equal(t, "Next() (value int)", methodSignature(types.MethodSignature{
+		Name:    "Next",
+		Returns: []types.Parameter{{Name: "value", Type: "int"}},
+	}))
+}
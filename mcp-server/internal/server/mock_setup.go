@@ -0,0 +1,139 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// Scenarios suggest_mock_setup can set up a method call for
+const (
+	scenarioSuccess = "success"
+	scenarioError   = "error"
+)
+
+// mockSetupScenarios lists the accepted scenarios
+var mockSetupScenarios = []string{scenarioSuccess, scenarioError}
+
+// mockSetup builds the statements that construct a mock and expect one call
+// of method, returning values for the scenario: nil errors on success, and
+// an error built with errors.New in every error position otherwise. Other
+// results get their zero value.
+func mockSetup(method types.MethodSignature, scenario, mockPackage, mockName, mockVar string, declared map[string]bool) (string, error) {
+	args := make([]string, len(method.Parameters))
+	for i, param := range method.Parameters {
+		args[i] = argumentPlaceholder(param, declared)
+	}
+
+	returns := make([]string, len(method.Returns))
+	for i, result := range method.Returns {
+		typeName := matcherTypeOf(result)
+		if typeName == "error" && scenario == scenarioError {
+			returns[i] = fmt.Sprintf("errors.New(%q)", method.Name+" failed")
+			continue
+		}
+		returns[i] = zeroValue(typeName, declared)
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s := %s.New%s(t)\n", mockVar, mockPackage, mockName)
+	fmt.Fprintf(&body, "%s.EXPECT().%s(%s)", mockVar, method.Name, strings.Join(args, ", "))
+	if len(returns) > 0 {
+		fmt.Fprintf(&body, ".Return(%s)", strings.Join(returns, ", "))
+	}
+	body.WriteString(".Once()\n")
+	return formatStatements(body.String())
+}
+
+// returnsError reports whether any of a method's results is an error
+func returnsError(method types.MethodSignature) bool {
+	for _, result := range method.Returns {
+		if matcherTypeOf(result) == "error" {
+			return true
+		}
+	}
+	return false
+}
+
+// handleSuggestMockSetup implements the suggest_mock_setup tool
+func (s *MockeryMCPServer) handleSuggestMockSetup(requestID interface{}, args map[string]interface{}) *MCPResponse {
+	filePath, ok := args["file_path"].(string)
+	if !ok || filePath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid file_path", nil)
+	}
+	interfaceName, ok := args["interface_name"].(string)
+	if !ok || interfaceName == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid interface_name", nil)
+	}
+	methodName, ok := args["method_name"].(string)
+	if !ok || methodName == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid method_name", nil)
+	}
+
+	scenario := scenarioSuccess
+	if value, ok := args["scenario"].(string); ok && value != "" {
+		scenario = value
+	}
+	if scenario != scenarioSuccess && scenario != scenarioError {
+		return s.errorResponse(requestID, -32602, "Invalid scenario",
+			fmt.Sprintf("unknown scenario %q (expected one of: %s)", scenario, strings.Join(mockSetupScenarios, ", ")))
+	}
+
+	mockPackage := "mocks"
+	if value, ok := args["mock_package"].(string); ok && value != "" {
+		mockPackage = value
+	}
+	mockName := interfaceName
+	if value, ok := args["mock_name"].(string); ok && value != "" {
+		mockName = expandInterfaceName(value, interfaceName)
+	}
+	mockVar := "mock" + interfaceName
+	if value, ok := args["mock_var"].(string); ok && value != "" {
+		mockVar = value
+	}
+
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", filePath), err)
+	}
+
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("File does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	iface, err := s.scanner.ExtractInterfaceMetadata(absPath, interfaceName)
+	if err != nil {
+		s.logger.Error("Failed to extract interface metadata", zap.String("file", absPath), zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to extract interface metadata", err)
+	}
+
+	var method *types.MethodSignature
+	names := make([]string, len(iface.Methods))
+	for i := range iface.Methods {
+		names[i] = iface.Methods[i].Name
+		if iface.Methods[i].Name == methodName {
+			method = &iface.Methods[i]
+		}
+	}
+	if method == nil {
+		detail := fmt.Sprintf("%s has no method %s", interfaceName, methodName)
+		if suggestions := similarNames(methodName, names); len(suggestions) > 0 {
+			detail += fmt.Sprintf(" (found: %s)", strings.Join(suggestions, ", "))
+		}
+		return s.errorResponse(requestID, -32602, "Invalid method_name", detail)
+	}
+	if scenario == scenarioError && !returnsError(*method) {
+		return s.errorResponse(requestID, -32602, "Invalid scenario", fmt.Sprintf("%s.%s doesn't return an error", interfaceName, methodName))
+	}
+
+	snippet, err := mockSetup(*method, scenario, mockPackage, mockName, mockVar, declaredTypes(filepath.Dir(absPath)))
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to format mock setup", err)
+	}
+	return s.textResponse(requestID, snippet)
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_SuggestMockSetup(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "user.go", "package domain\n\ntype User struct{}\n")
+	filePath := writeFile(t, dir, "repo.go", `package domain
+
+import "context"
+
+type UserRepository interface {
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	Count() (int, error)
+	Close()
+}
+`)
+
+	s := newTestServer(t)
+	suggest := func(args map[string]interface{}) *MCPResponse {
+		args["file_path"] = filePath
+		args["interface_name"] = "UserRepository"
+		return callTool(t, s, "suggest_mock_setup", args)
+	}
+
+	assert.Equal(t, `mockUserRepository := mocks.NewUserRepository(t)
+mockUserRepository.EXPECT().GetByEmail(mock.Anything, mock.AnythingOfType("string")).Return(nil, nil).Once()
+`, responseText(t, suggest(map[string]interface{}{"method_name": "GetByEmail"})))
+
+	assert.Equal(t, `mockRepo := mocks.NewUserRepository(t)
+mockRepo.EXPECT().Count().Return(0, errors.New("Count failed")).Once()
+`, responseText(t, suggest(map[string]interface{}{
+		"method_name": "Count",
+		"scenario":    "error",
+		"mock_var":    "mockRepo",
+	})))
+
+	assert.Equal(t, `mockUserRepository := mocks.NewUserRepository(t)
+mockUserRepository.EXPECT().Close().Once()
+`, responseText(t, suggest(map[string]interface{}{"method_name": "Close"})))
+
+	t.Run("invalid", func(t *testing.T) {
+		response := suggest(map[string]interface{}{"method_name": "Close", "scenario": "error"})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, "Invalid scenario", response.Error.Message)
+
+		response = suggest(map[string]interface{}{"method_name": "GetByMail"})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, "Invalid method_name", response.Error.Message)
+		assert.Contains(t, response.Error.Data, "found: GetByEmail")
+
+		response = suggest(map[string]interface{}{"method_name": "Count", "scenario": "timeout"})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, -32602, response.Error.Code)
+	})
+}
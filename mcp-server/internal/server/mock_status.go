@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/models"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+)
+
+// interfaceMockStatus is one interface of a mock_status report
+type interfaceMockStatus struct {
+	Name          string `json:"name"`
+	Package       string `json:"package"`
+	QualifiedName string `json:"qualified_name"`
+	FilePath      string `json:"file_path"`
+	LineNumber    int    `json:"line_number"`
+	HasMock       bool   `json:"has_mock"`
+	// MockFile and GeneratedAt describe the most recently generated mock
+	MockFile    string     `json:"mock_file,omitempty"`
+	GeneratedAt *time.Time `json:"generated_at,omitempty"`
+}
+
+// mockStatusReport is the mock_status result
+type mockStatusReport struct {
+	ProjectPath string                `json:"project_path"`
+	Module      string                `json:"module,omitempty"`
+	Mocked      int                   `json:"mocked"`
+	Unmocked    int                   `json:"unmocked"`
+	Interfaces  []interfaceMockStatus `json:"interfaces"`
+}
+
+// mockImportPath returns the import path of the package a recorded mock was
+// generated from, or its directory outside a module, matching how
+// discovered interfaces are qualified. It is empty when the package can no
+// longer be found.
+func mockImportPath(mock *models.GeneratedMock) string {
+	dir, err := filepath.Abs(mock.PackagePath)
+	if err != nil {
+		return ""
+	}
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		moduleRoot, modulePath := scanner.FindModule(dir)
+		return packagePath(dir, moduleRoot, modulePath)
+	}
+	if looksLikeImportPath(mock.PackagePath) {
+		return mock.PackagePath
+	}
+	return ""
+}
+
+// handleMockStatus implements the mock_status tool
+func (s *MockeryMCPServer) handleMockStatus(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	projectPath, ok := args["project_path"].(string)
+	if !ok || projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid project_path", nil)
+	}
+
+	projectID, _ := args["project_id"].(string)
+	if projectID != "" {
+		if _, exists := s.projectManager.GetProject(projectID); !exists {
+			return s.toolErrorResponse(requestID, "Unknown project_id", fmt.Errorf("%w: %s", ErrProjectNotFound, projectID))
+		}
+	}
+
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", projectPath), err)
+	}
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Project path does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	interfaces, _, err := s.scanner.ScanProjectContext(ctx, absPath, scanner.ScanOptions{})
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return s.toolErrorResponse(requestID, "Scan cancelled", err)
+	}
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to scan project", fmt.Errorf("%w: %v", ErrScanFailed, err))
+	}
+	interfaces = excludeInterfaces(interfaces, s.config().ExcludeInterfaces)
+
+	// Index the latest mock of each interface whose file is still on disk
+	latest := make(map[string]*models.GeneratedMock)
+	for _, mock := range s.projectManager.GetGeneratedMocks(projectID) {
+		if _, err := os.Stat(mock.FilePath); err != nil {
+			continue
+		}
+		importPath := mockImportPath(mock)
+		if importPath == "" {
+			continue
+		}
+		key := importPath + "." + mock.InterfaceName
+		if previous, ok := latest[key]; !ok || mock.GeneratedAt.After(previous.GeneratedAt) {
+			latest[key] = mock
+		}
+	}
+
+	_, modulePath := scanner.FindModule(absPath)
+	report := mockStatusReport{
+		ProjectPath: absPath,
+		Module:      modulePath,
+		Interfaces:  make([]interfaceMockStatus, 0, len(interfaces)),
+	}
+	// Packages are qualified by their own module, which may be nested
+	importPaths := make(map[string]string)
+	for _, iface := range interfaces {
+		dir := filepath.Dir(iface.FilePath)
+		importPath, ok := importPaths[dir]
+		if !ok {
+			moduleRoot, modulePath := scanner.FindModule(dir)
+			importPath = packagePath(dir, moduleRoot, modulePath)
+			importPaths[dir] = importPath
+		}
+		status := interfaceMockStatus{
+			Name:          iface.Name,
+			Package:       iface.Package,
+			QualifiedName: importPath + "." + iface.Name,
+			FilePath:      iface.FilePath,
+			LineNumber:    iface.LineNumber,
+		}
+		if mock, ok := latest[status.QualifiedName]; ok {
+			generatedAt := mock.GeneratedAt
+			status.HasMock = true
+			status.MockFile = mock.FilePath
+			status.GeneratedAt = &generatedAt
+			report.Mocked++
+		} else {
+			report.Unmocked++
+		}
+		report.Interfaces = append(report.Interfaces, status)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to encode mock status", err)
+	}
+	return s.textResponse(requestID, string(data))
+}
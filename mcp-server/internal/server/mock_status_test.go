@@ -0,0 +1,72 @@
+package server
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_MockStatus(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	writeFile(t, root, "store/store.go", "package store\n\ntype Users interface{ Get() error }\n\ntype Orders interface{ List() error }\n")
+	// Same name in another package, which the Users mock must not cover
+	writeFile(t, root, "legacy/legacy.go", "package legacy\n\ntype Users interface{ Find() error }\n")
+
+	s := newTestServer(t)
+	useFakeMockery(t, s, fakeMockeryScript)
+	responseText(t, callTool(t, s, "generate_mock", map[string]interface{}{
+		"interface_name": "Users",
+		"package_path":   filepath.Join(root, "store"),
+	}))
+	mockFile := filepath.Join(root, "store", "mocks", "mock_users.go")
+
+	status := func(t *testing.T) mockStatusReport {
+		t.Helper()
+		var report mockStatusReport
+		require.NoError(t, json.Unmarshal([]byte(responseText(t, callTool(t, s, "mock_status", map[string]interface{}{
+			"project_path": root,
+		}))), &report))
+		return report
+	}
+	byName := func(report mockStatusReport) map[string]interfaceMockStatus {
+		statuses := make(map[string]interfaceMockStatus)
+		for _, iface := range report.Interfaces {
+			statuses[iface.QualifiedName] = iface
+		}
+		return statuses
+	}
+
+	report := status(t)
+	assert.Equal(t, "example.com/app", report.Module)
+	assert.Equal(t, 1, report.Mocked)
+	assert.Equal(t, 2, report.Unmocked)
+
+	statuses := byName(report)
+	require.Len(t, statuses, 3)
+	users := statuses["example.com/app/store.Users"]
+	assert.True(t, users.HasMock)
+	assert.Equal(t, mockFile, users.MockFile)
+	require.NotNil(t, users.GeneratedAt)
+	assert.False(t, users.GeneratedAt.IsZero())
+
+	orders := statuses["example.com/app/store.Orders"]
+	assert.False(t, orders.HasMock)
+	assert.Empty(t, orders.MockFile)
+	assert.Nil(t, orders.GeneratedAt)
+	assert.False(t, statuses["example.com/app/legacy.Users"].HasMock)
+
+	// A mock deleted from disk no longer counts
+	require.NoError(t, os.Remove(mockFile))
+	assert.Equal(t, 0, status(t).Mocked)
+
+	response := callTool(t, s, "mock_status", map[string]interface{}{
+		"project_path": root,
+		"project_id":   "missing",
+	})
+	requireErrorKind(t, response, CodeProjectNotFound, KindProjectNotFound)
+}
@@ -0,0 +1,300 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+)
+
+// Mockery versions the server can build command lines for. Auto picks one
+// from the installed mockery's --version output.
+const (
+	mockeryVersionAuto = "auto"
+	mockeryVersionV2   = "v2"
+	mockeryVersionV3   = "v3"
+)
+
+// mockeryV3ConfigFilename names the scoped config written for mockery v3
+const mockeryV3ConfigFilename = ".mockery.yml"
+
+// mockeryVersions lists the accepted -mockery-version values
+var mockeryVersions = []string{mockeryVersionAuto, mockeryVersionV2, mockeryVersionV3}
+
+// validateMockeryVersion checks a -mockery-version value; empty means auto
+func validateMockeryVersion(version string) error {
+	if version == "" {
+		return nil
+	}
+	for _, known := range mockeryVersions {
+		if version == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown mockery version %q (expected one of: %s)", version, strings.Join(mockeryVersions, ", "))
+}
+
+// mockeryArgsBuilder builds the mockery command line for a generation plan.
+// Mockery v2 takes the interface and output settings as flags, while v3
+// reads them from a config file.
+type mockeryArgsBuilder interface {
+	// usesConfig reports whether the command line refers to a config file,
+	// which the caller must then provide a path for
+	usesConfig() bool
+	// args returns the arguments generating the plan's mock into outputDir,
+	// and the contents to write to configPath before running mockery
+	args(p *generationPlan, outputDir, configPath string) ([]string, []byte, error)
+	// packageConfig returns a config generating the mocks of every plan, all
+	// for interfaces of the package importPath, in one --config run. A plan
+	// that can't be generated fails alone, with its error at its index in
+	// errs.
+	packageConfig(plans []*generationPlan, importPath string) (content []byte, errs []error, err error)
+}
+
+// argsBuilder returns the builder for the configured mockery version,
+// asking the mockery binary when the version is auto. A version that can't
+// be determined is treated as v2.
+func (s *MockeryMCPServer) argsBuilder(ctx context.Context, cfg *runtimeConfig) mockeryArgsBuilder {
+	version := cfg.MockeryVersion
+	if version == "" || version == mockeryVersionAuto {
+		version = mockeryVersionV2
+		if strings.HasPrefix(s.mockeryVersion(ctx, cfg.MockeryCommand), "v3.") {
+			version = mockeryVersionV3
+		}
+	}
+	if version == mockeryVersionV3 {
+		return mockeryV3Args{}
+	}
+	return mockeryV2Args{}
+}
+
+// mockeryV2Args passes everything as mockery v2 flags
+type mockeryV2Args struct{}
+
+func (mockeryV2Args) usesConfig() bool { return false }
+
+func (mockeryV2Args) args(p *generationPlan, outputDir, _ string) ([]string, []byte, error) {
+	request := p.request
+	args := []string{
+		"--name=" + request.InterfaceName,
+		p.source.arg,
+		"--output=" + outputDir,
+		"--filename=" + p.filename,
+	}
+	if p.outPkg != "" {
+		args = append(args, "--outpkg="+p.outPkg)
+	}
+
+	if request.InPackage {
+		args = append(args, "--inpackage")
+	}
+	if request.TestOnly {
+		args = append(args, "--testonly")
+	}
+	if mockName := resolveMockName(request); mockName != "" {
+		args = append(args, "--mockname="+mockName)
+	}
+
+	if request.WithExpector {
+		args = append(args, "--with-expecter")
+	}
+	// Function type mocks are on by default, but a config file can turn
+	// them off
+	if request.FunctionType {
+		args = append(args, "--disable-func-mocks=false")
+	}
+
+	if request.BoilerplateFile != "" {
+		boilerplateFile, err := resolveBoilerplateFile(request.BoilerplateFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		args = append(args, "--boilerplate-file="+boilerplateFile)
+	}
+
+	// Extra arguments come last and were checked not to override the above
+	return append(args, request.ExtraArgs...), nil, nil
+}
+
+func (mockeryV2Args) packageConfig(plans []*generationPlan, importPath string) ([]byte, []error, error) {
+	errs := make([]error, len(plans))
+	interfaces := make(map[string]batchInterface, len(plans))
+	for i, p := range plans {
+		request := p.request
+		settings := batchInterfaceSettings{
+			Dir:          p.outputDir,
+			Filename:     p.filename,
+			MockName:     resolveMockName(request),
+			OutPkg:       "mocks",
+			InPackage:    request.InPackage,
+			WithExpecter: request.WithExpector,
+		}
+		if settings.MockName == "" {
+			settings.MockName = request.InterfaceName
+		}
+		if request.InPackage {
+			settings.OutPkg = p.source.packageName
+		} else if p.outPkg != "" {
+			settings.OutPkg = p.outPkg
+		}
+		if request.BoilerplateFile != "" {
+			boilerplateFile, err := resolveBoilerplateFile(request.BoilerplateFile)
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			settings.BoilerplateFile = boilerplateFile
+		}
+		interfaces[request.InterfaceName] = batchInterface{Config: settings}
+	}
+
+	content, err := yaml.Marshal(batchConfig{
+		Packages: map[string]batchPackage{importPath: {Interfaces: interfaces}},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode batch config: %w", err)
+	}
+	return content, errs, nil
+}
+
+// mockeryV3Config is the scoped mockery v3 config generated for one mock,
+// or for several of one package with their settings per interface
+type mockeryV3Config struct {
+	All            bool                        `yaml:"all"`
+	Dir            string                      `yaml:"dir,omitempty"`
+	Filename       string                      `yaml:"filename,omitempty"`
+	StructName     string                      `yaml:"structname,omitempty"`
+	PkgName        string                      `yaml:"pkgname,omitempty"`
+	Template       string                      `yaml:"template"`
+	TemplateData   map[string]string           `yaml:"template-data,omitempty"`
+	ForceFileWrite bool                        `yaml:"force-file-write"`
+	Packages       map[string]mockeryV3Package `yaml:"packages"`
+}
+
+// mockeryV3Package selects interfaces of one package in a v3 config
+type mockeryV3Package struct {
+	Interfaces map[string]mockeryV3Interface `yaml:"interfaces"`
+}
+
+// mockeryV3Interface selects one interface, with settings of its own when
+// a config generates several
+type mockeryV3Interface struct {
+	Config *mockeryV3Settings `yaml:"config,omitempty"`
+}
+
+// mockeryV3Settings are the settings of one mock in a v3 config
+type mockeryV3Settings struct {
+	Dir          string            `yaml:"dir"`
+	Filename     string            `yaml:"filename"`
+	StructName   string            `yaml:"structname"`
+	PkgName      string            `yaml:"pkgname"`
+	TemplateData map[string]string `yaml:"template-data,omitempty"`
+}
+
+// mockeryV3Args writes the request into a v3 config naming only the one
+// interface, and points mockery at it with --config. Mockery v3 always
+// generates expecter methods and no longer mocks function types or has a
+// test-only mode.
+type mockeryV3Args struct{}
+
+func (mockeryV3Args) usesConfig() bool { return true }
+
+// settings resolves the v3 settings of a plan's mock, generated into
+// outputDir
+func (mockeryV3Args) settings(p *generationPlan, outputDir string) (mockeryV3Settings, error) {
+	request := p.request
+	if request.FunctionType {
+		return mockeryV3Settings{}, fmt.Errorf("mockery v3 does not generate function type mocks")
+	}
+	if request.TestOnly {
+		return mockeryV3Settings{}, fmt.Errorf("mockery v3 has no test-only mode; use a _test.go filename instead")
+	}
+
+	settings := mockeryV3Settings{
+		Dir:        outputDir,
+		Filename:   p.filename,
+		StructName: resolveMockName(request),
+		PkgName:    p.outPkg,
+	}
+	// Keep v2's defaults: a mock named after the interface in package mocks
+	if settings.StructName == "" {
+		settings.StructName = request.InterfaceName
+	}
+	if settings.PkgName == "" {
+		settings.PkgName = "mocks"
+		if request.InPackage {
+			settings.PkgName = p.source.packageName
+		}
+	}
+	if request.BoilerplateFile != "" {
+		boilerplateFile, err := resolveBoilerplateFile(request.BoilerplateFile)
+		if err != nil {
+			return mockeryV3Settings{}, err
+		}
+		settings.TemplateData = map[string]string{"boilerplate-file": boilerplateFile}
+	}
+	return settings, nil
+}
+
+func (b mockeryV3Args) args(p *generationPlan, outputDir, configPath string) ([]string, []byte, error) {
+	request := p.request
+	settings, err := b.settings(p, outputDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// v3 configs name packages by import path only
+	importPath := p.source.importPath
+	if importPath == "" {
+		moduleRoot, modulePath := scanner.FindModule(p.source.dir)
+		if modulePath == "" {
+			return nil, nil, fmt.Errorf("%w: mockery v3 needs %s to be inside a Go module", ErrModuleNotFound, p.source.dir)
+		}
+		importPath = packagePath(p.source.dir, moduleRoot, modulePath)
+	}
+
+	config := mockeryV3Config{
+		Dir:            settings.Dir,
+		Filename:       settings.Filename,
+		StructName:     settings.StructName,
+		PkgName:        settings.PkgName,
+		Template:       "testify",
+		TemplateData:   settings.TemplateData,
+		ForceFileWrite: true,
+		Packages: map[string]mockeryV3Package{
+			importPath: {Interfaces: map[string]mockeryV3Interface{request.InterfaceName: {}}},
+		},
+	}
+
+	content, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode mockery v3 config: %w", err)
+	}
+	return append([]string{"--config=" + configPath}, request.ExtraArgs...), content, nil
+}
+
+func (b mockeryV3Args) packageConfig(plans []*generationPlan, importPath string) ([]byte, []error, error) {
+	errs := make([]error, len(plans))
+	interfaces := make(map[string]mockeryV3Interface, len(plans))
+	for i, p := range plans {
+		settings, err := b.settings(p, p.outputDir)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		interfaces[p.request.InterfaceName] = mockeryV3Interface{Config: &settings}
+	}
+
+	content, err := yaml.Marshal(mockeryV3Config{
+		Template:       "testify",
+		ForceFileWrite: true,
+		Packages:       map[string]mockeryV3Package{importPath: {Interfaces: interfaces}},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode mockery v3 config: %w", err)
+	}
+	return content, errs, nil
+}
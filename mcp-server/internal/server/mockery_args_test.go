@@ -0,0 +1,241 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+func TestMockeryV2Args(t *testing.T) {
+	plan := &generationPlan{
+		request: &types.MockGenerationRequest{
+			InterfaceName: "UserRepository",
+			WithExpector:  true,
+			InPackage:     true,
+			ExtraArgs:     []string{"--unroll-variadic=false"},
+		},
+		source:   &mockSource{dir: "/src/repo", packageName: "repo", arg: "--dir=/src/repo"},
+		filename: "mock_user_repository.go",
+	}
+
+	builder := mockeryV2Args{}
+	assert.False(t, builder.usesConfig())
+	args, config, err := builder.args(plan, "/src/repo", "")
+	require.NoError(t, err)
+	assert.Nil(t, config)
+	assert.Equal(t, []string{
+		"--name=UserRepository",
+		"--dir=/src/repo",
+		"--output=/src/repo",
+		"--filename=mock_user_repository.go",
+		"--inpackage",
+		"--mockname=MockUserRepository",
+		"--with-expecter",
+		"--unroll-variadic=false",
+	}, args)
+}
+
+func TestMockeryV3Args(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	dir := filepath.Join(root, "internal", "repo")
+
+	plan := func(request types.MockGenerationRequest) *generationPlan {
+		return &generationPlan{
+			request:  &request,
+			source:   &mockSource{dir: dir, packageName: "repo", arg: "--dir=" + dir},
+			filename: "mock_user_repository.go",
+		}
+	}
+	decode := func(t *testing.T, content []byte) mockeryV3Config {
+		t.Helper()
+		var config mockeryV3Config
+		require.NoError(t, yaml.Unmarshal(content, &config))
+		return config
+	}
+
+	builder := mockeryV3Args{}
+	assert.True(t, builder.usesConfig())
+
+	t.Run("scoped config", func(t *testing.T) {
+		args, content, err := builder.args(plan(types.MockGenerationRequest{
+			InterfaceName: "UserRepository",
+			ExtraArgs:     []string{"--log-level=debug"},
+		}), "/out/mocks", "/tmp/x/.mockery.yml")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"--config=/tmp/x/.mockery.yml", "--log-level=debug"}, args)
+		assert.Equal(t, mockeryV3Config{
+			Dir:            "/out/mocks",
+			Filename:       "mock_user_repository.go",
+			StructName:     "UserRepository",
+			PkgName:        "mocks",
+			Template:       "testify",
+			ForceFileWrite: true,
+			Packages: map[string]mockeryV3Package{
+				"example.com/app/internal/repo": {Interfaces: map[string]mockeryV3Interface{"UserRepository": {}}},
+			},
+		}, decode(t, content))
+	})
+
+	t.Run("in package", func(t *testing.T) {
+		_, content, err := builder.args(plan(types.MockGenerationRequest{
+			InterfaceName: "UserRepository",
+			InPackage:     true,
+		}), dir, "/tmp/x/.mockery.yml")
+		require.NoError(t, err)
+		config := decode(t, content)
+		assert.Equal(t, "MockUserRepository", config.StructName)
+		assert.Equal(t, "repo", config.PkgName)
+	})
+
+	t.Run("import path sources", func(t *testing.T) {
+		external := plan(types.MockGenerationRequest{InterfaceName: "Reader"})
+		external.source = &mockSource{dir: "/usr/lib/go/src/io", packageName: "io", importPath: "io", external: true}
+		_, content, err := builder.args(external, "/out/mocks", "/tmp/x/.mockery.yml")
+		require.NoError(t, err)
+		assert.Contains(t, decode(t, content).Packages, "io")
+	})
+
+	t.Run("unsupported requests", func(t *testing.T) {
+		for name, request := range map[string]types.MockGenerationRequest{
+			"function type": {InterfaceName: "HandlerFunc", FunctionType: true},
+			"test only":     {InterfaceName: "UserRepository", TestOnly: true},
+		} {
+			_, _, err := builder.args(plan(request), "/out/mocks", "/tmp/x/.mockery.yml")
+			assert.Error(t, err, name)
+		}
+
+		outside := plan(types.MockGenerationRequest{InterfaceName: "UserRepository"})
+		outside.source.dir = t.TempDir()
+		_, _, err := builder.args(outside, "/out/mocks", "/tmp/x/.mockery.yml")
+		assert.ErrorIs(t, err, ErrModuleNotFound)
+	})
+}
+
+// fakeMockeryV3Script reports v3, records its arguments to $ARGS_FILE and,
+// given a config, copies it to $CONFIG_COPY and writes a stub mock wherever
+// the config says
+const fakeMockeryV3Script = `#!/bin/sh
+if [ "$1" = "--version" ]; then
+	echo "v3.2.5"
+	exit 0
+fi
+printf '%s\n' "$@" > "$ARGS_FILE"
+case "$1" in
+	--config=*) config="${1#--config=}" ;;
+	*) exit 0 ;;
+esac
+cp "$config" "$CONFIG_COPY"
+awk '$1 == "dir:" { dir = $2 } $1 == "filename:" { print dir "/" $2 }' "$config" | while read -r file; do
+	mkdir -p "$(dirname "$file")"
+	printf 'package mocks\n' > "$file"
+done
+`
+
+// useFakeMockeryV3 points the server at fakeMockeryV3Script, returning
+// functions reading the arguments and config of its last run
+func useFakeMockeryV3(t *testing.T, s *MockeryMCPServer) (lastArgs func() []string, lastConfig func() string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("ARGS_FILE", filepath.Join(dir, "args"))
+	t.Setenv("CONFIG_COPY", filepath.Join(dir, "config.yml"))
+	useFakeMockery(t, s, fakeMockeryV3Script)
+
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		return string(data)
+	}
+	return func() []string { return strings.Fields(read("args")) }, func() string { return read("config.yml") }
+}
+
+func TestMockeryMCPServer_GenerateMock_MockeryVersion(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	writeFile(t, root, "repo/repo.go", "package repo\n\ntype UserRepository interface{ Get() error }\n")
+	request := func(outputDir string) *types.MockGenerationRequest {
+		return &types.MockGenerationRequest{
+			InterfaceName: "UserRepository",
+			PackagePath:   filepath.Join(root, "repo"),
+			OutputDir:     outputDir,
+		}
+	}
+
+	t.Run("auto detects v3", func(t *testing.T) {
+		s := newTestServer(t)
+		lastArgs, lastConfig := useFakeMockeryV3(t, s)
+		outputDir := t.TempDir()
+
+		result, err := s.GenerateMock(context.Background(), request(outputDir))
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(outputDir, "mock_user_repository.go"), result.GeneratedFile)
+		assert.FileExists(t, result.GeneratedFile)
+
+		args := lastArgs()
+		require.Len(t, args, 1)
+		assert.True(t, strings.HasPrefix(args[0], "--config="), args[0])
+		assert.Contains(t, lastConfig(), "example.com/app/repo:")
+		// The scoped config is removed after the run
+		assert.NoFileExists(t, strings.TrimPrefix(args[0], "--config="))
+	})
+
+	t.Run("v2 forced", func(t *testing.T) {
+		s := newTestServer(t)
+		lastArgs, _ := useFakeMockeryV3(t, s)
+		cfg := s.Config()
+		cfg.MockeryVersion = mockeryVersionV2
+		require.NoError(t, s.ApplyConfig(cfg))
+
+		_, err := s.GenerateMock(context.Background(), request(t.TempDir()))
+		require.NoError(t, err)
+		assert.Contains(t, lastArgs(), "--name=UserRepository")
+	})
+
+	t.Run("v3 batches a package in one config", func(t *testing.T) {
+		batchRoot := t.TempDir()
+		writeFile(t, batchRoot, "go.mod", "module example.com/app\n\ngo 1.22\n")
+		writeFile(t, batchRoot, "store/store.go", "package store\n\ntype Users interface{ Get() error }\n\ntype Orders interface{ List() error }\n")
+
+		s := newTestServer(t)
+		lastArgs, lastConfig := useFakeMockeryV3(t, s)
+		outputDir := t.TempDir()
+
+		results, err := s.GenerateMocksRecursive(context.Background(), &types.MockGenerationRequest{
+			PackagePath: batchRoot,
+			OutputDir:   outputDir,
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		for _, result := range results {
+			assert.True(t, result.Success, result.ErrorMessage)
+			assert.FileExists(t, result.GeneratedFile)
+		}
+
+		args := lastArgs()
+		require.Len(t, args, 1)
+		assert.True(t, strings.HasPrefix(args[0], "--config="), args[0])
+		var config mockeryV3Config
+		require.NoError(t, yaml.Unmarshal([]byte(lastConfig()), &config))
+		storeMocks := filepath.Join(outputDir, "store")
+		assert.Equal(t, "testify", config.Template)
+		assert.Equal(t, map[string]mockeryV3Interface{
+			"Users":  {Config: &mockeryV3Settings{Dir: storeMocks, Filename: "mock_users.go", StructName: "Users", PkgName: "mocks"}},
+			"Orders": {Config: &mockeryV3Settings{Dir: storeMocks, Filename: "mock_orders.go", StructName: "Orders", PkgName: "mocks"}},
+		}, config.Packages["example.com/app/store"].Interfaces)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		s := newTestServer(t)
+		cfg := s.Config()
+		cfg.MockeryVersion = "v4"
+		assert.ErrorContains(t, s.ApplyConfig(cfg), `unknown mockery version "v4"`)
+	})
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// mocksModule is the module mocks are written to when it isn't the module
+// of the interface they mock
+type mocksModule struct {
+	root       string // Directory holding the mocks module's go.mod
+	path       string // Module path of the mocks module
+	create     bool   // The go.mod doesn't exist yet and is created on generation
+	sourceRoot string // Root of the interface's module
+	sourcePath string // Module path of the interface's module
+}
+
+// resolveMocksModule works out whether outputDir belongs to a different
+// module than the package in source, going by the nearest go.mod of each.
+// When it doesn't and the request names a mocks module, a new module rooted
+// at outputDir is planned. It returns nil when mocks stay in the source's
+// module, and for external and in-package sources, which never move.
+func resolveMocksModule(request *types.MockGenerationRequest, source *mockSource, outputDir string) *mocksModule {
+	if source.external || request.InPackage {
+		return nil
+	}
+	sourceRoot, sourcePath := scanner.FindModule(source.dir)
+	if sourcePath == "" {
+		return nil
+	}
+
+	outputRoot, outputPath := scanner.FindModule(outputDir)
+	if outputRoot != "" && outputRoot != sourceRoot {
+		return &mocksModule{root: outputRoot, path: outputPath, sourceRoot: sourceRoot, sourcePath: sourcePath}
+	}
+	if request.MocksModule == "" {
+		return nil
+	}
+	return &mocksModule{root: outputDir, path: request.MocksModule, create: true, sourceRoot: sourceRoot, sourcePath: sourcePath}
+}
+
+// mocksPackageName returns the package name for mocks written to dir in a
+// separate module: the package already declared there, or the directory
+// name if it is a valid identifier, or "mocks"
+func mocksPackageName(dir string) string {
+	name := packageName(dir)
+	if !token.IsIdentifier(name) || token.IsKeyword(name) {
+		return "mocks"
+	}
+	return name
+}
+
+// testifyModule is the module generated mocks import
+const testifyModule = "github.com/stretchr/testify"
+
+// writeGoMod creates the go.mod of a new mocks module. It requires the
+// source module and replaces it with its local directory, so the mocks can
+// import the interfaces' package before the source module is published. It
+// also requires testify at the version the source module does, if any.
+func (m *mocksModule) writeGoMod() error {
+	goModPath := filepath.Join(m.root, "go.mod")
+	if _, err := os.Stat(goModPath); err == nil {
+		return nil
+	}
+
+	replacement, err := filepath.Rel(m.root, m.sourceRoot)
+	if err != nil {
+		return fmt.Errorf("failed to locate source module from %s: %w", m.root, err)
+	}
+	replacement = filepath.ToSlash(replacement)
+	if !strings.HasPrefix(replacement, ".") {
+		replacement = "./" + replacement
+	}
+
+	source := readGoMod(m.sourceRoot)
+	file := new(modfile.File)
+	if err := file.AddModuleStmt(m.path); err != nil {
+		return fmt.Errorf("invalid mocks module path: %w", err)
+	}
+	if source != nil && source.Go != nil {
+		if err := file.AddGoStmt(source.Go.Version); err != nil {
+			return fmt.Errorf("invalid go version in source module: %w", err)
+		}
+	}
+	requires := []*modfile.Require{{Mod: module.Version{Path: m.sourcePath, Version: "v0.0.0"}}}
+	if source != nil {
+		for _, require := range source.Require {
+			if require.Mod.Path == testifyModule {
+				requires = append(requires, &modfile.Require{Mod: require.Mod})
+			}
+		}
+	}
+	file.SetRequire(requires)
+	if err := file.AddReplace(m.sourcePath, "", replacement, ""); err != nil {
+		return fmt.Errorf("failed to replace source module: %w", err)
+	}
+	content, err := file.Format()
+	if err != nil {
+		return fmt.Errorf("failed to format mocks module go.mod: %w", err)
+	}
+
+	if err := os.MkdirAll(m.root, 0755); err != nil {
+		return fmt.Errorf("failed to create mocks module directory: %w", err)
+	}
+	if err := os.WriteFile(goModPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write mocks module go.mod: %w", err)
+	}
+	return nil
+}
+
+// readGoMod parses the go.mod in moduleRoot, returning nil if it can't be
+// read or parsed
+func readGoMod(moduleRoot string) *modfile.File {
+	goModPath := filepath.Join(moduleRoot, "go.mod")
+	content, err := os.ReadFile(goModPath)
+	if err != nil {
+		return nil
+	}
+	file, err := modfile.ParseLax(goModPath, content, nil)
+	if err != nil {
+		return nil
+	}
+	return file
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+func TestMockeryMCPServer_GenerateMockSeparateModule(t *testing.T) {
+	workspace := t.TempDir()
+	writeFile(t, workspace, "app/go.mod", "module example.com/app\n\ngo 1.22\n\nrequire (\n\tgithub.com/stretchr/testify v1.9.0\n\tgo.uber.org/zap v1.27.0\n)\n")
+	writeFile(t, workspace, "app/store/store.go", "package store\n\ntype Store interface{ Get() error }\n")
+	packageDir := filepath.Join(workspace, "app", "store")
+
+	t.Run("existing mocks module", func(t *testing.T) {
+		writeFile(t, workspace, "testmocks/go.mod", "module example.com/testmocks\n\ngo 1.22\n")
+		outputDir := filepath.Join(workspace, "testmocks", "storemocks")
+
+		s := newTestServer(t)
+		lastArgs := recordingMockery(t, s)
+
+		result, err := s.GenerateMock(context.Background(), &types.MockGenerationRequest{
+			InterfaceName: "Store",
+			PackagePath:   packageDir,
+			OutputDir:     outputDir,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(outputDir, "mock_store.go"), result.GeneratedFile)
+		assert.Contains(t, lastArgs(), "--outpkg=storemocks")
+	})
+
+	t.Run("creates the mocks module", func(t *testing.T) {
+		outputDir := filepath.Join(workspace, "generated", "mocks")
+
+		s := newTestServer(t)
+		lastArgs := recordingMockery(t, s)
+
+		_, err := s.GenerateMock(context.Background(), &types.MockGenerationRequest{
+			InterfaceName: "Store",
+			PackagePath:   packageDir,
+			OutputDir:     outputDir,
+			MocksModule:   "example.com/app/mocks",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, lastArgs(), "--outpkg=mocks")
+
+		goMod, err := os.ReadFile(filepath.Join(outputDir, "go.mod"))
+		require.NoError(t, err)
+		assert.Equal(t, "module example.com/app/mocks\n\ngo 1.22\n\n"+
+			"require (\n\texample.com/app v0.0.0\n\tgithub.com/stretchr/testify v1.9.0\n)\n\n"+
+			"replace example.com/app => ../../app\n", string(goMod))
+
+		// The next generation finds the module and leaves its go.mod alone
+		require.NoError(t, os.WriteFile(filepath.Join(outputDir, "go.mod"), []byte("module example.com/app/mocks\n"), 0644))
+		_, err = s.GenerateMock(context.Background(), &types.MockGenerationRequest{
+			InterfaceName: "Store",
+			PackagePath:   packageDir,
+			OutputDir:     outputDir,
+			MocksModule:   "example.com/app/mocks",
+		})
+		require.NoError(t, err)
+		goMod, err = os.ReadFile(filepath.Join(outputDir, "go.mod"))
+		require.NoError(t, err)
+		assert.Equal(t, "module example.com/app/mocks\n", string(goMod))
+	})
+
+	t.Run("same module is unchanged", func(t *testing.T) {
+		s := newTestServer(t)
+		lastArgs := recordingMockery(t, s)
+
+		_, err := s.GenerateMock(context.Background(), &types.MockGenerationRequest{
+			InterfaceName: "Store",
+			PackagePath:   packageDir,
+		})
+		require.NoError(t, err)
+		for _, arg := range lastArgs() {
+			assert.NotContains(t, arg, "--outpkg")
+		}
+		assert.NoFileExists(t, filepath.Join(packageDir, "mocks", "go.mod"))
+	})
+}
+
+func TestMocksPackageName(t *testing.T) {
+	assert.Equal(t, "storemocks", mocksPackageName(filepath.Join(t.TempDir(), "storemocks")))
+	assert.Equal(t, "mocks", mocksPackageName(filepath.Join(t.TempDir(), "test-mocks")))
+	assert.Equal(t, "mocks", mocksPackageName(filepath.Join(t.TempDir(), "func")))
+
+	dir := filepath.Dir(writeFile(t, t.TempDir(), "out/existing.go", "package fakes\n"))
+	assert.Equal(t, "fakes", mocksPackageName(dir))
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// defaultDirMode is the mode mock output directories are created with when
+// no directory mode is configured, before the umask applies
+const defaultDirMode os.FileMode = 0755
+
+// parseMode parses an octal permission setting such as 0664. The mode must
+// fit in the permission bits and grant the owner at least ownerBits, so the
+// server can always go on writing what it generates. Empty means unset.
+func parseMode(setting, value string, ownerBits os.FileMode) (os.FileMode, error) {
+	if value == "" {
+		return 0, nil
+	}
+	bits, err := strconv.ParseUint(value, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: must be an octal mode such as 0%o", setting, value, ownerBits|0044)
+	}
+	mode := os.FileMode(bits)
+	if mode&^os.ModePerm != 0 {
+		return 0, fmt.Errorf("invalid %s %q: only permission bits (at most 0777) are allowed", setting, value)
+	}
+	if mode&ownerBits != ownerBits {
+		return 0, fmt.Errorf("invalid %s %q: the owner needs at least 0%o", setting, value, ownerBits)
+	}
+	return mode, nil
+}
+
+// makeOutputDir creates dir and any missing parents. With a directory mode
+// configured, the directories it creates get exactly that mode whatever the
+// umask; existing directories are left alone.
+func (c *runtimeConfig) makeOutputDir(dir string) error {
+	if c.dirMode == 0 {
+		return os.MkdirAll(dir, defaultDirMode)
+	}
+
+	var missing []string
+	for path := filepath.Clean(dir); ; path = filepath.Dir(path) {
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		missing = append(missing, path)
+		if filepath.Dir(path) == path {
+			break
+		}
+	}
+
+	if err := os.MkdirAll(dir, c.dirMode); err != nil {
+		return err
+	}
+	for _, path := range missing {
+		if err := os.Chmod(path, c.dirMode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyFileMode sets a generated file's mode when a file mode is
+// configured, leaving mockery's default otherwise
+func (c *runtimeConfig) applyFileMode(path string) error {
+	if c.fileMode == 0 {
+		return nil
+	}
+	return os.Chmod(path, c.fileMode)
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMode(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    os.FileMode
+		wantErr string
+	}{
+		{value: "", want: 0},
+		{value: "0664", want: 0664},
+		{value: "640", want: 0640},
+		{value: "0600", want: 0600},
+		{value: "rw-r--r--", wantErr: "must be an octal mode"},
+		{value: "0888", wantErr: "must be an octal mode"},
+		{value: "01664", wantErr: "at most 0777"},
+		{value: "0444", wantErr: "the owner needs at least 0600"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			mode, err := parseMode("file_mode", tt.value, 0600)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, mode)
+		})
+	}
+}
+
+func TestMockeryMCPServer_GeneratedFileModes(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype Repo interface{ Get() error }\n")
+
+	t.Run("configured modes", func(t *testing.T) {
+		s := newTestServer(t)
+		useFakeMockery(t, s, fakeMockeryScript)
+		cfg := s.Config()
+		cfg.FileMode = "0660"
+		cfg.DirMode = "0770"
+		require.NoError(t, s.ApplyConfig(cfg))
+
+		// Directory modes apply to every directory created, not only the last
+		root := t.TempDir()
+		outputDir := filepath.Join(root, "internal", "mocks")
+		responseText(t, callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "Repo",
+			"package_path":   dir,
+			"output_dir":     outputDir,
+		}))
+
+		info, err := os.Stat(filepath.Join(outputDir, "mock_repo.go"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0660), info.Mode().Perm())
+		for _, created := range []string{outputDir, filepath.Dir(outputDir)} {
+			info, err := os.Stat(created)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0770), info.Mode().Perm(), created)
+		}
+
+		// Existing directories keep their mode
+		info, err = os.Stat(root)
+		require.NoError(t, err)
+		assert.NotEqual(t, os.FileMode(0770), info.Mode().Perm())
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		s := newTestServer(t)
+		useFakeMockery(t, s, fakeMockeryScript)
+
+		outputDir := filepath.Join(t.TempDir(), "mocks")
+		responseText(t, callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "Repo",
+			"package_path":   dir,
+			"output_dir":     outputDir,
+		}))
+
+		info, err := os.Stat(outputDir)
+		require.NoError(t, err)
+		assert.Zero(t, info.Mode().Perm()&^0755, "default directory mode is at most 0755")
+	})
+
+	t.Run("invalid modes are rejected", func(t *testing.T) {
+		s := newTestServer(t)
+		cfg := s.Config()
+		cfg.DirMode = "0644"
+		err := s.ApplyConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid dir_mode")
+
+		cfg.DirMode = ""
+		cfg.FileMode = "abc"
+		err = s.ApplyConfig(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid file_mode")
+	})
+}
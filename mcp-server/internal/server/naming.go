@@ -0,0 +1,179 @@
+package server
+
+import (
+	"fmt"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// expandInterfaceName substitutes the interface name into a filename or
+// mock name template
+func expandInterfaceName(format, interfaceName string) string {
+	return strings.ReplaceAll(format, "{{.InterfaceName}}", interfaceName)
+}
+
+// Built-in variables of filename templates
+const (
+	templateVarInterfaceName = "InterfaceName"
+	templateVarPackageName   = "PackageName"
+	templateVarDate          = "Date"
+)
+
+// builtinTemplateVars lists the variables every filename template can use
+var builtinTemplateVars = []string{templateVarInterfaceName, templateVarPackageName, templateVarDate}
+
+// validateTemplateVars checks that custom template variable names can be
+// referenced as {{.Name}} and don't redefine a built-in variable
+func validateTemplateVars(vars map[string]string) error {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !token.IsIdentifier(name) {
+			return fmt.Errorf("template variable %q is not a valid identifier", name)
+		}
+		for _, builtin := range builtinTemplateVars {
+			if name == builtin {
+				return fmt.Errorf("template variable %s is built in and can't be redefined", name)
+			}
+		}
+	}
+	return nil
+}
+
+// mockTemplateVars merges the built-in variables with the request's own
+// TemplateVars. PackageName is left out when packageName is empty, so
+// templates using it fail rather than render an empty name.
+func mockTemplateVars(request *types.MockGenerationRequest, packageName string) map[string]string {
+	vars := make(map[string]string, len(request.TemplateVars)+len(builtinTemplateVars))
+	for name, value := range request.TemplateVars {
+		vars[name] = value
+	}
+	vars[templateVarInterfaceName] = request.InterfaceName
+	vars[templateVarDate] = time.Now().Format("2006-01-02")
+	if packageName != "" {
+		vars[templateVarPackageName] = packageName
+	}
+	return vars
+}
+
+// renderMockTemplate executes a filename template with vars, failing on
+// references to variables that aren't defined
+func renderMockTemplate(text string, vars map[string]string) (string, error) {
+	tmpl, err := template.New("filename").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid filename template: %w", err)
+	}
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, vars); err != nil {
+		return "", fmt.Errorf("failed to evaluate filename template %q: %w", text, err)
+	}
+	return rendered.String(), nil
+}
+
+// renderMockFilename returns the filename for a mock of a package named
+// packageName, executing an explicit FilenameFormat as a template
+func renderMockFilename(request *types.MockGenerationRequest, packageName string) (string, error) {
+	if request.FilenameFormat == "" {
+		return resolveMockFilename(request), nil
+	}
+	return renderMockTemplate(request.FilenameFormat, mockTemplateVars(request, packageName))
+}
+
+// Casing styles for the interface name in default mock filenames
+const (
+	filenameCaseSnake    = "snake"    // mock_user_repository.go
+	filenameCaseLower    = "lower"    // mock_userrepository.go
+	filenameCaseOriginal = "original" // mock_UserRepository.go
+)
+
+// filenameCases lists the accepted filename casing styles
+var filenameCases = []string{filenameCaseSnake, filenameCaseLower, filenameCaseOriginal}
+
+// validateFilenameCase checks a filename casing style. Empty means the
+// default, snake case.
+func validateFilenameCase(style string) error {
+	if style == "" {
+		return nil
+	}
+	for _, known := range filenameCases {
+		if style == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown filename case %q (expected one of: %s)", style, strings.Join(filenameCases, ", "))
+}
+
+// snakeCase converts a Go identifier to snake case, keeping acronyms
+// together: HTTPServer becomes http_server and UserID becomes user_id
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var out strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			// Split at the start of a word, and before the last capital of an
+			// acronym that is followed by a word
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				out.WriteByte('_')
+			}
+		}
+		out.WriteRune(unicode.ToLower(r))
+	}
+	return out.String()
+}
+
+// filenameCase renders an interface name in a filename casing style
+func filenameCase(name, style string) string {
+	switch style {
+	case filenameCaseLower:
+		return strings.ToLower(name)
+	case filenameCaseOriginal:
+		return name
+	default:
+		return snakeCase(name)
+	}
+}
+
+// resolveMockFilename returns the filename for a mock, honoring an explicit
+// FilenameFormat. The default only gains a _test.go suffix for test-only
+// mocks, so production mocks never become invisible to non-test code. A
+// FilenameFormat that needs the package name is rendered by
+// renderMockFilename; here it only has the interface name substituted.
+func resolveMockFilename(request *types.MockGenerationRequest) string {
+	if request.FilenameFormat != "" {
+		if filename, err := renderMockTemplate(request.FilenameFormat, mockTemplateVars(request, "")); err == nil {
+			return filename
+		}
+		return expandInterfaceName(request.FilenameFormat, request.InterfaceName)
+	}
+
+	name := filenameCase(request.InterfaceName, request.FilenameCase)
+	if request.TestOnly {
+		return fmt.Sprintf("mock_%s_test.go", name)
+	}
+	return fmt.Sprintf("mock_%s.go", name)
+}
+
+// resolveMockName returns the mock type name to pass to mockery, or "" to keep
+// mockery's default. In-package mocks default to Mock<Interface> so they
+// don't collide with the interface they implement.
+func resolveMockName(request *types.MockGenerationRequest) string {
+	if request.MockName != "" {
+		return expandInterfaceName(request.MockName, request.InterfaceName)
+	}
+
+	if request.InPackage {
+		return "Mock" + request.InterfaceName
+	}
+	return ""
+}
@@ -0,0 +1,224 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+func TestResolveMockNaming(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  types.MockGenerationRequest
+		filename string
+		mockName string
+	}{
+		{
+			name:     "default",
+			request:  types.MockGenerationRequest{InterfaceName: "UserRepository"},
+			filename: "mock_user_repository.go",
+		},
+		{
+			name:     "in package",
+			request:  types.MockGenerationRequest{InterfaceName: "UserRepository", InPackage: true},
+			filename: "mock_user_repository.go",
+			mockName: "MockUserRepository",
+		},
+		{
+			name:     "in package test only",
+			request:  types.MockGenerationRequest{InterfaceName: "UserRepository", InPackage: true, TestOnly: true},
+			filename: "mock_user_repository_test.go",
+			mockName: "MockUserRepository",
+		},
+		{
+			name:     "test only",
+			request:  types.MockGenerationRequest{InterfaceName: "UserRepository", TestOnly: true},
+			filename: "mock_user_repository_test.go",
+		},
+		{
+			name: "explicit overrides",
+			request: types.MockGenerationRequest{
+				InterfaceName:  "UserRepository",
+				InPackage:      true,
+				TestOnly:       true,
+				FilenameFormat: "{{.InterfaceName}}_fake.go",
+				MockName:       "Fake{{.InterfaceName}}",
+			},
+			filename: "UserRepository_fake.go",
+			mockName: "FakeUserRepository",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.filename, resolveMockFilename(&tt.request))
+			assert.Equal(t, tt.mockName, resolveMockName(&tt.request))
+		})
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"Store":          "store",
+		"UserRepository": "user_repository",
+		"HTTPServer":     "http_server",
+		"UserID":         "user_id",
+		"JSONAPIClient":  "jsonapi_client",
+		"OAuth2Client":   "o_auth2_client",
+		"S3Uploader":     "s3_uploader",
+		"io":             "io",
+		"getHTTP":        "get_http",
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, snakeCase(name), name)
+	}
+}
+
+func TestResolveMockFilename_Case(t *testing.T) {
+	request := types.MockGenerationRequest{InterfaceName: "HTTPServer"}
+	for style, want := range map[string]string{
+		"":                   "mock_http_server.go",
+		filenameCaseSnake:    "mock_http_server.go",
+		filenameCaseLower:    "mock_httpserver.go",
+		filenameCaseOriginal: "mock_HTTPServer.go",
+	} {
+		request.FilenameCase = style
+		assert.Equal(t, want, resolveMockFilename(&request), style)
+	}
+
+	// An explicit format isn't affected
+	request.FilenameFormat = "{{.InterfaceName}}.go"
+	assert.Equal(t, "HTTPServer.go", resolveMockFilename(&request))
+
+	assert.Error(t, validateFilenameCase("camel"))
+}
+
+func TestRenderMockFilename(t *testing.T) {
+	request := types.MockGenerationRequest{
+		InterfaceName:  "UserRepository",
+		FilenameFormat: "{{.Team}}_{{.PackageName}}_{{.InterfaceName}}_{{.Date}}.go",
+		TemplateVars:   map[string]string{"Team": "payments"},
+	}
+	filename, err := renderMockFilename(&request, "repo")
+	require.NoError(t, err)
+	assert.Equal(t, "payments_repo_UserRepository_"+time.Now().Format("2006-01-02")+".go", filename)
+
+	// Undefined variables fail instead of rendering "<no value>"
+	request.FilenameFormat = "{{.Owner}}_{{.InterfaceName}}.go"
+	_, err = renderMockFilename(&request, "repo")
+	assert.ErrorContains(t, err, `map has no entry for key "Owner"`)
+
+	request.FilenameFormat = "{{.PackageName}}.go"
+	_, err = renderMockFilename(&request, "")
+	assert.Error(t, err)
+
+	request.FilenameFormat = ""
+	filename, err = renderMockFilename(&request, "repo")
+	require.NoError(t, err)
+	assert.Equal(t, "mock_user_repository.go", filename)
+
+	assert.NoError(t, validateTemplateVars(map[string]string{"Team": "payments"}))
+	assert.ErrorContains(t, validateTemplateVars(map[string]string{"InterfaceName": "x"}), "built in")
+	assert.ErrorContains(t, validateTemplateVars(map[string]string{"team-name": "x"}), "not a valid identifier")
+}
+
+func TestMockeryMCPServer_GenerateMock_TemplateVars(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype UserRepository interface{ Get() error }\n")
+
+	s := newTestServer(t)
+	useFakeMockery(t, s, fakeMockeryScript)
+	outputDir := t.TempDir()
+
+	text := responseText(t, callTool(t, s, "generate_mock", map[string]interface{}{
+		"interface_name":  "UserRepository",
+		"package_path":    dir,
+		"output_dir":      outputDir,
+		"filename_format": "{{.PackageName}}_{{.Team}}_{{.InterfaceName}}.go",
+		"template_vars":   map[string]interface{}{"Team": "payments"},
+	}))
+	assert.Contains(t, text, "- Generated: "+filepath.Join(outputDir, "repo_payments_UserRepository.go"))
+
+	for name, args := range map[string]map[string]interface{}{
+		"Invalid filename_format": {"filename_format": "{{.Team}}.go"},
+		"Invalid template_vars":   {"template_vars": map[string]interface{}{"PackageName": "other"}},
+	} {
+		args["interface_name"] = "UserRepository"
+		args["package_path"] = dir
+		response := callTool(t, s, "generate_mock", args)
+		require.NotNil(t, response.Error, name)
+		assert.Equal(t, name, response.Error.Message)
+	}
+}
+
+func TestMockeryMCPServer_GenerateMock_FilenameCase(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype UserRepository interface{ Get() error }\n")
+
+	s := newTestServer(t)
+	useFakeMockery(t, s, fakeMockeryScript)
+	require.NoError(t, s.updateConfig(func(cfg *ServerConfig) {
+		cfg.FilenameCase = filenameCaseLower
+	}))
+
+	generated := func(args map[string]interface{}) string {
+		args["interface_name"] = "UserRepository"
+		args["package_path"] = dir
+		args["output_dir"] = t.TempDir()
+		callTool(t, s, "generate_mock", args)
+		mocks := s.projectManager.GetGeneratedMocks("")
+		require.NotEmpty(t, mocks)
+		for _, mock := range mocks {
+			if mock.Request.OutputDir == args["output_dir"] {
+				return filepath.Base(mock.FilePath)
+			}
+		}
+		t.Fatalf("no mock recorded in %s", args["output_dir"])
+		return ""
+	}
+
+	assert.Equal(t, "mock_userrepository.go", generated(map[string]interface{}{}))
+	assert.Equal(t, "mock_user_repository.go", generated(map[string]interface{}{"filename_case": "snake"}))
+
+	response := callTool(t, s, "generate_mock", map[string]interface{}{
+		"interface_name": "UserRepository",
+		"package_path":   dir,
+		"filename_case":  "camel",
+	})
+	require.NotNil(t, response.Error)
+	assert.Equal(t, -32602, response.Error.Code)
+
+	assert.Error(t, s.updateConfig(func(cfg *ServerConfig) {
+		cfg.FilenameCase = "camel"
+	}))
+}
+
+func TestMockeryMCPServer_GenerateMock_InPackageTestOnly(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype UserRepository interface{ Get() error }\n")
+
+	s := newTestServer(t)
+	lastArgs := recordingMockery(t, s)
+
+	result, err := s.GenerateMock(context.Background(), &types.MockGenerationRequest{
+		InterfaceName: "UserRepository",
+		PackagePath:   dir,
+		InPackage:     true,
+		TestOnly:      true,
+	})
+	require.NoError(t, err)
+
+	// In-package mocks default to the package directory
+	assert.Equal(t, filepath.Join(dir, "mock_user_repository_test.go"), result.GeneratedFile)
+	args := lastArgs()
+	assert.Contains(t, args, "--inpackage")
+	assert.Contains(t, args, "--testonly")
+	assert.Contains(t, args, "--mockname=MockUserRepository")
+	assert.Contains(t, args, "--output="+dir)
+}
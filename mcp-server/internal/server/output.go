@@ -0,0 +1,87 @@
+package server
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// OutputDirData holds the values available to the output directory template
+type OutputDirData struct {
+	PackageName string // Go package name declared in the package directory
+	PackagePath string // Package path as given in the request
+	PackageDir  string // Absolute package directory (the project directory for external packages)
+}
+
+// resolveOutputDir determines the absolute output directory for a request.
+// Mocks of external packages default to the project rather than the package
+// directory, which may be a read-only module cache or GOROOT.
+func (s *MockeryMCPServer) resolveOutputDir(cfg *runtimeConfig, request *types.MockGenerationRequest, source *mockSource) (string, error) {
+	outputDir := request.OutputDir
+
+	// In-package mocks must sit beside the interface they mock
+	if outputDir == "" && request.InPackage && !source.external {
+		return source.dir, nil
+	}
+
+	if outputDir == "" {
+		if cfg.outputTemplate == nil {
+			if source.external {
+				return filepath.Join(source.workDir, "mocks", source.packageName), nil
+			}
+			return filepath.Join(source.dir, "mocks"), nil
+		}
+
+		data := OutputDirData{
+			PackageName: source.packageName,
+			PackagePath: request.PackagePath,
+			PackageDir:  source.dir,
+		}
+		if source.external {
+			data.PackageDir = source.workDir
+		}
+
+		var rendered strings.Builder
+		if err := cfg.outputTemplate.Execute(&rendered, data); err != nil {
+			return "", fmt.Errorf("failed to evaluate output template: %w", err)
+		}
+		outputDir = rendered.String()
+	}
+
+	if !filepath.IsAbs(outputDir) {
+		absOutputDir, err := filepath.Abs(outputDir)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve output directory: %w", err)
+		}
+		outputDir = absOutputDir
+	}
+
+	return filepath.Clean(outputDir), nil
+}
+
+// packageName returns the Go package name declared in dir, falling back to
+// the directory name when no non-test Go file can be parsed
+func packageName(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err == nil {
+		fileSet := token.NewFileSet()
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+				continue
+			}
+
+			src, err := parser.ParseFile(fileSet, filepath.Join(dir, name), nil, parser.PackageClauseOnly)
+			if err == nil {
+				return src.Name.Name
+			}
+		}
+	}
+
+	return filepath.Base(dir)
+}
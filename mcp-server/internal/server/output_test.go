@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+func TestMockeryMCPServer_SetOutputTemplate(t *testing.T) {
+	s := newTestServer(t)
+
+	assert.NoError(t, s.SetOutputTemplate("./mocks/{{.PackageName}}"))
+	assert.NoError(t, s.SetOutputTemplate(""))
+
+	err := s.SetOutputTemplate("./mocks/{{.PackageName")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid output template")
+
+	err = s.SetOutputTemplate("./mocks/{{.Unknown}}")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid output template")
+}
+
+// mustResolveSource resolves the mock source for a request
+func mustResolveSource(t *testing.T, request *types.MockGenerationRequest) *mockSource {
+	t.Helper()
+	source, err := resolveMockSource(context.Background(), request)
+	require.NoError(t, err)
+	return source
+}
+
+func TestMockeryMCPServer_ResolveOutputDir(t *testing.T) {
+	root := t.TempDir()
+	packageDir := filepath.Join(root, "internal", "storage")
+	writeFile(t, packageDir, "repo.go", "package persistence\n\ntype Repo interface{}\n")
+
+	t.Run("default output dir", func(t *testing.T) {
+		s := newTestServer(t)
+		request := &types.MockGenerationRequest{PackagePath: packageDir}
+
+		outputDir, err := s.resolveOutputDir(s.config(), request, mustResolveSource(t, request))
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(packageDir, "mocks"), outputDir)
+	})
+
+	t.Run("template nests by package name", func(t *testing.T) {
+		s := newTestServer(t)
+		require.NoError(t, s.SetOutputTemplate(root+"/mocks/{{.PackageName}}"))
+		request := &types.MockGenerationRequest{PackagePath: packageDir}
+
+		outputDir, err := s.resolveOutputDir(s.config(), request, mustResolveSource(t, request))
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(root, "mocks", "persistence"), outputDir)
+	})
+
+	t.Run("template can use package dir", func(t *testing.T) {
+		s := newTestServer(t)
+		require.NoError(t, s.SetOutputTemplate("{{.PackageDir}}/../mocks"))
+		request := &types.MockGenerationRequest{PackagePath: packageDir}
+
+		outputDir, err := s.resolveOutputDir(s.config(), request, mustResolveSource(t, request))
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(root, "internal", "mocks"), outputDir)
+	})
+
+	t.Run("explicit output dir wins", func(t *testing.T) {
+		s := newTestServer(t)
+		require.NoError(t, s.SetOutputTemplate(root+"/mocks/{{.PackageName}}"))
+		explicit := filepath.Join(root, "custom")
+		request := &types.MockGenerationRequest{PackagePath: packageDir, OutputDir: explicit}
+
+		outputDir, err := s.resolveOutputDir(s.config(), request, mustResolveSource(t, request))
+		require.NoError(t, err)
+		assert.Equal(t, explicit, outputDir)
+	})
+}
@@ -0,0 +1,269 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+)
+
+// goListTimeout bounds how long package resolution may take
+const goListTimeout = 30 * time.Second
+
+// resolvedPackage is the subset of `go list -json` output used for scanning
+type resolvedPackage struct {
+	ImportPath string
+	Name       string
+	Dir        string
+	GoFiles    []string
+	Module     *struct {
+		Path string
+	}
+	Error *struct {
+		Err string
+	}
+}
+
+// resolvePackage resolves an import path to its directory and source files
+// using `go list`, run from workDir so the module there (and its
+// dependencies in the module cache) are visible
+func resolvePackage(ctx context.Context, workDir, importPath string) (*resolvedPackage, error) {
+	ctx, cancel := context.WithTimeout(ctx, goListTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "go", "list", "-e", "-json", "--", importPath)
+	cmd.Dir = workDir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		var execErr *exec.Error
+		if errors.As(err, &execErr) {
+			return nil, fmt.Errorf("go command not available: %w", err)
+		}
+		return nil, fmt.Errorf("go list failed: %v\nOutput: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var pkg resolvedPackage
+	if err := json.Unmarshal(output, &pkg); err != nil {
+		return nil, fmt.Errorf("failed to parse go list output: %w", err)
+	}
+
+	if pkg.Error != nil || pkg.Dir == "" {
+		detail := "package has no source directory"
+		if pkg.Error != nil {
+			detail = pkg.Error.Err
+		}
+		// Packages outside the module graph can't be resolved without
+		// changing go.mod, which is left to the user
+		return nil, fmt.Errorf("%w: cannot resolve package %s from %s: %s (if it belongs to another module, add it with go get first)",
+			ErrPathNotFound, importPath, workDir, detail)
+	}
+
+	return &pkg, nil
+}
+
+// handleScanPackage implements the scan_package tool
+func (s *MockeryMCPServer) handleScanPackage(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	importPath, ok := args["import_path"].(string)
+	if !ok || importPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid import_path", nil)
+	}
+
+	workDir := "."
+	if projectPath, ok := args["project_path"].(string); ok && projectPath != "" {
+		workDir = projectPath
+	}
+	absWorkDir, err := filepath.Abs(workDir)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", workDir), err)
+	}
+	if _, err := os.Stat(absWorkDir); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Project path does not exist: %s", absWorkDir), fmt.Errorf("%w: %s", ErrPathNotFound, absWorkDir))
+	}
+
+	pkg, err := resolvePackage(ctx, absWorkDir, importPath)
+	if err != nil {
+		s.logger.Error("Failed to resolve package", zap.String("import_path", importPath), zap.Error(err))
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve package %s", importPath), err)
+	}
+
+	files := make([]string, len(pkg.GoFiles))
+	for i, name := range pkg.GoFiles {
+		files[i] = filepath.Join(pkg.Dir, name)
+	}
+
+	interfaces, err := s.scanner.ScanFiles(files)
+	if err != nil {
+		s.logger.Error("Failed to scan package", zap.String("dir", pkg.Dir), zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to scan package", fmt.Errorf("%w: %v", ErrScanFailed, err))
+	}
+	interfaces = excludeInterfaces(interfaces, s.config().ExcludeInterfaces)
+
+	return s.textResponse(requestID, fmt.Sprintf("Found %d interfaces in package %s (%s):\n\n%s",
+		len(interfaces),
+		pkg.ImportPath,
+		pkg.Dir,
+		formatInterfaceList(simplifyInterfaces(interfaces))))
+}
+
+// PackageInfo summarizes a package found in a project tree
+type PackageInfo struct {
+	ImportPath string `json:"import_path"`
+	Name       string `json:"name"`
+	Dir        string `json:"dir"`
+	FileCount  int    `json:"file_count"`
+}
+
+// listPackages returns the packages beneath root, and the paths skipped
+// because they couldn't be read. Inside a module it asks go list, which
+// understands build constraints and nested modules; if that is unavailable
+// or fails it falls back to grouping Go files by directory.
+func listPackages(ctx context.Context, root string) ([]PackageInfo, []string, error) {
+	moduleRoot, modulePath := scanner.FindModule(root)
+	if moduleRoot != "" {
+		if packages, err := goListPackages(ctx, root); err == nil {
+			return packages, nil, nil
+		}
+	}
+	return walkPackages(ctx, root, moduleRoot, modulePath)
+}
+
+// goListPackages lists the packages beneath root with `go list ./...`
+func goListPackages(ctx context.Context, root string) ([]PackageInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, goListTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "go", "list", "-e", "-json", "./...")
+	cmd.Dir = root
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list failed: %w", err)
+	}
+
+	var packages []PackageInfo
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	for decoder.More() {
+		var pkg resolvedPackage
+		if err := decoder.Decode(&pkg); err != nil {
+			return nil, fmt.Errorf("failed to parse go list output: %w", err)
+		}
+		if len(pkg.GoFiles) == 0 {
+			continue
+		}
+		packages = append(packages, PackageInfo{
+			ImportPath: pkg.ImportPath,
+			Name:       pkg.Name,
+			Dir:        pkg.Dir,
+			FileCount:  len(pkg.GoFiles),
+		})
+	}
+	return packages, nil
+}
+
+// walkPackages groups the Go files beneath root by directory, applying the
+// same exclusions as the interface scanner. Import paths are derived from
+// the module path when there is one, or are relative to root otherwise.
+// Paths that can't be read are skipped and returned, as the interface scan
+// records them; only an unreadable root is an error.
+func walkPackages(ctx context.Context, root, moduleRoot, modulePath string) ([]PackageInfo, []string, error) {
+	counts := make(map[string]int)
+	var skipped []string
+	err := filepath.Walk(root, func(filePath string, info os.FileInfo, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			if filePath == root {
+				return err
+			}
+			skipped = append(skipped, err.Error())
+			if info != nil && info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !scanner.IsSourceFile(root, filePath, false) {
+			return nil
+		}
+		counts[filepath.Dir(filePath)]++
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to walk project: %w", err)
+	}
+
+	packages := make([]PackageInfo, 0, len(counts))
+	for dir, count := range counts {
+		importPath := packagePath(dir, moduleRoot, modulePath)
+		if modulePath == "" {
+			rel, err := filepath.Rel(root, dir)
+			if err != nil {
+				return nil, nil, err
+			}
+			importPath = filepath.ToSlash(rel)
+		}
+		packages = append(packages, PackageInfo{
+			ImportPath: importPath,
+			Name:       packageName(dir),
+			Dir:        dir,
+			FileCount:  count,
+		})
+	}
+
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].ImportPath < packages[j].ImportPath
+	})
+	return packages, skipped, nil
+}
+
+// handleListPackages implements the list_packages tool
+func (s *MockeryMCPServer) handleListPackages(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	projectPath, ok := args["project_path"].(string)
+	if !ok || projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid project_path", nil)
+	}
+
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", projectPath), err)
+	}
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Project path does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	packages, skipped, err := listPackages(ctx, absPath)
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return s.toolErrorResponse(requestID, "Scan cancelled", err)
+	}
+	if err != nil {
+		s.logger.Error("Failed to list packages", zap.String("path", absPath), zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to list packages", fmt.Errorf("%w: %v", ErrScanFailed, err))
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "Found %d packages in %s:\n", len(packages), absPath)
+	for _, pkg := range packages {
+		fmt.Fprintf(&text, "\n- %s (%s package) - %d files\n  Dir: %s", pkg.ImportPath, pkg.Name, pkg.FileCount, pkg.Dir)
+	}
+
+	// Paths that couldn't be read are reported rather than failing the listing
+	if len(skipped) > 0 {
+		fmt.Fprintf(&text, "\n\nSkipped %d paths with errors:", len(skipped))
+		for _, entry := range skipped {
+			text.WriteString("\n- " + entry)
+		}
+	}
+	return s.textResponse(requestID, text.String())
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_ScanPackage(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not available")
+	}
+
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	writeFile(t, root, "domain/repo.go", `package domain
+
+type UserRepository interface{ Get() error }
+`)
+	writeFile(t, root, "domain/nested/nested.go", `package nested
+
+type Nested interface{ Do() }
+`)
+
+	s := newTestServer(t)
+
+	t.Run("module package", func(t *testing.T) {
+		text := responseText(t, callTool(t, s, "scan_package", map[string]interface{}{
+			"import_path":  "example.com/app/domain",
+			"project_path": root,
+		}))
+		assert.Contains(t, text, "Found 1 interfaces in package example.com/app/domain ("+filepath.Join(root, "domain")+")")
+		assert.Contains(t, text, "UserRepository")
+		assert.NotContains(t, text, "Nested")
+	})
+
+	t.Run("package outside the module", func(t *testing.T) {
+		response := callTool(t, s, "scan_package", map[string]interface{}{
+			"import_path":  "example.com/elsewhere/pkg",
+			"project_path": root,
+		})
+		requireErrorKind(t, response, CodePathNotFound, KindPathNotFound)
+		assert.Contains(t, response.Error.Data.(ErrorData).Detail, "go get")
+	})
+
+	t.Run("missing import path", func(t *testing.T) {
+		response := callTool(t, s, "scan_package", map[string]interface{}{})
+		assert.Equal(t, -32602, response.Error.Code)
+	})
+}
+
+func TestMockeryMCPServer_ListPackages(t *testing.T) {
+	layout := func(t *testing.T, root string) {
+		writeFile(t, root, "main.go", "package main\n\nfunc main() {}\n")
+		writeFile(t, root, "domain/user.go", "package domain\n\ntype User struct{}\n")
+		writeFile(t, root, "domain/repo.go", "package domain\n\ntype Repo interface{ Get() error }\n")
+		writeFile(t, root, "testutil/helpers_test.go", "package testutil\n")
+		writeFile(t, root, "vendor/example.com/dep/dep.go", "package dep\n")
+	}
+
+	s := newTestServer(t)
+
+	t.Run("module", func(t *testing.T) {
+		if _, err := exec.LookPath("go"); err != nil {
+			t.Skip("go command not available")
+		}
+		root := t.TempDir()
+		writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n")
+		layout(t, root)
+
+		text := responseText(t, callTool(t, s, "list_packages", map[string]interface{}{"project_path": root}))
+		assert.Contains(t, text, "Found 2 packages")
+		assert.Contains(t, text, "- example.com/app (main package) - 1 files")
+		assert.Contains(t, text, "- example.com/app/domain (domain package) - 2 files\n  Dir: "+filepath.Join(root, "domain"))
+	})
+
+	t.Run("no module", func(t *testing.T) {
+		root := t.TempDir()
+		layout(t, root)
+
+		packages, skipped, err := walkPackages(context.Background(), root, "", "")
+		assert.NoError(t, err)
+		assert.Empty(t, skipped)
+		assert.Equal(t, []PackageInfo{
+			{ImportPath: ".", Name: "main", Dir: root, FileCount: 1},
+			{ImportPath: "domain", Name: "domain", Dir: filepath.Join(root, "domain"), FileCount: 2},
+		}, packages)
+	})
+
+	t.Run("unreadable directory", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("directory permissions are not enforced on Windows")
+		}
+		if os.Geteuid() == 0 {
+			t.Skip("root can read directories regardless of permissions")
+		}
+		root := t.TempDir()
+		layout(t, root)
+		locked := filepath.Dir(writeFile(t, root, "locked/hidden.go", "package locked\n"))
+		require.NoError(t, os.Chmod(locked, 0))
+		t.Cleanup(func() { os.Chmod(locked, 0755) })
+
+		text := responseText(t, callTool(t, s, "list_packages", map[string]interface{}{"project_path": root}))
+		assert.Contains(t, text, "Found 2 packages")
+		assert.Contains(t, text, "Skipped 1 paths with errors:")
+		assert.Contains(t, text, locked)
+	})
+}
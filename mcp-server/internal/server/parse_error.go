@@ -0,0 +1,30 @@
+package server
+
+import (
+	"encoding/json"
+	"regexp"
+)
+
+// requestIDPattern finds a string, number or null "id" member in a message
+// that failed to parse as a request
+var requestIDPattern = regexp.MustCompile(`"id"\s*:\s*("(?:[^"\\]|\\.)*"|-?\d+(?:\.\d+)?(?:[eE][+-]?\d+)?|null)`)
+
+// methodPattern finds a "method" member in a message that failed to parse
+var methodPattern = regexp.MustCompile(`"method"\s*:`)
+
+// parseErrorResponse returns the -32700 response for a message that could
+// not be parsed, addressed to whatever id can be salvaged from it, or to a
+// null id when there is none. It returns nil for messages that look like
+// notifications, which never get a response.
+func (s *MockeryMCPServer) parseErrorResponse(message []byte, parseErr error) *MCPResponse {
+	var id interface{}
+	if match := requestIDPattern.FindSubmatch(message); match != nil {
+		if err := json.Unmarshal(match[1], &id); err != nil {
+			id = nil
+		}
+	} else if methodPattern.Match(message) {
+		return nil
+	}
+
+	return s.errorResponse(id, -32700, "Parse error", parseErr.Error())
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_StdioParseError(t *testing.T) {
+	s := newTestServer(t)
+
+	var out strings.Builder
+	in := strings.NewReader(strings.Join([]string{
+		`{"jsonrpc":"2.0","id":3,"method":"ping",}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"`,
+		`not json`,
+		`{"jsonrpc":"2.0","id":"req-9","method":"tools/list","params":`,
+		`{"jsonrpc":"2.0","id":4,"method":"ping"}`,
+	}, "\n") + "\n")
+	require.NoError(t, s.ServeStdio(in, &out))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	// The malformed notification gets no response
+	require.Len(t, lines, 4)
+
+	responses := make(map[string]map[string]interface{})
+	for _, line := range lines {
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &response))
+		id, _ := json.Marshal(response["id"])
+		responses[string(id)] = response
+	}
+
+	for _, id := range []string{`3`, `null`, `"req-9"`} {
+		response, ok := responses[id]
+		require.True(t, ok, "no response for id %s", id)
+		errorObject := response["error"].(map[string]interface{})
+		assert.Equal(t, float64(-32700), errorObject["code"])
+		assert.Equal(t, "Parse error", errorObject["message"])
+	}
+
+	// Later requests are still served
+	assert.NotContains(t, responses[`4`], "error")
+}
+
+func TestParseErrorResponse(t *testing.T) {
+	s := newTestServer(t)
+	parseErr := errors.New("unexpected end of JSON input")
+
+	assert.Equal(t, float64(12), s.parseErrorResponse([]byte(`{"id": 12, "method": "ping"`), parseErr).ID)
+	assert.Equal(t, `a"b`, s.parseErrorResponse([]byte(`{"id":"a\"b","method":`), parseErr).ID)
+	assert.Nil(t, s.parseErrorResponse([]byte(`{"id":null,"method":`), parseErr).ID)
+	assert.Nil(t, s.parseErrorResponse([]byte(`garbage`), parseErr).ID)
+	assert.Nil(t, s.parseErrorResponse([]byte(`{"method":"notifications/cancelled","params":`), parseErr))
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// maxSuggestions caps how many interface names a not-found error lists
+const maxSuggestions = 5
+
+// lookupInterface scans a local package for the interface a request names.
+// Unless the request skips the pre-check, an interface that isn't declared
+// there is reported with the names of similar ones instead of leaving
+// mockery to fail. The interface is nil when it couldn't be looked up: for
+// import-path packages, names mockery treats as patterns, or packages the
+// scanner can't parse, all of which are left to mockery.
+func (s *MockeryMCPServer) lookupInterface(ctx context.Context, request *types.MockGenerationRequest, source *mockSource) (*types.InterfaceDefinition, error) {
+	if source.external || !token.IsIdentifier(request.InterfaceName) {
+		return nil, nil
+	}
+
+	// Test files count too, since mocks of test-only interfaces are valid
+	files, err := filepath.Glob(filepath.Join(source.dir, "*.go"))
+	if err != nil {
+		return nil, nil
+	}
+	interfaces, err := s.scanner.ScanFiles(files)
+	if err != nil {
+		s.requestLogger(ctx).Debug("Skipping interface pre-check", zap.String("package", source.dir), zap.Error(err))
+		return nil, nil
+	}
+
+	for i := range interfaces {
+		if interfaces[i].Name == request.InterfaceName {
+			return &interfaces[i], nil
+		}
+	}
+	if request.SkipPrecheck {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(interfaces))
+	for _, iface := range interfaces {
+		names = append(names, iface.Name)
+	}
+	found := "no interfaces declared"
+	if suggestions := similarNames(request.InterfaceName, names); len(suggestions) > 0 {
+		found = "found: " + strings.Join(suggestions, ", ")
+	}
+	return nil, fmt.Errorf("%w: interface %s not found in %s (%s)", ErrInterfaceNotFound, request.InterfaceName, source.dir, found)
+}
+
+// lookupFunctionType is lookupInterface for requests that mock a named
+// function type. The function type is nil when it couldn't be looked up.
+func (s *MockeryMCPServer) lookupFunctionType(ctx context.Context, request *types.MockGenerationRequest, source *mockSource) (*types.FunctionTypeDefinition, error) {
+	if source.external || !token.IsIdentifier(request.InterfaceName) {
+		return nil, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(source.dir, "*.go"))
+	if err != nil {
+		return nil, nil
+	}
+	functions, err := s.scanner.ScanFunctionTypes(files)
+	if err != nil {
+		s.requestLogger(ctx).Debug("Skipping function type pre-check", zap.String("package", source.dir), zap.Error(err))
+		return nil, nil
+	}
+
+	for i := range functions {
+		if functions[i].Name == request.InterfaceName {
+			return &functions[i], nil
+		}
+	}
+	if request.SkipPrecheck {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(functions))
+	for _, function := range functions {
+		names = append(names, function.Name)
+	}
+	found := "no function types declared"
+	if suggestions := similarNames(request.InterfaceName, names); len(suggestions) > 0 {
+		found = "found: " + strings.Join(suggestions, ", ")
+	}
+	return nil, fmt.Errorf("%w: function type %s not found in %s (%s)", ErrInterfaceNotFound, request.InterfaceName, source.dir, found)
+}
+
+// similarNames returns up to maxSuggestions names, closest to name first by
+// case-insensitive edit distance. Names containing name, or contained in it,
+// count as close.
+func similarNames(name string, names []string) []string {
+	sorted := append([]string(nil), names...)
+	lower := strings.ToLower(name)
+	distances := make(map[string]int, len(sorted))
+	for _, candidate := range sorted {
+		candidateLower := strings.ToLower(candidate)
+		distance := editDistance(lower, candidateLower)
+		if strings.Contains(candidateLower, lower) || strings.Contains(lower, candidateLower) {
+			distance = min(distance, 1)
+		}
+		distances[candidate] = distance
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if distances[sorted[i]] != distances[sorted[j]] {
+			return distances[sorted[i]] < distances[sorted[j]]
+		}
+		return sorted[i] < sorted[j]
+	})
+
+	if len(sorted) > maxSuggestions {
+		sorted = sorted[:maxSuggestions]
+	}
+	return sorted
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimilarNames(t *testing.T) {
+	names := []string{"Sender", "UserStore", "UserRepository", "Clock", "UserRepo", "Cache", "Queue"}
+
+	suggestions := similarNames("UserRepositry", names)
+	assert.Len(t, suggestions, maxSuggestions)
+	assert.Equal(t, []string{"UserRepo", "UserRepository", "UserStore"}, suggestions[:3])
+	assert.Equal(t, []string{"UserRepo", "UserRepository"}, similarNames("userrepo", names)[:2])
+	assert.Empty(t, similarNames("Anything", nil))
+}
+
+func TestMockeryMCPServer_GenerateMock_Precheck(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype UserRepository interface{ Get() error }\n\ntype UserStore interface{ Put() error }\n")
+	writeFile(t, dir, "clock_test.go", "package repo\n\ntype testClock interface{ Now() int64 }\n")
+
+	t.Run("suggests similar interfaces", func(t *testing.T) {
+		s := newTestServer(t)
+		useFakeMockery(t, s, "#!/bin/sh\necho mockery should not run >&2\nexit 1\n")
+
+		response := callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "UserRepositry",
+			"package_path":   dir,
+		})
+		requireErrorKind(t, response, CodeInterfaceNotFound, KindInterfaceNotFound)
+		detail := response.Error.Data.(ErrorData).Detail
+		assert.Contains(t, detail, "interface UserRepositry not found in "+dir+" (found: UserRepository, UserStore, testClock)")
+	})
+
+	t.Run("empty packages", func(t *testing.T) {
+		s := newTestServer(t)
+		empty := t.TempDir()
+		writeFile(t, empty, "doc.go", "package empty\n")
+
+		response := callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "Store",
+			"package_path":   empty,
+		})
+		requireErrorKind(t, response, CodeInterfaceNotFound, KindInterfaceNotFound)
+		assert.Contains(t, response.Error.Data.(ErrorData).Detail, "(no interfaces declared)")
+	})
+
+	t.Run("test files and patterns are checked by mockery", func(t *testing.T) {
+		s := newTestServer(t)
+		lastArgs := recordingMockery(t, s)
+
+		for _, name := range []string{"testClock", "User.*"} {
+			responseText(t, callTool(t, s, "generate_mock", map[string]interface{}{
+				"interface_name": name,
+				"package_path":   dir,
+			}))
+			assert.Contains(t, lastArgs(), "--name="+name)
+		}
+	})
+
+	t.Run("can be skipped", func(t *testing.T) {
+		s := newTestServer(t)
+		lastArgs := recordingMockery(t, s)
+
+		responseText(t, callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "Generated",
+			"package_path":   dir,
+			"skip_precheck":  true,
+		}))
+		require.NotEmpty(t, lastArgs())
+		assert.Contains(t, lastArgs(), "--name=Generated")
+	})
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+)
+
+// Sizes above which preview_scan warns that a discovery scan will be slow
+const (
+	previewWarnFiles = 5000
+	previewWarnBytes = 100 << 20
+)
+
+// scanPreviewReport is the preview_scan result
+type scanPreviewReport struct {
+	ProjectPath string `json:"project_path"`
+	scanner.ScanPreview
+	// Warning is set when the scan would be large enough to want confirming
+	Warning string `json:"warning,omitempty"`
+}
+
+// scanPreviewWarning returns a warning when a preview is over the expected
+// size of one project, typically because the path is a home or root
+// directory, and "" otherwise
+func scanPreviewWarning(path string, preview scanner.ScanPreview) string {
+	if preview.GoFiles <= previewWarnFiles && preview.TotalBytes <= previewWarnBytes {
+		return ""
+	}
+	return fmt.Sprintf("Scanning %s would parse %d Go files (%d MB), more than the %d files or %d MB expected of one project; "+
+		"check the path, or narrow it with max_depth, before calling discover_interfaces",
+		path, preview.GoFiles, preview.TotalBytes>>20, previewWarnFiles, previewWarnBytes>>20)
+}
+
+// handlePreviewScan implements the preview_scan tool
+func (s *MockeryMCPServer) handlePreviewScan(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	projectPath, ok := args["project_path"].(string)
+	if !ok || projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid project_path", nil)
+	}
+
+	maxDepth := 0
+	if value, ok := args["max_depth"]; ok {
+		depth, ok := value.(float64)
+		if !ok || depth < 0 || depth != float64(int(depth)) {
+			return s.errorResponse(requestID, -32602, "Invalid max_depth", "must be a non-negative integer")
+		}
+		maxDepth = int(depth)
+	}
+
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", projectPath), err)
+	}
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Project path does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	preview, err := s.scanner.PreviewScan(ctx, absPath, scanner.ScanOptions{MaxDepth: maxDepth})
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return s.toolErrorResponse(requestID, "Scan preview cancelled", err)
+	}
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to preview scan", fmt.Errorf("%w: %v", ErrScanFailed, err))
+	}
+
+	report := scanPreviewReport{
+		ProjectPath: absPath,
+		ScanPreview: preview,
+		Warning:     scanPreviewWarning(absPath, preview),
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to encode scan preview", err)
+	}
+	return s.textResponse(requestID, string(data))
+}
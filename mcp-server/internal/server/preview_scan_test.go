@@ -0,0 +1,58 @@
+package server
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+)
+
+func TestMockeryMCPServer_PreviewScan(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "main.go", "package main\n")
+	writeFile(t, root, "store/store.go", "package store\n\ntype Users interface{ Get() error }\n")
+	writeFile(t, root, "store/store_test.go", "package store\n")
+	writeFile(t, root, "store/cache/cache.go", "package cache\n")
+	writeFile(t, root, "vendor/lib/lib.go", "package lib\n")
+	writeFile(t, root, "README.md", "# app\n")
+
+	s := newTestServer(t)
+	preview := func(t *testing.T, args map[string]interface{}) scanPreviewReport {
+		t.Helper()
+		var report scanPreviewReport
+		require.NoError(t, json.Unmarshal([]byte(responseText(t, callTool(t, s, "preview_scan", args))), &report))
+		return report
+	}
+
+	report := preview(t, map[string]interface{}{"project_path": root})
+	assert.Equal(t, root, report.ProjectPath)
+	// Test and vendored files are left out, as discover_interfaces leaves them
+	assert.Equal(t, 3, report.GoFiles)
+	assert.Equal(t, int64(len("package main\n")+len("package store\n\ntype Users interface{ Get() error }\n")+len("package cache\n")), report.TotalBytes)
+	assert.Equal(t, 0, report.LargeFiles)
+	assert.Empty(t, report.Warning)
+
+	report = preview(t, map[string]interface{}{"project_path": root, "max_depth": float64(2)})
+	assert.Equal(t, 2, report.GoFiles)
+
+	// Files the scanner would skip for their size are counted apart
+	s.SetMaxScanFileSize(20)
+	report = preview(t, map[string]interface{}{"project_path": root})
+	assert.Equal(t, 2, report.GoFiles)
+	assert.Equal(t, 1, report.LargeFiles)
+
+	response := callTool(t, s, "preview_scan", map[string]interface{}{"project_path": root + "/missing"})
+	requireErrorKind(t, response, CodePathNotFound, KindPathNotFound)
+}
+
+func TestScanPreviewWarning(t *testing.T) {
+	assert.Empty(t, scanPreviewWarning("/src/app", scanner.ScanPreview{GoFiles: 120, TotalBytes: 2 << 20}))
+
+	warning := scanPreviewWarning("/home/me", scanner.ScanPreview{GoFiles: 48000, TotalBytes: 900 << 20})
+	assert.True(t, strings.HasPrefix(warning, "Scanning /home/me would parse 48000 Go files (900 MB)"), warning)
+	assert.NotEmpty(t, scanPreviewWarning("/src/app", scanner.ScanPreview{GoFiles: 10, TotalBytes: previewWarnBytes + 1}))
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+)
+
+// goVersionTimeout bounds how long `go version` may take, which includes
+// downloading a toolchain when go.mod asks for a newer one
+const goVersionTimeout = 30 * time.Second
+
+// goModRequirement is one module listed in a go.mod require directive
+type goModRequirement struct {
+	Path     string `json:"path"`
+	Version  string `json:"version"`
+	Indirect bool   `json:"indirect,omitempty"`
+}
+
+// goModFile is the subset of a go.mod file project_info reports
+type goModFile struct {
+	Module    string             `json:"module"`
+	Go        string             `json:"go,omitempty"`
+	Toolchain string             `json:"toolchain,omitempty"`
+	Require   []goModRequirement `json:"require"`
+}
+
+// projectInfo is the project_info report
+type projectInfo struct {
+	ModuleRoot string `json:"module_root"`
+	goModFile
+	// GoVersion is the toolchain `go version` reports in the module root,
+	// e.g. go1.22.3, and GoVersionError why it couldn't be run
+	GoVersion      string `json:"go_version,omitempty"`
+	GoVersionError string `json:"go_version_error,omitempty"`
+}
+
+// parseGoMod reads the module, go, toolchain and require directives of a
+// go.mod file. Other directives are ignored.
+func parseGoMod(content []byte) (*goModFile, error) {
+	parsed, err := modfile.ParseLax("go.mod", content, nil)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Module == nil {
+		return nil, fmt.Errorf("go.mod: no module directive")
+	}
+
+	file := &goModFile{Module: parsed.Module.Mod.Path, Require: []goModRequirement{}}
+	if parsed.Go != nil {
+		file.Go = parsed.Go.Version
+	}
+	// ParseLax skips toolchain directives, so they are read from the syntax
+	for _, stmt := range parsed.Syntax.Stmt {
+		if line, ok := stmt.(*modfile.Line); ok && len(line.Token) == 2 && line.Token[0] == "toolchain" {
+			file.Toolchain = line.Token[1]
+		}
+	}
+	for _, require := range parsed.Require {
+		file.Require = append(file.Require, goModRequirement{
+			Path:     require.Mod.Path,
+			Version:  require.Mod.Version,
+			Indirect: require.Indirect,
+		})
+	}
+	return file, nil
+}
+
+// installedGoVersion runs `go version` in dir and returns the toolchain
+// version it reports, e.g. go1.22.3
+func installedGoVersion(ctx context.Context, dir string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, goVersionTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "go", "version")
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		var execErr *exec.Error
+		if errors.As(err, &execErr) {
+			return "", fmt.Errorf("go command not available: %w", err)
+		}
+		return "", fmt.Errorf("go version failed: %v\nOutput: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	// "go version go1.22.3 linux/amd64"
+	fields := strings.Fields(string(output))
+	if len(fields) < 3 || fields[0] != "go" || fields[1] != "version" {
+		return "", fmt.Errorf("unexpected go version output: %s", strings.TrimSpace(string(output)))
+	}
+	return fields[2], nil
+}
+
+// handleProjectInfo implements the project_info tool
+func (s *MockeryMCPServer) handleProjectInfo(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	projectPath, ok := args["project_path"].(string)
+	if !ok || projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid project_path", nil)
+	}
+
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", projectPath), err)
+	}
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Path does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	moduleRoot, _ := scanner.FindModule(absPath)
+	if moduleRoot == "" {
+		return s.toolErrorResponse(requestID, "Project is not in a Go module",
+			fmt.Errorf("%w: no go.mod in %s or any parent directory", ErrModuleNotFound, absPath))
+	}
+
+	content, err := os.ReadFile(filepath.Join(moduleRoot, "go.mod"))
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to read go.mod", err)
+	}
+	goMod, err := parseGoMod(content)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to parse go.mod", err)
+	}
+
+	info := projectInfo{ModuleRoot: moduleRoot, goModFile: *goMod}
+	// A missing or broken go command is part of the diagnosis, not a failure
+	if version, err := installedGoVersion(ctx, moduleRoot); err != nil {
+		info.GoVersionError = err.Error()
+	} else {
+		info.GoVersion = version
+	}
+
+	report, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to encode project info", err)
+	}
+	return s.textResponse(requestID, string(report))
+}
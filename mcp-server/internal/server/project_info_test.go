@@ -0,0 +1,99 @@
+package server
+
+import (
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGoMod(t *testing.T) {
+	goMod, err := parseGoMod([]byte(`// The app module
+module "example.com/app"
+
+go 1.22
+
+toolchain go1.22.3
+
+require github.com/stretchr/testify v1.9.0
+
+require (
+	go.uber.org/zap v1.27.0
+	github.com/davecgh/go-spew v1.1.1 // indirect
+)
+
+replace example.com/old => ../old
+
+exclude (
+	example.com/bad v1.0.0
+)
+`))
+	require.NoError(t, err)
+	assert.Equal(t, &goModFile{
+		Module:    "example.com/app",
+		Go:        "1.22",
+		Toolchain: "go1.22.3",
+		Require: []goModRequirement{
+			{Path: "github.com/stretchr/testify", Version: "v1.9.0"},
+			{Path: "go.uber.org/zap", Version: "v1.27.0"},
+			{Path: "github.com/davecgh/go-spew", Version: "v1.1.1", Indirect: true},
+		},
+	}, goMod)
+
+	t.Run("syntax variants", func(t *testing.T) {
+		goMod, err := parseGoMod([]byte("module \"example.com/app//v2\"\n\nrequire(\n\texample.com/dep v1.0.0 // indirect; pulled in by tools\n)\n"))
+		require.NoError(t, err)
+		assert.Equal(t, "example.com/app//v2", goMod.Module)
+		assert.Equal(t, []goModRequirement{{Path: "example.com/dep", Version: "v1.0.0", Indirect: true}}, goMod.Require)
+	})
+
+	for name, content := range map[string]string{
+		"no module":    "go 1.22\n",
+		"bad require":  "module example.com/app\n\nrequire example.com/dep\n",
+		"open block":   "module example.com/app\n\nrequire (\n\texample.com/dep v1.0.0\n",
+		"extra module": "module example.com/app extra\n",
+	} {
+		_, err := parseGoMod([]byte(content))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestMockeryMCPServer_ProjectInfo(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n\nrequire github.com/stretchr/testify v1.9.0 // indirect\n")
+	writeFile(t, root, "internal/store/store.go", "package store\n")
+	s := newTestServer(t)
+
+	text := responseText(t, callTool(t, s, "project_info", map[string]interface{}{
+		"project_path": filepath.Join(root, "internal", "store"),
+	}))
+
+	var info struct {
+		ModuleRoot     string             `json:"module_root"`
+		Module         string             `json:"module"`
+		Go             string             `json:"go"`
+		Require        []goModRequirement `json:"require"`
+		GoVersion      string             `json:"go_version"`
+		GoVersionError string             `json:"go_version_error"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(text), &info))
+	assert.Equal(t, root, info.ModuleRoot)
+	assert.Equal(t, "example.com/app", info.Module)
+	assert.Equal(t, "1.22", info.Go)
+	assert.Equal(t, []goModRequirement{{Path: "github.com/stretchr/testify", Version: "v1.9.0", Indirect: true}}, info.Require)
+
+	if _, err := exec.LookPath("go"); err != nil {
+		assert.Contains(t, info.GoVersionError, "go command not available")
+	} else {
+		assert.True(t, strings.HasPrefix(info.GoVersion, "go"), info.GoVersion)
+	}
+
+	t.Run("outside a module", func(t *testing.T) {
+		response := callTool(t, s, "project_info", map[string]interface{}{"project_path": t.TempDir()})
+		requireErrorKind(t, response, CodeModuleNotFound, KindModuleNotFound)
+	})
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/models"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// projectSummary is a project as reported by list_projects
+type projectSummary struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Path           string    `json:"path"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	InterfaceCount int       `json:"interface_count"`
+	MockCount      int       `json:"mock_count"`
+}
+
+// projectDetails is a project as reported by get_project, with its
+// registered interfaces and the mocks generated for it
+type projectDetails struct {
+	projectSummary
+	Config     types.MockeryConfig         `json:"config"`
+	Interfaces []types.InterfaceDefinition `json:"interfaces"`
+	Mocks      []*models.GeneratedMock     `json:"mocks"`
+}
+
+// projectMocks returns the mocks recorded for a project, sorted by file
+func (s *MockeryMCPServer) projectMocks(projectID string) []*models.GeneratedMock {
+	mocks := s.projectManager.GetGeneratedMocks(projectID)
+	sort.Slice(mocks, func(i, j int) bool {
+		return mocks[i].FilePath < mocks[j].FilePath
+	})
+	if mocks == nil {
+		mocks = []*models.GeneratedMock{}
+	}
+	return mocks
+}
+
+// summarizeProject builds the list_projects entry for a project
+func (s *MockeryMCPServer) summarizeProject(project *models.MockeryProject) projectSummary {
+	return projectSummary{
+		ID:             project.ID,
+		Name:           project.Name,
+		Path:           project.Path,
+		CreatedAt:      project.CreatedAt,
+		UpdatedAt:      project.UpdatedAt,
+		InterfaceCount: len(project.Interfaces),
+		MockCount:      len(s.projectManager.GetGeneratedMocks(project.ID)),
+	}
+}
+
+// handleCreateProject implements the create_project tool
+func (s *MockeryMCPServer) handleCreateProject(requestID interface{}, args map[string]interface{}) *MCPResponse {
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid name", nil)
+	}
+	path, ok := args["path"].(string)
+	if !ok || path == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid path", nil)
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", path), err)
+	}
+	info, err := os.Stat(absPath)
+	if err != nil || !info.IsDir() {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Project path does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	project := s.projectManager.CreateProject(name, absPath)
+
+	text := fmt.Sprintf("Project created:\n- ID: %s\n- Name: %s\n- Path: %s\n\nPass the ID as project_id to generate_mock to associate mocks with the project.",
+		project.ID, project.Name, project.Path)
+	return s.textResponse(requestID, text)
+}
+
+// handleGetProject implements the get_project tool
+func (s *MockeryMCPServer) handleGetProject(requestID interface{}, args map[string]interface{}) *MCPResponse {
+	projectID, ok := args["project_id"].(string)
+	if !ok || projectID == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid project_id", nil)
+	}
+
+	project, exists := s.projectManager.GetProject(projectID)
+	if !exists {
+		return s.toolErrorResponse(requestID, "Unknown project_id", fmt.Errorf("%w: %s", ErrProjectNotFound, projectID))
+	}
+
+	mocks := s.projectMocks(project.ID)
+	details := projectDetails{
+		projectSummary: s.summarizeProject(project),
+		Config:         project.Config,
+		Interfaces:     project.Interfaces,
+		Mocks:          mocks,
+	}
+	details.MockCount = len(mocks)
+	if details.Interfaces == nil {
+		details.Interfaces = []types.InterfaceDefinition{}
+	}
+
+	data, err := json.MarshalIndent(details, "", "  ")
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to encode project", err)
+	}
+	return s.textResponse(requestID, string(data))
+}
+
+// handleListProjects implements the list_projects tool
+func (s *MockeryMCPServer) handleListProjects(requestID interface{}, args map[string]interface{}) *MCPResponse {
+	projects := s.projectManager.ListProjects()
+	summaries := make([]projectSummary, 0, len(projects))
+	for _, project := range projects {
+		summaries = append(summaries, s.summarizeProject(project))
+	}
+
+	data, err := json.MarshalIndent(summaries, "", "  ")
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to encode projects", err)
+	}
+	return s.textResponse(requestID, string(data))
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_Projects(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype UserRepository interface{ Get() error }\n")
+
+	s := newTestServer(t)
+	useFakeMockery(t, s, fakeMockeryScript)
+
+	text := responseText(t, callTool(t, s, "create_project", map[string]interface{}{
+		"name": "repo",
+		"path": dir,
+	}))
+	match := regexp.MustCompile(`- ID: (\S+)`).FindStringSubmatch(text)
+	require.Len(t, match, 2, text)
+	projectID := match[1]
+
+	callTool(t, s, "generate_mock", map[string]interface{}{
+		"interface_name": "UserRepository",
+		"package_path":   dir,
+		"output_dir":     filepath.Join(t.TempDir(), "mocks"),
+		"project_id":     projectID,
+	})
+
+	mocks := s.projectManager.GetGeneratedMocks(projectID)
+	require.Len(t, mocks, 1)
+	assert.Equal(t, projectID, mocks[0].Request.ProjectID)
+	assert.Empty(t, s.projectManager.GetGeneratedMocks(""))
+
+	var details projectDetails
+	require.NoError(t, json.Unmarshal([]byte(responseText(t, callTool(t, s, "get_project", map[string]interface{}{
+		"project_id": projectID,
+	}))), &details))
+	assert.Equal(t, "repo", details.Name)
+	assert.Equal(t, dir, details.Path)
+	assert.Equal(t, 1, details.MockCount)
+	assert.Equal(t, 0, details.InterfaceCount)
+	assert.NotNil(t, details.Interfaces)
+	assert.False(t, details.UpdatedAt.IsZero())
+	require.Len(t, details.Mocks, 1)
+	assert.Equal(t, "UserRepository", details.Mocks[0].InterfaceName)
+
+	var summaries []projectSummary
+	require.NoError(t, json.Unmarshal([]byte(responseText(t, callTool(t, s, "list_projects", map[string]interface{}{}))), &summaries))
+	require.Len(t, summaries, 1)
+	assert.Equal(t, projectID, summaries[0].ID)
+	assert.Equal(t, 1, summaries[0].MockCount)
+
+	t.Run("unknown project", func(t *testing.T) {
+		requireErrorKind(t, callTool(t, s, "get_project", map[string]interface{}{
+			"project_id": "missing",
+		}), CodeProjectNotFound, KindProjectNotFound)
+
+		requireErrorKind(t, callTool(t, s, "generate_mock", map[string]interface{}{
+			"interface_name": "UserRepository",
+			"package_path":   dir,
+			"project_id":     "missing",
+		}), CodeProjectNotFound, KindProjectNotFound)
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		requireErrorKind(t, callTool(t, s, "create_project", map[string]interface{}{
+			"name": "gone",
+			"path": filepath.Join(dir, "missing"),
+		}), CodePathNotFound, KindPathNotFound)
+	})
+}
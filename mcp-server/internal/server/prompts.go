@@ -0,0 +1,220 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// Prompt represents an MCP prompt template
+type Prompt struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Arguments   []PromptArgument `json:"arguments,omitempty"`
+}
+
+// PromptArgument describes an argument a prompt template accepts
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// PromptsListResponse represents the response to prompts/list
+type PromptsListResponse struct {
+	Prompts []Prompt `json:"prompts"`
+}
+
+// promptParams are the parameters of a prompts/get request
+type promptParams struct {
+	Name      string            `json:"name"`
+	Arguments map[string]string `json:"arguments"`
+}
+
+// prompts are the templates the server offers, filled in from the scanner
+var prompts = []Prompt{
+	{
+		Name:        "table_driven_tests",
+		Description: "Write table-driven tests for code that depends on an interface, using its mockery mock",
+		Arguments: []PromptArgument{
+			{Name: "package_path", Description: "Directory of the package declaring the interface", Required: true},
+			{Name: "interface_name", Description: "Name of the interface to mock", Required: true},
+			{Name: "mock_package", Description: "Package name of the generated mocks (default: mocks)"},
+		},
+	},
+	{
+		Name:        "mock_package_interfaces",
+		Description: "Generate mocks for every interface declared in a package",
+		Arguments: []PromptArgument{
+			{Name: "package_path", Description: "Directory of the package to mock", Required: true},
+		},
+	},
+}
+
+// handlePromptsList returns the available prompt templates
+func (s *MockeryMCPServer) handlePromptsList(request *MCPRequest) *MCPResponse {
+	return &MCPResponse{
+		JSONRPC: "2.0",
+		ID:      request.ID,
+		Result:  PromptsListResponse{Prompts: prompts},
+	}
+}
+
+// handlePromptsGet renders a prompt template for the interfaces named in its
+// arguments
+func (s *MockeryMCPServer) handlePromptsGet(request *MCPRequest) *MCPResponse {
+	var params promptParams
+
+	paramsBytes, err := json.Marshal(request.Params)
+	if err != nil {
+		return s.errorResponse(request.ID, -32602, "Invalid params", err.Error())
+	}
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		return s.errorResponse(request.ID, -32602, "Invalid params", err.Error())
+	}
+
+	var prompt *Prompt
+	for i := range prompts {
+		if prompts[i].Name == params.Name {
+			prompt = &prompts[i]
+		}
+	}
+	if prompt == nil {
+		return s.errorResponse(request.ID, -32602, "Unknown prompt", params.Name)
+	}
+	for _, argument := range prompt.Arguments {
+		if argument.Required && params.Arguments[argument.Name] == "" {
+			return s.errorResponse(request.ID, -32602, "Missing or invalid "+argument.Name, nil)
+		}
+	}
+
+	packageDir, err := filepath.Abs(params.Arguments["package_path"])
+	if err != nil {
+		return s.toolErrorResponse(request.ID, "Failed to resolve path", err)
+	}
+	interfaces, err := s.packageInterfaces(packageDir)
+	if err != nil {
+		return s.toolErrorResponse(request.ID, "Failed to scan package", err)
+	}
+
+	var text string
+	switch prompt.Name {
+	case "table_driven_tests":
+		text, err = s.tableDrivenTestsPrompt(packageDir, interfaces, params.Arguments)
+	case "mock_package_interfaces":
+		text = mockPackageInterfacesPrompt(packageDir, interfaces)
+	}
+	if err != nil {
+		return s.toolErrorResponse(request.ID, "Failed to render prompt", err)
+	}
+
+	return &MCPResponse{
+		JSONRPC: "2.0",
+		ID:      request.ID,
+		Result: map[string]interface{}{
+			"description": prompt.Description,
+			"messages": []map[string]interface{}{
+				{
+					"role":    "user",
+					"content": map[string]interface{}{"type": "text", "text": text},
+				},
+			},
+		},
+	}
+}
+
+// packageInterfaces returns the interfaces declared in a package directory,
+// excluding test files
+func (s *MockeryMCPServer) packageInterfaces(packageDir string) ([]types.InterfaceDefinition, error) {
+	if info, err := os.Stat(packageDir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%w: package directory does not exist: %s", ErrPathNotFound, packageDir)
+	}
+
+	files, err := filepath.Glob(filepath.Join(packageDir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var sources []string
+	for _, file := range files {
+		if !strings.HasSuffix(file, "_test.go") {
+			sources = append(sources, file)
+		}
+	}
+
+	interfaces, err := s.scanner.ScanFiles(sources)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrScanFailed, err)
+	}
+	return interfaces, nil
+}
+
+// tableDrivenTestsPrompt asks for table-driven tests against an interface's
+// mock, quoting the interface and an EXPECT() scaffold for it
+func (s *MockeryMCPServer) tableDrivenTestsPrompt(packageDir string, interfaces []types.InterfaceDefinition, arguments map[string]string) (string, error) {
+	interfaceName := arguments["interface_name"]
+	mockPackage := arguments["mock_package"]
+	if mockPackage == "" {
+		mockPackage = "mocks"
+	}
+
+	var iface *types.InterfaceDefinition
+	for i := range interfaces {
+		if interfaces[i].Name == interfaceName {
+			iface = &interfaces[i]
+		}
+	}
+	if iface == nil {
+		return "", fmt.Errorf("%w: %s in %s", ErrInterfaceNotFound, interfaceName, packageDir)
+	}
+
+	source, err := s.scanner.ExtractInterfaceSource(iface.FilePath, iface.Name)
+	if err != nil {
+		return "", err
+	}
+	scaffold, err := expectScaffold(iface, mockPackage, iface.Name, "mock"+iface.Name, declaredTypes(packageDir))
+	if err != nil {
+		return "", err
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "Write table-driven Go tests for the code that depends on the %s interface from package %s (%s), using the mockery mock in package %s.\n\n",
+		iface.Name, iface.Package, packageDir, mockPackage)
+	fmt.Fprintf(&text, "The interface:\n\n```go\n%s\n```\n\n", source)
+	fmt.Fprintf(&text, "Give every test case a name, the inputs, the expected calls on the mock and the expected result. "+
+		"In each case create the mock with %s.New%s(t), which asserts its expectations when the test ends, "+
+		"set up only the calls that case makes, and run the cases with t.Run. "+
+		"Cover both successful and error returns of every method the code under test calls.\n\n",
+		mockPackage, iface.Name)
+	fmt.Fprintf(&text, "Expectations can start from this scaffold, replacing the matchers and return values:\n\n```go\n%s```\n\n", scaffold)
+	fmt.Fprintf(&text, "If the mock doesn't exist yet, generate it first with the generate_mock tool "+
+		"(interface_name: %s, package_path: %s).", iface.Name, packageDir)
+	return text.String(), nil
+}
+
+// mockPackageInterfacesPrompt asks for a mock of every interface in a package
+func mockPackageInterfacesPrompt(packageDir string, interfaces []types.InterfaceDefinition) string {
+	names := make([]string, 0, len(interfaces))
+	for _, iface := range interfaces {
+		names = append(names, iface.Name)
+	}
+	sort.Strings(names)
+
+	var text strings.Builder
+	if len(names) == 0 {
+		fmt.Fprintf(&text, "The package in %s declares no interfaces, so there is nothing to mock.", packageDir)
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "Generate mockery mocks for the %d interfaces declared in %s:\n", len(names), packageDir)
+	for _, name := range names {
+		fmt.Fprintf(&text, "\n- %s", name)
+	}
+	fmt.Fprintf(&text, "\n\nCall the generate_mock tool once per interface with package_path %s and with_expecter enabled, "+
+		"then report the generated files and any failures.", packageDir)
+	return text.String()
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getPrompt sends a prompts/get request
+func getPrompt(t *testing.T, s *MockeryMCPServer, name string, arguments map[string]string) *MCPResponse {
+	t.Helper()
+	return s.handleMCPRequest(context.Background(), &MCPRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "prompts/get",
+		Params:  map[string]interface{}{"name": name, "arguments": arguments},
+	})
+}
+
+// promptText returns the text of a prompt's single user message
+func promptText(t *testing.T, response *MCPResponse) string {
+	t.Helper()
+	require.Nil(t, response.Error)
+	messages := response.Result.(map[string]interface{})["messages"].([]map[string]interface{})
+	require.Len(t, messages, 1)
+	assert.Equal(t, "user", messages[0]["role"])
+	return messages[0]["content"].(map[string]interface{})["text"].(string)
+}
+
+func TestMockeryMCPServer_Prompts(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", `package domain
+
+import "context"
+
+// UserRepository loads users
+type UserRepository interface {
+	Get(ctx context.Context, id string) (*User, error)
+}
+
+type User struct{}
+
+type Clock interface{ Now() int64 }
+`)
+	writeFile(t, dir, "repo_test.go", "package domain\n\ntype fakeStore interface{ Load() }\n")
+
+	s := newTestServer(t)
+
+	t.Run("capability advertised", func(t *testing.T) {
+		response := s.handleMCPRequest(context.Background(), &MCPRequest{JSONRPC: "2.0", ID: 1, Method: "initialize"})
+		capabilities := response.Result.(map[string]interface{})["capabilities"].(map[string]interface{})
+		assert.Contains(t, capabilities, "prompts")
+	})
+
+	t.Run("list", func(t *testing.T) {
+		response := s.handleMCPRequest(context.Background(), &MCPRequest{JSONRPC: "2.0", ID: 1, Method: "prompts/list"})
+		require.Nil(t, response.Error)
+
+		list := response.Result.(PromptsListResponse)
+		var names []string
+		for _, prompt := range list.Prompts {
+			names = append(names, prompt.Name)
+		}
+		assert.Equal(t, []string{"table_driven_tests", "mock_package_interfaces"}, names)
+	})
+
+	t.Run("table driven tests", func(t *testing.T) {
+		text := promptText(t, getPrompt(t, s, "table_driven_tests", map[string]string{
+			"package_path":   dir,
+			"interface_name": "UserRepository",
+			"mock_package":   "domainmocks",
+		}))
+		assert.Contains(t, text, "UserRepository interface from package domain")
+		assert.Contains(t, text, "// UserRepository loads users\ntype UserRepository interface {\n\tGet(ctx context.Context, id string) (*User, error)\n}")
+		assert.Contains(t, text, "domainmocks.NewUserRepository(t)")
+		assert.Contains(t, text, `mockUserRepository.EXPECT().Get(mock.Anything, mock.AnythingOfType("string")).Return(nil, nil)`)
+	})
+
+	t.Run("mock package interfaces", func(t *testing.T) {
+		text := promptText(t, getPrompt(t, s, "mock_package_interfaces", map[string]string{"package_path": dir}))
+		assert.Contains(t, text, "Generate mockery mocks for the 2 interfaces")
+		assert.Contains(t, text, "\n- Clock\n- UserRepository\n")
+		assert.NotContains(t, text, "fakeStore")
+	})
+
+	t.Run("unknown interface", func(t *testing.T) {
+		response := getPrompt(t, s, "table_driven_tests", map[string]string{"package_path": dir, "interface_name": "Missing"})
+		requireErrorKind(t, response, CodeInterfaceNotFound, KindInterfaceNotFound)
+	})
+
+	t.Run("missing argument", func(t *testing.T) {
+		response := getPrompt(t, s, "table_driven_tests", map[string]string{"package_path": dir})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, -32602, response.Error.Code)
+	})
+
+	t.Run("unknown prompt", func(t *testing.T) {
+		response := getPrompt(t, s, "write_docs", nil)
+		require.NotNil(t, response.Error)
+		assert.Equal(t, -32602, response.Error.Code)
+	})
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultToolCallBurst is how many tool calls a connection may make at once
+// before the rate limit applies
+const DefaultToolCallBurst = 10
+
+// tokenBucket is a token-bucket rate limiter: it holds up to burst tokens,
+// refilled at rate tokens per second, and each call takes one
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// newTokenBucket returns a full bucket
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// take removes a token if one is available. Otherwise it reports how long
+// until the next token is.
+func (b *tokenBucket) take() (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	return false, wait
+}
+
+// SetToolCallRateLimit limits each connection to rate tool calls per second,
+// allowing bursts of up to burst calls. A rate of zero or less removes the
+// limit. It must be called before the server starts handling requests.
+func (s *MockeryMCPServer) SetToolCallRateLimit(rate float64, burst int) error {
+	if rate > 0 && burst < 1 {
+		return fmt.Errorf("rate limit burst must be at least 1, got %d", burst)
+	}
+	s.toolCallRate = rate
+	s.toolCallBurst = burst
+	return nil
+}
+
+// checkRateLimit takes a token from the connection's limiter, returning an
+// error response telling the client when to retry if none is left
+func (s *MockeryMCPServer) checkRateLimit(ctx context.Context, requestID interface{}, tool string) *MCPResponse {
+	limiter := connectionFrom(ctx).limiter
+	if limiter == nil {
+		return nil
+	}
+	allowed, wait := limiter.take()
+	if allowed {
+		return nil
+	}
+
+	s.requestLogger(ctx).Warn("Tool call rate limited", zap.String("tool", tool), zap.Duration("retry_after", wait))
+	retryAfter := wait.Round(time.Millisecond)
+	return s.errorResponse(requestID, CodeRateLimited, "Rate limited", ErrorData{
+		Kind:         KindRateLimited,
+		Detail:       fmt.Sprintf("%v: more than %g tool calls per second; retry after %s", ErrRateLimited, s.toolCallRate, retryAfter),
+		RetryAfterMS: max(retryAfter.Milliseconds(), 1),
+	})
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Unix(0, 0)
+	bucket := newTokenBucket(2, 3)
+	bucket.last = now
+	bucket.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		ok, _ := bucket.take()
+		assert.True(t, ok, "call %d within the burst", i)
+	}
+	ok, wait := bucket.take()
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	now = now.Add(250 * time.Millisecond)
+	ok, wait = bucket.take()
+	assert.False(t, ok)
+	assert.Equal(t, 250*time.Millisecond, wait)
+
+	now = now.Add(250 * time.Millisecond)
+	ok, _ = bucket.take()
+	assert.True(t, ok)
+
+	// Idle time refills no more than the burst
+	now = now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		ok, _ := bucket.take()
+		assert.True(t, ok)
+	}
+	ok, _ = bucket.take()
+	assert.False(t, ok)
+}
+
+func TestMockeryMCPServer_ToolCallRateLimit(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype Repo interface{ Get() error }\n")
+	call := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"discover_interfaces","arguments":{"project_path":"` + dir + `"}}}` + "\n"
+	ping := `{"jsonrpc":"2.0","id":2,"method":"ping"}` + "\n"
+
+	s := newTestServer(t)
+	require.Error(t, s.SetToolCallRateLimit(1, 0))
+	require.NoError(t, s.SetToolCallRateLimit(0.001, 2))
+
+	serve := func() []MCPResponse {
+		var out strings.Builder
+		in := strings.NewReader(call + call + ping + call + ping)
+		require.NoError(t, s.ServeStdio(in, &out))
+
+		var responses []MCPResponse
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			var response MCPResponse
+			require.NoError(t, json.Unmarshal([]byte(line), &response))
+			responses = append(responses, response)
+		}
+		require.Len(t, responses, 5)
+		return responses
+	}
+
+	responses := serve()
+	assert.Nil(t, responses[0].Error)
+	assert.Nil(t, responses[1].Error)
+	assert.Nil(t, responses[2].Error, "ping is not rate limited")
+	assert.Nil(t, responses[4].Error, "ping is not rate limited")
+
+	throttled := responses[3].Error
+	require.NotNil(t, throttled)
+	assert.Equal(t, CodeRateLimited, throttled.Code)
+	assert.Equal(t, "Rate limited", throttled.Message)
+	data, ok := throttled.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, string(KindRateLimited), data["kind"])
+	assert.Greater(t, data["retry_after_ms"].(float64), float64(0))
+
+	// Each connection has its own budget
+	responses = serve()
+	assert.Nil(t, responses[0].Error)
+	assert.NotNil(t, responses[3].Error)
+
+	// Calls made without a connection aren't limited
+	for i := 0; i < 3; i++ {
+		responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{"project_path": dir}))
+	}
+}
+
+func TestMockeryMCPServer_WebSocketRateLimit(t *testing.T) {
+	s := newTestServer(t)
+	require.NoError(t, s.SetToolCallRateLimit(0.001, 1))
+	conn := dialTestServer(t, s)
+
+	call := MCPRequest{JSONRPC: "2.0", ID: 1, Method: "tools/call", Params: map[string]interface{}{
+		"name":      "discover_interfaces",
+		"arguments": map[string]interface{}{"project_path": t.TempDir()},
+	}}
+	for i, wantLimited := range []bool{false, true} {
+		require.NoError(t, conn.WriteJSON(call))
+		var response MCPResponse
+		require.NoError(t, conn.ReadJSON(&response))
+		if wantLimited {
+			require.NotNil(t, response.Error, "call %d", i)
+			assert.Equal(t, CodeRateLimited, response.Error.Code)
+		} else {
+			assert.Nil(t, response.Error, "call %d", i)
+		}
+	}
+}
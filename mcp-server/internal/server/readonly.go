@@ -0,0 +1,53 @@
+package server
+
+// mutatingTools are the tools that write files, run project code or change
+// server state, which read-only mode disables
+var mutatingTools = map[string]bool{
+	"generate_mock":            true,
+	"update_mockery_config":    true,
+	"init_project":             true,
+	"generate_from_config":     true,
+	"format_file":              true,
+	"run_tests":                true, // Tests are arbitrary code and may write files
+	"scaffold_layout":          true,
+	"export_interfaces":        true,
+	"regenerate_mocks":         true,
+	"reload_config":            true,
+	"create_project":           true,
+	"generate_mock_if_changed": true,
+	"generate_func_mock":       true,
+	"clean_mocks":              true,
+	"apply_mock_to_test":       true,
+}
+
+// SetReadOnly enables or disables read-only mode. In read-only mode mutating
+// tools are left out of tools/list and calls to them are rejected, so
+// untrusted clients can browse interfaces without changing anything.
+func (s *MockeryMCPServer) SetReadOnly(readOnly bool) {
+	s.readOnly.Store(readOnly)
+}
+
+// SetReloadConfigEnabled offers or withdraws the reload_config tool. It is
+// off by default, since it lets any client change the server's settings.
+func (s *MockeryMCPServer) SetReloadConfigEnabled(enabled bool) {
+	s.reloadConfigEnabled.Store(enabled)
+}
+
+// toolEnabled reports whether the server accepts calls to a tool
+func (s *MockeryMCPServer) toolEnabled(name string) bool {
+	if name == "reload_config" && !s.reloadConfigEnabled.Load() {
+		return false
+	}
+	return !s.readOnly.Load() || !mutatingTools[name]
+}
+
+// availableTools filters tools down to those the server accepts calls for
+func (s *MockeryMCPServer) availableTools(tools []Tool) []Tool {
+	available := make([]Tool, 0, len(tools))
+	for _, tool := range tools {
+		if s.toolEnabled(tool.Name) {
+			available = append(available, tool)
+		}
+	}
+	return available
+}
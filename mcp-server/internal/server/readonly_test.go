@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listToolNames returns the names of the tools the server lists
+func listToolNames(t *testing.T, s *MockeryMCPServer) []string {
+	t.Helper()
+	response := s.handleMCPRequest(context.Background(), &MCPRequest{JSONRPC: "2.0", ID: 1, Method: "tools/list"})
+	require.Nil(t, response.Error)
+	result, ok := response.Result.(ToolsListResponse)
+	require.True(t, ok)
+
+	names := make([]string, 0, len(result.Tools))
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestMockeryMCPServer_ReadOnly(t *testing.T) {
+	s := newTestServer(t)
+	s.SetReloadConfigEnabled(true)
+	all := listToolNames(t, s)
+	for name := range mutatingTools {
+		assert.Contains(t, all, name, "mutating tool %s is not a listed tool", name)
+	}
+
+	s.SetReadOnly(true)
+
+	t.Run("hides mutating tools", func(t *testing.T) {
+		names := listToolNames(t, s)
+		assert.Len(t, names, len(all)-len(mutatingTools))
+		for name := range mutatingTools {
+			assert.NotContains(t, names, name)
+		}
+		assert.Contains(t, names, "discover_interfaces")
+		assert.Contains(t, names, "get_interface_source")
+	})
+
+	t.Run("rejects mutating tools", func(t *testing.T) {
+		dir := t.TempDir()
+		for name := range mutatingTools {
+			response := callTool(t, s, name, map[string]interface{}{
+				"project_path": dir,
+				"package_name": "storage",
+			})
+			requireErrorKind(t, response, CodeReadOnly, KindReadOnly)
+		}
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("keeps read-only tools", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "repo.go", "package repo\n\ntype Repo interface {\n\tGet() error\n}\n")
+
+		text := responseText(t, callTool(t, s, "discover_interfaces", map[string]interface{}{"project_path": dir}))
+		assert.Contains(t, text, "Repo")
+
+		response := s.handleMCPRequest(context.Background(), &MCPRequest{JSONRPC: "2.0", ID: 2, Method: "ping"})
+		assert.Nil(t, response.Error)
+	})
+
+	t.Run("can be turned off", func(t *testing.T) {
+		s.SetReadOnly(false)
+		assert.ElementsMatch(t, all, listToolNames(t, s))
+
+		dir := t.TempDir()
+		writeFile(t, dir, "go.mod", "module example.com/app\n\ngo 1.22\n")
+		responseText(t, callTool(t, s, "scaffold_layout", map[string]interface{}{
+			"project_path": dir,
+			"package_name": "storage",
+		}))
+		assert.FileExists(t, filepath.Join(dir, "Makefile"))
+	})
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// GenerateMocksRecursive generates mocks for every interface beneath the
+// request's package path. Each interface is generated from its own package
+// directory so output directories are computed per subpackage, and the
+// interfaces of a package share one mockery run. Failures for individual
+// interfaces are reported in the results rather than aborting. With
+// CombinedFile set, the mocks are merged into that one file.
+func (s *MockeryMCPServer) GenerateMocksRecursive(ctx context.Context, request *types.MockGenerationRequest) ([]*types.MockGenerationResult, error) {
+	rootPath, err := filepath.Abs(request.PackagePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve package path: %w", err)
+	}
+
+	info, err := os.Stat(rootPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: package path does not exist: %s", ErrPathNotFound, rootPath)
+		}
+		return nil, fmt.Errorf("failed to stat package path: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("package path %s is a file, but recursive generation expects a directory", rootPath)
+	}
+	if request.CombinedFile != "" {
+		return s.generateMocksCombined(ctx, request, rootPath)
+	}
+
+	interfaces, _, err := s.scanner.ScanProjectContext(ctx, rootPath, scanner.ScanOptions{})
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to scan package tree: %v", ErrScanFailed, err)
+	}
+
+	// Generate in a stable order so results are reproducible
+	sort.SliceStable(interfaces, func(i, j int) bool {
+		if interfaces[i].FilePath != interfaces[j].FilePath {
+			return interfaces[i].FilePath < interfaces[j].FilePath
+		}
+		return interfaces[i].LineNumber < interfaces[j].LineNumber
+	})
+
+	var requests []*types.MockGenerationRequest
+	for _, iface := range interfaces {
+		if request.InterfaceName != "" && iface.Name != request.InterfaceName {
+			continue
+		}
+
+		packageDir := filepath.Dir(iface.FilePath)
+
+		subRequest := *request
+		subRequest.Recursive = false
+		subRequest.InterfaceName = iface.Name
+		subRequest.PackagePath = packageDir
+
+		// Mirror the package tree under an explicit output directory; with no
+		// output directory the per-package default applies
+		if request.OutputDir != "" {
+			relDir, err := filepath.Rel(rootPath, packageDir)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compute relative package path: %w", err)
+			}
+			subRequest.OutputDir = filepath.Join(request.OutputDir, relDir)
+		}
+		requests = append(requests, &subRequest)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Interfaces sharing a package are generated in one mockery run
+	results := s.GenerateMockBatch(ctx, requests)
+	for i, result := range results {
+		if !result.Success {
+			s.requestLogger(ctx).Warn("Recursive mock generation failed",
+				zap.String("interface", result.InterfaceName),
+				zap.String("package", requests[i].PackagePath),
+				zap.String("error", result.ErrorMessage),
+			)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+// handleGenerateMocksRecursive implements generate_mock with recursive set
+func (s *MockeryMCPServer) handleGenerateMocksRecursive(ctx context.Context, requestID interface{}, request *types.MockGenerationRequest) *MCPResponse {
+	results, err := s.GenerateMocksRecursive(ctx, request)
+	if err != nil {
+		s.requestLogger(ctx).Error("Recursive mock generation failed", zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to generate mocks", err)
+	}
+
+	var generated, failed []string
+	for _, result := range results {
+		if result.Success {
+			generated = append(generated, fmt.Sprintf("- %s: %s", result.InterfaceName, result.GeneratedFile))
+		} else {
+			failed = append(failed, fmt.Sprintf("- %s: %s", result.InterfaceName, result.ErrorMessage))
+		}
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("Generated %d of %d mocks under %s", len(generated), len(results), request.PackagePath))
+	if len(generated) > 0 {
+		text.WriteString(":\n" + strings.Join(generated, "\n"))
+	}
+	if len(failed) > 0 {
+		text.WriteString("\n\nFailed:\n" + strings.Join(failed, "\n"))
+	}
+
+	return s.textResponse(requestID, text.String())
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+func TestMockeryMCPServer_GenerateMocksRecursive(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "user/repo.go", "package user\n\ntype Repository interface {\n\tGet(id string) error\n}\n")
+	writeFile(t, root, "billing/invoice/service.go", "package invoice\n\ntype Service interface {\n\tSend() error\n}\n\ntype Store interface {\n\tSave() error\n}\n")
+
+	t.Run("default output dir per subpackage", func(t *testing.T) {
+		s := newTestServer(t)
+		useFakeMockery(t, s, fakeMockeryScript)
+
+		results, err := s.GenerateMocksRecursive(context.Background(), &types.MockGenerationRequest{
+			PackagePath: root,
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		files := map[string]string{}
+		for _, result := range results {
+			assert.True(t, result.Success, result.ErrorMessage)
+			files[result.InterfaceName] = result.GeneratedFile
+		}
+		assert.Equal(t, filepath.Join(root, "user", "mocks", "mock_repository.go"), files["Repository"])
+		assert.Equal(t, filepath.Join(root, "billing", "invoice", "mocks", "mock_service.go"), files["Service"])
+		assert.Equal(t, filepath.Join(root, "billing", "invoice", "mocks", "mock_store.go"), files["Store"])
+		assert.FileExists(t, files["Service"])
+	})
+
+	t.Run("explicit output dir mirrors package tree", func(t *testing.T) {
+		s := newTestServer(t)
+		useFakeMockery(t, s, fakeMockeryScript)
+		outputDir := filepath.Join(t.TempDir(), "mocks")
+
+		results, err := s.GenerateMocksRecursive(context.Background(), &types.MockGenerationRequest{
+			PackagePath:   root,
+			OutputDir:     outputDir,
+			InterfaceName: "Repository",
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, filepath.Join(outputDir, "user", "mock_repository.go"), results[0].GeneratedFile)
+		assert.FileExists(t, results[0].GeneratedFile)
+	})
+
+	t.Run("tool reports each generated file", func(t *testing.T) {
+		s := newTestServer(t)
+		useFakeMockery(t, s, fakeMockeryScript)
+
+		response := callTool(t, s, "generate_mock", map[string]interface{}{
+			"package_path": root,
+			"recursive":    true,
+			"output_dir":   filepath.Join(t.TempDir(), "mocks"),
+		})
+
+		text := responseText(t, response)
+		assert.Contains(t, text, "Generated 3 of 3 mocks")
+		assert.Contains(t, text, "Repository: ")
+		assert.Contains(t, text, "Service: ")
+		assert.Contains(t, text, "Store: ")
+	})
+
+	t.Run("interface_name required without recursive", func(t *testing.T) {
+		s := newTestServer(t)
+
+		response := callTool(t, s, "generate_mock", map[string]interface{}{
+			"package_path": root,
+		})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, -32602, response.Error.Code)
+	})
+}
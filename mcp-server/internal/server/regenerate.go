@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+)
+
+// RegenerationResult reports the outcome of regenerating one recorded mock
+type RegenerationResult struct {
+	MockID         string `json:"mock_id"`
+	InterfaceName  string `json:"interface_name"`
+	FilePath       string `json:"file_path"`
+	Success        bool   `json:"success"`
+	Hash           string `json:"hash,omitempty"`
+	MockeryVersion string `json:"mockery_version,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// RegenerateMocks re-runs generation for every mock recorded for a project,
+// using each mock's original request. Successful runs update the record's
+// hash, timestamp and mockery version.
+func (s *MockeryMCPServer) RegenerateMocks(ctx context.Context, projectID string) []RegenerationResult {
+	mocks := s.projectManager.GetGeneratedMocks(projectID)
+	sort.Slice(mocks, func(i, j int) bool {
+		return mocks[i].FilePath < mocks[j].FilePath
+	})
+
+	results := make([]RegenerationResult, 0, len(mocks))
+	for _, mock := range mocks {
+		entry := RegenerationResult{
+			MockID:        mock.ID,
+			InterfaceName: mock.InterfaceName,
+			FilePath:      mock.FilePath,
+		}
+
+		if err := ctx.Err(); err != nil {
+			entry.Error = err.Error()
+			results = append(results, entry)
+			continue
+		}
+
+		request := mock.Request
+		if _, err := s.GenerateMock(ctx, &request); err != nil {
+			s.requestLogger(ctx).Warn("Failed to regenerate mock", zap.String("interface", mock.InterfaceName), zap.Error(err))
+			entry.Error = err.Error()
+			results = append(results, entry)
+			continue
+		}
+
+		entry.Success = true
+		if updated, ok := s.projectManager.FindGeneratedMock(mock.ProjectID, mock.FilePath); ok {
+			entry.MockID = updated.ID
+			entry.Hash = updated.Hash
+			entry.MockeryVersion = updated.MockeryVersion
+		}
+		results = append(results, entry)
+	}
+
+	return results
+}
+
+// handleRegenerateMocks implements the regenerate_mocks tool
+func (s *MockeryMCPServer) handleRegenerateMocks(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	projectID, _ := args["project_id"].(string)
+
+	results := s.RegenerateMocks(ctx, projectID)
+
+	succeeded := 0
+	for _, result := range results {
+		if result.Success {
+			succeeded++
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Regeneration cancelled after %d of %d mocks", succeeded, len(results)), err)
+	}
+
+	report, err := json.MarshalIndent(map[string]interface{}{
+		"project_id": projectID,
+		"total":      len(results),
+		"succeeded":  succeeded,
+		"failed":     len(results) - succeeded,
+		"results":    results,
+	}, "", "  ")
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to render regeneration report", err)
+	}
+
+	return s.textResponse(requestID, string(report))
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+func TestMockeryMCPServer_RegenerateMocks(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", "package repo\n\ntype UserRepository interface{ Get() error }\n")
+	outputDir := filepath.Join(t.TempDir(), "mocks")
+
+	s := newTestServer(t)
+	useFakeMockery(t, s, fakeMockeryScript)
+
+	result, err := s.GenerateMock(context.Background(), &types.MockGenerationRequest{
+		InterfaceName:  "UserRepository",
+		PackagePath:    dir,
+		OutputDir:      outputDir,
+		FilenameFormat: "{{.InterfaceName}}_mock.go",
+	})
+	require.NoError(t, err)
+
+	mocks := s.projectManager.GetGeneratedMocks("")
+	require.Len(t, mocks, 1)
+	original := *mocks[0]
+	assert.Equal(t, "{{.InterfaceName}}_mock.go", original.Request.FilenameFormat)
+
+	// Tamper with the mock so regeneration produces a different hash
+	require.NoError(t, os.WriteFile(result.GeneratedFile, []byte("package mocks\n"), 0644))
+	stale := original
+	stale.Hash = "stale"
+	s.projectManager.AddGeneratedMock(&stale)
+
+	response := callTool(t, s, "regenerate_mocks", map[string]interface{}{})
+
+	var report struct {
+		Total     int                  `json:"total"`
+		Succeeded int                  `json:"succeeded"`
+		Results   []RegenerationResult `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(responseText(t, response)), &report))
+	assert.Equal(t, 1, report.Total)
+	assert.Equal(t, 1, report.Succeeded)
+	require.Len(t, report.Results, 1)
+	assert.True(t, report.Results[0].Success)
+	assert.Equal(t, original.ID, report.Results[0].MockID)
+	assert.Equal(t, original.Hash, report.Results[0].Hash)
+	assert.Equal(t, "v2.53.3", report.Results[0].MockeryVersion)
+
+	// The record is updated in place rather than duplicated
+	mocks = s.projectManager.GetGeneratedMocks("")
+	require.Len(t, mocks, 1)
+	assert.Equal(t, original.Hash, mocks[0].Hash)
+
+	content, err := os.ReadFile(result.GeneratedFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "MockUserRepository")
+}
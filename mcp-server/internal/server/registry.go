@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/models"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// recordGeneratedMock registers a successful generation with the project
+// manager, replacing any earlier record for the same file
+func (s *MockeryMCPServer) recordGeneratedMock(ctx context.Context, request *types.MockGenerationRequest, result *types.MockGenerationResult) *models.GeneratedMock {
+	mock := &models.GeneratedMock{
+		ProjectID:      request.ProjectID,
+		InterfaceName:  request.InterfaceName,
+		PackagePath:    request.PackagePath,
+		FilePath:       result.GeneratedFile,
+		GeneratedAt:    result.GeneratedAt,
+		MockeryVersion: result.MockeryVersion,
+		SourceHash:     result.SourceHash,
+		Request:        *request,
+	}
+
+	if existing, ok := s.projectManager.FindGeneratedMock(mock.ProjectID, mock.FilePath); ok {
+		mock.ID = existing.ID
+	}
+
+	if hash, err := fileHash(result.GeneratedFile); err == nil {
+		mock.Hash = hash
+	} else {
+		s.requestLogger(ctx).Debug("Failed to hash generated mock", zap.String("file", result.GeneratedFile), zap.Error(err))
+	}
+
+	s.projectManager.AddGeneratedMock(mock)
+	return mock
+}
+
+// fileHash returns the hex-encoded SHA-256 of a file's content
+func fileHash(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// mockeryWaitDelay is how long a cancelled mockery run may take to release
+// its output before it is abandoned
+const mockeryWaitDelay = time.Second
+
+// permanentMockeryPatterns identify deterministic mockery failures that
+// would fail again on retry. They take precedence over transient patterns.
+var permanentMockeryPatterns = []string{
+	"unable to find",
+	"not found",
+	"syntax error",
+	"undefined:",
+}
+
+// transientMockeryPatterns identify mockery failures caused by races or
+// contention, such as module cache locking, which usually succeed on retry
+var transientMockeryPatterns = []string{
+	"resource temporarily unavailable",
+	"text file busy",
+	"acquire lock",
+	"lockedfile",
+	"i/o timeout",
+	"connection reset by peer",
+	"tls handshake timeout",
+}
+
+// SetMockeryRetry configures how often a transient mockery failure is
+// retried and the initial backoff, which doubles after each attempt.
+// Zero retries preserves the single-attempt behaviour.
+func (s *MockeryMCPServer) SetMockeryRetry(retries int, backoff time.Duration) {
+	if retries < 0 {
+		retries = 0
+	}
+	s.updateConfig(func(cfg *ServerConfig) {
+		cfg.MockeryRetries = retries
+		cfg.RetryBackoff = backoff
+	})
+}
+
+// isTransientMockeryFailure reports whether mockery output indicates a
+// failure worth retrying
+func isTransientMockeryFailure(output string) bool {
+	lower := strings.ToLower(output)
+	for _, pattern := range permanentMockeryPatterns {
+		if strings.Contains(lower, pattern) {
+			return false
+		}
+	}
+	for _, pattern := range transientMockeryPatterns {
+		if strings.Contains(lower, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// runMockery executes mockery in dir, retrying transient failures with
+// exponential backoff
+func (s *MockeryMCPServer) runMockery(ctx context.Context, cfg *runtimeConfig, dir string, args []string) ([]byte, error) {
+	logger := s.requestLogger(ctx)
+	backoff := cfg.RetryBackoff
+
+	for attempt := 0; ; attempt++ {
+		logger.Info("Executing mockery", zap.Strings("args", args), zap.Int("attempt", attempt+1))
+		cmd := exec.CommandContext(ctx, cfg.MockeryCommand, args...)
+		cmd.Dir = dir // Set working directory
+		// Don't wait on processes mockery left holding its output once it is killed
+		cmd.WaitDelay = mockeryWaitDelay
+		output, err := cmd.CombinedOutput()
+
+		logger.Debug("Mockery output", zap.String("output", string(output)))
+
+		if err == nil {
+			return output, nil
+		}
+
+		failure := fmt.Errorf("%w: mockery failed: %v\nOutput: %s", ErrGenerationFailed, err, string(output))
+		if ctx.Err() != nil || attempt >= cfg.MockeryRetries || !isTransientMockeryFailure(string(output)) {
+			return nil, failure
+		}
+
+		logger.Warn("Transient mockery failure, retrying",
+			zap.Int("attempt", attempt+1),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return nil, failure
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
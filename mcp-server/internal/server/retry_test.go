@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// flakyMockeryScript fails with a transient error on its first run, recording
+// each invocation in $COUNT_FILE, then behaves like fakeMockeryScript
+const flakyMockeryScript = `#!/bin/sh
+[ "$1" = "--version" ] && exec sh "$FAKE_MOCKERY" "$@"
+echo run >> "$COUNT_FILE"
+if [ "$(wc -l < "$COUNT_FILE")" -eq 1 ]; then
+	echo "go: could not acquire lock on module cache: resource temporarily unavailable" >&2
+	exit 1
+fi
+` + "exec sh \"$FAKE_MOCKERY\" \"$@\"\n"
+
+// brokenMockeryScript always fails with a deterministic error
+const brokenMockeryScript = `#!/bin/sh
+[ "$1" = "--version" ] && exit 1
+echo run >> "$COUNT_FILE"
+echo "Unable to find 'Missing' in any go files under this path" >&2
+exit 1
+`
+
+// countingMockery installs script as the fake mockery and returns a function
+// reporting how many times it ran
+func countingMockery(t *testing.T, s *MockeryMCPServer, script string) func() int {
+	t.Helper()
+	dir := t.TempDir()
+	countFile := filepath.Join(dir, "count")
+	fake := filepath.Join(dir, "fake-mockery")
+	require.NoError(t, os.WriteFile(fake, []byte(fakeMockeryScript), 0755))
+	t.Setenv("COUNT_FILE", countFile)
+	t.Setenv("FAKE_MOCKERY", fake)
+	useFakeMockery(t, s, script)
+
+	return func() int {
+		data, err := os.ReadFile(countFile)
+		if err != nil {
+			return 0
+		}
+		return strings.Count(string(data), "run")
+	}
+}
+
+func TestIsTransientMockeryFailure(t *testing.T) {
+	assert.True(t, isTransientMockeryFailure("go: could not acquire lock: resource temporarily unavailable"))
+	assert.True(t, isTransientMockeryFailure("fork/exec: text file busy"))
+	assert.False(t, isTransientMockeryFailure("Unable to find 'Foo' in any go files under this path"))
+	assert.False(t, isTransientMockeryFailure("interface Foo not found; resource temporarily unavailable"))
+	assert.False(t, isTransientMockeryFailure("exit status 1"))
+}
+
+func TestMockeryMCPServer_GenerateMockRetry(t *testing.T) {
+	packageDir := t.TempDir()
+	writeFile(t, packageDir, "repo.go", "package repo\n\ntype Repo interface{}\n")
+	request := func() *types.MockGenerationRequest {
+		return &types.MockGenerationRequest{
+			InterfaceName: "Repo",
+			PackagePath:   packageDir,
+			OutputDir:     t.TempDir(),
+		}
+	}
+
+	t.Run("retries transient failure", func(t *testing.T) {
+		s := newTestServer(t)
+		runs := countingMockery(t, s, flakyMockeryScript)
+		s.SetMockeryRetry(2, time.Millisecond)
+
+		result, err := s.GenerateMock(context.Background(), request())
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.FileExists(t, result.GeneratedFile)
+		assert.Equal(t, 2, runs())
+	})
+
+	t.Run("single attempt by default", func(t *testing.T) {
+		s := newTestServer(t)
+		runs := countingMockery(t, s, flakyMockeryScript)
+
+		_, err := s.GenerateMock(context.Background(), request())
+		require.ErrorIs(t, err, ErrGenerationFailed)
+		assert.Equal(t, 1, runs())
+	})
+
+	t.Run("never retries deterministic failure", func(t *testing.T) {
+		s := newTestServer(t)
+		runs := countingMockery(t, s, brokenMockeryScript)
+		s.SetMockeryRetry(3, time.Millisecond)
+
+		_, err := s.GenerateMock(context.Background(), request())
+		require.ErrorIs(t, err, ErrGenerationFailed)
+		assert.Equal(t, 1, runs())
+	})
+}
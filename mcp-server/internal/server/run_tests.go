@@ -0,0 +1,396 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// testRunTimeout bounds a run_tests invocation, on top of go test's own
+// per-binary timeout
+const testRunTimeout = 15 * time.Minute
+
+// Test outcomes reported by run_tests
+const (
+	TestPassed  = "pass"
+	TestFailed  = "fail"
+	TestSkipped = "skip"
+)
+
+// testEvent is one line of the go test -json (test2json) event stream
+type testEvent struct {
+	Time        time.Time `json:"Time"`
+	Action      string    `json:"Action"`
+	Package     string    `json:"Package"`
+	ImportPath  string    `json:"ImportPath"`
+	Test        string    `json:"Test"`
+	Elapsed     float64   `json:"Elapsed"`
+	Output      string    `json:"Output"`
+	FailedBuild string    `json:"FailedBuild"`
+}
+
+// TestCaseResult is the outcome of a single test
+type TestCaseResult struct {
+	Name    string  `json:"name"`
+	Status  string  `json:"status"`
+	Elapsed float64 `json:"elapsed"`
+	Output  string  `json:"output,omitempty"`
+}
+
+// PackageTestResult is the outcome of a package's tests. Output holds
+// package-level output such as build errors.
+type PackageTestResult struct {
+	Package string           `json:"package"`
+	Status  string           `json:"status"`
+	Elapsed float64          `json:"elapsed"`
+	Tests   []TestCaseResult `json:"tests"`
+	Output  string           `json:"output,omitempty"`
+}
+
+// TestReport summarizes a go test run
+type TestReport struct {
+	Packages []PackageTestResult `json:"packages"`
+	Passed   int                 `json:"passed"`
+	Failed   int                 `json:"failed"`
+	Skipped  int                 `json:"skipped"`
+}
+
+// parseTestEvents builds a report from a go test -json event stream. Output
+// is kept for failed tests and packages only.
+func parseTestEvents(r io.Reader) (*TestReport, error) {
+	packages := make(map[string]*PackageTestResult)
+	var order []string
+	tests := make(map[string]map[string]*TestCaseResult)
+	testOutput := make(map[string]map[string]*strings.Builder)
+	packageOutput := make(map[string]*strings.Builder)
+	buildOutput := make(map[string]*strings.Builder)
+	failedBuilds := make(map[string]string)
+
+	pkg := func(name string) *PackageTestResult {
+		if result, ok := packages[name]; ok {
+			return result
+		}
+		result := &PackageTestResult{Package: name}
+		packages[name] = result
+		order = append(order, name)
+		tests[name] = make(map[string]*TestCaseResult)
+		testOutput[name] = make(map[string]*strings.Builder)
+		packageOutput[name] = &strings.Builder{}
+		return result
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var event testEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			return nil, fmt.Errorf("invalid test event %q: %w", line, err)
+		}
+
+		// Build failures are reported against the import path being built
+		switch event.Action {
+		case "build-output":
+			if buildOutput[event.ImportPath] == nil {
+				buildOutput[event.ImportPath] = &strings.Builder{}
+			}
+			buildOutput[event.ImportPath].WriteString(event.Output)
+			continue
+		case "build-fail":
+			continue
+		}
+		if event.Package == "" {
+			continue
+		}
+
+		result := pkg(event.Package)
+		if event.Test == "" {
+			switch event.Action {
+			case "output":
+				packageOutput[event.Package].WriteString(event.Output)
+			case "pass", "fail", "skip":
+				result.Status = event.Action
+				result.Elapsed = event.Elapsed
+				failedBuilds[event.Package] = event.FailedBuild
+			}
+			continue
+		}
+
+		test, ok := tests[event.Package][event.Test]
+		if !ok {
+			test = &TestCaseResult{Name: event.Test}
+			tests[event.Package][event.Test] = test
+			testOutput[event.Package][event.Test] = &strings.Builder{}
+		}
+		switch event.Action {
+		case "output":
+			testOutput[event.Package][event.Test].WriteString(event.Output)
+		case "pass", "fail", "skip":
+			test.Status = event.Action
+			test.Elapsed = event.Elapsed
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read test events: %w", err)
+	}
+
+	report := &TestReport{Packages: []PackageTestResult{}}
+	for _, name := range order {
+		result := packages[name]
+		result.Tests = []TestCaseResult{}
+		if result.Status == TestFailed {
+			// Compiler errors come before go test's own FAIL line
+			if output := buildOutput[failedBuilds[name]]; output != nil {
+				result.Output = output.String()
+			}
+			result.Output += packageOutput[name].String()
+		}
+
+		names := make([]string, 0, len(tests[name]))
+		for testName := range tests[name] {
+			names = append(names, testName)
+		}
+		sort.Strings(names)
+
+		for _, testName := range names {
+			test := tests[name][testName]
+			switch test.Status {
+			case TestPassed:
+				report.Passed++
+			case TestSkipped:
+				report.Skipped++
+			default:
+				// A test that never finished, e.g. because of a panic or
+				// timeout, counts as failed
+				test.Status = TestFailed
+				report.Failed++
+			}
+			if test.Status == TestFailed {
+				test.Output = testOutput[name][testName].String()
+			}
+			result.Tests = append(result.Tests, *test)
+		}
+		report.Packages = append(report.Packages, *result)
+	}
+	return report, nil
+}
+
+// junitTestSuites is the root of a JUnit XML report
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+	SystemOut string          `xml:"system-out,omitempty"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *struct{}     `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Output  string `xml:",chardata"`
+}
+
+// junitReport renders a report as JUnit XML. A package that failed without
+// a failing test, such as one that doesn't build, is reported as an error.
+func junitReport(report *TestReport) (string, error) {
+	suites := junitTestSuites{Suites: []junitTestSuite{}}
+	for _, pkg := range report.Packages {
+		suite := junitTestSuite{
+			Name:  pkg.Package,
+			Tests: len(pkg.Tests),
+			Time:  fmt.Sprintf("%.3f", pkg.Elapsed),
+		}
+		for _, test := range pkg.Tests {
+			testCase := junitTestCase{
+				ClassName: pkg.Package,
+				Name:      test.Name,
+				Time:      fmt.Sprintf("%.3f", test.Elapsed),
+			}
+			switch test.Status {
+			case TestFailed:
+				suite.Failures++
+				testCase.Failure = &junitFailure{Message: "Failed", Output: test.Output}
+			case TestSkipped:
+				suite.Skipped++
+				testCase.Skipped = &struct{}{}
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+		if pkg.Status == TestFailed && suite.Failures == 0 {
+			suite.Errors = 1
+			suite.SystemOut = pkg.Output
+		}
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(data) + "\n", nil
+}
+
+// formatTestReport renders a report as a summary with the output of every
+// failure inline
+func formatTestReport(path string, report *TestReport) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "Tests in %s: %d passed, %d failed, %d skipped", path, report.Passed, report.Failed, report.Skipped)
+	for _, pkg := range report.Packages {
+		fmt.Fprintf(&text, "\n%-4s %s (%.2fs)", strings.ToUpper(pkg.Status), pkg.Package, pkg.Elapsed)
+		if pkg.Status == TestFailed && pkg.Output != "" && !hasFailedTest(pkg) {
+			fmt.Fprintf(&text, "\n%s", indent(pkg.Output))
+		}
+		for _, test := range pkg.Tests {
+			if test.Status == TestFailed {
+				fmt.Fprintf(&text, "\n  FAIL %s (%.2fs)\n%s", test.Name, test.Elapsed, indent(test.Output))
+			}
+		}
+	}
+	return text.String()
+}
+
+// hasFailedTest reports whether any of a package's tests failed
+func hasFailedTest(pkg PackageTestResult) bool {
+	for _, test := range pkg.Tests {
+		if test.Status == TestFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// indent indents every line of output by four spaces
+func indent(output string) string {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = "    " + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// runGoTests runs go test -json in dir and parses its event stream. A
+// failing run is not an error; only failing to run go test at all is.
+func runGoTests(ctx context.Context, dir string, packages []string, run string) (*TestReport, error) {
+	ctx, cancel := context.WithTimeout(ctx, testRunTimeout)
+	defer cancel()
+
+	args := []string{"test", "-json"}
+	if run != "" {
+		args = append(args, "-run="+run)
+	}
+	args = append(args, packages...)
+
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, fmt.Errorf("failed to run go test: %w", err)
+	}
+
+	report, parseErr := parseTestEvents(bytes.NewReader(output))
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	if err != nil && len(report.Packages) == 0 {
+		return nil, fmt.Errorf("go test failed: %v\nOutput: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return report, nil
+}
+
+// handleRunTests implements the run_tests tool
+func (s *MockeryMCPServer) handleRunTests(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	projectPath, ok := args["project_path"].(string)
+	if !ok || projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid project_path", nil)
+	}
+
+	packages, err := stringSliceArg(args, "packages")
+	if err != nil {
+		return s.errorResponse(requestID, -32602, "Invalid packages", err.Error())
+	}
+	if len(packages) == 0 {
+		packages = []string{"./..."}
+	}
+	for _, pkg := range packages {
+		if strings.HasPrefix(pkg, "-") {
+			return s.errorResponse(requestID, -32602, "Invalid packages", fmt.Sprintf("%s is not a package pattern", pkg))
+		}
+	}
+
+	run, _ := args["run"].(string)
+	format, _ := args["format"].(string)
+	switch format {
+	case "", "text", "json", "junit":
+	default:
+		return s.errorResponse(requestID, -32602, "Invalid format", fmt.Sprintf("unknown format %q (want text, json or junit)", format))
+	}
+
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", projectPath), err)
+	}
+
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Project path does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	report, err := runGoTests(ctx, absPath, packages, run)
+	// A run cut short reports only the packages that finished
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return s.toolErrorResponse(requestID, "Test run cancelled", ctxErr)
+	}
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to run tests", err)
+	}
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return s.toolErrorResponse(requestID, "Failed to encode test report", err)
+		}
+		return s.textResponse(requestID, string(data))
+	case "junit":
+		text, err := junitReport(report)
+		if err != nil {
+			return s.toolErrorResponse(requestID, "Failed to encode test report", err)
+		}
+		return s.textResponse(requestID, text)
+	default:
+		return s.textResponse(requestID, formatTestReport(absPath, report))
+	}
+}
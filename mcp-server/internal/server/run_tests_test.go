@@ -0,0 +1,176 @@
+package server
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordedTestEvents is go test -json output for a module with a package of
+// passing, failing and skipped tests, a package that doesn't compile and a
+// package without tests
+const recordedTestEvents = `{"Time":"2026-10-15T12:32:52.127911593Z","Action":"start","Package":"example.com/calc/add"}
+{"Time":"2026-10-15T12:32:52.12971757Z","Action":"run","Package":"example.com/calc/add","Test":"TestAdd"}
+{"Time":"2026-10-15T12:32:52.129760452Z","Action":"output","Package":"example.com/calc/add","Test":"TestAdd","Output":"=== RUN   TestAdd\n","OutputType":"frame"}
+{"Time":"2026-10-15T12:32:52.129823771Z","Action":"output","Package":"example.com/calc/add","Test":"TestAdd","Output":"--- PASS: TestAdd (0.00s)\n","OutputType":"frame"}
+{"Time":"2026-10-15T12:32:52.129836607Z","Action":"pass","Package":"example.com/calc/add","Test":"TestAdd","Elapsed":0}
+{"Time":"2026-10-15T12:32:52.129851268Z","Action":"run","Package":"example.com/calc/add","Test":"TestAddFails"}
+{"Time":"2026-10-15T12:32:52.129853749Z","Action":"output","Package":"example.com/calc/add","Test":"TestAddFails","Output":"=== RUN   TestAddFails\n","OutputType":"frame"}
+{"Time":"2026-10-15T12:32:52.129891752Z","Action":"output","Package":"example.com/calc/add","Test":"TestAddFails","Output":"    add_test.go:12: Add(2, 2) = 4, want 5\n","OutputType":"error"}
+{"Time":"2026-10-15T12:32:52.129906545Z","Action":"output","Package":"example.com/calc/add","Test":"TestAddFails","Output":"--- FAIL: TestAddFails (0.01s)\n","OutputType":"frame"}
+{"Time":"2026-10-15T12:32:52.129918188Z","Action":"fail","Package":"example.com/calc/add","Test":"TestAddFails","Elapsed":0.01}
+{"Time":"2026-10-15T12:32:52.129928035Z","Action":"run","Package":"example.com/calc/add","Test":"TestAddSkipped"}
+{"Time":"2026-10-15T12:32:52.129932016Z","Action":"output","Package":"example.com/calc/add","Test":"TestAddSkipped","Output":"=== RUN   TestAddSkipped\n","OutputType":"frame"}
+{"Time":"2026-10-15T12:32:52.130004548Z","Action":"output","Package":"example.com/calc/add","Test":"TestAddSkipped","Output":"    add_test.go:16: not implemented\n"}
+{"Time":"2026-10-15T12:32:52.130093475Z","Action":"output","Package":"example.com/calc/add","Test":"TestAddSkipped","Output":"--- SKIP: TestAddSkipped (0.00s)\n","OutputType":"frame"}
+{"Time":"2026-10-15T12:32:52.130096325Z","Action":"skip","Package":"example.com/calc/add","Test":"TestAddSkipped","Elapsed":0}
+{"Time":"2026-10-15T12:32:52.13009856Z","Action":"output","Package":"example.com/calc/add","Output":"FAIL\n","OutputType":"frame"}
+{"Time":"2026-10-15T12:32:52.130214794Z","Action":"output","Package":"example.com/calc/add","Output":"FAIL\texample.com/calc/add\t0.002s\n","OutputType":"frame"}
+{"Time":"2026-10-15T12:32:52.130222397Z","Action":"fail","Package":"example.com/calc/add","Elapsed":0.002}
+{"ImportPath":"example.com/calc/broken [example.com/calc/broken.test]","Action":"build-output","Output":"# example.com/calc/broken [example.com/calc/broken.test]\n"}
+{"ImportPath":"example.com/calc/broken [example.com/calc/broken.test]","Action":"build-output","Output":"broken/broken.go:3:28: undefined: undefined\n"}
+{"ImportPath":"example.com/calc/broken [example.com/calc/broken.test]","Action":"build-fail"}
+{"Time":"2026-10-15T12:32:52.135405587Z","Action":"start","Package":"example.com/calc/broken"}
+{"Time":"2026-10-15T12:32:52.135420624Z","Action":"output","Package":"example.com/calc/broken","Output":"FAIL\texample.com/calc/broken [build failed]\n","OutputType":"frame"}
+{"Time":"2026-10-15T12:32:52.135426859Z","Action":"fail","Package":"example.com/calc/broken","Elapsed":0,"FailedBuild":"example.com/calc/broken [example.com/calc/broken.test]"}
+{"Time":"2026-10-15T12:32:52.144763801Z","Action":"start","Package":"example.com/calc/empty"}
+{"Time":"2026-10-15T12:32:52.144790637Z","Action":"output","Package":"example.com/calc/empty","Output":"?   \texample.com/calc/empty\t[no test files]\n"}
+{"Time":"2026-10-15T12:32:52.144798861Z","Action":"skip","Package":"example.com/calc/empty","Elapsed":0}
+`
+
+func TestParseTestEvents(t *testing.T) {
+	report, err := parseTestEvents(strings.NewReader(recordedTestEvents))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, report.Passed)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, 1, report.Skipped)
+	require.Len(t, report.Packages, 3)
+
+	add := report.Packages[0]
+	assert.Equal(t, "example.com/calc/add", add.Package)
+	assert.Equal(t, TestFailed, add.Status)
+	assert.Equal(t, 0.002, add.Elapsed)
+	assert.Equal(t, []TestCaseResult{
+		{Name: "TestAdd", Status: TestPassed},
+		{Name: "TestAddFails", Status: TestFailed, Elapsed: 0.01,
+			Output: "=== RUN   TestAddFails\n    add_test.go:12: Add(2, 2) = 4, want 5\n--- FAIL: TestAddFails (0.01s)\n"},
+		{Name: "TestAddSkipped", Status: TestSkipped},
+	}, add.Tests)
+
+	broken := report.Packages[1]
+	assert.Equal(t, TestFailed, broken.Status)
+	assert.Empty(t, broken.Tests)
+	assert.Equal(t, "# example.com/calc/broken [example.com/calc/broken.test]\n"+
+		"broken/broken.go:3:28: undefined: undefined\n"+
+		"FAIL\texample.com/calc/broken [build failed]\n", broken.Output)
+
+	empty := report.Packages[2]
+	assert.Equal(t, TestSkipped, empty.Status)
+	assert.Empty(t, empty.Output)
+
+	t.Run("unfinished tests fail", func(t *testing.T) {
+		report, err := parseTestEvents(strings.NewReader(
+			`{"Action":"run","Package":"example.com/calc/add","Test":"TestHangs"}
+{"Action":"output","Package":"example.com/calc/add","Test":"TestHangs","Output":"panic: test timed out after 1s\n"}
+{"Action":"fail","Package":"example.com/calc/add","Elapsed":1}
+`))
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Failed)
+		assert.Equal(t, "panic: test timed out after 1s\n", report.Packages[0].Tests[0].Output)
+	})
+
+	t.Run("invalid events", func(t *testing.T) {
+		_, err := parseTestEvents(strings.NewReader("ok  \texample.com/calc/add\n"))
+		assert.Error(t, err)
+	})
+}
+
+func TestJUnitReport(t *testing.T) {
+	report, err := parseTestEvents(strings.NewReader(recordedTestEvents))
+	require.NoError(t, err)
+
+	text, err := junitReport(report)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(text, xml.Header))
+
+	var suites junitTestSuites
+	require.NoError(t, xml.Unmarshal([]byte(text), &suites))
+	require.Len(t, suites.Suites, 3)
+
+	add := suites.Suites[0]
+	assert.Equal(t, "example.com/calc/add", add.Name)
+	assert.Equal(t, 3, add.Tests)
+	assert.Equal(t, 1, add.Failures)
+	assert.Equal(t, 1, add.Skipped)
+	assert.Equal(t, "0.002", add.Time)
+	require.Len(t, add.TestCases, 3)
+	require.NotNil(t, add.TestCases[1].Failure)
+	assert.Contains(t, add.TestCases[1].Failure.Output, "Add(2, 2) = 4, want 5")
+	assert.NotNil(t, add.TestCases[2].Skipped)
+
+	broken := suites.Suites[1]
+	assert.Equal(t, 1, broken.Errors)
+	assert.Contains(t, broken.SystemOut, "undefined: undefined")
+}
+
+func TestMockeryMCPServer_RunTests(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not available")
+	}
+
+	dir := t.TempDir()
+	writeFile(t, dir, "go.mod", "module example.com/calc\n\ngo 1.22\n")
+	writeFile(t, dir, "add/add.go", "package add\n\nfunc Add(a, b int) int { return a + b }\n")
+	writeFile(t, dir, "add/add_test.go", `package add
+
+import "testing"
+
+func TestAdd(t *testing.T) {
+	if Add(1, 2) != 3 {
+		t.Fatal("wrong")
+	}
+}
+
+func TestAddFails(t *testing.T) {
+	t.Errorf("Add(2, 2) = %d, want 5", Add(2, 2))
+}
+`)
+
+	s := newTestServer(t)
+
+	text := responseText(t, callTool(t, s, "run_tests", map[string]interface{}{"project_path": dir}))
+	assert.Contains(t, text, "1 passed, 1 failed, 0 skipped")
+	assert.Contains(t, text, "FAIL example.com/calc/add")
+	assert.Contains(t, text, "  FAIL TestAddFails")
+	assert.Contains(t, text, "add_test.go:12: Add(2, 2) = 4, want 5")
+
+	t.Run("json", func(t *testing.T) {
+		text := responseText(t, callTool(t, s, "run_tests", map[string]interface{}{
+			"project_path": dir,
+			"run":          "^TestAdd$",
+			"format":       "json",
+		}))
+		var report TestReport
+		require.NoError(t, json.Unmarshal([]byte(text), &report))
+		assert.Equal(t, 1, report.Passed)
+		assert.Zero(t, report.Failed)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		for _, args := range []map[string]interface{}{
+			{"project_path": dir, "packages": []interface{}{"-exec=sh"}},
+			{"project_path": dir, "format": "xml"},
+			{},
+		} {
+			response := callTool(t, s, "run_tests", args)
+			require.NotNil(t, response.Error, args)
+			assert.Equal(t, -32602, response.Error.Code)
+		}
+	})
+}
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// scaffoldInterfacesDir is the directory, relative to the project, that
+// scaffold_layout creates interface packages in
+const scaffoldInterfacesDir = "internal"
+
+// scaffoldInterfaceTemplate is the starter interface file
+var scaffoldInterfaceTemplate = template.Must(template.New("interface").Parse(`package {{.Package}}
+
+import "context"
+
+// {{.Interface}} is a starter interface. Replace its methods with your own and
+// run make generate-mocks to regenerate its mock in {{.MocksDir}}/.
+type {{.Interface}} interface {
+	// Get returns the value stored under id
+	Get(ctx context.Context, id string) (string, error)
+}
+`))
+
+// scaffoldMakefileTemplate holds the mock generation targets, mirroring
+// examples/makefile
+var scaffoldMakefileTemplate = template.Must(template.New("makefile").Parse(`.PHONY: install-mockery generate-mocks clean-mocks
+
+# Install mockery if not present
+install-mockery: ## Install mockery if not present
+	@if ! command -v mockery >/dev/null 2>&1; then \
+		echo "Installing mockery..."; \
+		go install github.com/vektra/mockery/v2@latest; \
+	else \
+		echo "Mockery already installed: $$(mockery --version)"; \
+	fi
+
+# Generate all mocks
+generate-mocks: install-mockery ## Generate mocks for all interfaces
+	@echo "Generating mocks..."
+	mockery --config={{.Config}}
+	@echo "Mock generation complete. Generated files:"
+	@ls -la {{.MocksDir}}/
+
+# Clean generated mocks
+clean-mocks: ## Remove all generated mock files
+	@echo "Cleaning generated mocks..."
+	rm -rf {{.MocksDir}}/
+	@echo "Mock files cleaned."
+`))
+
+// makefileTargetPattern matches the targets scaffold_layout adds to a Makefile
+var makefileTargetPattern = regexp.MustCompile(`(?m)^(install-mockery|generate-mocks|clean-mocks)\s*:`)
+
+// scaffoldData fills the scaffold templates
+type scaffoldData struct {
+	Package   string
+	Interface string
+	MocksDir  string
+	Config    string
+}
+
+// scaffoldFile is a file scaffold_layout considered, relative to the project
+type scaffoldFile struct {
+	Path   string
+	Action string // created, replaced, updated or skipped
+	Reason string // Why a file was skipped
+}
+
+// scaffoldLayout creates the interfaces + mocks layout the examples follow:
+// a package under internal/ with a starter interface, a mocks directory, a
+// .mockery.yaml listing the interface and mock generation Makefile targets.
+// Existing files are kept unless overwrite is set; a Makefile is never
+// replaced, only given the targets it lacks.
+func (s *MockeryMCPServer) scaffoldLayout(projectPath, moduleRoot, modulePath string, data scaffoldData, overwrite bool) ([]scaffoldFile, error) {
+	packageDir := filepath.Join(scaffoldInterfacesDir, data.Package)
+	var files []scaffoldFile
+
+	// write creates a file unless it exists, reporting what happened
+	write := func(rel string, content []byte) error {
+		path := filepath.Join(projectPath, rel)
+		action := "created"
+		if _, err := os.Stat(path); err == nil {
+			if !overwrite {
+				files = append(files, scaffoldFile{Path: rel, Action: "skipped", Reason: "already exists"})
+				return nil
+			}
+			action = "replaced"
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", rel, err)
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", rel, err)
+		}
+		files = append(files, scaffoldFile{Path: rel, Action: action})
+		return nil
+	}
+
+	var source bytes.Buffer
+	if err := scaffoldInterfaceTemplate.Execute(&source, data); err != nil {
+		return nil, fmt.Errorf("failed to render interface file: %w", err)
+	}
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format interface file: %w", err)
+	}
+	if err := write(filepath.Join(packageDir, data.Package+".go"), formatted); err != nil {
+		return nil, err
+	}
+
+	mocksDir := filepath.Join(projectPath, data.MocksDir)
+	if _, err := os.Stat(mocksDir); os.IsNotExist(err) {
+		if err := s.config().makeOutputDir(mocksDir); err != nil {
+			return nil, fmt.Errorf("failed to create mocks directory: %w", err)
+		}
+		files = append(files, scaffoldFile{Path: data.MocksDir + "/", Action: "created"})
+	}
+
+	mockeryConfig := s.configManager.GetDefaultConfig()
+	mockeryConfig.Packages = make(map[string]types.Package)
+	err = s.configManager.UpdateInterfaceConfig(&mockeryConfig,
+		packagePath(filepath.Join(projectPath, packageDir), moduleRoot, modulePath),
+		data.Interface,
+		types.InterfaceSettings{
+			Dir:      data.MocksDir,
+			Filename: resolveMockFilename(&types.MockGenerationRequest{InterfaceName: data.Interface, FilenameCase: s.config().FilenameCase}),
+		})
+	if err != nil {
+		return nil, err
+	}
+	configPath := filepath.Join(projectPath, data.Config)
+	if _, err := os.Stat(configPath); err == nil && !overwrite {
+		files = append(files, scaffoldFile{Path: data.Config, Action: "skipped", Reason: "already exists"})
+	} else {
+		action := "created"
+		if err == nil {
+			action = "replaced"
+		}
+		if err := s.configManager.WriteConfigFile(&mockeryConfig, configPath); err != nil {
+			return nil, err
+		}
+		files = append(files, scaffoldFile{Path: data.Config, Action: action})
+	}
+
+	var targets bytes.Buffer
+	if err := scaffoldMakefileTemplate.Execute(&targets, data); err != nil {
+		return nil, fmt.Errorf("failed to render Makefile targets: %w", err)
+	}
+	makefilePath := filepath.Join(projectPath, "Makefile")
+	existing, err := os.ReadFile(makefilePath)
+	switch {
+	case os.IsNotExist(err):
+		if err := write("Makefile", targets.Bytes()); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to read Makefile: %w", err)
+	case makefileTargetPattern.Match(existing):
+		files = append(files, scaffoldFile{Path: "Makefile", Action: "skipped", Reason: "already has mock generation targets"})
+	default:
+		content := append(existing, '\n')
+		if !bytes.HasSuffix(existing, []byte("\n")) {
+			content = append(content, '\n')
+		}
+		content = append(content, targets.Bytes()...)
+		if err := os.WriteFile(makefilePath, content, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write Makefile: %w", err)
+		}
+		files = append(files, scaffoldFile{Path: "Makefile", Action: "updated"})
+	}
+
+	return files, nil
+}
+
+// handleScaffoldLayout implements the scaffold_layout tool
+func (s *MockeryMCPServer) handleScaffoldLayout(requestID interface{}, args map[string]interface{}) *MCPResponse {
+	projectPath, ok := args["project_path"].(string)
+	if !ok || projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid project_path", nil)
+	}
+	packageName, ok := args["package_name"].(string)
+	if !ok || !token.IsIdentifier(packageName) || strings.ToLower(packageName) != packageName {
+		return s.errorResponse(requestID, -32602, "Missing or invalid package_name", "package_name must be a lower-case Go identifier")
+	}
+
+	interfaceName := "Service"
+	if value, ok := args["interface_name"].(string); ok && value != "" {
+		if !token.IsIdentifier(value) || !token.IsExported(value) {
+			return s.errorResponse(requestID, -32602, "Invalid interface_name", "interface_name must be an exported Go identifier")
+		}
+		interfaceName = value
+	}
+	overwrite, _ := args["overwrite"].(bool)
+
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", projectPath), err)
+	}
+
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Project path does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	// Mockery configs list packages by import path, so a module is required
+	moduleRoot, modulePath := scanner.FindModule(absPath)
+	if moduleRoot == "" {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("No go.mod found in or above %s", absPath),
+			fmt.Errorf("%w: no go.mod in or above %s; run go mod init first", ErrModuleNotFound, absPath))
+	}
+
+	files, err := s.scaffoldLayout(absPath, moduleRoot, modulePath, scaffoldData{
+		Package:   packageName,
+		Interface: interfaceName,
+		MocksDir:  defaultMocksDir,
+		Config:    mockeryConfigFilename,
+	}, overwrite)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to scaffold layout", err)
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "Scaffolded %s in %s:", packageName, absPath)
+	for _, file := range files {
+		fmt.Fprintf(&text, "\n- %s: %s", file.Action, file.Path)
+		if file.Reason != "" {
+			fmt.Fprintf(&text, " (%s)", file.Reason)
+		}
+	}
+	fmt.Fprintf(&text, "\n\nRun make generate-mocks to generate the %s mock.", interfaceName)
+	return s.textResponse(requestID, text.String())
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/config"
+)
+
+func TestMockeryMCPServer_ScaffoldLayout(t *testing.T) {
+	newModule := func(t *testing.T) string {
+		root := t.TempDir()
+		writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n")
+		return root
+	}
+
+	t.Run("creates the layout", func(t *testing.T) {
+		root := newModule(t)
+		s := newTestServer(t)
+
+		text := responseText(t, callTool(t, s, "scaffold_layout", map[string]interface{}{
+			"project_path":   root,
+			"package_name":   "storage",
+			"interface_name": "BlobStore",
+		}))
+		assert.Contains(t, text, "- created: internal/storage/storage.go")
+		assert.Contains(t, text, "- created: mocks/")
+		assert.Contains(t, text, "- created: .mockery.yaml")
+		assert.Contains(t, text, "- created: Makefile")
+		assert.DirExists(t, filepath.Join(root, "mocks"))
+
+		source, err := os.ReadFile(filepath.Join(root, "internal", "storage", "storage.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(source), "package storage")
+		assert.Contains(t, string(source), "type BlobStore interface {")
+
+		interfaces, err := s.scanner.ScanProject(root)
+		require.NoError(t, err)
+		require.Len(t, interfaces, 1)
+		assert.Equal(t, "BlobStore", interfaces[0].Name)
+
+		mockeryConfig, err := config.NewMockeryConfigManager().ReadConfigFile(filepath.Join(root, ".mockery.yaml"))
+		require.NoError(t, err)
+		settings := mockeryConfig.Packages["example.com/app/internal/storage"].Interfaces["BlobStore"].Config
+		assert.Equal(t, "mocks", settings.Dir)
+		assert.Equal(t, "mock_blob_store.go", settings.Filename)
+
+		makefile, err := os.ReadFile(filepath.Join(root, "Makefile"))
+		require.NoError(t, err)
+		assert.Contains(t, string(makefile), "generate-mocks: install-mockery")
+		assert.Contains(t, string(makefile), "\tmockery --config=.mockery.yaml\n")
+		assert.Contains(t, string(makefile), "\trm -rf mocks/\n")
+
+		if _, err := exec.LookPath("make"); err == nil {
+			cmd := exec.Command("make", "-n", "clean-mocks")
+			cmd.Dir = root
+			output, err := cmd.CombinedOutput()
+			require.NoError(t, err, string(output))
+		}
+	})
+
+	t.Run("keeps existing files", func(t *testing.T) {
+		root := newModule(t)
+		writeFile(t, root, ".mockery.yaml", "with-expecter: false\n")
+		writeFile(t, root, "Makefile", "build:\n\tgo build ./...")
+		s := newTestServer(t)
+
+		text := responseText(t, callTool(t, s, "scaffold_layout", map[string]interface{}{
+			"project_path": root,
+			"package_name": "interfaces",
+		}))
+		assert.Contains(t, text, "- skipped: .mockery.yaml (already exists)")
+		assert.Contains(t, text, "- updated: Makefile")
+
+		data, err := os.ReadFile(filepath.Join(root, ".mockery.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, "with-expecter: false\n", string(data))
+
+		makefile, err := os.ReadFile(filepath.Join(root, "Makefile"))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(makefile), "build:\n\tgo build ./...\n\n.PHONY:"))
+
+		// Scaffolding again leaves everything in place
+		text = responseText(t, callTool(t, s, "scaffold_layout", map[string]interface{}{
+			"project_path": root,
+			"package_name": "interfaces",
+		}))
+		assert.Contains(t, text, "- skipped: internal/interfaces/interfaces.go (already exists)")
+		assert.Contains(t, text, "- skipped: Makefile (already has mock generation targets)")
+		assert.NotContains(t, text, "mocks/")
+
+		text = responseText(t, callTool(t, s, "scaffold_layout", map[string]interface{}{
+			"project_path": root,
+			"package_name": "interfaces",
+			"overwrite":    true,
+		}))
+		assert.Contains(t, text, "- replaced: internal/interfaces/interfaces.go")
+		assert.Contains(t, text, "- replaced: .mockery.yaml")
+		assert.Contains(t, text, "- skipped: Makefile")
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		root := newModule(t)
+		s := newTestServer(t)
+
+		for _, args := range []map[string]interface{}{
+			{"project_path": root},
+			{"project_path": root, "package_name": "Storage"},
+			{"project_path": root, "package_name": "../storage"},
+			{"project_path": root, "package_name": "storage", "interface_name": "blobStore"},
+		} {
+			response := callTool(t, s, "scaffold_layout", args)
+			require.NotNil(t, response.Error, args)
+			assert.Equal(t, -32602, response.Error.Code)
+		}
+	})
+
+	t.Run("requires a module", func(t *testing.T) {
+		s := newTestServer(t)
+		response := callTool(t, s, "scaffold_layout", map[string]interface{}{
+			"project_path": t.TempDir(),
+			"package_name": "storage",
+		})
+		requireErrorKind(t, response, CodeModuleNotFound, KindModuleNotFound)
+	})
+}
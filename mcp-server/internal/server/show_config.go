@@ -0,0 +1,84 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// mockeryConfigSummary counts what a mockery config generates mocks for
+type mockeryConfigSummary struct {
+	Packages   int `json:"packages"`
+	Interfaces int `json:"interfaces"`
+}
+
+// mockeryConfigReport is the show_mockery_config result
+type mockeryConfigReport struct {
+	ConfigPath string               `json:"config_path"`
+	Summary    mockeryConfigSummary `json:"summary"`
+	// Config is the parsed config keyed as in the file, with environment
+	// variables left as written; Error says why there is none
+	Config map[string]interface{} `json:"config,omitempty"`
+	Error  string                 `json:"error,omitempty"`
+	Raw    string                 `json:"raw"`
+}
+
+// handleShowMockeryConfig implements the show_mockery_config tool
+func (s *MockeryMCPServer) handleShowMockeryConfig(requestID interface{}, args map[string]interface{}) *MCPResponse {
+	configPath, _ := args["config_path"].(string)
+	projectPath, _ := args["project_path"].(string)
+	if configPath == "" && projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing project_path or config_path", nil)
+	}
+
+	// Having no config yet is an answer, not a failure
+	absPath, err := resolveMockeryConfigPath(projectPath, configPath)
+	if errors.Is(err, ErrPathNotFound) {
+		return s.textResponse(requestID, fmt.Sprintf("No mockery config found: %v\n\nUse init_project to create one.", err))
+	}
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Mockery config not found", err)
+	}
+
+	raw, err := os.ReadFile(absPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to read mockery config", err)
+	}
+	report := mockeryConfigReport{ConfigPath: absPath, Raw: string(raw)}
+
+	// An invalid config is still shown as written, with the reason.
+	// Environment variables aren't expanded, so the server's environment
+	// doesn't leak into the report.
+	mockeryConfig, err := s.configManager.LoadLiteralConfigFile(absPath)
+	if err == nil {
+		if err = s.configManager.ValidateConfigSyntax(mockeryConfig); err != nil {
+			err = fmt.Errorf("invalid configuration in %s: %w", absPath, err)
+		}
+	}
+	if err != nil {
+		report.Error = err.Error()
+	} else {
+		report.Summary.Packages = len(mockeryConfig.Packages)
+		for _, pkg := range mockeryConfig.Packages {
+			report.Summary.Interfaces += len(pkg.Interfaces)
+		}
+
+		// Round-trip through YAML so the JSON uses the file's keys
+		encoded, err := yaml.Marshal(mockeryConfig)
+		if err != nil {
+			return s.toolErrorResponse(requestID, "Failed to encode mockery config", err)
+		}
+		if err := yaml.Unmarshal(encoded, &report.Config); err != nil {
+			return s.toolErrorResponse(requestID, "Failed to encode mockery config", err)
+		}
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to encode mockery config", err)
+	}
+	return s.textResponse(requestID, string(data))
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_ShowMockeryConfig(t *testing.T) {
+	s := newTestServer(t)
+
+	t.Run("parsed and raw", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, ".mockery.yml", validMockeryConfig+`  example.com/app/store:
+    interfaces:
+      Cache:
+        config:
+          dir: ./mocks
+      Queue:
+        config:
+          dir: ./mocks
+`)
+
+		text := responseText(t, callTool(t, s, "show_mockery_config", map[string]interface{}{
+			"project_path": dir,
+		}))
+		var report mockeryConfigReport
+		require.NoError(t, json.Unmarshal([]byte(text), &report))
+		assert.Equal(t, filepath.Join(dir, ".mockery.yml"), report.ConfigPath)
+		assert.Equal(t, mockeryConfigSummary{Packages: 2, Interfaces: 3}, report.Summary)
+		assert.Contains(t, report.Raw, "filename: mock_{{.InterfaceName}}.go")
+		assert.Empty(t, report.Error)
+
+		// The parsed config keeps the file's keys
+		assert.Equal(t, true, report.Config["with-expecter"])
+		assert.Equal(t, "mocks", report.Config["outpkg"])
+		assert.Contains(t, report.Config["packages"], "example.com/app/store")
+	})
+
+	t.Run("environment variables are not expanded", func(t *testing.T) {
+		t.Setenv("MOCKS_SECRET_ROOT", "/home/deploy/secret")
+		dir := t.TempDir()
+		writeFile(t, dir, ".mockery.yaml", `with-expecter: true
+filename: "mock_{{.InterfaceName}}.go"
+outpkg: mocks
+packages:
+  example.com/app:
+    interfaces:
+      Repo:
+        config:
+          dir: ${MOCKS_SECRET_ROOT}/mocks
+`)
+
+		text := responseText(t, callTool(t, s, "show_mockery_config", map[string]interface{}{
+			"project_path": dir,
+		}))
+		assert.NotContains(t, text, "/home/deploy/secret")
+		assert.Contains(t, text, `"dir": "${MOCKS_SECRET_ROOT}/mocks"`)
+	})
+
+	t.Run("invalid config is shown with the error", func(t *testing.T) {
+		path := writeFile(t, t.TempDir(), "mockery.yaml", "packages:\n  example.com/app:\n    interfaces:\n      Repo:\n        config:\n          filename: mock_{{.Bad\n")
+
+		text := responseText(t, callTool(t, s, "show_mockery_config", map[string]interface{}{
+			"config_path": path,
+		}))
+		var report mockeryConfigReport
+		require.NoError(t, json.Unmarshal([]byte(text), &report))
+		assert.NotEmpty(t, report.Error)
+		assert.Nil(t, report.Config)
+		assert.Contains(t, report.Raw, "mock_{{.Bad")
+	})
+
+	t.Run("missing config", func(t *testing.T) {
+		dir := t.TempDir()
+		text := responseText(t, callTool(t, s, "show_mockery_config", map[string]interface{}{
+			"project_path": dir,
+		}))
+		assert.Contains(t, text, "No mockery config found")
+		assert.Contains(t, text, dir)
+	})
+
+	t.Run("no path", func(t *testing.T) {
+		response := callTool(t, s, "show_mockery_config", map[string]interface{}{})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, -32602, response.Error.Code)
+	})
+}
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// matcherSignatures renders an interface's methods using the matcher type
+// of every parameter and return value, e.g.
+// Save(context.Context, *domain.User) error
+func matcherSignatures(iface types.InterfaceDefinition) []string {
+	signatures := make([]string, len(iface.Methods))
+	for i, method := range iface.Methods {
+		params := matcherTypes(method.Parameters)
+		signature := method.Name + "(" + strings.Join(params, ", ") + ")"
+
+		returns := matcherTypes(method.Returns)
+		switch len(returns) {
+		case 0:
+		case 1:
+			signature += " " + returns[0]
+		default:
+			signature += " (" + strings.Join(returns, ", ") + ")"
+		}
+		signatures[i] = signature
+	}
+	return signatures
+}
+
+// matcherTypes lists the matcher types of parameters
+func matcherTypes(params []types.Parameter) []string {
+	result := make([]string, len(params))
+	for i, param := range params {
+		result[i] = matcherTypeOf(param)
+	}
+	return result
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+)
+
+// handleGetInterfaceSource implements the get_interface_source tool
+func (s *MockeryMCPServer) handleGetInterfaceSource(requestID interface{}, args map[string]interface{}) *MCPResponse {
+	filePath, ok := args["file_path"].(string)
+	if !ok || filePath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid file_path", nil)
+	}
+
+	interfaceName, ok := args["interface_name"].(string)
+	if !ok || interfaceName == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid interface_name", nil)
+	}
+
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", filePath), err)
+	}
+
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("File does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	source, err := s.scanner.ExtractInterfaceSource(absPath, interfaceName)
+	if err != nil {
+		s.logger.Error("Failed to extract interface source", zap.String("file", absPath), zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to extract interface source", err)
+	}
+
+	return s.textResponse(requestID, source)
+}
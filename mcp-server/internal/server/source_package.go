@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// mockSource describes where mockery finds the interface to mock
+type mockSource struct {
+	dir         string // Absolute package directory
+	packageName string
+	workDir     string // Directory mockery runs in
+	arg         string // --dir or --srcpkg argument for mockery
+	importPath  string // Set when resolved from an import path
+	external    bool   // Package resolved from an import path, outside the project tree
+}
+
+// resolveMockSource locates the package named by request.PackagePath, which
+// is either a local directory or an import path. Import paths, including
+// standard library and module cache packages, are resolved with go list
+// from request.ProjectPath and handed to mockery via --srcpkg.
+func resolveMockSource(ctx context.Context, request *types.MockGenerationRequest) (*mockSource, error) {
+	// Convert relative package path to absolute if needed
+	absPackagePath, err := filepath.Abs(request.PackagePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve package path: %w", err)
+	}
+
+	// Mockery's --dir expects a directory, so catch file paths early
+	info, err := os.Stat(absPackagePath)
+	if err == nil {
+		if !info.IsDir() {
+			return nil, fmt.Errorf("package path %s is a file, but mockery --dir expects a directory; use %s instead",
+				absPackagePath, filepath.Dir(absPackagePath))
+		}
+		return &mockSource{
+			dir:         absPackagePath,
+			packageName: packageName(absPackagePath),
+			workDir:     absPackagePath,
+			arg:         "--dir=" + absPackagePath,
+		}, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to stat package path: %w", err)
+	}
+	if !looksLikeImportPath(request.PackagePath) {
+		return nil, fmt.Errorf("%w: package path does not exist: %s", ErrPathNotFound, absPackagePath)
+	}
+
+	workDir := request.ProjectPath
+	if workDir == "" {
+		workDir = "."
+	}
+	absWorkDir, err := filepath.Abs(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve project path: %w", err)
+	}
+
+	pkg, err := resolvePackage(ctx, absWorkDir, request.PackagePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mockSource{
+		dir:         pkg.Dir,
+		packageName: pkg.Name,
+		workDir:     absWorkDir,
+		arg:         "--srcpkg=" + pkg.ImportPath,
+		importPath:  pkg.ImportPath,
+		external:    true,
+	}, nil
+}
+
+// looksLikeImportPath reports whether a package path that doesn't exist on
+// disk could be an import path rather than a mistyped directory
+func looksLikeImportPath(path string) bool {
+	return path != "" &&
+		!filepath.IsAbs(path) &&
+		!strings.HasPrefix(path, ".") &&
+		!strings.Contains(path, `\`)
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+func TestMockeryMCPServer_GenerateMock_ExternalPackage(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not available")
+	}
+
+	// A project depending on a separate module through a replace directive,
+	// which resolves like a module cache dependency without the network
+	workspace := t.TempDir()
+	project := filepath.Join(workspace, "app")
+	writeFile(t, workspace, "lib/go.mod", "module example.com/lib\n\ngo 1.22\n")
+	writeFile(t, workspace, "lib/store/store.go", "package store\n\ntype Store interface{ Get(key string) ([]byte, error) }\n")
+	writeFile(t, project, "go.mod", "module example.com/app\n\ngo 1.22\n\nrequire example.com/lib v0.0.0\n\nreplace example.com/lib => ../lib\n")
+	writeFile(t, project, "main.go", "package main\n\nimport _ \"example.com/lib/store\"\n\nfunc main() {}\n")
+
+	t.Run("external module", func(t *testing.T) {
+		s := newTestServer(t)
+		lastArgs := recordingMockery(t, s)
+
+		result, err := s.GenerateMock(context.Background(), &types.MockGenerationRequest{
+			InterfaceName: "Store",
+			PackagePath:   "example.com/lib/store",
+			ProjectPath:   project,
+		})
+		require.NoError(t, err)
+
+		// The mock lands in the project, not next to the dependency's source
+		assert.Equal(t, filepath.Join(project, "mocks", "store", "mock_store.go"), result.GeneratedFile)
+		assert.FileExists(t, result.GeneratedFile)
+		assert.Contains(t, lastArgs(), "--srcpkg=example.com/lib/store")
+		assert.NotContains(t, lastArgs(), "--dir="+filepath.Join(workspace, "lib", "store"))
+	})
+
+	t.Run("standard library", func(t *testing.T) {
+		s := newTestServer(t)
+		lastArgs := recordingMockery(t, s)
+		outputDir := filepath.Join(t.TempDir(), "mocks")
+
+		result, err := s.GenerateMock(context.Background(), &types.MockGenerationRequest{
+			InterfaceName: "Reader",
+			PackagePath:   "io",
+			ProjectPath:   project,
+			OutputDir:     outputDir,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(outputDir, "mock_reader.go"), result.GeneratedFile)
+		assert.Contains(t, lastArgs(), "--srcpkg=io")
+	})
+
+	t.Run("unresolvable import path", func(t *testing.T) {
+		s := newTestServer(t)
+		useFakeMockery(t, s, fakeMockeryScript)
+
+		_, err := s.GenerateMock(context.Background(), &types.MockGenerationRequest{
+			InterfaceName: "Missing",
+			PackagePath:   "example.com/nowhere/pkg",
+			ProjectPath:   project,
+		})
+		assert.ErrorIs(t, err, ErrPathNotFound)
+	})
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// interfaceStats summarizes how much work mocking an interface is
+type interfaceStats struct {
+	Name              string `json:"name"`
+	Package           string `json:"package"`
+	FilePath          string `json:"file_path"`
+	MethodCount       int    `json:"method_count"`
+	ParameterCount    int    `json:"parameter_count"`
+	ReturnCount       int    `json:"return_count"`
+	VariadicMethods   int    `json:"variadic_methods"`
+	FuncParamMethods  int    `json:"func_param_methods"`
+	Generic           bool   `json:"generic"`
+	Complexity        int    `json:"complexity"`
+	ComplexityRanking string `json:"complexity_ranking"`
+}
+
+// Complexity weights. Every method and argument becomes mock code and
+// expectations to set up; variadic and callback parameters are awkward to
+// match, and generic mocks need instantiating in every test.
+const (
+	complexityPerMethod    = 2
+	complexityPerArgument  = 1
+	complexityPerVariadic  = 3
+	complexityPerFuncParam = 3
+	complexityGeneric      = 5
+)
+
+// complexityRanking buckets a complexity score
+func complexityRanking(score int) string {
+	switch {
+	case score < 15:
+		return "low"
+	case score < 40:
+		return "medium"
+	default:
+		return "high"
+	}
+}
+
+// computeInterfaceStats aggregates an interface's method signatures
+func computeInterfaceStats(iface types.InterfaceDefinition) interfaceStats {
+	stats := interfaceStats{
+		Name:        iface.Name,
+		Package:     iface.Package,
+		FilePath:    iface.FilePath,
+		MethodCount: len(iface.Methods),
+		Generic:     len(iface.TypeParams) > 0,
+	}
+
+	for _, method := range iface.Methods {
+		stats.ParameterCount += len(method.Parameters)
+		stats.ReturnCount += len(method.Returns)
+
+		variadic, funcParam := false, false
+		for _, param := range method.Parameters {
+			if strings.HasPrefix(param.Type, "...") {
+				variadic = true
+			}
+			if strings.Contains(param.Type, "func(") {
+				funcParam = true
+			}
+		}
+		if variadic {
+			stats.VariadicMethods++
+		}
+		if funcParam {
+			stats.FuncParamMethods++
+		}
+	}
+
+	stats.Complexity = stats.MethodCount*complexityPerMethod +
+		(stats.ParameterCount+stats.ReturnCount)*complexityPerArgument +
+		stats.VariadicMethods*complexityPerVariadic +
+		stats.FuncParamMethods*complexityPerFuncParam
+	if stats.Generic {
+		stats.Complexity += complexityGeneric
+	}
+	stats.ComplexityRanking = complexityRanking(stats.Complexity)
+	return stats
+}
+
+// handleInterfaceStats implements the interface_stats tool
+func (s *MockeryMCPServer) handleInterfaceStats(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	projectPath, ok := args["project_path"].(string)
+	if !ok || projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid project_path", nil)
+	}
+
+	names, err := stringSliceArg(args, "interfaces")
+	if err != nil {
+		return s.errorResponse(requestID, -32602, "Invalid interfaces", err.Error())
+	}
+	if err := validateInterfacePatterns(names); err != nil {
+		return s.errorResponse(requestID, -32602, "Invalid interfaces", err.Error())
+	}
+
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", projectPath), err)
+	}
+
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Project path does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	interfaces, _, err := s.scanner.ScanProjectContext(ctx, absPath, scanner.ScanOptions{})
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return s.toolErrorResponse(requestID, "Scan cancelled", err)
+	}
+	if err != nil {
+		s.logger.Error("Failed to scan project", zap.String("path", absPath), zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to scan project", fmt.Errorf("%w: %v", ErrScanFailed, err))
+	}
+	interfaces = excludeInterfaces(interfaces, s.config().ExcludeInterfaces)
+
+	stats := make([]interfaceStats, 0, len(interfaces))
+	for _, iface := range interfaces {
+		if len(names) > 0 && !interfaceMatches(iface.Name, names) {
+			continue
+		}
+		stats = append(stats, computeInterfaceStats(iface))
+	}
+
+	// Most complex first, since those are the ones worth looking at
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].Complexity > stats[j].Complexity
+	})
+
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to encode interface stats", err)
+	}
+	return s.textResponse(requestID, string(data))
+}
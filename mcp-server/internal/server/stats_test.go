@@ -0,0 +1,76 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_InterfaceStats(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "bus.go", `package bus
+
+import "context"
+
+type Bus interface {
+	Publish(ctx context.Context, topic string, payloads ...[]byte) error
+	Subscribe(topic string, handler func(context.Context, []byte) error) (func(), error)
+	Close()
+}
+
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+}
+
+type Pinger interface{ Ping() error }
+`)
+
+	s := newTestServer(t)
+
+	text := responseText(t, callTool(t, s, "interface_stats", map[string]interface{}{
+		"project_path": dir,
+	}))
+
+	var stats []interfaceStats
+	require.NoError(t, json.Unmarshal([]byte(text), &stats))
+	require.Len(t, stats, 3)
+
+	// Sorted by complexity, highest first
+	bus := stats[0]
+	assert.Equal(t, "Bus", bus.Name)
+	assert.Equal(t, 3, bus.MethodCount)
+	assert.Equal(t, 5, bus.ParameterCount)
+	assert.Equal(t, 3, bus.ReturnCount)
+	assert.Equal(t, 1, bus.VariadicMethods)
+	assert.Equal(t, 1, bus.FuncParamMethods)
+	assert.False(t, bus.Generic)
+	assert.Equal(t, 3*2+8+3+3, bus.Complexity)
+	assert.Equal(t, "medium", bus.ComplexityRanking)
+
+	cache := stats[1]
+	assert.Equal(t, "Cache", cache.Name)
+	assert.True(t, cache.Generic)
+	assert.Equal(t, 2+3+5, cache.Complexity)
+
+	assert.Equal(t, "Pinger", stats[2].Name)
+	assert.Equal(t, "low", stats[2].ComplexityRanking)
+
+	t.Run("filtered", func(t *testing.T) {
+		text := responseText(t, callTool(t, s, "interface_stats", map[string]interface{}{
+			"project_path": dir,
+			"interfaces":   []interface{}{"P*"},
+		}))
+		var stats []interfaceStats
+		require.NoError(t, json.Unmarshal([]byte(text), &stats))
+		require.Len(t, stats, 1)
+		assert.Equal(t, "Pinger", stats[0].Name)
+	})
+}
+
+func TestComplexityRanking(t *testing.T) {
+	assert.Equal(t, "low", complexityRanking(0))
+	assert.Equal(t, "medium", complexityRanking(15))
+	assert.Equal(t, "high", complexityRanking(40))
+}
@@ -0,0 +1,415 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"go.uber.org/zap"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/scanner"
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// testifyImportPath is the package generated testify mocks embed
+const testifyImportPath = "github.com/stretchr/testify/mock"
+
+// predeclaredTypes are the type names that are never qualified with the
+// interface's package
+var predeclaredTypes = map[string]bool{
+	"any": true, "bool": true, "byte": true, "comparable": true, "complex64": true, "complex128": true,
+	"error": true, "float32": true, "float64": true, "int": true, "int8": true, "int16": true,
+	"int32": true, "int64": true, "rune": true, "string": true, "uint": true, "uint8": true,
+	"uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+}
+
+// generatedNames matches the identifiers generated method bodies declare,
+// which parameters are renamed to avoid
+var generatedNames = regexp.MustCompile(`^(_m|ret|r[0-9]+)$`)
+
+// testifyMockTemplate renders a mock in the shape mockery generates, minus
+// the expecter: a struct embedding mock.Mock, a method per interface method
+// recording the call, and a constructor asserting expectations on cleanup
+var testifyMockTemplate = template.Must(template.New("testify-mock").Parse(`// Code generated by mockery-mcp-server generate_testify_mock. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+)
+
+// {{.Name}} is a mock type for the {{.InterfaceName}} type
+type {{.Name}}{{.TypeParams}} struct {
+	mock.Mock
+}
+{{range .Methods}}
+// {{.Name}} provides a mock function{{if .ArgNames}} with given fields: {{.ArgNames}}{{end}}
+func (_m *{{$.Name}}{{$.TypeArgs}}) {{.Name}}({{.Params}}){{.Results}} {
+	{{if .Returns}}ret := {{end}}_m.Called({{.ArgNames}})
+{{- range .Returns}}
+{{if .IsError}}
+	{{.Var}} := ret.Error({{.Index}})
+{{- else}}
+	var {{.Var}} {{.Type}}
+	if v := ret.Get({{.Index}}); v != nil {
+		{{.Var}} = v.({{.Type}})
+	}
+{{- end}}
+{{- end}}
+{{- if .Returns}}
+
+	return {{.ReturnVars}}
+{{- end}}
+}
+{{end}}
+// New{{.Name}} creates a new instance of {{.Name}}. It also registers a
+// testing interface on the mock and a cleanup function to assert the mock's
+// expectations.
+func New{{.Name}}{{.TypeParams}}(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *{{.Name}}{{.TypeArgs}} {
+	m := &{{.Name}}{{.TypeArgs}}{}
+	m.Mock.Test(t)
+
+	t.Cleanup(func() { m.AssertExpectations(t) })
+
+	return m
+}
+{{- if .Assertion}}
+
+var _ {{.Assertion}} = (*{{.Name}})(nil)
+{{- end}}
+`))
+
+// testifyMock is the data testifyMockTemplate renders
+type testifyMock struct {
+	Package       string
+	Imports       []string
+	Name          string
+	InterfaceName string
+	TypeParams    string // [K comparable, V any], or empty
+	TypeArgs      string // [K, V], or empty
+	Methods       []testifyMethod
+	Assertion     string // Qualified interface the mock is checked against
+}
+
+// testifyMethod is one mocked method
+type testifyMethod struct {
+	Name       string
+	Params     string
+	Results    string
+	ArgNames   string
+	Returns    []testifyReturn
+	ReturnVars string
+}
+
+// testifyReturn is one result of a mocked method
+type testifyReturn struct {
+	Index   int
+	Var     string
+	Type    string
+	IsError bool
+}
+
+// typeQualifier rewrites type expressions written inside the interface's
+// package so they can be used from the mock's package
+type typeQualifier struct {
+	packageName string
+	qualify     bool            // false when the mock lives in the interface's package
+	typeParams  map[string]bool // Names that refer to type parameters
+	used        map[string]bool // Package names referenced by rewritten types
+}
+
+// rewrite parses a type as written in the interface and returns it with
+// package-local names qualified
+func (q *typeQualifier) rewrite(typeName string) (string, error) {
+	expr, err := parser.ParseExpr(typeName)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse type %s: %w", typeName, err)
+	}
+	expr = q.qualifyExpr(expr)
+
+	var out bytes.Buffer
+	if err := printer.Fprint(&out, token.NewFileSet(), expr); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// qualifyExpr qualifies the package-local type names in expr, recording
+// every package the result refers to
+func (q *typeQualifier) qualifyExpr(expr ast.Expr) ast.Expr {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if predeclaredTypes[t.Name] || q.typeParams[t.Name] || !q.qualify {
+			return t
+		}
+		q.used[q.packageName] = true
+		return &ast.SelectorExpr{X: ast.NewIdent(q.packageName), Sel: t}
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok {
+			q.used[pkg.Name] = true
+		}
+	case *ast.StarExpr:
+		t.X = q.qualifyExpr(t.X)
+	case *ast.ParenExpr:
+		t.X = q.qualifyExpr(t.X)
+	case *ast.ArrayType:
+		t.Elt = q.qualifyExpr(t.Elt)
+	case *ast.Ellipsis:
+		t.Elt = q.qualifyExpr(t.Elt)
+	case *ast.MapType:
+		t.Key = q.qualifyExpr(t.Key)
+		t.Value = q.qualifyExpr(t.Value)
+	case *ast.ChanType:
+		t.Value = q.qualifyExpr(t.Value)
+	case *ast.IndexExpr:
+		t.X = q.qualifyExpr(t.X)
+		t.Index = q.qualifyExpr(t.Index)
+	case *ast.IndexListExpr:
+		t.X = q.qualifyExpr(t.X)
+		for i := range t.Indices {
+			t.Indices[i] = q.qualifyExpr(t.Indices[i])
+		}
+	case *ast.FuncType:
+		q.qualifyFields(t.Params)
+		q.qualifyFields(t.Results)
+	case *ast.StructType:
+		q.qualifyFields(t.Fields)
+	case *ast.InterfaceType:
+		q.qualifyFields(t.Methods)
+	case *ast.BinaryExpr:
+		// Union constraints, e.g. ~int | ~string
+		t.X = q.qualifyExpr(t.X)
+		t.Y = q.qualifyExpr(t.Y)
+	case *ast.UnaryExpr:
+		t.X = q.qualifyExpr(t.X)
+	}
+	return expr
+}
+
+// qualifyFields qualifies the types of a field list
+func (q *typeQualifier) qualifyFields(fields *ast.FieldList) {
+	if fields == nil {
+		return
+	}
+	for _, field := range fields.List {
+		field.Type = q.qualifyExpr(field.Type)
+	}
+}
+
+// testifyMockSource generates the gofmt-formatted source of a testify mock
+// for iface. importPath is the import path of the interface's package,
+// needed unless the mock is generated into that package.
+func testifyMockSource(iface *types.InterfaceDefinition, mockPackage, mockName, importPath string) (string, error) {
+	q := &typeQualifier{
+		packageName: iface.Package,
+		qualify:     mockPackage != iface.Package,
+		typeParams:  make(map[string]bool),
+		used:        make(map[string]bool),
+	}
+
+	data := testifyMock{Package: mockPackage, Name: mockName, InterfaceName: iface.Name}
+
+	if len(iface.TypeParams) > 0 {
+		params := make([]string, len(iface.TypeParams))
+		names := make([]string, len(iface.TypeParams))
+		for i, param := range iface.TypeParams {
+			q.typeParams[param.Name] = true
+			names[i] = param.Name
+		}
+		for i, param := range iface.TypeParams {
+			constraint, err := q.rewrite(param.Constraint)
+			if err != nil {
+				return "", err
+			}
+			params[i] = param.Name + " " + constraint
+		}
+		data.TypeParams = "[" + strings.Join(params, ", ") + "]"
+		data.TypeArgs = "[" + strings.Join(names, ", ") + "]"
+	}
+
+	for _, method := range iface.Methods {
+		generated, err := testifyMethodOf(method, q)
+		if err != nil {
+			return "", fmt.Errorf("method %s: %w", method.Name, err)
+		}
+		data.Methods = append(data.Methods, generated)
+	}
+
+	// Generic mocks can't be checked without picking type arguments
+	if len(iface.TypeParams) == 0 {
+		data.Assertion = iface.Name
+		if q.qualify {
+			q.used[iface.Package] = true
+			data.Assertion = iface.Package + "." + iface.Name
+		}
+	}
+
+	importPaths := map[string]string{"mock": testifyImportPath}
+	for _, imp := range iface.Imports {
+		importPaths[imp.Name] = imp.Path
+	}
+	if q.qualify {
+		if importPath == "" {
+			return "", fmt.Errorf("%w: the import path of package %s is unknown; generate the mock into package %s instead",
+				ErrModuleNotFound, iface.Package, iface.Package)
+		}
+		importPaths[iface.Package] = importPath
+	}
+
+	q.used["mock"] = true
+	for name := range q.used {
+		importPath, ok := importPaths[name]
+		if !ok {
+			return "", fmt.Errorf("no import found for package %s", name)
+		}
+		if path.Base(importPath) == name {
+			data.Imports = append(data.Imports, fmt.Sprintf("%q", importPath))
+		} else {
+			data.Imports = append(data.Imports, fmt.Sprintf("%s %q", name, importPath))
+		}
+	}
+	// format.Source puts the imports in order
+
+	var source bytes.Buffer
+	if err := testifyMockTemplate.Execute(&source, data); err != nil {
+		return "", fmt.Errorf("failed to render mock: %w", err)
+	}
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("failed to format mock: %w", err)
+	}
+	return string(formatted), nil
+}
+
+// testifyMethodOf builds the template data for one method. Variadic
+// arguments are recorded as a single slice, the way the mock receives them.
+func testifyMethodOf(method types.MethodSignature, q *typeQualifier) (testifyMethod, error) {
+	generated := testifyMethod{Name: method.Name}
+
+	params := make([]string, len(method.Parameters))
+	argNames := make([]string, len(method.Parameters))
+	for i, param := range method.Parameters {
+		typeName, err := q.rewrite(strings.TrimPrefix(param.Type, "..."))
+		if err != nil {
+			return generated, err
+		}
+		if strings.HasPrefix(param.Type, "...") {
+			typeName = "..." + typeName
+		}
+
+		name := param.Name
+		if name == "" || name == "_" || generatedNames.MatchString(name) {
+			name = fmt.Sprintf("_a%d", i)
+		}
+		params[i] = name + " " + typeName
+		argNames[i] = name
+	}
+	generated.Params = strings.Join(params, ", ")
+	generated.ArgNames = strings.Join(argNames, ", ")
+
+	results := make([]string, len(method.Returns))
+	vars := make([]string, len(method.Returns))
+	for i, result := range method.Returns {
+		typeName, err := q.rewrite(result.Type)
+		if err != nil {
+			return generated, err
+		}
+		results[i] = typeName
+		vars[i] = fmt.Sprintf("r%d", i)
+		generated.Returns = append(generated.Returns, testifyReturn{
+			Index:   i,
+			Var:     vars[i],
+			Type:    typeName,
+			IsError: typeName == "error",
+		})
+	}
+	switch len(results) {
+	case 0:
+	case 1:
+		generated.Results = " " + results[0]
+	default:
+		generated.Results = " (" + strings.Join(results, ", ") + ")"
+	}
+	generated.ReturnVars = strings.Join(vars, ", ")
+	return generated, nil
+}
+
+// handleGenerateTestifyMock implements the generate_testify_mock tool
+func (s *MockeryMCPServer) handleGenerateTestifyMock(requestID interface{}, args map[string]interface{}) *MCPResponse {
+	filePath, ok := args["file_path"].(string)
+	if !ok || filePath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid file_path", nil)
+	}
+	interfaceName, ok := args["interface_name"].(string)
+	if !ok || interfaceName == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid interface_name", nil)
+	}
+
+	mockPackage := "mocks"
+	if value, ok := args["mock_package"].(string); ok && value != "" {
+		if !token.IsIdentifier(value) {
+			return s.errorResponse(requestID, -32602, "Invalid mock_package", fmt.Sprintf("%q is not a valid package name", value))
+		}
+		mockPackage = value
+	}
+	var mockName string
+	if value, ok := args["mock_name"].(string); ok && value != "" {
+		mockName = expandInterfaceName(value, interfaceName)
+		if !token.IsIdentifier(mockName) {
+			return s.errorResponse(requestID, -32602, "Invalid mock_name", fmt.Sprintf("%q is not a valid type name", mockName))
+		}
+	}
+
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", filePath), err)
+	}
+
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("File does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	iface, err := s.scanner.ExtractInterfaceMetadata(absPath, interfaceName)
+	if err != nil {
+		s.logger.Error("Failed to extract interface metadata", zap.String("file", absPath), zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to extract interface metadata", err)
+	}
+
+	// A mock in the interface's own package can't share its name
+	if mockPackage == iface.Package {
+		if mockName == "" {
+			mockName = "Mock" + interfaceName
+		} else if mockName == interfaceName {
+			return s.errorResponse(requestID, -32602, "Invalid mock_name", fmt.Sprintf("%s is the interface's own name in package %s", mockName, mockPackage))
+		}
+	} else if mockName == "" {
+		mockName = interfaceName
+	}
+
+	var importPath string
+	dir := filepath.Dir(absPath)
+	if moduleRoot, modulePath := scanner.FindModule(dir); modulePath != "" {
+		importPath = packagePath(dir, moduleRoot, modulePath)
+	}
+
+	source, err := testifyMockSource(iface, mockPackage, mockName, importPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to generate testify mock", err)
+	}
+	return s.textResponse(requestID, source)
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_GenerateTestifyMock(t *testing.T) {
+	project := t.TempDir()
+	writeFile(t, project, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	filePath := writeFile(t, project, "domain/repo.go", `package domain
+
+import (
+	"context"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+type User struct{}
+
+type Filter func(*User) bool
+
+type UserRepository interface {
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	List(ctx context.Context, filters ...Filter) ([]User, error)
+	Save(_ context.Context, ret map[string]User) error
+	Node() *yaml.Node
+	Close()
+}
+
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+}
+`)
+
+	s := newTestServer(t)
+	generate := func(args map[string]interface{}) string {
+		args["file_path"] = filePath
+		return responseText(t, callTool(t, s, "generate_testify_mock", args))
+	}
+
+	source := generate(map[string]interface{}{"interface_name": "UserRepository"})
+	formatted, err := format.Source([]byte(source))
+	require.NoError(t, err)
+	assert.Equal(t, string(formatted), source)
+
+	assert.Contains(t, source, "package mocks\n")
+	assert.Contains(t, source, `	"example.com/app/domain"
+	"github.com/stretchr/testify/mock"
+	yaml "gopkg.in/yaml.v3"
+`)
+	assert.Contains(t, source, `func (_m *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	ret := _m.Called(ctx, email)
+
+	var r0 *domain.User
+	if v := ret.Get(0); v != nil {
+		r0 = v.(*domain.User)
+	}
+
+	r1 := ret.Error(1)
+
+	return r0, r1
+}`)
+	assert.Contains(t, source, "List(ctx context.Context, filters ...domain.Filter) ([]domain.User, error) {\n\tret := _m.Called(ctx, filters)")
+	assert.Contains(t, source, "Save(_a0 context.Context, _a1 map[string]domain.User) error {")
+	assert.Contains(t, source, "func (_m *UserRepository) Close() {\n\t_m.Called()\n}")
+	assert.Contains(t, source, "func NewUserRepository(t interface {")
+	assert.Contains(t, source, "var _ domain.UserRepository = (*UserRepository)(nil)")
+
+	t.Run("in package", func(t *testing.T) {
+		source := generate(map[string]interface{}{
+			"interface_name": "Cache",
+			"mock_package":   "domain",
+			"mock_name":      "Mock{{.InterfaceName}}",
+		})
+		assert.Contains(t, source, "package domain\n")
+		assert.NotContains(t, source, "example.com/app/domain")
+		assert.Contains(t, source, "type MockCache[K comparable, V any] struct {")
+		assert.Contains(t, source, "func (_m *MockCache[K, V]) Get(key K) (V, bool) {")
+		assert.Contains(t, source, "func NewMockCache[K comparable, V any](t interface {")
+	})
+
+	t.Run("outside a module", func(t *testing.T) {
+		filePath := writeFile(t, t.TempDir(), "repo.go", "package repo\n\ntype Item struct{}\n\ntype Repo interface{ Get() Item }\n")
+		response := callTool(t, s, "generate_testify_mock", map[string]interface{}{"file_path": filePath, "interface_name": "Repo"})
+		requireErrorKind(t, response, CodeModuleNotFound, KindModuleNotFound)
+
+		source := responseText(t, callTool(t, s, "generate_testify_mock", map[string]interface{}{
+			"file_path":      filePath,
+			"interface_name": "Repo",
+			"mock_package":   "repo",
+		}))
+		assert.Contains(t, source, "func (_m *MockRepo) Get() Item {")
+		assert.Contains(t, source, "var _ Repo = (*MockRepo)(nil)")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		invalid := func(args map[string]interface{}) *MCPResponse {
+			args["file_path"] = filePath
+			args["interface_name"] = "UserRepository"
+			return callTool(t, s, "generate_testify_mock", args)
+		}
+
+		response := invalid(map[string]interface{}{"mock_package": "my-mocks"})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, "Invalid mock_package", response.Error.Message)
+
+		response = invalid(map[string]interface{}{"mock_package": "domain", "mock_name": "UserRepository"})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, "Invalid mock_name", response.Error.Message)
+	})
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// handleFindUnusedInterfaces implements the find_unused_interfaces tool
+func (s *MockeryMCPServer) handleFindUnusedInterfaces(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	projectPath, ok := args["project_path"].(string)
+	if !ok || projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid project_path", nil)
+	}
+
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Failed to resolve path: %s", projectPath), err)
+	}
+
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return s.toolErrorResponse(requestID, fmt.Sprintf("Project path does not exist: %s", absPath), fmt.Errorf("%w: %s", ErrPathNotFound, absPath))
+	}
+
+	unused, err := s.scanner.FindUnusedInterfacesContext(ctx, absPath)
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return s.toolErrorResponse(requestID, "Scan cancelled", err)
+	}
+	if err != nil {
+		s.logger.Error("Failed to analyze interface usage", zap.String("path", absPath), zap.Error(err))
+		return s.toolErrorResponse(requestID, "Failed to analyze interface usage", fmt.Errorf("%w: %v", ErrScanFailed, err))
+	}
+	unused = excludeInterfaces(unused, s.config().ExcludeInterfaces)
+
+	if len(unused) == 0 {
+		return s.textResponse(requestID, fmt.Sprintf("No unused interfaces found in %s", absPath))
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "Found %d unused interfaces in %s (review before deleting):\n", len(unused), absPath)
+	for _, iface := range unused {
+		fmt.Fprintf(&text, "\n- %s (%s package)\n  File: %s:%d", iface.Name, iface.Package, iface.FilePath, iface.LineNumber)
+	}
+
+	return s.textResponse(requestID, text.String())
+}
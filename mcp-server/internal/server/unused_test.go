@@ -0,0 +1,32 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockeryMCPServer_FindUnusedInterfaces(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "repo.go", `package repo
+
+type UserRepository interface{ Get() error }
+
+type Orphan interface{ Do() }
+
+type Service struct{ repo UserRepository }
+`)
+
+	s := newTestServer(t)
+	text := responseText(t, callTool(t, s, "find_unused_interfaces", map[string]interface{}{
+		"project_path": dir,
+	}))
+	assert.Contains(t, text, "Found 1 unused interfaces")
+	assert.Contains(t, text, "- Orphan (repo package)")
+	assert.NotContains(t, text, "UserRepository")
+
+	response := callTool(t, s, "find_unused_interfaces", map[string]interface{}{
+		"project_path": dir + "/missing",
+	})
+	requireErrorKind(t, response, CodePathNotFound, KindPathNotFound)
+}
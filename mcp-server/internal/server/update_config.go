@@ -0,0 +1,248 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+// handleUpdateMockeryConfig implements the update_mockery_config tool. The
+// config is edited as a YAML document rather than through
+// types.MockeryConfig, so settings the server doesn't model, comments and
+// environment variable references survive the update.
+func (s *MockeryMCPServer) handleUpdateMockeryConfig(requestID interface{}, args map[string]interface{}) *MCPResponse {
+	configPath, _ := args["config_path"].(string)
+	projectPath, _ := args["project_path"].(string)
+	if configPath == "" && projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing project_path or config_path", nil)
+	}
+
+	globals, ok := args["global_config"].(map[string]interface{})
+	if !ok && args["global_config"] != nil {
+		return s.errorResponse(requestID, -32602, "Invalid global_config", "global_config must be an object of settings")
+	}
+	interfaces, ok := args["interfaces"].(map[string]interface{})
+	if !ok && args["interfaces"] != nil {
+		return s.errorResponse(requestID, -32602, "Invalid interfaces", "interfaces must be an object keyed by package path")
+	}
+	if len(globals) == 0 && len(interfaces) == 0 {
+		return s.errorResponse(requestID, -32602, "Missing interfaces or global_config", nil)
+	}
+
+	absPath, create, err := updateConfigPath(projectPath, configPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Mockery config not found", err)
+	}
+
+	var document *yaml.Node
+	if create {
+		document, err = s.defaultConfigDocument()
+	} else {
+		document, err = readConfigDocument(absPath)
+	}
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to read mockery config", err)
+	}
+	root := document.Content[0]
+
+	for _, key := range sortedKeys(globals) {
+		if key == "packages" {
+			return s.errorResponse(requestID, -32602, "Invalid global_config", "packages are set through interfaces")
+		}
+		if err := setConfigValue(root, key, globals[key]); err != nil {
+			return s.errorResponse(requestID, -32602, "Invalid global_config", err.Error())
+		}
+	}
+
+	updated := 0
+	for _, packagePath := range sortedKeys(interfaces) {
+		packageInterfaces, ok := interfaces[packagePath].(map[string]interface{})
+		if !ok {
+			return s.errorResponse(requestID, -32602, "Invalid interfaces",
+				fmt.Sprintf("%s must map interface names to their settings", packagePath))
+		}
+		for _, name := range sortedKeys(packageInterfaces) {
+			settings, ok := packageInterfaces[name].(map[string]interface{})
+			if !ok {
+				return s.errorResponse(requestID, -32602, "Invalid interfaces",
+					fmt.Sprintf("settings of %s in %s must be an object", name, packagePath))
+			}
+			node, err := configMapping(root, "packages", packagePath, "interfaces", name, "config")
+			if err != nil {
+				return s.errorResponse(requestID, -32602, "Invalid mockery config", err.Error())
+			}
+			for _, key := range sortedKeys(settings) {
+				if err := setConfigValue(node, key, settings[key]); err != nil {
+					return s.errorResponse(requestID, -32602, "Invalid interfaces",
+						fmt.Sprintf("%s in %s: %v", name, packagePath, err))
+				}
+			}
+			updated++
+		}
+	}
+
+	var content bytes.Buffer
+	encoder := yaml.NewEncoder(&content)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return s.toolErrorResponse(requestID, "Failed to encode mockery config", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return s.toolErrorResponse(requestID, "Failed to encode mockery config", err)
+	}
+
+	// Nothing is written unless every tool can still read the result
+	var mockeryConfig types.MockeryConfig
+	if err := yaml.Unmarshal(content.Bytes(), &mockeryConfig); err != nil {
+		return s.errorResponse(requestID, -32602, "Invalid mockery config", err.Error())
+	}
+	if err := s.configManager.ValidateConfigSyntax(&mockeryConfig); err != nil {
+		return s.errorResponse(requestID, -32602, "Invalid mockery config", err.Error())
+	}
+
+	if err := os.MkdirAll(filepath.Dir(absPath), 0755); err != nil {
+		return s.toolErrorResponse(requestID, "Failed to write mockery config", err)
+	}
+	if err := os.WriteFile(absPath, content.Bytes(), 0644); err != nil {
+		return s.toolErrorResponse(requestID, "Failed to write mockery config", err)
+	}
+
+	action := "Updated"
+	if create {
+		action = "Created"
+	}
+	return s.textResponse(requestID, fmt.Sprintf("%s mockery config %s:\n- Global settings: %d\n- Interfaces: %d",
+		action, absPath, len(globals), updated))
+}
+
+// updateConfigPath returns the config update_mockery_config edits and
+// whether it has to be created: configPath when given, or else the
+// project's existing config, or a new .mockery.yaml in projectPath
+func updateConfigPath(projectPath, configPath string) (string, bool, error) {
+	if configPath != "" {
+		absPath, err := filepath.Abs(configPath)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to resolve path %s: %w", configPath, err)
+		}
+		_, err = os.Stat(absPath)
+		return absPath, os.IsNotExist(err), nil
+	}
+
+	absPath, err := resolveMockeryConfigPath(projectPath, "")
+	if err == nil || !errors.Is(err, ErrPathNotFound) {
+		return absPath, false, err
+	}
+
+	absDir, err := filepath.Abs(projectPath)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to resolve path %s: %w", projectPath, err)
+	}
+	if info, err := os.Stat(absDir); err != nil || !info.IsDir() {
+		return "", false, fmt.Errorf("%w: project directory does not exist: %s", ErrPathNotFound, absDir)
+	}
+	return filepath.Join(absDir, mockeryConfigFilename), true, nil
+}
+
+// defaultConfigDocument returns the server's default mockery config as a
+// YAML document, the starting point of a new config file
+func (s *MockeryMCPServer) defaultConfigDocument() (*yaml.Node, error) {
+	defaults := s.configManager.GetDefaultConfig()
+	var root yaml.Node
+	if err := root.Encode(&defaults); err != nil {
+		return nil, err
+	}
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{&root}}, nil
+}
+
+// readConfigDocument parses a mockery config file as a YAML document whose
+// root is a mapping. An empty file yields an empty mapping.
+func readConfigDocument(path string) (*yaml.Node, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if document.Kind == 0 {
+		document = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if document.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s is not a mapping of settings", path)
+	}
+	return &document, nil
+}
+
+// configMapping returns the mapping at the end of a path of keys beneath
+// node, adding empty mappings for keys that are missing or null
+func configMapping(node *yaml.Node, keys ...string) (*yaml.Node, error) {
+	for _, key := range keys {
+		var child *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				child = node.Content[i+1]
+				break
+			}
+		}
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, child)
+		} else if child.Kind == yaml.ScalarNode && child.Tag == "!!null" {
+			*child = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		if child.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s is not a mapping", key)
+		}
+		// An empty {} gains entries, so write it out as a block
+		if len(child.Content) == 0 {
+			child.Style = 0
+		}
+		node = child
+	}
+	return node, nil
+}
+
+// setConfigValue sets key in a mapping node to a string, boolean or number,
+// keeping the key's position and comments if it is already there
+func setConfigValue(mapping *yaml.Node, key string, value interface{}) error {
+	switch value.(type) {
+	case string, bool, float64:
+	default:
+		return fmt.Errorf("%s must be a string, boolean or number", key)
+	}
+
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			previous := mapping.Content[i+1]
+			node.HeadComment, node.LineComment, node.FootComment = previous.HeadComment, previous.LineComment, previous.FootComment
+			mapping.Content[i+1] = &node
+			return nil
+		}
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, &node)
+	return nil
+}
+
+// sortedKeys returns the keys of m in order, so updates are applied and
+// written deterministically
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
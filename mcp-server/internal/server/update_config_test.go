@@ -0,0 +1,135 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockeryMCPServer_UpdateMockeryConfig(t *testing.T) {
+	s := newTestServer(t)
+
+	t.Run("updates the existing config in place", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeFile(t, dir, ".mockery.yml", `# Shared mocks
+with-expecter: true
+filename: mock_{{.InterfaceName}}.go
+outpkg: mocks # package of every mock
+all: false
+packages:
+  example.com/app/store:
+    interfaces:
+      Store:
+        config:
+          dir: ${MOCKS_DIR}/store
+`)
+
+		text := responseText(t, callTool(t, s, "update_mockery_config", map[string]interface{}{
+			"project_path":  dir,
+			"global_config": map[string]interface{}{"outpkg": "fakes"},
+			"interfaces": map[string]interface{}{
+				"example.com/app/store": map[string]interface{}{
+					"Store": map[string]interface{}{"filename": "store_mock.go"},
+				},
+				"example.com/app/billing": map[string]interface{}{
+					"Invoicer": map[string]interface{}{"dir": "mocks/billing"},
+				},
+			},
+		}))
+		assert.Equal(t, "Updated mockery config "+path+":\n- Global settings: 1\n- Interfaces: 2", text)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, `# Shared mocks
+with-expecter: true
+filename: mock_{{.InterfaceName}}.go
+outpkg: fakes # package of every mock
+all: false
+packages:
+  example.com/app/store:
+    interfaces:
+      Store:
+        config:
+          dir: ${MOCKS_DIR}/store
+          filename: store_mock.go
+  example.com/app/billing:
+    interfaces:
+      Invoicer:
+        config:
+          dir: mocks/billing
+`, string(content))
+		assert.NoFileExists(t, filepath.Join(dir, ".mockery.yaml"))
+	})
+
+	t.Run("creates a config from the defaults", func(t *testing.T) {
+		dir := t.TempDir()
+		text := responseText(t, callTool(t, s, "update_mockery_config", map[string]interface{}{
+			"project_path": dir,
+			"interfaces": map[string]interface{}{
+				"example.com/app/store": map[string]interface{}{
+					"Store": map[string]interface{}{"dir": "mocks/store"},
+				},
+			},
+		}))
+		path := filepath.Join(dir, ".mockery.yaml")
+		assert.Contains(t, text, "Created mockery config "+path)
+
+		mockeryConfig, err := s.configManager.ReadConfigFile(path)
+		require.NoError(t, err)
+		assert.True(t, mockeryConfig.WithExpector)
+		assert.Equal(t, "mocks", mockeryConfig.OutPkg)
+		assert.Equal(t, "mocks/store", mockeryConfig.Packages["example.com/app/store"].Interfaces["Store"].Config.Dir)
+	})
+
+	t.Run("explicit config path", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config", "mockery.yaml")
+		text := responseText(t, callTool(t, s, "update_mockery_config", map[string]interface{}{
+			"config_path":   path,
+			"global_config": map[string]interface{}{"with-expecter": false},
+		}))
+		assert.Contains(t, text, "Created mockery config "+path)
+
+		mockeryConfig, err := s.configManager.ReadConfigFile(path)
+		require.NoError(t, err)
+		assert.False(t, mockeryConfig.WithExpector)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeFile(t, dir, ".mockery.yaml", validMockeryConfig)
+
+		update := func(args map[string]interface{}) *MCPResponse {
+			args["project_path"] = dir
+			return callTool(t, s, "update_mockery_config", args)
+		}
+
+		response := update(map[string]interface{}{})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, "Missing interfaces or global_config", response.Error.Message)
+
+		response = update(map[string]interface{}{"global_config": map[string]interface{}{"filename": []interface{}{"a"}}})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, "Invalid global_config", response.Error.Message)
+
+		response = update(map[string]interface{}{"interfaces": map[string]interface{}{"example.com/app": "Repo"}})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, "Invalid interfaces", response.Error.Message)
+
+		// A result that would fail validation is not written
+		response = update(map[string]interface{}{"global_config": map[string]interface{}{"outpkg": ""}})
+		require.NotNil(t, response.Error)
+		assert.Equal(t, "Invalid mockery config", response.Error.Message)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, validMockeryConfig, string(content))
+
+		response = callTool(t, s, "update_mockery_config", map[string]interface{}{
+			"project_path":  filepath.Join(dir, "missing"),
+			"global_config": map[string]interface{}{"outpkg": "fakes"},
+		})
+		requireErrorKind(t, response, CodePathNotFound, KindPathNotFound)
+	})
+}
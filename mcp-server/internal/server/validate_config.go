@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/config"
+)
+
+// mockeryConfigFilename is the name new mockery config files are written as
+const mockeryConfigFilename = ".mockery.yaml"
+
+// resolveMockeryConfigPath returns the absolute path of a project's mockery
+// config: explicitPath when given, otherwise the first of
+// config.ConfigFilenames found in projectPath
+func resolveMockeryConfigPath(projectPath, explicitPath string) (string, error) {
+	if explicitPath != "" {
+		absPath, err := filepath.Abs(explicitPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve path %s: %w", explicitPath, err)
+		}
+		if _, err := os.Stat(absPath); os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: config file does not exist: %s", ErrPathNotFound, absPath)
+		}
+		return absPath, nil
+	}
+
+	absDir, err := filepath.Abs(projectPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path %s: %w", projectPath, err)
+	}
+	path, ok := config.FindConfigFile(absDir)
+	if !ok {
+		return "", fmt.Errorf("%w: no mockery config (%s) in %s",
+			ErrPathNotFound, strings.Join(config.ConfigFilenames, ", "), absDir)
+	}
+	return path, nil
+}
+
+// handleValidateMockeryConfig implements the validate_mockery_config tool.
+// When the server drives mockery v3, keys only v2 reads are flagged too.
+func (s *MockeryMCPServer) handleValidateMockeryConfig(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	configPath, _ := args["config_path"].(string)
+	projectPath, _ := args["project_path"].(string)
+	if configPath == "" && projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing project_path or config_path", nil)
+	}
+
+	absPath, err := resolveMockeryConfigPath(projectPath, configPath)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Mockery config not found", err)
+	}
+
+	// A file that isn't valid YAML can't be checked any further. Values are
+	// checked as written, so issues never quote the server's environment.
+	mockeryConfig, err := s.configManager.LoadLiteralConfigFile(absPath)
+	if err != nil {
+		return s.textResponse(requestID, formatConfigIssues(absPath, []config.ConfigIssue{
+			{Severity: config.SeverityError, Message: err.Error()},
+		}))
+	}
+
+	issues := s.configManager.ValidateConfig(mockeryConfig)
+	// Expanding only names the variables that are undefined, if any
+	if _, err := s.configManager.LoadConfigFile(absPath); err != nil {
+		issues = append(issues, config.ConfigIssue{Severity: config.SeverityError, Message: err.Error()})
+		config.SortIssues(issues)
+	}
+	if _, v3 := s.argsBuilder(ctx, s.config()).(mockeryV3Args); v3 {
+		content, err := os.ReadFile(absPath)
+		if err != nil {
+			return s.toolErrorResponse(requestID, "Failed to read mockery config", err)
+		}
+		v2Keys, err := config.CheckV2OnlyKeys(content)
+		if err != nil {
+			return s.toolErrorResponse(requestID, "Failed to read mockery config", err)
+		}
+		issues = append(issues, v2Keys...)
+		config.SortIssues(issues)
+	}
+
+	return s.textResponse(requestID, formatConfigIssues(absPath, issues))
+}
+
+// formatConfigIssues renders a validation report
+func formatConfigIssues(path string, issues []config.ConfigIssue) string {
+	if len(issues) == 0 {
+		return fmt.Sprintf("%s is valid", path)
+	}
+
+	errorCount := 0
+	for _, issue := range issues {
+		if issue.Severity == config.SeverityError {
+			errorCount++
+		}
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "Found %d issues in %s (%d errors, %d warnings):\n",
+		len(issues), path, errorCount, len(issues)-errorCount)
+	for _, issue := range issues {
+		if issue.Path != "" {
+			fmt.Fprintf(&text, "\n- [%s] %s: %s", issue.Severity, issue.Path, issue.Message)
+		} else {
+			fmt.Fprintf(&text, "\n- [%s] %s", issue.Severity, issue.Message)
+		}
+	}
+	return text.String()
+}
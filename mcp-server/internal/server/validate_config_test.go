@@ -0,0 +1,145 @@
+package server
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// validMockeryConfig is a minimal config with no validation issues
+const validMockeryConfig = `with-expecter: true
+filename: mock_{{.InterfaceName}}.go
+outpkg: mocks
+packages:
+  example.com/app:
+    interfaces:
+      Repo:
+        config:
+          dir: ./mocks
+`
+
+func TestMockeryMCPServer_ValidateMockeryConfig(t *testing.T) {
+	s := newTestServer(t)
+
+	t.Run("valid", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, ".mockery.yaml", `with-expecter: true
+filename: mock_{{.InterfaceName}}.go
+outpkg: mocks
+packages:
+  github.com/example/project:
+    interfaces:
+      UserRepository:
+        config:
+          dir: ./mocks
+`)
+		text := responseText(t, callTool(t, s, "validate_mockery_config", map[string]interface{}{
+			"project_path": dir,
+		}))
+		assert.Equal(t, filepath.Join(dir, ".mockery.yaml")+" is valid", text)
+	})
+
+	t.Run("collects every issue", func(t *testing.T) {
+		path := writeFile(t, t.TempDir(), "mockery.yml", `filename: mock_{{.InterfaceName}.go
+packages:
+  github.com/example/project:
+    interfaces: {}
+`)
+		text := responseText(t, callTool(t, s, "validate_mockery_config", map[string]interface{}{
+			"config_path": path,
+		}))
+		assert.Contains(t, text, "Found 3 issues")
+		assert.Contains(t, text, "- [error] filename: invalid filename template")
+		assert.Contains(t, text, "- [error] outpkg: outpkg is required")
+		assert.Contains(t, text, "- [error] packages.github.com/example/project: package github.com/example/project has no interfaces configured")
+	})
+
+	t.Run("malformed yaml", func(t *testing.T) {
+		path := writeFile(t, t.TempDir(), ".mockery.yaml", "filename: [unclosed\n")
+		text := responseText(t, callTool(t, s, "validate_mockery_config", map[string]interface{}{
+			"config_path": path,
+		}))
+		assert.Contains(t, text, "- [error] failed to unmarshal configuration")
+	})
+
+	t.Run("alternate filenames", func(t *testing.T) {
+		for _, name := range []string{".mockery.yml", "mockery.yaml"} {
+			dir := t.TempDir()
+			writeFile(t, dir, name, validMockeryConfig)
+
+			text := responseText(t, callTool(t, s, "validate_mockery_config", map[string]interface{}{
+				"project_path": dir,
+			}))
+			assert.Equal(t, filepath.Join(dir, name)+" is valid", text)
+		}
+	})
+
+	t.Run("explicit config_path overrides discovery", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, ".mockery.yaml", "filename: [unclosed\n")
+		path := writeFile(t, dir, "config/mockery.yaml", validMockeryConfig)
+
+		text := responseText(t, callTool(t, s, "validate_mockery_config", map[string]interface{}{
+			"project_path": dir,
+			"config_path":  path,
+		}))
+		assert.Equal(t, path+" is valid", text)
+	})
+
+	t.Run("v2 settings with mockery v3", func(t *testing.T) {
+		path := writeFile(t, t.TempDir(), ".mockery.yaml", validMockeryConfig+`          mockname: MockRepo
+`)
+
+		// Mockery v2 reads them, so they are only flagged for v3
+		assert.Equal(t, path+" is valid", responseText(t, callTool(t, s, "validate_mockery_config", map[string]interface{}{
+			"config_path": path,
+		})))
+
+		v3 := newTestServer(t)
+		useFakeMockeryV3(t, v3)
+		text := responseText(t, callTool(t, v3, "validate_mockery_config", map[string]interface{}{
+			"config_path": path,
+		}))
+		assert.Contains(t, text, "Found 3 issues")
+		assert.Contains(t, text, "- [warning] outpkg: outpkg is a mockery v2 setting that mockery v3 ignores; use pkgname")
+		assert.Contains(t, text, "- [warning] packages.example.com/app.interfaces.Repo.config.mockname: mockname is a mockery v2 setting that mockery v3 ignores; use structname")
+		assert.Contains(t, text, "- [warning] with-expecter: ")
+	})
+
+	t.Run("environment variables are not expanded", func(t *testing.T) {
+		t.Setenv("MOCKS_SECRET_NAME", "{{.Secret")
+		path := writeFile(t, t.TempDir(), ".mockery.yaml", `with-expecter: true
+filename: "${MOCKS_SECRET_NAME}.go"
+outpkg: mocks
+packages: {}
+`)
+
+		text := responseText(t, callTool(t, s, "validate_mockery_config", map[string]interface{}{
+			"config_path": path,
+		}))
+		assert.NotContains(t, text, "Secret")
+	})
+
+	t.Run("undefined variables in strict mode", func(t *testing.T) {
+		strict := newTestServer(t)
+		strict.SetConfigEnvExpansion(true, true)
+		path := writeFile(t, t.TempDir(), ".mockery.yaml", `with-expecter: true
+filename: "mock_{{.InterfaceName}}.go"
+outpkg: ${MOCKS_UNSET_PKG}
+packages: {}
+`)
+
+		text := responseText(t, callTool(t, strict, "validate_mockery_config", map[string]interface{}{
+			"config_path": path,
+		}))
+		assert.Contains(t, text, "undefined environment variables: MOCKS_UNSET_PKG")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		response := callTool(t, s, "validate_mockery_config", map[string]interface{}{
+			"project_path": t.TempDir(),
+		})
+		requireErrorKind(t, response, CodePathNotFound, KindPathNotFound)
+	})
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"os/exec"
+	"regexp"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// versionFailureTTL is how long a failed mockery --version query is
+// remembered, so a missing or broken binary isn't queried for every
+// generation while a fixed one is picked up soon after
+const versionFailureTTL = 30 * time.Second
+
+// cachedMockeryVersion is a mockery --version result. Failed queries expire;
+// successful ones are kept for as long as the server runs.
+type cachedMockeryVersion struct {
+	version string
+	expires time.Time
+}
+
+// mockeryVersionPattern matches a semantic version in mockery's --version
+// output, which varies across v2 releases ("v2.53.3", "mockery version
+// v2.20.0", or a log line ending in "version=v2.16.0")
+var mockeryVersionPattern = regexp.MustCompile(`v?(\d+\.\d+\.\d+(?:-[0-9A-Za-z.\-]+)?)`)
+
+// parseMockeryVersion extracts a "vX.Y.Z" version from mockery output,
+// returning an empty string when none is present
+func parseMockeryVersion(output string) string {
+	match := mockeryVersionPattern.FindStringSubmatch(output)
+	if match == nil {
+		return ""
+	}
+	return "v" + match[1]
+}
+
+// MockeryVersion returns the version of the configured mockery binary. The
+// result is cached per command, failures for versionFailureTTL; an empty
+// string means it couldn't be determined.
+func (s *MockeryMCPServer) MockeryVersion(ctx context.Context) string {
+	return s.mockeryVersion(ctx, s.config().MockeryCommand)
+}
+
+// mockeryVersion returns the cached version of the given mockery command
+func (s *MockeryMCPServer) mockeryVersion(ctx context.Context, command string) string {
+	s.versionMu.Lock()
+	defer s.versionMu.Unlock()
+
+	if cached, ok := s.mockeryVersions[command]; ok && (cached.expires.IsZero() || time.Now().Before(cached.expires)) {
+		return cached.version
+	}
+	if s.mockeryVersions == nil {
+		s.mockeryVersions = make(map[string]cachedMockeryVersion)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, command, "--version").CombinedOutput()
+	if err != nil {
+		s.requestLogger(ctx).Debug("Failed to query mockery version", zap.Error(err))
+		s.mockeryVersions[command] = cachedMockeryVersion{expires: time.Now().Add(versionFailureTTL)}
+		return ""
+	}
+
+	version := parseMockeryVersion(string(output))
+	s.mockeryVersions[command] = cachedMockeryVersion{version: version}
+	return version
+}
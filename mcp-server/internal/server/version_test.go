@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kohofinancial/experiments/ai_claude_prime/mcp-server/internal/types"
+)
+
+func TestParseMockeryVersion(t *testing.T) {
+	tests := []struct {
+		output   string
+		expected string
+	}{
+		{"v2.53.3\n", "v2.53.3"},
+		{"mockery version v2.20.0", "v2.20.0"},
+		{"24 Jan 23 10:00 INF Starting mockery dry-run=false version=v2.16.0\nv2.16.0\n", "v2.16.0"},
+		{"2.42.1", "v2.42.1"},
+		{"v3.0.0-beta.1", "v3.0.0-beta.1"},
+		{"no version here", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, parseMockeryVersion(tt.output), tt.output)
+	}
+}
+
+func TestMockeryMCPServer_GenerateMockRecordsVersion(t *testing.T) {
+	s := newTestServer(t)
+	useFakeMockery(t, s, fakeMockeryScript)
+	packageDir := t.TempDir()
+	writeFile(t, packageDir, "repo.go", "package repo\n\ntype Repo interface{}\n")
+
+	result, err := s.GenerateMock(context.Background(), &types.MockGenerationRequest{
+		InterfaceName: "Repo",
+		PackagePath:   packageDir,
+		OutputDir:     t.TempDir(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "v2.53.3", result.MockeryVersion)
+
+	mocks := s.projectManager.GetGeneratedMocks("")
+	require.Len(t, mocks, 1)
+	assert.Equal(t, "Repo", mocks[0].InterfaceName)
+	assert.Equal(t, "v2.53.3", mocks[0].MockeryVersion)
+	assert.Equal(t, result.GeneratedFile, mocks[0].FilePath)
+	assert.NotEmpty(t, mocks[0].Hash)
+	assert.NotEmpty(t, mocks[0].ID)
+}
+
+func TestMockeryMCPServer_MockeryVersionCached(t *testing.T) {
+	s := newTestServer(t)
+	runs := countingMockery(t, s, "#!/bin/sh\necho run >> \"$COUNT_FILE\"\necho 'mockery version v2.40.1'\n")
+
+	assert.Equal(t, "v2.40.1", s.MockeryVersion(context.Background()))
+	assert.Equal(t, "v2.40.1", s.MockeryVersion(context.Background()))
+	assert.Equal(t, 1, runs())
+}
+
+func TestMockeryMCPServer_MockeryVersionFailureExpires(t *testing.T) {
+	s := newTestServer(t)
+	runs := countingMockery(t, s, "#!/bin/sh\necho run >> \"$COUNT_FILE\"\nexit 1\n")
+
+	assert.Empty(t, s.MockeryVersion(context.Background()))
+	assert.Empty(t, s.MockeryVersion(context.Background()))
+	assert.Equal(t, 1, runs())
+
+	// Once the failure expires the binary is queried again
+	command := s.config().MockeryCommand
+	s.mockeryVersions[command] = cachedMockeryVersion{expires: time.Now().Add(-time.Second)}
+	assert.Empty(t, s.MockeryVersion(context.Background()))
+	assert.Equal(t, 2, runs())
+}
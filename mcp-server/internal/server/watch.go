@@ -0,0 +1,289 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// Watch debounce bounds. Changes are reported once the project's Go files
+// have had no events for the debounce period, so a burst of saves (a
+// branch switch, a formatter run) produces one notification.
+const (
+	defaultWatchDebounce    = 500 * time.Millisecond
+	minWatchDebounce        = 50 * time.Millisecond
+	maxWatchesPerConnection = 8
+)
+
+// interfacesChangedMethod is the notification sent when a watched
+// project's interfaces change
+const interfacesChangedMethod = "notifications/interfaces/changed"
+
+// projectWatch is a project being watched for interface changes on behalf
+// of a connection
+type projectWatch struct {
+	id       string
+	path     string
+	debounce time.Duration
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// interfacesChangedParams are the parameters of an interfaces changed
+// notification
+type interfacesChangedParams struct {
+	WatchID     string            `json:"watch_id"`
+	ProjectPath string            `json:"project_path"`
+	Added       []InterfaceChange `json:"added"`
+	Removed     []InterfaceChange `json:"removed"`
+	Changed     []InterfaceChange `json:"changed"`
+}
+
+// skipWatchDir reports whether a directory beneath a watched root is left
+// unwatched: vendored and hidden directories hold no interfaces to report
+func skipWatchDir(root, path string) bool {
+	name := filepath.Base(path)
+	return path != root && (name == "vendor" || strings.HasPrefix(name, "."))
+}
+
+// addWatchDirs adds dir and the directories beneath it to watcher. Only a
+// failure to watch dir itself is returned; subdirectories that can't be
+// read or watched are skipped.
+func addWatchDirs(watcher *fsnotify.Watcher, root, dir string) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir {
+				return err
+			}
+			return nil
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if skipWatchDir(root, path) {
+			return filepath.SkipDir
+		}
+		if err := watcher.Add(path); err != nil && path == dir {
+			return err
+		}
+		return nil
+	})
+}
+
+// watchEvent handles a filesystem event of a watch on root, reporting
+// whether it may have changed the project's interfaces. Directories created
+// beneath root are watched in turn.
+func watchEvent(watcher *fsnotify.Watcher, root string, event fsnotify.Event) bool {
+	if event.Has(fsnotify.Create) {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			if skipWatchDir(root, event.Name) {
+				return false
+			}
+			// Files written before the directory was watched are found by
+			// the rescan this event triggers
+			addWatchDirs(watcher, root, event.Name)
+			return true
+		}
+	}
+	if strings.HasSuffix(event.Name, ".go") {
+		return !strings.HasSuffix(event.Name, "_test.go") && event.Op != fsnotify.Chmod
+	}
+	// A removed or renamed directory takes its files with it
+	return event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename)
+}
+
+// startWatch begins watching a project for conn, which must be able to
+// receive notifications
+func (s *MockeryMCPServer) startWatch(ctx context.Context, conn *connection, projectPath string, debounce time.Duration) (*projectWatch, error) {
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path %s: %w", projectPath, err)
+	}
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrPathNotFound, absPath)
+	}
+
+	// The initial scan is the baseline changes are reported against. The
+	// directories are watched first, so an edit made during or right after
+	// the scan is still seen as a change.
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create watcher: %w", err)
+	}
+	if err := addWatchDirs(watcher, absPath, absPath); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch %s: %w", absPath, err)
+	}
+	baseline, err := s.scanInterfaceHashes(ctx, absPath)
+	if err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	conn.watchesMu.Lock()
+	defer conn.watchesMu.Unlock()
+	if len(conn.watches) >= maxWatchesPerConnection {
+		watcher.Close()
+		return nil, fmt.Errorf("a connection can watch at most %d projects", maxWatchesPerConnection)
+	}
+
+	// The watch outlives the call that started it
+	watchCtx, cancel := context.WithCancel(context.Background())
+	watch := &projectWatch{
+		id:       fmt.Sprintf("watch-%d", s.watchIDs.Add(1)),
+		path:     absPath,
+		debounce: debounce,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	if conn.watches == nil {
+		conn.watches = make(map[string]*projectWatch)
+	}
+	conn.watches[watch.id] = watch
+
+	s.activeWatches.Add(1)
+	go s.runWatch(watchCtx, conn, watch, watcher, baseline)
+	return watch, nil
+}
+
+// runWatch handles a watched project's filesystem events until its context
+// is cancelled, rescanning once they have settled for the debounce period
+func (s *MockeryMCPServer) runWatch(ctx context.Context, conn *connection, watch *projectWatch, watcher *fsnotify.Watcher, baseline map[string]scannedInterface) {
+	defer s.activeWatches.Add(-1)
+	defer close(watch.done)
+	defer watcher.Close()
+
+	// The timer only runs while changes are waiting to settle
+	settle := time.NewTimer(watch.debounce)
+	settle.Stop()
+	defer settle.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if watchEvent(watcher, watch.path, event) {
+				settle.Reset(watch.debounce)
+			}
+			continue
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			// Events may have been dropped, so rescan to be sure
+			s.logger.Warn("Watched project event error", zap.String("watch_id", watch.id), zap.Error(err))
+			settle.Reset(watch.debounce)
+			continue
+		case <-settle.C:
+		}
+
+		scanned, err := s.scanInterfaceHashes(ctx, watch.path)
+		if err != nil {
+			s.logger.Warn("Failed to rescan watched project", zap.String("watch_id", watch.id), zap.Error(err))
+			continue
+		}
+		diff := diffInterfaces(baseline, scanned)
+		baseline = scanned
+		if len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0 {
+			continue
+		}
+
+		err = conn.notify(&MCPNotification{
+			JSONRPC: "2.0",
+			Method:  interfacesChangedMethod,
+			Params: interfacesChangedParams{
+				WatchID:     watch.id,
+				ProjectPath: watch.path,
+				Added:       diff.Added,
+				Removed:     diff.Removed,
+				Changed:     diff.Changed,
+			},
+		})
+		if err != nil {
+			s.logger.Warn("Failed to send interface change notification", zap.String("watch_id", watch.id), zap.Error(err))
+		}
+	}
+}
+
+// stopWatch stops one of conn's watches, reporting whether it existed
+func (s *MockeryMCPServer) stopWatch(conn *connection, id string) bool {
+	conn.watchesMu.Lock()
+	watch, ok := conn.watches[id]
+	delete(conn.watches, id)
+	conn.watchesMu.Unlock()
+
+	if ok {
+		watch.cancel()
+		<-watch.done
+	}
+	return ok
+}
+
+// stopWatches stops every watch of a connection that is going away
+func (s *MockeryMCPServer) stopWatches(conn *connection) {
+	conn.watchesMu.Lock()
+	watches := conn.watches
+	conn.watches = nil
+	conn.watchesMu.Unlock()
+
+	for _, watch := range watches {
+		watch.cancel()
+		<-watch.done
+	}
+}
+
+// handleWatchProject implements the watch_project tool
+func (s *MockeryMCPServer) handleWatchProject(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	projectPath, ok := args["project_path"].(string)
+	if !ok || projectPath == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid project_path", nil)
+	}
+
+	debounce := defaultWatchDebounce
+	if value, ok := args["debounce_ms"]; ok {
+		ms, ok := value.(float64)
+		if !ok || time.Duration(ms)*time.Millisecond < minWatchDebounce {
+			return s.errorResponse(requestID, -32602, "Invalid debounce_ms", fmt.Sprintf("must be a number of at least %d", minWatchDebounce.Milliseconds()))
+		}
+		debounce = time.Duration(ms) * time.Millisecond
+	}
+
+	conn := connectionFrom(ctx)
+	if conn.notify == nil {
+		return s.errorResponse(requestID, -32602, "Watching is unavailable", "the connection can't receive notifications")
+	}
+
+	watch, err := s.startWatch(ctx, conn, projectPath, debounce)
+	if err != nil {
+		return s.toolErrorResponse(requestID, "Failed to watch project", err)
+	}
+
+	text := fmt.Sprintf("Watching %s for interface changes:\n- Watch ID: %s\n- Debounce: %s\n\nChanges are sent as %s notifications until unwatch_project is called or the connection closes.",
+		watch.path, watch.id, watch.debounce, interfacesChangedMethod)
+	return s.textResponse(requestID, text)
+}
+
+// handleUnwatchProject implements the unwatch_project tool
+func (s *MockeryMCPServer) handleUnwatchProject(ctx context.Context, requestID interface{}, args map[string]interface{}) *MCPResponse {
+	watchID, ok := args["watch_id"].(string)
+	if !ok || watchID == "" {
+		return s.errorResponse(requestID, -32602, "Missing or invalid watch_id", nil)
+	}
+
+	// Watches belong to the connection that started them
+	if !s.stopWatch(connectionFrom(ctx), watchID) {
+		return s.errorResponse(requestID, -32602, "Invalid watch_id", fmt.Sprintf("no watch %s on this connection", watchID))
+	}
+	return s.textResponse(requestID, fmt.Sprintf("Stopped watch %s", watchID))
+}
@@ -0,0 +1,170 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// watchConnection serves a connection whose requests are sent with call
+// and whose notifications arrive on the returned channel. Closing the
+// connection waits for serveConnection to return.
+func watchConnection(t *testing.T, s *MockeryMCPServer) (call func(name string, args map[string]interface{}) *MCPResponse, notifications <-chan *MCPNotification, closeConn func()) {
+	t.Helper()
+	requests := make(chan *MCPRequest)
+	responses := make(chan *MCPResponse)
+	notified := make(chan *MCPNotification, 16)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		s.serveConnection(context.Background(),
+			func() (*MCPRequest, error) {
+				request, ok := <-requests
+				if !ok {
+					return nil, io.EOF
+				}
+				return request, nil
+			},
+			func(response *MCPResponse) error {
+				responses <- response
+				return nil
+			},
+			func(notification *MCPNotification) error {
+				notified <- notification
+				return nil
+			})
+	}()
+
+	id := 0
+	call = func(name string, args map[string]interface{}) *MCPResponse {
+		id++
+		requests <- &MCPRequest{JSONRPC: "2.0", ID: float64(id), Method: "tools/call", Params: map[string]interface{}{
+			"name":      name,
+			"arguments": args,
+		}}
+		return <-responses
+	}
+	closeConn = func() {
+		close(requests)
+		<-done
+	}
+	return call, notified, closeConn
+}
+
+func TestMockeryMCPServer_WatchProject(t *testing.T) {
+	dir := t.TempDir()
+	source := writeFile(t, dir, "store.go", "package store\n\ntype Store interface{ Get() error }\n")
+
+	s := newTestServer(t)
+	call, notifications, closeConn := watchConnection(t, s)
+
+	text := responseText(t, call("watch_project", map[string]interface{}{
+		"project_path": dir,
+		"debounce_ms":  float64(100),
+	}))
+	match := regexp.MustCompile(`- Watch ID: (\S+)`).FindStringSubmatch(text)
+	require.Len(t, match, 2, text)
+	watchID := match[1]
+	assert.Equal(t, int64(1), s.activeWatches.Load())
+
+	require.NoError(t, os.WriteFile(source, []byte("package store\n\ntype Store interface{ Get() (string, error) }\n\ntype Cache interface{ Purge() }\n"), 0644))
+
+	select {
+	case notification := <-notifications:
+		assert.Equal(t, interfacesChangedMethod, notification.Method)
+		data, err := json.Marshal(notification.Params)
+		require.NoError(t, err)
+		var params interfacesChangedParams
+		require.NoError(t, json.Unmarshal(data, &params))
+		assert.Equal(t, watchID, params.WatchID)
+		require.Len(t, params.Added, 1)
+		assert.Equal(t, "Cache", params.Added[0].Interface)
+		require.Len(t, params.Changed, 1)
+		assert.Equal(t, "Store", params.Changed[0].Interface)
+		assert.Empty(t, params.Removed)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification after the interfaces changed")
+	}
+
+	// Watches are per connection, and unknown IDs are rejected
+	response := call("unwatch_project", map[string]interface{}{"watch_id": "watch-missing"})
+	require.NotNil(t, response.Error)
+	assert.Equal(t, -32602, response.Error.Code)
+
+	responseText(t, call("unwatch_project", map[string]interface{}{"watch_id": watchID}))
+	assert.Equal(t, int64(0), s.activeWatches.Load())
+
+	t.Run("new directories", func(t *testing.T) {
+		text := responseText(t, call("watch_project", map[string]interface{}{
+			"project_path": dir,
+			"debounce_ms":  float64(100),
+		}))
+		match := regexp.MustCompile(`- Watch ID: (\S+)`).FindStringSubmatch(text)
+		require.Len(t, match, 2, text)
+		defer call("unwatch_project", map[string]interface{}{"watch_id": match[1]})
+
+		sub := filepath.Join(dir, "orders")
+		require.NoError(t, os.Mkdir(sub, 0755))
+		writeFile(t, sub, "orders.go", "package orders\n\ntype Orders interface{ List() error }\n")
+		waitForAdded(t, notifications, "orders.Orders")
+
+		// Files written later in the new directory are watched too
+		writeFile(t, sub, "ledger.go", "package orders\n\ntype Ledger interface{ Post() error }\n")
+		waitForAdded(t, notifications, "orders.Ledger")
+	})
+
+	t.Run("stopped on disconnect", func(t *testing.T) {
+		responseText(t, call("watch_project", map[string]interface{}{"project_path": dir}))
+		assert.Equal(t, int64(1), s.activeWatches.Load())
+		closeConn()
+		assert.Equal(t, int64(0), s.activeWatches.Load())
+	})
+}
+
+func TestMockeryMCPServer_WatchProjectErrors(t *testing.T) {
+	s := newTestServer(t)
+
+	// Calls outside a connection can't be notified
+	response := callTool(t, s, "watch_project", map[string]interface{}{"project_path": t.TempDir()})
+	require.NotNil(t, response.Error)
+	assert.Equal(t, -32602, response.Error.Code)
+
+	call, _, closeConn := watchConnection(t, s)
+	defer closeConn()
+
+	response = call("watch_project", map[string]interface{}{
+		"project_path": t.TempDir(),
+		"debounce_ms":  float64(10),
+	})
+	require.NotNil(t, response.Error)
+	assert.Equal(t, -32602, response.Error.Code)
+
+	requireErrorKind(t, call("watch_project", map[string]interface{}{
+		"project_path": filepath.Join(t.TempDir(), "missing"),
+	}), CodePathNotFound, KindPathNotFound)
+}
+
+// waitForAdded waits for a notification reporting name as added
+func waitForAdded(t *testing.T, notifications <-chan *MCPNotification, name string) {
+	t.Helper()
+	select {
+	case notification := <-notifications:
+		data, err := json.Marshal(notification.Params)
+		require.NoError(t, err)
+		var params interfacesChangedParams
+		require.NoError(t, json.Unmarshal(data, &params))
+		require.Len(t, params.Added, 1)
+		assert.Equal(t, name, params.Added[0].Interface)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no notification after %s was added", name)
+	}
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// compressionThreshold is the smallest WebSocket message, in bytes, that is
+// compressed on connections that negotiated permessage-deflate
+const compressionThreshold = 1024
+
+// SetWebSocketTimeouts configures per-message deadlines on WebSocket
+// connections. A connection is closed when no message or pong arrives within
+// readTimeout, or when a write does not complete within writeTimeout. Pings
+// are sent often enough to keep healthy idle clients alive. Zero disables
+// the corresponding deadline.
+func (s *MockeryMCPServer) SetWebSocketTimeouts(readTimeout, writeTimeout time.Duration) {
+	s.updateConfig(func(cfg *ServerConfig) {
+		cfg.ReadTimeout = readTimeout
+		cfg.WriteTimeout = writeTimeout
+	})
+}
+
+// pingInterval returns how often keepalive pings are sent, leaving the
+// client time to answer before the read deadline expires
+func (c *runtimeConfig) pingInterval() time.Duration {
+	return c.ReadTimeout * 9 / 10
+}
+
+// extendReadDeadline pushes the connection's read deadline forward
+func (c *runtimeConfig) extendReadDeadline(conn *websocket.Conn) {
+	if c.ReadTimeout > 0 {
+		conn.SetReadDeadline(time.Now().Add(c.ReadTimeout))
+	}
+}
+
+// extendWriteDeadline pushes the connection's write deadline forward
+func (c *runtimeConfig) extendWriteDeadline(conn *websocket.Conn) {
+	if c.WriteTimeout > 0 {
+		conn.SetWriteDeadline(time.Now().Add(c.WriteTimeout))
+	}
+}
+
+// startKeepalive refreshes the read deadline on every pong and pings the
+// client periodically, calling onLost if a ping can't be sent. The returned
+// function stops the pinger.
+func (s *MockeryMCPServer) startKeepalive(conn *websocket.Conn, cfg *runtimeConfig, onLost func()) func() {
+	if cfg.ReadTimeout <= 0 {
+		return func() {}
+	}
+
+	conn.SetPongHandler(func(string) error {
+		cfg.extendReadDeadline(conn)
+		return nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(cfg.pingInterval())
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				deadline := time.Now().Add(cfg.pingInterval())
+				if cfg.WriteTimeout > 0 {
+					deadline = time.Now().Add(cfg.WriteTimeout)
+				}
+				// WriteControl is safe to call concurrently with other writes
+				if err := conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+					s.logger.Debug("Failed to send keepalive ping", zap.Error(err))
+					onLost()
+					return
+				}
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// isTimeout reports whether err was caused by an exceeded deadline
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dialTestServer starts an httptest server for s and dials its WebSocket endpoint
+func dialTestServer(t *testing.T, s *MockeryMCPServer) *websocket.Conn {
+	t.Helper()
+	httpServer := httptest.NewServer(http.HandlerFunc(s.handleWebSocket))
+	t.Cleanup(httpServer.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestMockeryMCPServer_WebSocketReadTimeout(t *testing.T) {
+	s := newTestServer(t)
+	s.SetWebSocketTimeouts(100*time.Millisecond, time.Second)
+	conn := dialTestServer(t, s)
+
+	// The client never reads, so pings go unanswered and the server gives up
+	time.Sleep(300 * time.Millisecond)
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		_, _, err := conn.ReadMessage()
+		if err != nil {
+			assert.False(t, isTimeout(err), "expected the server to close the connection, got %v", err)
+			return
+		}
+	}
+}
+
+func TestMockeryMCPServer_WebSocketKeepalive(t *testing.T) {
+	s := newTestServer(t)
+	s.SetWebSocketTimeouts(100*time.Millisecond, time.Second)
+	conn := dialTestServer(t, s)
+
+	// Reading answers the server's pings, keeping the idle connection alive
+	responses := make(chan MCPResponse)
+	go func() {
+		for {
+			var response MCPResponse
+			if err := conn.ReadJSON(&response); err != nil {
+				close(responses)
+				return
+			}
+			responses <- response
+		}
+	}()
+
+	time.Sleep(400 * time.Millisecond)
+	require.NoError(t, conn.WriteJSON(MCPRequest{JSONRPC: "2.0", ID: 7, Method: "ping"}))
+
+	select {
+	case response, ok := <-responses:
+		require.True(t, ok, "connection closed despite keepalive")
+		assert.Equal(t, float64(7), response.ID)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for ping response")
+	}
+}
+
+func TestMockeryMCPServer_RegisterHandlers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/app", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("app"))
+	})
+
+	s := newTestServer(t)
+	s.RegisterHandlers(mux)
+
+	httpServer := httptest.NewServer(mux)
+	t.Cleanup(httpServer.Close)
+
+	resp, err := http.Get(httpServer.URL + "/health")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http")+"/mcp", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, conn.WriteJSON(MCPRequest{JSONRPC: "2.0", ID: 1, Method: "ping"}))
+	var response MCPResponse
+	require.NoError(t, conn.ReadJSON(&response))
+	assert.Nil(t, response.Error)
+	assert.EqualValues(t, 1, response.ID)
+}
+
+func TestMockeryMCPServer_WebSocketCompression(t *testing.T) {
+	dir := t.TempDir()
+	var source strings.Builder
+	source.WriteString("package big\n")
+	for i := 0; i < 50; i++ {
+		fmt.Fprintf(&source, "\ntype Repository%d interface{ Get() error }\n", i)
+	}
+	writeFile(t, dir, "big.go", source.String())
+
+	s := newTestServer(t)
+	httpServer := httptest.NewServer(http.HandlerFunc(s.handleWebSocket))
+	defer httpServer.Close()
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+	dialer := &websocket.Dialer{EnableCompression: true}
+
+	discover := func(t *testing.T) string {
+		conn, response, err := dialer.Dial(url, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.NoError(t, conn.WriteJSON(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  "tools/call",
+			"params": map[string]interface{}{
+				"name":      "discover_interfaces",
+				"arguments": map[string]interface{}{"project_path": dir},
+			},
+		}))
+		var result MCPResponse
+		require.NoError(t, conn.ReadJSON(&result))
+		require.Nil(t, result.Error)
+		assert.Contains(t, fmt.Sprint(result.Result), "Found 50 interfaces")
+		return response.Header.Get("Sec-WebSocket-Extensions")
+	}
+
+	// Compression is opt-in on the server, so the offer is declined by default
+	assert.Empty(t, discover(t))
+
+	require.NoError(t, s.updateConfig(func(cfg *ServerConfig) {
+		cfg.WSCompression = true
+	}))
+	assert.Contains(t, discover(t), "permessage-deflate")
+
+	// Clients that don't offer compression still connect
+	conn, response, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	conn.Close()
+	assert.Empty(t, response.Header.Get("Sec-WebSocket-Extensions"))
+}
@@ -4,10 +4,11 @@ import "time"
 
 // MockeryConfig represents the configuration for Mockery mock generation
 type MockeryConfig struct {
-	WithExpector   bool                    `yaml:"with-expecter"`
-	Filename       string                  `yaml:"filename"`
-	OutPkg         string                  `yaml:"outpkg"`
-	Packages       map[string]Package      `yaml:"packages"`
+	WithExpector    bool               `yaml:"with-expecter"`
+	Filename        string             `yaml:"filename"`
+	OutPkg          string             `yaml:"outpkg"`
+	BoilerplateFile string             `yaml:"boilerplate-file,omitempty"`
+	Packages        map[string]Package `yaml:"packages"`
 }
 
 // Package represents a Go package configuration for mock generation
@@ -24,16 +25,62 @@ type InterfaceConfig struct {
 type InterfaceSettings struct {
 	Dir      string `yaml:"dir,omitempty"`
 	Filename string `yaml:"filename,omitempty"`
+	OutPkg   string `yaml:"outpkg,omitempty"`
 }
 
 // InterfaceDefinition holds metadata about a discovered Go interface
 type InterfaceDefinition struct {
-	Name        string            `json:"name"`
-	Package     string            `json:"package"`
-	Methods     []MethodSignature `json:"methods"`
-	FilePath    string            `json:"file_path"`
-	LineNumber  int               `json:"line_number"`
-	Comments    []string          `json:"comments,omitempty"`
+	Name       string            `json:"name"`
+	Package    string            `json:"package"`
+	Methods    []MethodSignature `json:"methods"`
+	FilePath   string            `json:"file_path"`
+	LineNumber int               `json:"line_number"`
+	Comments   []string          `json:"comments,omitempty"`
+	Imports    []Import          `json:"imports,omitempty"`
+
+	// StartColumn, EndLine and EndColumn complete the range of the
+	// declaration that LineNumber starts, from the interface's name to just
+	// past its closing brace. Columns are 1-based byte columns.
+	StartColumn int `json:"start_column"`
+	EndLine     int `json:"end_line"`
+	EndColumn   int `json:"end_column"`
+
+	// Embeds lists embedded interfaces and constraints as written, e.g.
+	// Reader or io.Closer. Methods of embedded interfaces declared in the
+	// same package are included in Methods once the package is resolved.
+	Embeds []string `json:"embeds,omitempty"`
+
+	// TypeParams are the type parameters of a generic interface
+	TypeParams []TypeParam `json:"type_params,omitempty"`
+
+	// Directives are mock settings from //mockery: and //go:generate mockery
+	// comments on the interface, keyed by setting (dir, filename, ...)
+	Directives map[string]string `json:"directives,omitempty"`
+}
+
+// FunctionTypeDefinition holds metadata about a named function type, e.g.
+// type HandlerFunc func(ctx context.Context, event Event) error, which
+// mockery can mock like a single-method interface
+type FunctionTypeDefinition struct {
+	Name       string      `json:"name"`
+	Package    string      `json:"package"`
+	Parameters []Parameter `json:"parameters"`
+	Returns    []Parameter `json:"returns"`
+	FilePath   string      `json:"file_path"`
+	LineNumber int         `json:"line_number"`
+	Comments   []string    `json:"comments,omitempty"`
+	Imports    []Import    `json:"imports,omitempty"`
+
+	// TypeParams are the type parameters of a generic function type
+	TypeParams []TypeParam `json:"type_params,omitempty"`
+}
+
+// Import maps the name a file refers to a package by to its import path.
+// Name is the explicit alias if there is one, "." for dot imports, or the
+// name inferred from the path.
+type Import struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
 }
 
 // MethodSignature represents a method signature within an interface
@@ -42,12 +89,28 @@ type MethodSignature struct {
 	Parameters []Parameter `json:"parameters"`
 	Returns    []Parameter `json:"returns"`
 	Comments   []string    `json:"comments,omitempty"`
+	// Origin names the embedded interface a promoted method is declared
+	// in, and is empty for methods the interface declares itself
+	Origin string `json:"origin,omitempty"`
 }
 
 // Parameter represents a method parameter or return value
 type Parameter struct {
 	Name string `json:"name"`
 	Type string `json:"type"`
+	// MatcherType is the type as reflect prints it, qualified with the
+	// package name rather than any import alias, e.g. *interfaces.User.
+	// It is the string mock.AnythingOfType expects.
+	MatcherType string `json:"matcher_type,omitempty"`
+	// TypeArgs are the type arguments when the type instantiates a generic
+	// type, e.g. [K V] for *Cache[K, V]
+	TypeArgs []string `json:"type_args,omitempty"`
+}
+
+// TypeParam is a type parameter of a generic interface and its constraint
+type TypeParam struct {
+	Name       string `json:"name"`
+	Constraint string `json:"constraint"`
 }
 
 // MockGenerationRequest represents a request to generate mocks via MCP
@@ -57,15 +120,65 @@ type MockGenerationRequest struct {
 	OutputDir      string `json:"output_dir,omitempty"`
 	WithExpector   bool   `json:"with_expecter"`
 	FilenameFormat string `json:"filename_format,omitempty"`
+	Recursive      bool   `json:"recursive,omitempty"`
+	// BoilerplateFile is a header (e.g. a license) prepended to the generated mock
+	BoilerplateFile string `json:"boilerplate_file,omitempty"`
+	// ProjectPath is the module directory used to resolve PackagePath when it
+	// is an import path rather than a directory
+	ProjectPath string `json:"project_path,omitempty"`
+	// InPackage generates the mock inside the interface's own package
+	InPackage bool `json:"in_package,omitempty"`
+	// TestOnly generates a mock only visible to the package's tests
+	TestOnly bool `json:"test_only,omitempty"`
+	// MockName is a template for the mock type name
+	MockName string `json:"mock_name,omitempty"`
+	// OutPkg is the package name of the generated mock, mocks by default
+	OutPkg string `json:"outpkg,omitempty"`
+	// ReturnContent generates the mock into a temporary directory and
+	// returns its source instead of writing it to the output directory
+	ReturnContent bool `json:"return_content,omitempty"`
+	// ExtraArgs are passed to mockery after the arguments the server builds
+	ExtraArgs []string `json:"extra_args,omitempty"`
+	// SkipPrecheck runs mockery without first confirming the interface is
+	// declared in the package
+	SkipPrecheck bool `json:"skip_precheck,omitempty"`
+	// MocksModule is the module path of a go.mod created in the output
+	// directory when it isn't already in a module of its own
+	MocksModule string `json:"mocks_module,omitempty"`
+	// FilenameCase is how the interface name is cased in the default
+	// filename: snake (the default), lower or original
+	FilenameCase string `json:"filename_case,omitempty"`
+	// BuildTag is a build constraint expression, e.g. !production, written
+	// as //go:build and // +build lines at the top of the generated mock
+	BuildTag string `json:"build_tag,omitempty"`
+	// ProjectID associates the generated mock with a project created by
+	// create_project
+	ProjectID string `json:"project_id,omitempty"`
+	// FunctionType means InterfaceName names a function type, such as
+	// type HandlerFunc func(...), rather than an interface
+	FunctionType bool `json:"function_type,omitempty"`
+	// TemplateVars are custom variables for FilenameFormat, alongside the
+	// built-in InterfaceName, PackageName and Date
+	TemplateVars map[string]string `json:"template_vars,omitempty"`
+	// CombinedFile, with Recursive, merges every generated mock into this
+	// one file instead of writing a file per interface
+	CombinedFile string `json:"combined_file,omitempty"`
 }
 
 // MockGenerationResult represents the result of mock generation
 type MockGenerationResult struct {
-	Success       bool      `json:"success"`
-	GeneratedFile string    `json:"generated_file,omitempty"`
-	ErrorMessage  string    `json:"error_message,omitempty"`
-	GeneratedAt   time.Time `json:"generated_at"`
-	MockeryOutput string    `json:"mockery_output,omitempty"`
+	Success        bool      `json:"success"`
+	InterfaceName  string    `json:"interface_name,omitempty"`
+	GeneratedFile  string    `json:"generated_file,omitempty"`
+	ErrorMessage   string    `json:"error_message,omitempty"`
+	GeneratedAt    time.Time `json:"generated_at"`
+	MockeryOutput  string    `json:"mockery_output,omitempty"`
+	MockeryVersion string    `json:"mockery_version,omitempty"`
+	// Content is the generated mock source, set for ReturnContent requests
+	Content string `json:"content,omitempty"`
+	// SourceHash is the signature hash of the mocked interface, set when
+	// the interface was found in a local package before generation
+	SourceHash string `json:"source_hash,omitempty"`
 }
 
 // InterfaceDiscoveryRequest represents a request to discover interfaces
@@ -89,4 +202,4 @@ type DockerContainerConfig struct {
 	Volumes     map[string]string `json:"volumes"`
 	Environment map[string]string `json:"environment"`
 	Command     []string          `json:"command"`
-}
\ No newline at end of file
+}